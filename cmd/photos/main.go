@@ -0,0 +1,64 @@
+// Command photos stores the records of the photo files stored before the photos table,
+// the photo URLs of the checks are resolved only from the records.
+// It is run once after the migrations, the files already recorded are skipped.
+package main
+
+import (
+	"context"
+	_ "image/png" // the photos stored before the re-encoding to JPEG may be PNG
+	"log"
+	"log/slog"
+	"os/signal"
+	"syscall"
+
+	"github.com/PritOriginal/problem-map-server/internal/config"
+	"github.com/PritOriginal/problem-map-server/internal/storage/local"
+	"github.com/PritOriginal/problem-map-server/internal/storage/postgres"
+	"github.com/PritOriginal/problem-map-server/internal/storage/s3"
+	"github.com/PritOriginal/problem-map-server/internal/usecase"
+	slogger "github.com/PritOriginal/problem-map-server/pkg/logger"
+)
+
+func main() {
+	cfg := config.MustLoad()
+
+	logger, err := slogger.SetupLogger(cfg.Env)
+	if err != nil {
+		log.Fatalf("error init logger: %v", err)
+	}
+
+	postgresDB, err := postgres.New(cfg.DB)
+	if err != nil {
+		logger.Error("failed connection to database", slogger.Err(err))
+		panic(err)
+	}
+	defer postgresDB.Stop()
+
+	var photosRepo usecase.PhotosRepository
+	switch cfg.PhotoStorage {
+	case config.S3:
+		s3Client, err := s3.New(logger, cfg.Aws)
+		if err != nil {
+			logger.Error("failed connection to s3", slogger.Err(err))
+			panic(err)
+		}
+		photosRepo = s3.NewPhotos(s3Client, cfg.Aws)
+	default:
+		photosRepo = local.NewPhotos(cfg.Local)
+	}
+
+	photosUseCase := usecase.NewPhotos(logger, usecase.PhotosRepositories{
+		Checks: postgres.NewChecks(postgresDB.DB),
+		Photos: photosRepo,
+	})
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	added, err := photosUseCase.BackfillRecords(ctx)
+	logger.Info("photo records backfilled", slog.Int64("added", added))
+	if err != nil {
+		logger.Error("failed backfill photo records", slogger.Err(err))
+		panic(err)
+	}
+}
@@ -15,9 +15,29 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/auth/signin": {
+        "/.well-known/jwks.json": {
+            "get": {
+                "description": "get the public keys the access tokens are verified by as the JSON Web Key Set, it isn't wrapped in the response envelope, the HS256 secret is never published",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Get JWKS",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_pkg_token.JWKS"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/logout": {
             "post": {
-                "description": "sign in user",
+                "description": "revoke the session of the refresh token, the access tokens issued for it stay valid until they expire",
                 "consumes": [
                     "application/json"
                 ],
@@ -27,7 +47,7 @@ const docTemplate = `{
                 "tags": [
                     "auth"
                 ],
-                "summary": "Sign In",
+                "summary": "Logout",
                 "parameters": [
                     {
                         "description": "query params",
@@ -35,7 +55,7 @@ const docTemplate = `{
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/internal_handler_auth.SignInRequest"
+                            "$ref": "#/definitions/internal_handler_auth.LogoutRequest"
                         }
                     }
                 ],
@@ -43,7 +63,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_pkg_responses.Response-internal_handler_auth_SignInResponse"
+                            "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_pkg_responses.Response-internal_handler_auth_LogoutResponse"
                         }
                     },
                     "400": {
@@ -67,45 +87,35 @@ const docTemplate = `{
                 }
             }
         },
-        "/auth/signup": {
+        "/auth/logout/all": {
             "post": {
-                "description": "sign up a new user",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "revoke all sessions of the user, the access tokens issued for them stay valid until they expire",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "auth"
                 ],
-                "summary": "Sign Up",
+                "summary": "Logout all sessions",
                 "parameters": [
                     {
-                        "description": "query params",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/internal_handler_auth.SignUpRequest"
-                        }
+                        "type": "string",
+                        "default": "Bearer \u003cAdd access token here\u003e",
+                        "description": "Insert your access token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
-                        "schema": {
-                            "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_pkg_responses.Response-internal_handler_auth_SignUpResponse"
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_pkg_responses.Response-any"
+                            "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_pkg_responses.Response-internal_handler_auth_LogoutResponse"
                         }
                     },
-                    "409": {
-                        "description": "Conflict",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_pkg_responses.Response-any"
                         }
@@ -119,9 +129,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/auth/tokens/refresh": {
-            "post": {
-                "description": "refresh access and refresh tokens",
+        "/auth/password": {
+            "put": {
+                "description": "change the password of the user by the old password, the other sessions of the user are revoked",
                 "consumes": [
                     "application/json"
                 ],
@@ -131,15 +141,23 @@ const docTemplate = `{
                 "tags": [
                     "auth"
                 ],
-                "summary": "Refresh tokens",
+                "summary": "Change password",
                 "parameters": [
+                    {
+                        "type": "string",
+                        "default": "Bearer \u003cAdd access token here\u003e",
+                        "description": "Insert your access token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
                     {
                         "description": "query params",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/internal_handler_auth.RefreshTokensRequest"
+                            "$ref": "#/definitions/internal_handler_auth.ChangePasswordRequest"
                         }
                     }
                 ],
@@ -147,7 +165,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_pkg_responses.Response-internal_handler_auth_RefreshTokensResponse"
+                            "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_pkg_responses.Response-internal_handler_auth_ChangePasswordResponse"
                         }
                     },
                     "400": {
@@ -162,6 +180,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_pkg_responses.Response-any"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_pkg_responses.Response-any"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -171,34 +195,35 @@ const docTemplate = `{
                 }
             }
         },
-        "/checks": {
+        "/auth/password/reset": {
             "post": {
-                "description": "add check",
+                "description": "set the new password by the password reset token, the token can be used only once, all sessions of the user are revoked",
                 "consumes": [
-                    "multipart/form-data"
+                    "application/json"
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "checks"
+                    "auth"
                 ],
-                "summary": "Add check",
+                "summary": "Reset password",
                 "parameters": [
                     {
-                        "type": "string",
-                        "default": "Bearer \u003cAdd access token here\u003e",
-                        "description": "Insert your access token",
-                        "name": "Authorization",
-                        "in": "header",
-                        "required": true
+                        "description": "query params",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler_auth.ResetPasswordRequest"
+                        }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_pkg_responses.Response-internal_handler_checks_AddCheckResponse"
+                            "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_pkg_responses.Response-internal_handler_auth_ResetPasswordResponse"
                         }
                     },
                     "400": {
@@ -216,30 +241,35 @@ const docTemplate = `{
                 }
             }
         },
-        "/checks/mark/{id}": {
-            "get": {
-                "description": "get check by mark id",
+        "/auth/password/reset/request": {
+            "post": {
+                "description": "send the single-use password reset token to the user with the login, the request is accepted for the unknown logins too",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "checks"
+                    "auth"
                 ],
-                "summary": "Get check by mark id",
+                "summary": "Request password reset",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "mark id",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "query params",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler_auth.RequestPasswordResetRequest"
+                        }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "202": {
+                        "description": "Accepted",
                         "schema": {
-                            "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_pkg_responses.Response-internal_handler_checks_GetChecksByMarkIdResponse"
+                            "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_pkg_responses.Response-any"
                         }
                     },
                     "400": {
@@ -248,6 +278,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_pkg_responses.Response-any"
                         }
                     },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_pkg_responses.Response-any"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -257,30 +293,35 @@ const docTemplate = `{
                 }
             }
         },
-        "/checks/user/{id}": {
-            "get": {
-                "description": "get checks by user id",
+        "/auth/signin": {
+            "post": {
+                "description": "sign in user, every sign in starts a new session. Too many failed sign ins by the login or from the IP lock the sign in out for the time doubling with every next failure",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "checks"
+                    "auth"
                 ],
-                "summary": "List checks by user id",
+                "summary": "Sign In",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "user id",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "query params",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler_auth.SignInRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_pkg_responses.Response-internal_handler_checks_GetChecksByUserIdResponse"
+                            "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_pkg_responses.Response-internal_handler_auth_SignInResponse"
                         }
                     },
                     "400": {
@@ -289,6 +330,24 @@ const docTemplate = `{
                             "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_pkg_responses.Response-any"
                         }
                     },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_pkg_responses.Response-any"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_pkg_responses.Response-any"
+                        },
+                        "headers": {
+                            "Retry-After": {
+                                "type": "integer",
+                                "description": "seconds until the lockout ends"
+                            }
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -298,30 +357,35 @@ const docTemplate = `{
                 }
             }
         },
-        "/checks/{id}": {
-            "get": {
-                "description": "get check by id",
+        "/auth/signup": {
+            "post": {
+                "description": "sign up a new user",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "checks"
+                    "auth"
                 ],
-                "summary": "Get check by id",
+                "summary": "Sign Up",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "check id",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "query params",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler_auth.SignUpRequest"
+                        }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_pkg_responses.Response-internal_handler_checks_GetCheckByIdResponse"
+                            "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_pkg_responses.Response-internal_handler_auth_SignUpResponse"
                         }
                     },
                     "400": {
@@ -330,8 +394,8 @@ const docTemplate = `{
                             "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_pkg_responses.Response-any"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "409": {
+                        "description": "Conflict",
                         "schema": {
                             "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_pkg_responses.Response-any"
                         }
@@ -345,9 +409,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/map/admin-boundaries": {
-            "get": {
-                "description": "admin boundaries",
+        "/auth/tokens/refresh": {
+            "post": {
+                "description": "refresh access and refresh tokens, the refresh token can be used only once, using it again revokes its session",
                 "consumes": [
                     "application/json"
                 ],
@@ -355,26 +419,25 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "map"
+                    "auth"
                 ],
-                "summary": "List administrative boundaries",
+                "summary": "Refresh tokens",
                 "parameters": [
                     {
-                        "type": "array",
-                        "items": {
-                            "type": "number"
-                        },
-                        "collectionFormat": "csv",
-                        "description": "filter by admin level",
-                        "name": "admin_levels",
-                        "in": "query"
+                        "description": "query params",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler_auth.RefreshTokensRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_pkg_responses.Response-internal_handler_map_GetAdminBoundariesResponse"
+                            "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_pkg_responses.Response-internal_handler_auth_RefreshTokensResponse"
                         }
                     },
                     "400": {
@@ -383,6 +446,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_pkg_responses.Response-any"
                         }
                     },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_pkg_responses.Response-any"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -392,46 +461,34 @@ const docTemplate = `{
                 }
             }
         },
-        "/map/admin-boundaries/marks/count": {
-            "get": {
-                "description": "the count of markers of all administrative boundaries",
+        "/checks": {
+            "post": {
+                "description": "add check, the photos are optional and may be uploaded directly to the S3 photo storage and attached to the check afterwards",
                 "consumes": [
-                    "application/json"
+                    "multipart/form-data"
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "map"
+                    "checks"
                 ],
-                "summary": "The count of markers of all administrative boundaries",
+                "summary": "Add check",
                 "parameters": [
                     {
-                        "type": "array",
-                        "items": {
-                            "type": "number"
-                        },
-                        "collectionFormat": "csv",
-                        "description": "filter by admin level",
-                        "name": "admin_levels",
-                        "in": "query"
-                    },
-                    {
-                        "type": "array",
-                        "items": {
-                            "type": "number"
-                        },
-                        "collectionFormat": "csv",
-                        "description": "filter by mark type",
-                        "name": "mark_type_ids",
-                        "in": "query"
+                        "type": "string",
+                        "default": "Bearer \u003cAdd access token here\u003e",
+                        "description": "Insert your access token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_pkg_responses.Response-internal_handler_map_GetAdminBoundariesMarksCountResponse"
+                            "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_pkg_responses.Response-internal_handler_checks_AddCheckResponse"
                         }
                     },
                     "400": {
@@ -440,33 +497,16 @@ const docTemplate = `{
                             "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_pkg_responses.Response-any"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "409": {
+                        "description": "Conflict",
                         "schema": {
                             "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_pkg_responses.Response-any"
                         }
-                    }
-                }
-            }
-        },
-        "/map/cities": {
-            "get": {
-                "description": "get cities",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "map"
-                ],
-                "summary": "List cities",
-                "responses": {
-                    "200": {
-                        "description": "OK",
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
                         "schema": {
-                            "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_pkg_responses.Response-internal_handler_map_GetCitiesResponse"
+                            "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_pkg_responses.Response-internal_handler_checks_CheckTooFarResponse"
                         }
                     },
                     "500": {
@@ -478,24 +518,68 @@ const docTemplate = `{
                 }
             }
         },
-        "/map/districts": {
+        "/checks/mark/{id}": {
             "get": {
-                "description": "get districts",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "get check by mark id",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "map"
+                    "checks"
+                ],
+                "summary": "Get check by mark id",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "mark id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "max number of checks in the page (capped by the server)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "cursor to the next page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "created_at",
+                            "updated_at"
+                        ],
+                        "type": "string",
+                        "description": "sort field",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "asc",
+                            "desc"
+                        ],
+                        "type": "string",
+                        "description": "sort order",
+                        "name": "order",
+                        "in": "query"
+                    }
                 ],
-                "summary": "List districts",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_pkg_responses.Response-internal_handler_map_GetDistrictsResponse"
+                            "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_pkg_responses.Response-internal_handler_checks_GetChecksByMarkIdResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_pkg_responses.Response-any"
                         }
                     },
                     "500": {
@@ -507,24 +591,68 @@ const docTemplate = `{
                 }
             }
         },
-        "/map/regions": {
+        "/checks/user/{id}": {
             "get": {
-                "description": "get regions",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "get checks by user id",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "map"
+                    "checks"
+                ],
+                "summary": "List checks by user id",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "user id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "max number of checks in the page (capped by the server)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "cursor to the next page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "created_at",
+                            "updated_at"
+                        ],
+                        "type": "string",
+                        "description": "sort field",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "asc",
+                            "desc"
+                        ],
+                        "type": "string",
+                        "description": "sort order",
+                        "name": "order",
+                        "in": "query"
+                    }
                 ],
-                "summary": "List regions",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_pkg_responses.Response-internal_handler_map_GetRegionsResponse"
+                            "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_pkg_responses.Response-internal_handler_checks_GetChecksByUserIdResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_pkg_responses.Response-any"
                         }
                     },
                     "500": {
@@ -536,46 +664,30 @@ const docTemplate = `{
                 }
             }
         },
-        "/marks": {
+        "/checks/{id}": {
             "get": {
-                "description": "get markers",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "get check by id",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "marks"
+                    "checks"
                 ],
-                "summary": "List markers",
+                "summary": "Get check by id",
                 "parameters": [
                     {
-                        "type": "array",
-                        "items": {
-                            "type": "number"
-                        },
-                        "collectionFormat": "csv",
-                        "description": "filter by mark types",
-                        "name": "mark_type_ids",
-                        "in": "query"
-                    },
-                    {
-                        "type": "array",
-                        "items": {
-                            "type": "number"
-                        },
-                        "collectionFormat": "csv",
-                        "description": "filter by mark statuses",
-                        "name": "mark_status_ids",
-                        "in": "query"
+                        "type": "integer",
+                        "description": "check id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_pkg_responses.Response-internal_handler_marks_GetMarksResponse"
+                            "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_pkg_responses.Response-internal_handler_checks_GetCheckByIdResponse"
                         }
                     },
                     "400": {
@@ -584,6 +696,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_pkg_responses.Response-any"
                         }
                     },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_pkg_responses.Response-any"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -591,19 +709,21 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
+            }
+        },
+        "/checks/{id}/photos": {
             "post": {
-                "description": "add mark",
+                "description": "validate the photos uploaded by the presigned URLs and attach them to the check, allowed only for the author of the check",
                 "consumes": [
-                    "multipart/form-data"
+                    "application/json"
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "marks"
+                    "checks"
                 ],
-                "summary": "Add mark",
+                "summary": "Attach uploaded photos to check",
                 "parameters": [
                     {
                         "type": "string",
@@ -612,13 +732,29 @@ const docTemplate = `{
                         "name": "Authorization",
                         "in": "header",
                         "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "check id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "keys of the uploads",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler_uploads.AttachPhotosRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "201": {
                         "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_pkg_responses.Response-internal_handler_marks_AddMarkResponse"
+                            "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_pkg_responses.Response-internal_handler_uploads_AttachPhotosResponse"
                         }
                     },
                     "400": {
@@ -627,6 +763,24 @@ const docTemplate = `{
                             "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_pkg_responses.Response-any"
                         }
                     },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_pkg_responses.Response-any"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_pkg_responses.Response-any"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_pkg_responses.Response-any"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -636,9 +790,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/marks/statuses": {
+        "/map/admin-boundaries": {
             "get": {
-                "description": "get mark statuses",
+                "description": "admin boundaries",
                 "consumes": [
                     "application/json"
                 ],
@@ -646,14 +800,32 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "marks"
+                    "map"
+                ],
+                "summary": "List administrative boundaries",
+                "parameters": [
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "number"
+                        },
+                        "collectionFormat": "csv",
+                        "description": "filter by admin level",
+                        "name": "admin_levels",
+                        "in": "query"
+                    }
                 ],
-                "summary": "List mark statuses",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_pkg_responses.Response-internal_handler_marks_GetMarkStatusesResponse"
+                            "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_pkg_responses.Response-internal_handler_map_GetAdminBoundariesResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_pkg_responses.Response-any"
                         }
                     },
                     "500": {
@@ -665,9 +837,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/marks/types": {
+        "/map/admin-boundaries/marks/count": {
             "get": {
-                "description": "get mark types",
+                "description": "the count of markers of all administrative boundaries",
                 "consumes": [
                     "application/json"
                 ],
@@ -675,93 +847,36 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "marks"
+                    "map"
                 ],
-                "summary": "List mark types",
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_pkg_responses.Response-internal_handler_marks_GetMarkTypesResponse"
-                        }
-                    },
-                    "500": {
-                        "description": "Internal Server Error",
-                        "schema": {
-                            "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_pkg_responses.Response-any"
-                        }
-                    }
-                }
-            }
-        },
-        "/marks/user/{id}": {
-            "get": {
-                "description": "get markers by user id",
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "marks"
-                ],
-                "summary": "List markers by user id",
+                "summary": "The count of markers of all administrative boundaries",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "user id",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_pkg_responses.Response-internal_handler_marks_GetMarksByUserIdResponse"
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_pkg_responses.Response-any"
-                        }
+                        "type": "array",
+                        "items": {
+                            "type": "number"
+                        },
+                        "collectionFormat": "csv",
+                        "description": "filter by admin level",
+                        "name": "admin_levels",
+                        "in": "query"
                     },
-                    "500": {
-                        "description": "Internal Server Error",
-                        "schema": {
-                            "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_pkg_responses.Response-any"
-                        }
-                    }
-                }
-            }
-        },
-        "/marks/{id}": {
-            "get": {
-                "description": "get mark by id",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "marks"
-                ],
-                "summary": "Get mark by id",
-                "parameters": [
                     {
-                        "type": "integer",
-                        "description": "mark id",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "type": "array",
+                        "items": {
+                            "type": "number"
+                        },
+                        "collectionFormat": "csv",
+                        "description": "filter by mark type",
+                        "name": "mark_type_ids",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_pkg_responses.Response-internal_handler_marks_GetMarkByIdResponse"
+                            "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_pkg_responses.Response-internal_handler_map_GetAdminBoundariesMarksCountResponse"
                         }
                     },
                     "400": {
@@ -770,12 +885,6 @@ const docTemplate = `{
                             "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_pkg_responses.Response-any"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_pkg_responses.Response-any"
-                        }
-                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -785,9 +894,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/marks/{id}/confirm": {
-            "post": {
-                "description": "сonfirm the mark and moves it to a new status",
+        "/map/cities": {
+            "get": {
+                "description": "get cities",
                 "consumes": [
                     "application/json"
                 ],
@@ -795,26 +904,14 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "marks"
+                    "map"
                 ],
-                "summary": "Confirm the mark",
+                "summary": "List cities",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_pkg_responses.Response-internal_handler_marks_ConfirmResponse"
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_pkg_responses.Response-any"
-                        }
-                    },
-                    "409": {
-                        "description": "Conflict",
-                        "schema": {
-                            "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_pkg_responses.Response-any"
+                            "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_pkg_responses.Response-internal_handler_map_GetCitiesResponse"
                         }
                     },
                     "500": {
@@ -826,9 +923,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/marks/{id}/reject": {
-            "post": {
-                "description": "reject the mark and moves it to a new status",
+        "/map/districts": {
+            "get": {
+                "description": "get districts",
                 "consumes": [
                     "application/json"
                 ],
@@ -836,26 +933,14 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "marks"
+                    "map"
                 ],
-                "summary": "Reject the mark",
+                "summary": "List districts",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_pkg_responses.Response-internal_handler_marks_RejectResponse"
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_pkg_responses.Response-any"
-                        }
-                    },
-                    "409": {
-                        "description": "Conflict",
-                        "schema": {
-                            "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_pkg_responses.Response-any"
+                            "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_pkg_responses.Response-internal_handler_map_GetDistrictsResponse"
                         }
                     },
                     "500": {
@@ -867,9 +952,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/marks/{id}/status-history": {
+        "/map/marks/clusters": {
             "get": {
-                "description": "displays the entire list of status changes history for a specific marker by markId",
+                "description": "clusters of marks inside the bounding box with the count of marks by status, individual marks are returned starting from zoom 16, truncated reports that the bounding box has more marks than returned",
                 "consumes": [
                     "application/json"
                 ],
@@ -877,21 +962,36 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "marks"
+                    "map"
                 ],
-                "summary": "List mark statuses",
+                "summary": "Mark clusters",
                 "parameters": [
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "number"
+                        },
+                        "collectionFormat": "csv",
+                        "description": "bounding box: min lon, min lat, max lon, max lat",
+                        "name": "bbox",
+                        "in": "query",
+                        "required": true
+                    },
                     {
                         "type": "integer",
-                        "description": "mark id",
-                        "name": "id",
-                        "in": "path",
+                        "description": "map zoom level",
+                        "name": "zoom",
+                        "in": "query",
                         "required": true
                     },
                     {
-                        "type": "boolean",
-                        "description": "with checks",
-                        "name": "withChecks",
+                        "type": "array",
+                        "items": {
+                            "type": "number"
+                        },
+                        "collectionFormat": "csv",
+                        "description": "filter by mark type",
+                        "name": "mark_type_ids",
                         "in": "query"
                     }
                 ],
@@ -899,7 +999,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_pkg_responses.Response-internal_handler_marks_GetMarkStatusHistoryByMarkIdResponse"
+                            "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_pkg_responses.Response-internal_handler_map_GetMarkClustersResponse"
                         }
                     },
                     "400": {
@@ -917,62 +1017,24 @@ const docTemplate = `{
                 }
             }
         },
-        "/tasks": {
+        "/map/regions": {
             "get": {
-                "description": "get tasks",
-                "produces": [
+                "description": "get regions",
+                "consumes": [
                     "application/json"
                 ],
-                "tags": [
-                    "tasks"
-                ],
-                "summary": "List tasks",
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_pkg_responses.Response-internal_handler_tasks_GetTasksResponse"
-                        }
-                    },
-                    "500": {
-                        "description": "Internal Server Error",
-                        "schema": {
-                            "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_pkg_responses.Response-any"
-                        }
-                    }
-                }
-            },
-            "post": {
-                "description": "add new task",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "tasks"
-                ],
-                "summary": "Add task",
-                "parameters": [
-                    {
-                        "description": "query params",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/internal_handler_tasks.AddTaskRequest"
-                        }
-                    }
+                    "map"
                 ],
+                "summary": "List regions",
                 "responses": {
-                    "201": {
-                        "description": "Created",
-                        "schema": {
-                            "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_pkg_responses.Response-internal_handler_tasks_AddTaskResponse"
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_pkg_responses.Response-any"
+                            "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_pkg_responses.Response-internal_handler_map_GetRegionsResponse"
                         }
                     },
                     "500": {
@@ -984,21 +1046,46 @@ const docTemplate = `{
                 }
             }
         },
-        "/tasks/user/{id}": {
+        "/map/tiles/{layer}/{z}/{x}/{y}.mvt": {
             "get": {
-                "description": "get tasks by user id",
+                "description": "mapbox vector tile of the marks or admin_boundaries layer",
                 "produces": [
-                    "application/json"
+                    "application/vnd.mapbox-vector-tile"
                 ],
                 "tags": [
-                    "tasks"
+                    "map"
                 ],
-                "summary": "Get tasks by user id",
+                "summary": "Vector tile",
                 "parameters": [
+                    {
+                        "enum": [
+                            "marks",
+                            "admin_boundaries"
+                        ],
+                        "type": "string",
+                        "description": "tile layer",
+                        "name": "layer",
+                        "in": "path",
+                        "required": true
+                    },
                     {
                         "type": "integer",
-                        "description": "user id",
-                        "name": "id",
+                        "description": "zoom level",
+                        "name": "z",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "tile column",
+                        "name": "x",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "tile row",
+                        "name": "y",
                         "in": "path",
                         "required": true
                     }
@@ -1007,7 +1094,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_pkg_responses.Response-internal_handler_tasks_GetTasksByUserIdResponse"
+                            "type": "file"
                         }
                     },
                     "400": {
@@ -1016,6 +1103,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_pkg_responses.Response-any"
                         }
                     },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_pkg_responses.Response-any"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -1025,30 +1118,88 @@ const docTemplate = `{
                 }
             }
         },
-        "/tasks/{id}": {
+        "/marks": {
             "get": {
-                "description": "get task by id",
+                "description": "get markers",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "tasks"
+                    "marks"
                 ],
-                "summary": "Get task by id",
+                "summary": "List markers",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "task id",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "type": "array",
+                        "items": {
+                            "type": "number"
+                        },
+                        "collectionFormat": "csv",
+                        "description": "filter by mark types",
+                        "name": "mark_type_ids",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "number"
+                        },
+                        "collectionFormat": "csv",
+                        "description": "filter by mark statuses",
+                        "name": "mark_status_ids",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "number"
+                        },
+                        "collectionFormat": "csv",
+                        "description": "filter by bounding box: min lon, min lat, max lon, max lat",
+                        "name": "bbox",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "max number of marks in the page (capped by the server)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "cursor to the next page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "created_at",
+                            "updated_at"
+                        ],
+                        "type": "string",
+                        "description": "sort field",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "asc",
+                            "desc"
+                        ],
+                        "type": "string",
+                        "description": "sort order",
+                        "name": "order",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_pkg_responses.Response-internal_handler_tasks_GetTaskByIdResponse"
+                            "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_pkg_responses.Response-internal_handler_marks_GetMarksResponse"
                         }
                     },
                     "400": {
@@ -1057,12 +1208,61 @@ const docTemplate = `{
                             "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_pkg_responses.Response-any"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_pkg_responses.Response-any"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "add mark",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "marks"
+                ],
+                "summary": "Add mark",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "Bearer \u003cAdd access token here\u003e",
+                        "description": "Insert your access token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "add the mark even if possible duplicates were found",
+                        "name": "force",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_pkg_responses.Response-internal_handler_marks_AddMarkResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
                             "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_pkg_responses.Response-any"
                         }
                     },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_pkg_responses.Response-internal_handler_marks_DuplicateMarksResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -1072,21 +1272,53 @@ const docTemplate = `{
                 }
             }
         },
-        "/users": {
+        "/marks/duplicates": {
             "get": {
-                "description": "get users",
+                "description": "dry run of the duplicate check done when adding a mark: open marks of the same type created nearby recently",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "users"
+                    "marks"
+                ],
+                "summary": "Find duplicate markers",
+                "parameters": [
+                    {
+                        "type": "number",
+                        "description": "longitude of the new mark",
+                        "name": "longitude",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "number",
+                        "description": "latitude of the new mark",
+                        "name": "latitude",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "type of the new mark",
+                        "name": "mark_type_id",
+                        "in": "query",
+                        "required": true
+                    }
                 ],
-                "summary": "List users",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_pkg_responses.Response-internal_handler_users_GetUsersResponse"
+                            "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_pkg_responses.Response-internal_handler_marks_DuplicateMarksResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_pkg_responses.Response-any"
                         }
                     },
                     "500": {
@@ -1098,30 +1330,88 @@ const docTemplate = `{
                 }
             }
         },
-        "/users/{id}": {
+        "/marks/nearby": {
             "get": {
-                "description": "get user by id",
+                "description": "get markers around the point ordered by distance (in metres)",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "users"
+                    "marks"
                 ],
-                "summary": "Get user by id",
+                "summary": "List nearby markers",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "user id",
-                        "name": "id",
-                        "in": "path",
+                        "type": "number",
+                        "description": "longitude of the point",
+                        "name": "longitude",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "number",
+                        "description": "latitude of the point",
+                        "name": "latitude",
+                        "in": "query",
                         "required": true
+                    },
+                    {
+                        "type": "number",
+                        "description": "search radius in metres",
+                        "name": "radius",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "max number of marks in the page (capped by the server)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "cursor to the next page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "asc",
+                            "desc"
+                        ],
+                        "type": "string",
+                        "description": "sort order by distance",
+                        "name": "order",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "number"
+                        },
+                        "collectionFormat": "csv",
+                        "description": "filter by mark types",
+                        "name": "mark_type_ids",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "number"
+                        },
+                        "collectionFormat": "csv",
+                        "description": "filter by mark statuses",
+                        "name": "mark_status_ids",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_pkg_responses.Response-internal_handler_users_GetUserByIdResponse"
+                            "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_pkg_responses.Response-internal_handler_marks_GetNearbyMarksResponse"
                         }
                     },
                     "400": {
@@ -1130,8 +1420,88 @@ const docTemplate = `{
                             "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_pkg_responses.Response-any"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_pkg_responses.Response-any"
+                        }
+                    }
+                }
+            }
+        },
+        "/marks/search": {
+            "get": {
+                "description": "full-text search (russian) over mark descriptions and check comments, ordered by rank",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "marks"
+                ],
+                "summary": "Search markers",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "search query",
+                        "name": "q",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "number"
+                        },
+                        "collectionFormat": "csv",
+                        "description": "filter by mark types",
+                        "name": "mark_type_ids",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "number"
+                        },
+                        "collectionFormat": "csv",
+                        "description": "filter by mark statuses",
+                        "name": "mark_status_ids",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "number"
+                        },
+                        "collectionFormat": "csv",
+                        "description": "filter by bounding box: min lon, min lat, max lon, max lat",
+                        "name": "bbox",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "max number of marks in the page (capped by the server)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "cursor to the next page",
+                        "name": "cursor",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_pkg_responses.Response-internal_handler_marks_SearchMarksResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
                             "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_pkg_responses.Response-any"
                         }
@@ -1144,707 +1514,2822 @@ const docTemplate = `{
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "github_com_PritOriginal_problem-map-server_internal_models.AdminBoundary": {
-            "type": "object",
-            "properties": {
-                "admin_level": {
-                    "type": "integer"
-                },
-                "geom": {
-                    "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_internal_models.MultiPolygonJSON"
-                },
+        },
+        "/marks/statuses": {
+            "get": {
+                "description": "get mark statuses",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "marks"
+                ],
+                "summary": "List mark statuses",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_pkg_responses.Response-internal_handler_marks_GetMarkStatusesResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_pkg_responses.Response-any"
+                        }
+                    }
+                }
+            }
+        },
+        "/marks/types": {
+            "get": {
+                "description": "get mark types",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "marks"
+                ],
+                "summary": "List mark types",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_pkg_responses.Response-internal_handler_marks_GetMarkTypesResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_pkg_responses.Response-any"
+                        }
+                    }
+                }
+            }
+        },
+        "/marks/user/{id}": {
+            "get": {
+                "description": "get markers by user id",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "marks"
+                ],
+                "summary": "List markers by user id",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "user id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "max number of marks in the page (capped by the server)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "cursor to the next page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "created_at",
+                            "updated_at"
+                        ],
+                        "type": "string",
+                        "description": "sort field",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "asc",
+                            "desc"
+                        ],
+                        "type": "string",
+                        "description": "sort order",
+                        "name": "order",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_pkg_responses.Response-internal_handler_marks_GetMarksByUserIdResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_pkg_responses.Response-any"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_pkg_responses.Response-any"
+                        }
+                    }
+                }
+            }
+        },
+        "/marks/{id}": {
+            "get": {
+                "description": "get mark by id",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "marks"
+                ],
+                "summary": "Get mark by id",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "mark id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_pkg_responses.Response-internal_handler_marks_GetMarkByIdResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_pkg_responses.Response-any"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_pkg_responses.Response-any"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_pkg_responses.Response-any"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "delete the mark, allowed only for the author",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "marks"
+                ],
+                "summary": "Delete mark",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "Bearer \u003cAdd access token here\u003e",
+                        "description": "Insert your access token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "mark id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_pkg_responses.Response-internal_handler_marks_DeleteMarkResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_pkg_responses.Response-any"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_pkg_responses.Response-any"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_pkg_responses.Response-any"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_pkg_responses.Response-any"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_pkg_responses.Response-any"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "description": "edit description, type or correct the location of the unconfirmed mark, allowed only for the author",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "marks"
+                ],
+                "summary": "Update mark",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "Bearer \u003cAdd access token here\u003e",
+                        "description": "Insert your access token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "mark id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "mark fields to update",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler_marks.UpdateMarkRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_pkg_responses.Response-internal_handler_marks_UpdateMarkResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_pkg_responses.Response-any"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_pkg_responses.Response-any"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_pkg_responses.Response-any"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_pkg_responses.Response-any"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_pkg_responses.Response-any"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_pkg_responses.Response-any"
+                        }
+                    }
+                }
+            }
+        },
+        "/marks/{id}/confirm": {
+            "post": {
+                "description": "сonfirm the mark and moves it to a new status, allowed only for moderators",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "marks"
+                ],
+                "summary": "Confirm the mark",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "Bearer \u003cAdd access token here\u003e",
+                        "description": "Insert your access token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "mark id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_pkg_responses.Response-internal_handler_marks_ConfirmResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_pkg_responses.Response-any"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_pkg_responses.Response-any"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_pkg_responses.Response-any"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_pkg_responses.Response-any"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_pkg_responses.Response-any"
+                        }
+                    }
+                }
+            }
+        },
+        "/marks/{id}/consensus": {
+            "get": {
+                "description": "dry run of the consensus rule of the mark type on the checks of the current mark status, the status is not changed",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "marks"
+                ],
+                "summary": "Explain the mark consensus",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "mark id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_pkg_responses.Response-internal_handler_marks_ExplainConsensusResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_pkg_responses.Response-any"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_pkg_responses.Response-any"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_pkg_responses.Response-any"
+                        }
+                    }
+                }
+            }
+        },
+        "/marks/{id}/merge": {
+            "post": {
+                "description": "merge the duplicate marks into the mark, their checks and photos are moved to the mark and their ids resolve to it, allowed only for moderators",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "marks"
+                ],
+                "summary": "Merge duplicate marks",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "Bearer \u003cAdd access token here\u003e",
+                        "description": "Insert your access token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "canonical mark id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "merged marks",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler_marks.MergeMarksRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_pkg_responses.Response-internal_handler_marks_MergeMarksResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_pkg_responses.Response-any"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_pkg_responses.Response-any"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_pkg_responses.Response-any"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_pkg_responses.Response-any"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_pkg_responses.Response-any"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_pkg_responses.Response-any"
+                        }
+                    }
+                }
+            }
+        },
+        "/marks/{id}/reject": {
+            "post": {
+                "description": "reject the mark and moves it to a new status, allowed only for moderators",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "marks"
+                ],
+                "summary": "Reject the mark",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "Bearer \u003cAdd access token here\u003e",
+                        "description": "Insert your access token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "mark id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_pkg_responses.Response-internal_handler_marks_RejectResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_pkg_responses.Response-any"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_pkg_responses.Response-any"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_pkg_responses.Response-any"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_pkg_responses.Response-any"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_pkg_responses.Response-any"
+                        }
+                    }
+                }
+            }
+        },
+        "/marks/{id}/status-history": {
+            "get": {
+                "description": "displays the entire list of status changes history for a specific marker by markId",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "marks"
+                ],
+                "summary": "List mark statuses",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "mark id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "with checks",
+                        "name": "withChecks",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_pkg_responses.Response-internal_handler_marks_GetMarkStatusHistoryByMarkIdResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_pkg_responses.Response-any"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_pkg_responses.Response-any"
+                        }
+                    }
+                }
+            }
+        },
+        "/moderation/checks/{id}/photos/{number}/similar": {
+            "get": {
+                "description": "get the photos of the other checks near-identical to the check photo by the perceptual hash ordered by similarity, allowed only for moderators",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "moderation"
+                ],
+                "summary": "List similar photos",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "Bearer \u003cAdd access token here\u003e",
+                        "description": "Insert your access token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "check id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "photo number in the check",
+                        "name": "number",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_pkg_responses.Response-internal_handler_moderation_GetSimilarPhotosResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_pkg_responses.Response-any"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_pkg_responses.Response-any"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_pkg_responses.Response-any"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_pkg_responses.Response-any"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_pkg_responses.Response-any"
+                        }
+                    }
+                }
+            }
+        },
+        "/moderation/queue": {
+            "get": {
+                "description": "get the unconfirmed and under review marks with split votes or stalled voting ordered by priority, the marks claimed by other moderators are skipped, allowed only for moderators",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "moderation"
+                ],
+                "summary": "List moderation queue",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "Bearer \u003cAdd access token here\u003e",
+                        "description": "Insert your access token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "max number of marks in the page (capped by the server)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "cursor to the next page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "asc",
+                            "desc"
+                        ],
+                        "type": "string",
+                        "description": "sort order by priority",
+                        "name": "order",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_pkg_responses.Response-internal_handler_moderation_GetQueueResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_pkg_responses.Response-any"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_pkg_responses.Response-any"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_pkg_responses.Response-any"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_pkg_responses.Response-any"
+                        }
+                    }
+                }
+            }
+        },
+        "/moderation/queue/{markId}/claim": {
+            "post": {
+                "description": "reserve the mark for the moderator for a limited time, claiming the own mark again extends the claim, allowed only for moderators",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "moderation"
+                ],
+                "summary": "Claim the mark",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "Bearer \u003cAdd access token here\u003e",
+                        "description": "Insert your access token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "mark id",
+                        "name": "markId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_pkg_responses.Response-internal_handler_moderation_ClaimResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_pkg_responses.Response-any"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_pkg_responses.Response-any"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_pkg_responses.Response-any"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_pkg_responses.Response-any"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_pkg_responses.Response-any"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_pkg_responses.Response-any"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "remove the moderator's claim of the mark, allowed only for moderators",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "moderation"
+                ],
+                "summary": "Release the mark",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "Bearer \u003cAdd access token here\u003e",
+                        "description": "Insert your access token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "mark id",
+                        "name": "markId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_pkg_responses.Response-internal_handler_moderation_ReleaseResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_pkg_responses.Response-any"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_pkg_responses.Response-any"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_pkg_responses.Response-any"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_pkg_responses.Response-any"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_pkg_responses.Response-any"
+                        }
+                    }
+                }
+            }
+        },
+        "/photos/uploads": {
+            "post": {
+                "description": "get the presigned URLs the photos are uploaded by directly to the S3 photo storage with PUT, the uploaded photos are attached to a check afterwards",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "photos"
+                ],
+                "summary": "Create photo uploads",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "Bearer \u003cAdd access token here\u003e",
+                        "description": "Insert your access token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "number of photos",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler_uploads.CreateUploadsRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_pkg_responses.Response-internal_handler_uploads_CreateUploadsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_pkg_responses.Response-any"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_pkg_responses.Response-any"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_pkg_responses.Response-any"
+                        }
+                    }
+                }
+            }
+        },
+        "/photos/{key}": {
+            "get": {
+                "description": "get the photo from the local photo storage",
+                "produces": [
+                    "image/jpeg"
+                ],
+                "tags": [
+                    "photos"
+                ],
+                "summary": "Get photo",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "photo key, marks/{markId}/{checkId}/{n}.jpg or marks/{markId}/{checkId}/{n}_{variant}.jpg",
+                        "name": "key",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_pkg_responses.Response-any"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_pkg_responses.Response-any"
+                        }
+                    }
+                }
+            }
+        },
+        "/tasks": {
+            "get": {
+                "description": "get tasks",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tasks"
+                ],
+                "summary": "List tasks",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "max number of tasks in the page (capped by the server)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "cursor to the next page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "asc",
+                            "desc"
+                        ],
+                        "type": "string",
+                        "description": "sort order by id",
+                        "name": "order",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_pkg_responses.Response-internal_handler_tasks_GetTasksResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_pkg_responses.Response-any"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_pkg_responses.Response-any"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "add new task, allowed only for moderators",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tasks"
+                ],
+                "summary": "Add task",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "Bearer \u003cAdd access token here\u003e",
+                        "description": "Insert your access token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "query params",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler_tasks.AddTaskRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_pkg_responses.Response-internal_handler_tasks_AddTaskResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_pkg_responses.Response-any"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_pkg_responses.Response-any"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_pkg_responses.Response-any"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_pkg_responses.Response-any"
+                        }
+                    }
+                }
+            }
+        },
+        "/tasks/user/{id}": {
+            "get": {
+                "description": "get tasks by user id",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tasks"
+                ],
+                "summary": "Get tasks by user id",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "user id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_pkg_responses.Response-internal_handler_tasks_GetTasksByUserIdResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_pkg_responses.Response-any"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_pkg_responses.Response-any"
+                        }
+                    }
+                }
+            }
+        },
+        "/tasks/{id}": {
+            "get": {
+                "description": "get task by id",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tasks"
+                ],
+                "summary": "Get task by id",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "task id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_pkg_responses.Response-internal_handler_tasks_GetTaskByIdResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_pkg_responses.Response-any"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_pkg_responses.Response-any"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_pkg_responses.Response-any"
+                        }
+                    }
+                }
+            }
+        },
+        "/users": {
+            "get": {
+                "description": "get users",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "List users",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "max number of users in the page (capped by the server)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "cursor to the next page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "asc",
+                            "desc"
+                        ],
+                        "type": "string",
+                        "description": "sort order by id",
+                        "name": "order",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_pkg_responses.Response-internal_handler_users_GetUsersResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_pkg_responses.Response-any"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_pkg_responses.Response-any"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/me/sessions": {
+            "get": {
+                "description": "get the active sessions of the user ordered by the last use, the session of the access token is marked as current",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "List own sessions",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "Bearer \u003cAdd access token here\u003e",
+                        "description": "Insert your access token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_pkg_responses.Response-internal_handler_users_GetSessionsResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_pkg_responses.Response-any"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_pkg_responses.Response-any"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/me/sessions/{sessionId}": {
+            "delete": {
+                "description": "revoke the session of the user, its refresh token can't be used anymore, the access tokens issued for it stay valid until they expire",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Revoke own session",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "Bearer \u003cAdd access token here\u003e",
+                        "description": "Insert your access token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "session id",
+                        "name": "sessionId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_pkg_responses.Response-internal_handler_users_DeleteSessionResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_pkg_responses.Response-any"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_pkg_responses.Response-any"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_pkg_responses.Response-any"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{id}": {
+            "get": {
+                "description": "get user by id",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Get user by id",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "user id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_pkg_responses.Response-internal_handler_users_GetUserByIdResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_pkg_responses.Response-any"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_pkg_responses.Response-any"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_pkg_responses.Response-any"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "github_com_PritOriginal_problem-map-server_internal_models.AdminBoundary": {
+            "type": "object",
+            "properties": {
+                "admin_level": {
+                    "type": "integer"
+                },
+                "geom": {
+                    "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_internal_models.MultiPolygonJSON"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_PritOriginal_problem-map-server_internal_models.AdminBoundaryMarksCount": {
+            "type": "object",
+            "properties": {
+                "closed_count": {
+                    "type": "integer"
+                },
+                "confirmed_count": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "total_count": {
+                    "type": "integer"
+                },
+                "unconfirmed_count": {
+                    "type": "integer"
+                },
+                "under_review_count": {
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_PritOriginal_problem-map-server_internal_models.Check": {
+            "type": "object",
+            "properties": {
+                "check_id": {
+                    "type": "integer"
+                },
+                "comment": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "distance": {
+                    "$ref": "#/definitions/null.Float"
+                },
+                "flagged": {
+                    "type": "boolean"
+                },
+                "location": {
+                    "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_internal_models.PointJSON"
+                },
+                "mark_id": {
+                    "type": "integer"
+                },
+                "mark_status_history_id": {
+                    "type": "integer"
+                },
+                "mark_status_id": {
+                    "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_internal_models.MarkStatusType"
+                },
+                "photos": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_internal_models.PhotoURLs"
+                    }
+                },
+                "result": {
+                    "type": "boolean"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "integer"
+                },
+                "username": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_PritOriginal_problem-map-server_internal_models.City": {
+            "type": "object",
+            "properties": {
+                "city_id": {
+                    "type": "integer"
+                },
+                "geom": {
+                    "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_internal_models.PolygonJSON"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "region_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_PritOriginal_problem-map-server_internal_models.ConsensusDecision": {
+            "type": "string",
+            "enum": [
+                "none",
+                "confirm",
+                "reject"
+            ],
+            "x-enum-varnames": [
+                "NoDecision",
+                "ConfirmDecision",
+                "RejectDecision"
+            ]
+        },
+        "github_com_PritOriginal_problem-map-server_internal_models.ConsensusExplanation": {
+            "type": "object",
+            "properties": {
+                "decision": {
+                    "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_internal_models.ConsensusDecision"
+                },
+                "distinct_users": {
+                    "type": "integer"
+                },
+                "mark_id": {
+                    "type": "integer"
+                },
+                "mark_status_id": {
+                    "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_internal_models.MarkStatusType"
+                },
+                "mark_type_id": {
+                    "type": "integer"
+                },
+                "new_mark_status_id": {
+                    "$ref": "#/definitions/null.Value-github_com_PritOriginal_problem-map-server_internal_models_MarkStatusType"
+                },
+                "ratio": {
+                    "description": "Ratio is the share of the leading side in the weight of all checks",
+                    "type": "number"
+                },
+                "reasons": {
+                    "description": "Reasons explain the decision",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "rule": {
+                    "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_internal_models.ConsensusRule"
+                },
+                "score": {
+                    "description": "Score is the net weight of the checks, positive for confirming",
+                    "type": "number"
+                },
+                "votes": {
+                    "type": "integer"
+                },
+                "weight_against": {
+                    "type": "number"
+                },
+                "weight_for": {
+                    "type": "number"
+                }
+            }
+        },
+        "github_com_PritOriginal_problem-map-server_internal_models.ConsensusRule": {
+            "type": "object",
+            "properties": {
+                "flagged_weight": {
+                    "description": "FlaggedWeight scales the weight of the flagged checks, 0 ignores them",
+                    "type": "number"
+                },
+                "half_life": {
+                    "description": "HalfLife is the age of the check at which its weight halves, 0 disables the time decay",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/time.Duration"
+                        }
+                    ]
+                },
+                "min_distinct_users": {
+                    "description": "MinDistinctUsers is the min number of the users who checked the mark, 0 disables it",
+                    "type": "integer"
+                },
+                "min_votes": {
+                    "description": "MinVotes is the min number of the checks, 0 disables it",
+                    "type": "integer"
+                },
+                "ratio": {
+                    "description": "Ratio is the min share of the winning side in the weight of all checks, 0 disables it",
+                    "type": "number"
+                },
+                "reputation_weighted": {
+                    "description": "ReputationWeighted weights the checks by the rating of their users",
+                    "type": "boolean"
+                },
+                "threshold": {
+                    "description": "Threshold is the net weight of the checks for or against needed to change the status",
+                    "type": "number"
+                }
+            }
+        },
+        "github_com_PritOriginal_problem-map-server_internal_models.District": {
+            "type": "object",
+            "properties": {
+                "city_id": {
+                    "type": "integer"
+                },
+                "district_id": {
+                    "type": "integer"
+                },
+                "geom": {
+                    "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_internal_models.PolygonJSON"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_PritOriginal_problem-map-server_internal_models.FoundMark": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "geom": {
+                    "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_internal_models.PointJSON"
+                },
+                "mark_id": {
+                    "type": "integer"
+                },
+                "mark_status_id": {
+                    "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_internal_models.MarkStatusType"
+                },
+                "mark_type_id": {
+                    "type": "integer"
+                },
+                "rank": {
+                    "type": "number"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_PritOriginal_problem-map-server_internal_models.Mark": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "geom": {
+                    "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_internal_models.PointJSON"
+                },
+                "mark_id": {
+                    "type": "integer"
+                },
+                "mark_status_id": {
+                    "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_internal_models.MarkStatusType"
+                },
+                "mark_type_id": {
+                    "type": "integer"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_PritOriginal_problem-map-server_internal_models.MarkCluster": {
+            "type": "object",
+            "properties": {
+                "closed_count": {
+                    "type": "integer"
+                },
+                "confirmed_count": {
+                    "type": "integer"
+                },
+                "geom": {
+                    "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_internal_models.PointJSON"
+                },
+                "total_count": {
+                    "type": "integer"
+                },
+                "unconfirmed_count": {
+                    "type": "integer"
+                },
+                "under_review_count": {
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_PritOriginal_problem-map-server_internal_models.MarkStatus": {
+            "type": "object",
+            "properties": {
+                "mark_status_id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "parent_id": {
+                    "$ref": "#/definitions/null.Int"
+                }
+            }
+        },
+        "github_com_PritOriginal_problem-map-server_internal_models.MarkStatusHistoryItem": {
+            "type": "object",
+            "properties": {
+                "changed_at": {
+                    "type": "string"
+                },
+                "checks": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_internal_models.Check"
+                    }
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "mark_id": {
+                    "type": "integer"
+                },
+                "merged_mark_id": {
+                    "$ref": "#/definitions/null.Int"
+                },
+                "new_mark_status_id": {
+                    "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_internal_models.MarkStatusType"
+                },
+                "old_mark_status_id": {
+                    "$ref": "#/definitions/null.Value-github_com_PritOriginal_problem-map-server_internal_models_MarkStatusType"
+                },
+                "prev_id": {
+                    "$ref": "#/definitions/null.Int"
+                }
+            }
+        },
+        "github_com_PritOriginal_problem-map-server_internal_models.MarkStatusType": {
+            "type": "integer",
+            "enum": [
+                1,
+                2,
+                3,
+                4,
+                5,
+                6
+            ],
+            "x-enum-varnames": [
+                "UnconfirmedStatus",
+                "ConfirmedStatus",
+                "UnderReviewStatus",
+                "RediscoveredStatus",
+                "ClosedStatus",
+                "RefutedStatus"
+            ]
+        },
+        "github_com_PritOriginal_problem-map-server_internal_models.MarkType": {
+            "type": "object",
+            "properties": {
+                "mark_type_id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_PritOriginal_problem-map-server_internal_models.ModerationClaim": {
+            "type": "object",
+            "properties": {
+                "claimed_at": {
+                    "type": "string"
+                },
+                "expires_at": {
+                    "type": "string"
+                },
+                "mark_id": {
+                    "type": "integer"
+                },
+                "user_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_PritOriginal_problem-map-server_internal_models.ModerationQueueItem": {
+            "type": "object",
+            "properties": {
+                "claim_expires_at": {
+                    "type": "string"
+                },
+                "claimed_by": {
+                    "$ref": "#/definitions/null.Int"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "duplicate_photos": {
+                    "type": "boolean"
+                },
+                "geom": {
+                    "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_internal_models.PointJSON"
+                },
+                "last_activity_at": {
+                    "type": "string"
+                },
+                "mark_id": {
+                    "type": "integer"
+                },
+                "mark_status_id": {
+                    "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_internal_models.MarkStatusType"
+                },
+                "mark_type_id": {
+                    "type": "integer"
+                },
+                "population_density": {
+                    "type": "number"
+                },
+                "priority": {
+                    "type": "number"
+                },
+                "reasons": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_internal_models.ModerationReason"
+                    }
+                },
+                "status_changed_at": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "integer"
+                },
+                "votes_against": {
+                    "type": "integer"
+                },
+                "votes_for": {
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_PritOriginal_problem-map-server_internal_models.ModerationReason": {
+            "type": "string",
+            "enum": [
+                "split_votes",
+                "stalled",
+                "duplicate_photos"
+            ],
+            "x-enum-varnames": [
+                "SplitVotesReason",
+                "StalledReason",
+                "DuplicatePhotosReason"
+            ]
+        },
+        "github_com_PritOriginal_problem-map-server_internal_models.MultiPolygonJSON": {
+            "type": "object",
+            "properties": {
+                "coordinates": {
+                    "type": "array",
+                    "items": {
+                        "type": "array",
+                        "items": {
+                            "type": "array",
+                            "items": {
+                                "type": "array",
+                                "items": {
+                                    "type": "number",
+                                    "format": "float64"
+                                }
+                            }
+                        }
+                    }
+                },
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_PritOriginal_problem-map-server_internal_models.NearbyMark": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "distance": {
+                    "type": "number"
+                },
+                "geom": {
+                    "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_internal_models.PointJSON"
+                },
+                "mark_id": {
+                    "type": "integer"
+                },
+                "mark_status_id": {
+                    "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_internal_models.MarkStatusType"
+                },
+                "mark_type_id": {
+                    "type": "integer"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_PritOriginal_problem-map-server_internal_models.PhotoURLs": {
+            "type": "object",
+            "properties": {
+                "medium": {
+                    "type": "string"
+                },
+                "original": {
+                    "type": "string"
+                },
+                "thumbnail": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_PritOriginal_problem-map-server_internal_models.PhotoUpload": {
+            "type": "object",
+            "properties": {
+                "expires_at": {
+                    "type": "string"
+                },
+                "key": {
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_PritOriginal_problem-map-server_internal_models.PointJSON": {
+            "type": "object",
+            "properties": {
+                "coordinates": {
+                    "type": "array",
+                    "items": {
+                        "type": "number"
+                    },
+                    "example": [
+                        0,
+                        0
+                    ]
+                },
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_PritOriginal_problem-map-server_internal_models.PolygonJSON": {
+            "type": "object",
+            "properties": {
+                "coordinates": {
+                    "type": "array",
+                    "items": {
+                        "type": "array",
+                        "items": {
+                            "type": "number",
+                            "format": "float64"
+                        }
+                    }
+                },
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_PritOriginal_problem-map-server_internal_models.Region": {
+            "type": "object",
+            "properties": {
+                "geom": {
+                    "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_internal_models.PolygonJSON"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "region_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_PritOriginal_problem-map-server_internal_models.Session": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "current": {
+                    "description": "Current is set for the session of the request",
+                    "type": "boolean"
+                },
+                "expires_at": {
+                    "type": "string"
+                },
                 "id": {
+                    "type": "string"
+                },
+                "ip": {
+                    "type": "string"
+                },
+                "last_used_at": {
+                    "description": "LastUsedAt is the time of the sign-in or the last refresh of the session",
+                    "type": "string"
+                },
+                "user_agent": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_PritOriginal_problem-map-server_internal_models.SimilarPhoto": {
+            "type": "object",
+            "properties": {
+                "check_id": {
+                    "type": "integer"
+                },
+                "distance": {
+                    "description": "Distance is the Hamming distance between the perceptual hashes of the photos",
+                    "type": "integer"
+                },
+                "mark_id": {
+                    "type": "integer"
+                },
+                "number": {
+                    "type": "integer"
+                },
+                "urls": {
+                    "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_internal_models.PhotoURLs"
+                }
+            }
+        },
+        "github_com_PritOriginal_problem-map-server_internal_models.Task": {
+            "type": "object",
+            "properties": {
+                "mark_id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "status_id": {
+                    "type": "integer"
+                },
+                "task_id": {
+                    "type": "integer"
+                },
+                "user_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_PritOriginal_problem-map-server_internal_models.User": {
+            "type": "object",
+            "properties": {
+                "home_point": {
+                    "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_internal_models.PointJSON"
+                },
+                "login": {
+                    "type": "string"
+                },
+                "rating": {
+                    "type": "integer"
+                },
+                "role_id": {
+                    "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_internal_models.UserRole"
+                },
+                "user_id": {
                     "type": "integer"
                 },
-                "name": {
-                    "type": "string"
+                "username": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_PritOriginal_problem-map-server_internal_models.UserRole": {
+            "type": "integer",
+            "enum": [
+                1,
+                2,
+                3
+            ],
+            "x-enum-varnames": [
+                "CitizenRole",
+                "ModeratorRole",
+                "AdminRole"
+            ]
+        },
+        "github_com_PritOriginal_problem-map-server_pkg_responses.ErrorInfo": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_PritOriginal_problem-map-server_pkg_responses.Response-any": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_pkg_responses.ErrorInfo"
+                },
+                "payload": {},
+                "success": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "github_com_PritOriginal_problem-map-server_pkg_responses.Response-internal_handler_auth_ChangePasswordResponse": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_pkg_responses.ErrorInfo"
+                },
+                "payload": {
+                    "$ref": "#/definitions/internal_handler_auth.ChangePasswordResponse"
+                },
+                "success": {
+                    "type": "boolean"
                 }
             }
         },
-        "github_com_PritOriginal_problem-map-server_internal_models.AdminBoundaryMarksCount": {
+        "github_com_PritOriginal_problem-map-server_pkg_responses.Response-internal_handler_auth_LogoutResponse": {
             "type": "object",
             "properties": {
-                "closed_count": {
-                    "type": "integer"
-                },
-                "confirmed_count": {
-                    "type": "integer"
-                },
-                "id": {
-                    "type": "integer"
-                },
-                "name": {
-                    "type": "string"
-                },
-                "total_count": {
-                    "type": "integer"
+                "error": {
+                    "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_pkg_responses.ErrorInfo"
                 },
-                "unconfirmed_count": {
-                    "type": "integer"
+                "payload": {
+                    "$ref": "#/definitions/internal_handler_auth.LogoutResponse"
                 },
-                "under_review_count": {
-                    "type": "integer"
+                "success": {
+                    "type": "boolean"
                 }
             }
         },
-        "github_com_PritOriginal_problem-map-server_internal_models.Check": {
+        "github_com_PritOriginal_problem-map-server_pkg_responses.Response-internal_handler_auth_RefreshTokensResponse": {
             "type": "object",
             "properties": {
-                "check_id": {
-                    "type": "integer"
-                },
-                "comment": {
-                    "type": "string"
-                },
-                "created_at": {
-                    "type": "string"
-                },
-                "mark_id": {
-                    "type": "integer"
-                },
-                "mark_status_history_id": {
-                    "type": "integer"
-                },
-                "mark_status_id": {
-                    "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_internal_models.MarkStatusType"
+                "error": {
+                    "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_pkg_responses.ErrorInfo"
                 },
-                "photos": {
-                    "type": "array",
-                    "items": {
-                        "type": "string"
-                    }
+                "payload": {
+                    "$ref": "#/definitions/internal_handler_auth.RefreshTokensResponse"
                 },
-                "result": {
+                "success": {
                     "type": "boolean"
-                },
-                "updated_at": {
-                    "type": "string"
-                },
-                "user_id": {
-                    "type": "integer"
-                },
-                "username": {
-                    "type": "string"
                 }
             }
         },
-        "github_com_PritOriginal_problem-map-server_internal_models.City": {
+        "github_com_PritOriginal_problem-map-server_pkg_responses.Response-internal_handler_auth_ResetPasswordResponse": {
             "type": "object",
             "properties": {
-                "city_id": {
-                    "type": "integer"
-                },
-                "geom": {
-                    "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_internal_models.PolygonJSON"
+                "error": {
+                    "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_pkg_responses.ErrorInfo"
                 },
-                "name": {
-                    "type": "string"
+                "payload": {
+                    "$ref": "#/definitions/internal_handler_auth.ResetPasswordResponse"
                 },
-                "region_id": {
-                    "type": "integer"
+                "success": {
+                    "type": "boolean"
                 }
             }
         },
-        "github_com_PritOriginal_problem-map-server_internal_models.District": {
+        "github_com_PritOriginal_problem-map-server_pkg_responses.Response-internal_handler_auth_SignInResponse": {
             "type": "object",
             "properties": {
-                "city_id": {
-                    "type": "integer"
-                },
-                "district_id": {
-                    "type": "integer"
+                "error": {
+                    "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_pkg_responses.ErrorInfo"
                 },
-                "geom": {
-                    "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_internal_models.PolygonJSON"
+                "payload": {
+                    "$ref": "#/definitions/internal_handler_auth.SignInResponse"
                 },
-                "name": {
-                    "type": "string"
+                "success": {
+                    "type": "boolean"
                 }
             }
         },
-        "github_com_PritOriginal_problem-map-server_internal_models.Mark": {
+        "github_com_PritOriginal_problem-map-server_pkg_responses.Response-internal_handler_auth_SignUpResponse": {
             "type": "object",
             "properties": {
-                "created_at": {
-                    "type": "string"
-                },
-                "description": {
-                    "type": "string"
-                },
-                "geom": {
-                    "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_internal_models.PointJSON"
-                },
-                "mark_id": {
-                    "type": "integer"
+                "error": {
+                    "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_pkg_responses.ErrorInfo"
                 },
-                "mark_status_id": {
-                    "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_internal_models.MarkStatusType"
+                "payload": {
+                    "$ref": "#/definitions/internal_handler_auth.SignUpResponse"
                 },
-                "mark_type_id": {
-                    "type": "integer"
+                "success": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "github_com_PritOriginal_problem-map-server_pkg_responses.Response-internal_handler_checks_AddCheckResponse": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_pkg_responses.ErrorInfo"
                 },
-                "updated_at": {
-                    "type": "string"
+                "payload": {
+                    "$ref": "#/definitions/internal_handler_checks.AddCheckResponse"
                 },
-                "user_id": {
-                    "type": "integer"
+                "success": {
+                    "type": "boolean"
                 }
             }
         },
-        "github_com_PritOriginal_problem-map-server_internal_models.MarkStatus": {
+        "github_com_PritOriginal_problem-map-server_pkg_responses.Response-internal_handler_checks_CheckTooFarResponse": {
             "type": "object",
             "properties": {
-                "mark_status_id": {
-                    "type": "integer"
+                "error": {
+                    "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_pkg_responses.ErrorInfo"
                 },
-                "name": {
-                    "type": "string"
+                "payload": {
+                    "$ref": "#/definitions/internal_handler_checks.CheckTooFarResponse"
                 },
-                "parent_id": {
-                    "$ref": "#/definitions/null.Int"
+                "success": {
+                    "type": "boolean"
                 }
             }
         },
-        "github_com_PritOriginal_problem-map-server_internal_models.MarkStatusHistoryItem": {
+        "github_com_PritOriginal_problem-map-server_pkg_responses.Response-internal_handler_checks_GetCheckByIdResponse": {
             "type": "object",
             "properties": {
-                "changed_at": {
-                    "type": "string"
+                "error": {
+                    "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_pkg_responses.ErrorInfo"
                 },
-                "checks": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_internal_models.Check"
-                    }
+                "payload": {
+                    "$ref": "#/definitions/internal_handler_checks.GetCheckByIdResponse"
                 },
-                "id": {
-                    "type": "integer"
+                "success": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "github_com_PritOriginal_problem-map-server_pkg_responses.Response-internal_handler_checks_GetChecksByMarkIdResponse": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_pkg_responses.ErrorInfo"
                 },
-                "mark_id": {
-                    "type": "integer"
+                "payload": {
+                    "$ref": "#/definitions/internal_handler_checks.GetChecksByMarkIdResponse"
                 },
-                "new_mark_status_id": {
-                    "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_internal_models.MarkStatusType"
+                "success": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "github_com_PritOriginal_problem-map-server_pkg_responses.Response-internal_handler_checks_GetChecksByUserIdResponse": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_pkg_responses.ErrorInfo"
                 },
-                "old_mark_status_id": {
-                    "$ref": "#/definitions/null.Value-github_com_PritOriginal_problem-map-server_internal_models_MarkStatusType"
+                "payload": {
+                    "$ref": "#/definitions/internal_handler_checks.GetChecksByUserIdResponse"
                 },
-                "prev_id": {
-                    "$ref": "#/definitions/null.Int"
+                "success": {
+                    "type": "boolean"
                 }
             }
         },
-        "github_com_PritOriginal_problem-map-server_internal_models.MarkStatusType": {
-            "type": "integer",
-            "enum": [
-                1,
-                2,
-                3,
-                4,
-                5,
-                6
-            ],
-            "x-enum-varnames": [
-                "UnconfirmedStatus",
-                "ConfirmedStatus",
-                "UnderReviewStatus",
-                "RediscoveredStatus",
-                "ClosedStatus",
-                "RefutedStatus"
-            ]
-        },
-        "github_com_PritOriginal_problem-map-server_internal_models.MarkType": {
+        "github_com_PritOriginal_problem-map-server_pkg_responses.Response-internal_handler_map_GetAdminBoundariesMarksCountResponse": {
             "type": "object",
             "properties": {
-                "mark_type_id": {
-                    "type": "integer"
+                "error": {
+                    "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_pkg_responses.ErrorInfo"
                 },
-                "name": {
-                    "type": "string"
+                "payload": {
+                    "$ref": "#/definitions/internal_handler_map.GetAdminBoundariesMarksCountResponse"
+                },
+                "success": {
+                    "type": "boolean"
                 }
             }
         },
-        "github_com_PritOriginal_problem-map-server_internal_models.MultiPolygonJSON": {
+        "github_com_PritOriginal_problem-map-server_pkg_responses.Response-internal_handler_map_GetAdminBoundariesResponse": {
             "type": "object",
             "properties": {
-                "coordinates": {
-                    "type": "array",
-                    "items": {
-                        "type": "array",
-                        "items": {
-                            "type": "array",
-                            "items": {
-                                "type": "array",
-                                "items": {
-                                    "type": "number",
-                                    "format": "float64"
-                                }
-                            }
-                        }
-                    }
+                "error": {
+                    "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_pkg_responses.ErrorInfo"
+                },
+                "payload": {
+                    "$ref": "#/definitions/internal_handler_map.GetAdminBoundariesResponse"
                 },
-                "type": {
-                    "type": "string"
+                "success": {
+                    "type": "boolean"
                 }
             }
         },
-        "github_com_PritOriginal_problem-map-server_internal_models.PointJSON": {
+        "github_com_PritOriginal_problem-map-server_pkg_responses.Response-internal_handler_map_GetCitiesResponse": {
             "type": "object",
             "properties": {
-                "coordinates": {
-                    "type": "array",
-                    "items": {
-                        "type": "number"
-                    },
-                    "example": [
-                        0,
-                        0
-                    ]
+                "error": {
+                    "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_pkg_responses.ErrorInfo"
                 },
-                "type": {
-                    "type": "string"
+                "payload": {
+                    "$ref": "#/definitions/internal_handler_map.GetCitiesResponse"
+                },
+                "success": {
+                    "type": "boolean"
                 }
             }
         },
-        "github_com_PritOriginal_problem-map-server_internal_models.PolygonJSON": {
+        "github_com_PritOriginal_problem-map-server_pkg_responses.Response-internal_handler_map_GetDistrictsResponse": {
             "type": "object",
             "properties": {
-                "coordinates": {
-                    "type": "array",
-                    "items": {
-                        "type": "array",
-                        "items": {
-                            "type": "number",
-                            "format": "float64"
-                        }
-                    }
+                "error": {
+                    "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_pkg_responses.ErrorInfo"
                 },
-                "type": {
-                    "type": "string"
+                "payload": {
+                    "$ref": "#/definitions/internal_handler_map.GetDistrictsResponse"
+                },
+                "success": {
+                    "type": "boolean"
                 }
             }
         },
-        "github_com_PritOriginal_problem-map-server_internal_models.Region": {
+        "github_com_PritOriginal_problem-map-server_pkg_responses.Response-internal_handler_map_GetMarkClustersResponse": {
             "type": "object",
             "properties": {
-                "geom": {
-                    "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_internal_models.PolygonJSON"
+                "error": {
+                    "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_pkg_responses.ErrorInfo"
                 },
-                "name": {
-                    "type": "string"
+                "payload": {
+                    "$ref": "#/definitions/internal_handler_map.GetMarkClustersResponse"
                 },
-                "region_id": {
-                    "type": "integer"
+                "success": {
+                    "type": "boolean"
                 }
             }
         },
-        "github_com_PritOriginal_problem-map-server_internal_models.Task": {
+        "github_com_PritOriginal_problem-map-server_pkg_responses.Response-internal_handler_map_GetRegionsResponse": {
             "type": "object",
             "properties": {
-                "mark_id": {
-                    "type": "integer"
-                },
-                "name": {
-                    "type": "string"
-                },
-                "status_id": {
-                    "type": "integer"
+                "error": {
+                    "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_pkg_responses.ErrorInfo"
                 },
-                "task_id": {
-                    "type": "integer"
+                "payload": {
+                    "$ref": "#/definitions/internal_handler_map.GetRegionsResponse"
                 },
-                "user_id": {
-                    "type": "integer"
+                "success": {
+                    "type": "boolean"
                 }
             }
         },
-        "github_com_PritOriginal_problem-map-server_internal_models.User": {
+        "github_com_PritOriginal_problem-map-server_pkg_responses.Response-internal_handler_marks_AddMarkResponse": {
             "type": "object",
             "properties": {
-                "home_point": {
-                    "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_internal_models.PointJSON"
-                },
-                "login": {
-                    "type": "string"
-                },
-                "rating": {
-                    "type": "integer"
+                "error": {
+                    "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_pkg_responses.ErrorInfo"
                 },
-                "user_id": {
-                    "type": "integer"
+                "payload": {
+                    "$ref": "#/definitions/internal_handler_marks.AddMarkResponse"
                 },
-                "username": {
-                    "type": "string"
+                "success": {
+                    "type": "boolean"
                 }
             }
         },
-        "github_com_PritOriginal_problem-map-server_pkg_responses.ErrorInfo": {
+        "github_com_PritOriginal_problem-map-server_pkg_responses.Response-internal_handler_marks_ConfirmResponse": {
             "type": "object",
             "properties": {
-                "message": {
-                    "type": "string"
+                "error": {
+                    "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_pkg_responses.ErrorInfo"
+                },
+                "payload": {
+                    "$ref": "#/definitions/internal_handler_marks.ConfirmResponse"
+                },
+                "success": {
+                    "type": "boolean"
                 }
             }
         },
-        "github_com_PritOriginal_problem-map-server_pkg_responses.Response-any": {
+        "github_com_PritOriginal_problem-map-server_pkg_responses.Response-internal_handler_marks_DeleteMarkResponse": {
             "type": "object",
             "properties": {
                 "error": {
                     "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_pkg_responses.ErrorInfo"
                 },
-                "payload": {},
+                "payload": {
+                    "$ref": "#/definitions/internal_handler_marks.DeleteMarkResponse"
+                },
                 "success": {
                     "type": "boolean"
                 }
             }
         },
-        "github_com_PritOriginal_problem-map-server_pkg_responses.Response-internal_handler_auth_RefreshTokensResponse": {
+        "github_com_PritOriginal_problem-map-server_pkg_responses.Response-internal_handler_marks_DuplicateMarksResponse": {
             "type": "object",
             "properties": {
                 "error": {
                     "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_pkg_responses.ErrorInfo"
                 },
                 "payload": {
-                    "$ref": "#/definitions/internal_handler_auth.RefreshTokensResponse"
+                    "$ref": "#/definitions/internal_handler_marks.DuplicateMarksResponse"
                 },
                 "success": {
                     "type": "boolean"
                 }
             }
         },
-        "github_com_PritOriginal_problem-map-server_pkg_responses.Response-internal_handler_auth_SignInResponse": {
+        "github_com_PritOriginal_problem-map-server_pkg_responses.Response-internal_handler_marks_ExplainConsensusResponse": {
             "type": "object",
             "properties": {
                 "error": {
                     "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_pkg_responses.ErrorInfo"
                 },
                 "payload": {
-                    "$ref": "#/definitions/internal_handler_auth.SignInResponse"
+                    "$ref": "#/definitions/internal_handler_marks.ExplainConsensusResponse"
                 },
                 "success": {
                     "type": "boolean"
                 }
             }
         },
-        "github_com_PritOriginal_problem-map-server_pkg_responses.Response-internal_handler_auth_SignUpResponse": {
+        "github_com_PritOriginal_problem-map-server_pkg_responses.Response-internal_handler_marks_GetMarkByIdResponse": {
             "type": "object",
             "properties": {
                 "error": {
                     "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_pkg_responses.ErrorInfo"
                 },
                 "payload": {
-                    "$ref": "#/definitions/internal_handler_auth.SignUpResponse"
+                    "$ref": "#/definitions/internal_handler_marks.GetMarkByIdResponse"
                 },
                 "success": {
                     "type": "boolean"
                 }
             }
         },
-        "github_com_PritOriginal_problem-map-server_pkg_responses.Response-internal_handler_checks_AddCheckResponse": {
+        "github_com_PritOriginal_problem-map-server_pkg_responses.Response-internal_handler_marks_GetMarkStatusHistoryByMarkIdResponse": {
             "type": "object",
             "properties": {
                 "error": {
                     "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_pkg_responses.ErrorInfo"
                 },
                 "payload": {
-                    "$ref": "#/definitions/internal_handler_checks.AddCheckResponse"
+                    "$ref": "#/definitions/internal_handler_marks.GetMarkStatusHistoryByMarkIdResponse"
                 },
                 "success": {
                     "type": "boolean"
                 }
             }
         },
-        "github_com_PritOriginal_problem-map-server_pkg_responses.Response-internal_handler_checks_GetCheckByIdResponse": {
+        "github_com_PritOriginal_problem-map-server_pkg_responses.Response-internal_handler_marks_GetMarkStatusesResponse": {
             "type": "object",
             "properties": {
                 "error": {
                     "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_pkg_responses.ErrorInfo"
                 },
                 "payload": {
-                    "$ref": "#/definitions/internal_handler_checks.GetCheckByIdResponse"
+                    "$ref": "#/definitions/internal_handler_marks.GetMarkStatusesResponse"
                 },
                 "success": {
                     "type": "boolean"
                 }
             }
         },
-        "github_com_PritOriginal_problem-map-server_pkg_responses.Response-internal_handler_checks_GetChecksByMarkIdResponse": {
+        "github_com_PritOriginal_problem-map-server_pkg_responses.Response-internal_handler_marks_GetMarkTypesResponse": {
             "type": "object",
             "properties": {
                 "error": {
                     "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_pkg_responses.ErrorInfo"
                 },
                 "payload": {
-                    "$ref": "#/definitions/internal_handler_checks.GetChecksByMarkIdResponse"
+                    "$ref": "#/definitions/internal_handler_marks.GetMarkTypesResponse"
                 },
                 "success": {
                     "type": "boolean"
                 }
             }
         },
-        "github_com_PritOriginal_problem-map-server_pkg_responses.Response-internal_handler_checks_GetChecksByUserIdResponse": {
+        "github_com_PritOriginal_problem-map-server_pkg_responses.Response-internal_handler_marks_GetMarksByUserIdResponse": {
             "type": "object",
             "properties": {
                 "error": {
                     "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_pkg_responses.ErrorInfo"
                 },
                 "payload": {
-                    "$ref": "#/definitions/internal_handler_checks.GetChecksByUserIdResponse"
+                    "$ref": "#/definitions/internal_handler_marks.GetMarksByUserIdResponse"
                 },
                 "success": {
                     "type": "boolean"
                 }
             }
         },
-        "github_com_PritOriginal_problem-map-server_pkg_responses.Response-internal_handler_map_GetAdminBoundariesMarksCountResponse": {
+        "github_com_PritOriginal_problem-map-server_pkg_responses.Response-internal_handler_marks_GetMarksResponse": {
             "type": "object",
             "properties": {
                 "error": {
                     "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_pkg_responses.ErrorInfo"
                 },
                 "payload": {
-                    "$ref": "#/definitions/internal_handler_map.GetAdminBoundariesMarksCountResponse"
+                    "$ref": "#/definitions/internal_handler_marks.GetMarksResponse"
                 },
                 "success": {
                     "type": "boolean"
                 }
             }
         },
-        "github_com_PritOriginal_problem-map-server_pkg_responses.Response-internal_handler_map_GetAdminBoundariesResponse": {
+        "github_com_PritOriginal_problem-map-server_pkg_responses.Response-internal_handler_marks_GetNearbyMarksResponse": {
             "type": "object",
             "properties": {
                 "error": {
                     "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_pkg_responses.ErrorInfo"
                 },
                 "payload": {
-                    "$ref": "#/definitions/internal_handler_map.GetAdminBoundariesResponse"
+                    "$ref": "#/definitions/internal_handler_marks.GetNearbyMarksResponse"
                 },
                 "success": {
                     "type": "boolean"
                 }
             }
         },
-        "github_com_PritOriginal_problem-map-server_pkg_responses.Response-internal_handler_map_GetCitiesResponse": {
+        "github_com_PritOriginal_problem-map-server_pkg_responses.Response-internal_handler_marks_MergeMarksResponse": {
             "type": "object",
             "properties": {
                 "error": {
                     "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_pkg_responses.ErrorInfo"
                 },
                 "payload": {
-                    "$ref": "#/definitions/internal_handler_map.GetCitiesResponse"
+                    "$ref": "#/definitions/internal_handler_marks.MergeMarksResponse"
                 },
                 "success": {
                     "type": "boolean"
                 }
             }
         },
-        "github_com_PritOriginal_problem-map-server_pkg_responses.Response-internal_handler_map_GetDistrictsResponse": {
+        "github_com_PritOriginal_problem-map-server_pkg_responses.Response-internal_handler_marks_RejectResponse": {
             "type": "object",
             "properties": {
                 "error": {
                     "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_pkg_responses.ErrorInfo"
                 },
                 "payload": {
-                    "$ref": "#/definitions/internal_handler_map.GetDistrictsResponse"
+                    "$ref": "#/definitions/internal_handler_marks.RejectResponse"
                 },
                 "success": {
                     "type": "boolean"
                 }
             }
         },
-        "github_com_PritOriginal_problem-map-server_pkg_responses.Response-internal_handler_map_GetRegionsResponse": {
+        "github_com_PritOriginal_problem-map-server_pkg_responses.Response-internal_handler_marks_SearchMarksResponse": {
             "type": "object",
             "properties": {
                 "error": {
                     "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_pkg_responses.ErrorInfo"
                 },
                 "payload": {
-                    "$ref": "#/definitions/internal_handler_map.GetRegionsResponse"
+                    "$ref": "#/definitions/internal_handler_marks.SearchMarksResponse"
                 },
                 "success": {
                     "type": "boolean"
                 }
             }
         },
-        "github_com_PritOriginal_problem-map-server_pkg_responses.Response-internal_handler_marks_AddMarkResponse": {
+        "github_com_PritOriginal_problem-map-server_pkg_responses.Response-internal_handler_marks_UpdateMarkResponse": {
             "type": "object",
             "properties": {
                 "error": {
                     "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_pkg_responses.ErrorInfo"
                 },
                 "payload": {
-                    "$ref": "#/definitions/internal_handler_marks.AddMarkResponse"
+                    "$ref": "#/definitions/internal_handler_marks.UpdateMarkResponse"
                 },
                 "success": {
                     "type": "boolean"
                 }
             }
         },
-        "github_com_PritOriginal_problem-map-server_pkg_responses.Response-internal_handler_marks_ConfirmResponse": {
+        "github_com_PritOriginal_problem-map-server_pkg_responses.Response-internal_handler_moderation_ClaimResponse": {
             "type": "object",
             "properties": {
                 "error": {
                     "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_pkg_responses.ErrorInfo"
                 },
                 "payload": {
-                    "$ref": "#/definitions/internal_handler_marks.ConfirmResponse"
+                    "$ref": "#/definitions/internal_handler_moderation.ClaimResponse"
                 },
                 "success": {
                     "type": "boolean"
                 }
             }
         },
-        "github_com_PritOriginal_problem-map-server_pkg_responses.Response-internal_handler_marks_GetMarkByIdResponse": {
+        "github_com_PritOriginal_problem-map-server_pkg_responses.Response-internal_handler_moderation_GetQueueResponse": {
             "type": "object",
             "properties": {
                 "error": {
                     "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_pkg_responses.ErrorInfo"
                 },
                 "payload": {
-                    "$ref": "#/definitions/internal_handler_marks.GetMarkByIdResponse"
+                    "$ref": "#/definitions/internal_handler_moderation.GetQueueResponse"
                 },
                 "success": {
                     "type": "boolean"
                 }
             }
         },
-        "github_com_PritOriginal_problem-map-server_pkg_responses.Response-internal_handler_marks_GetMarkStatusHistoryByMarkIdResponse": {
+        "github_com_PritOriginal_problem-map-server_pkg_responses.Response-internal_handler_moderation_GetSimilarPhotosResponse": {
             "type": "object",
             "properties": {
                 "error": {
                     "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_pkg_responses.ErrorInfo"
                 },
                 "payload": {
-                    "$ref": "#/definitions/internal_handler_marks.GetMarkStatusHistoryByMarkIdResponse"
+                    "$ref": "#/definitions/internal_handler_moderation.GetSimilarPhotosResponse"
                 },
                 "success": {
                     "type": "boolean"
                 }
             }
         },
-        "github_com_PritOriginal_problem-map-server_pkg_responses.Response-internal_handler_marks_GetMarkStatusesResponse": {
+        "github_com_PritOriginal_problem-map-server_pkg_responses.Response-internal_handler_moderation_ReleaseResponse": {
             "type": "object",
             "properties": {
                 "error": {
                     "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_pkg_responses.ErrorInfo"
                 },
                 "payload": {
-                    "$ref": "#/definitions/internal_handler_marks.GetMarkStatusesResponse"
+                    "$ref": "#/definitions/internal_handler_moderation.ReleaseResponse"
                 },
                 "success": {
                     "type": "boolean"
                 }
             }
         },
-        "github_com_PritOriginal_problem-map-server_pkg_responses.Response-internal_handler_marks_GetMarkTypesResponse": {
+        "github_com_PritOriginal_problem-map-server_pkg_responses.Response-internal_handler_tasks_AddTaskResponse": {
             "type": "object",
             "properties": {
                 "error": {
                     "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_pkg_responses.ErrorInfo"
                 },
                 "payload": {
-                    "$ref": "#/definitions/internal_handler_marks.GetMarkTypesResponse"
+                    "$ref": "#/definitions/internal_handler_tasks.AddTaskResponse"
                 },
                 "success": {
                     "type": "boolean"
                 }
             }
         },
-        "github_com_PritOriginal_problem-map-server_pkg_responses.Response-internal_handler_marks_GetMarksByUserIdResponse": {
+        "github_com_PritOriginal_problem-map-server_pkg_responses.Response-internal_handler_tasks_GetTaskByIdResponse": {
             "type": "object",
             "properties": {
                 "error": {
                     "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_pkg_responses.ErrorInfo"
                 },
                 "payload": {
-                    "$ref": "#/definitions/internal_handler_marks.GetMarksByUserIdResponse"
+                    "$ref": "#/definitions/internal_handler_tasks.GetTaskByIdResponse"
                 },
                 "success": {
                     "type": "boolean"
                 }
             }
         },
-        "github_com_PritOriginal_problem-map-server_pkg_responses.Response-internal_handler_marks_GetMarksResponse": {
+        "github_com_PritOriginal_problem-map-server_pkg_responses.Response-internal_handler_tasks_GetTasksByUserIdResponse": {
             "type": "object",
             "properties": {
                 "error": {
                     "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_pkg_responses.ErrorInfo"
                 },
                 "payload": {
-                    "$ref": "#/definitions/internal_handler_marks.GetMarksResponse"
+                    "$ref": "#/definitions/internal_handler_tasks.GetTasksByUserIdResponse"
                 },
                 "success": {
                     "type": "boolean"
                 }
             }
         },
-        "github_com_PritOriginal_problem-map-server_pkg_responses.Response-internal_handler_marks_RejectResponse": {
+        "github_com_PritOriginal_problem-map-server_pkg_responses.Response-internal_handler_tasks_GetTasksResponse": {
             "type": "object",
             "properties": {
                 "error": {
                     "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_pkg_responses.ErrorInfo"
                 },
                 "payload": {
-                    "$ref": "#/definitions/internal_handler_marks.RejectResponse"
+                    "$ref": "#/definitions/internal_handler_tasks.GetTasksResponse"
                 },
                 "success": {
                     "type": "boolean"
                 }
             }
         },
-        "github_com_PritOriginal_problem-map-server_pkg_responses.Response-internal_handler_tasks_AddTaskResponse": {
+        "github_com_PritOriginal_problem-map-server_pkg_responses.Response-internal_handler_uploads_AttachPhotosResponse": {
             "type": "object",
             "properties": {
                 "error": {
                     "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_pkg_responses.ErrorInfo"
                 },
                 "payload": {
-                    "$ref": "#/definitions/internal_handler_tasks.AddTaskResponse"
+                    "$ref": "#/definitions/internal_handler_uploads.AttachPhotosResponse"
                 },
                 "success": {
                     "type": "boolean"
                 }
             }
         },
-        "github_com_PritOriginal_problem-map-server_pkg_responses.Response-internal_handler_tasks_GetTaskByIdResponse": {
+        "github_com_PritOriginal_problem-map-server_pkg_responses.Response-internal_handler_uploads_CreateUploadsResponse": {
             "type": "object",
             "properties": {
                 "error": {
                     "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_pkg_responses.ErrorInfo"
                 },
                 "payload": {
-                    "$ref": "#/definitions/internal_handler_tasks.GetTaskByIdResponse"
+                    "$ref": "#/definitions/internal_handler_uploads.CreateUploadsResponse"
                 },
                 "success": {
                     "type": "boolean"
                 }
             }
         },
-        "github_com_PritOriginal_problem-map-server_pkg_responses.Response-internal_handler_tasks_GetTasksByUserIdResponse": {
+        "github_com_PritOriginal_problem-map-server_pkg_responses.Response-internal_handler_users_DeleteSessionResponse": {
             "type": "object",
             "properties": {
                 "error": {
                     "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_pkg_responses.ErrorInfo"
                 },
                 "payload": {
-                    "$ref": "#/definitions/internal_handler_tasks.GetTasksByUserIdResponse"
+                    "$ref": "#/definitions/internal_handler_users.DeleteSessionResponse"
                 },
                 "success": {
                     "type": "boolean"
                 }
             }
         },
-        "github_com_PritOriginal_problem-map-server_pkg_responses.Response-internal_handler_tasks_GetTasksResponse": {
+        "github_com_PritOriginal_problem-map-server_pkg_responses.Response-internal_handler_users_GetSessionsResponse": {
             "type": "object",
             "properties": {
                 "error": {
                     "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_pkg_responses.ErrorInfo"
                 },
                 "payload": {
-                    "$ref": "#/definitions/internal_handler_tasks.GetTasksResponse"
+                    "$ref": "#/definitions/internal_handler_users.GetSessionsResponse"
                 },
                 "success": {
                     "type": "boolean"
@@ -1868,14 +4353,102 @@ const docTemplate = `{
         "github_com_PritOriginal_problem-map-server_pkg_responses.Response-internal_handler_users_GetUsersResponse": {
             "type": "object",
             "properties": {
-                "error": {
-                    "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_pkg_responses.ErrorInfo"
-                },
-                "payload": {
-                    "$ref": "#/definitions/internal_handler_users.GetUsersResponse"
-                },
-                "success": {
-                    "type": "boolean"
+                "error": {
+                    "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_pkg_responses.ErrorInfo"
+                },
+                "payload": {
+                    "$ref": "#/definitions/internal_handler_users.GetUsersResponse"
+                },
+                "success": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "github_com_PritOriginal_problem-map-server_pkg_token.JWK": {
+            "type": "object",
+            "properties": {
+                "alg": {
+                    "type": "string"
+                },
+                "crv": {
+                    "description": "Crv and X are the curve and the public key of the EdDSA key",
+                    "type": "string"
+                },
+                "e": {
+                    "type": "string"
+                },
+                "kid": {
+                    "type": "string"
+                },
+                "kty": {
+                    "type": "string"
+                },
+                "n": {
+                    "description": "N and E are the modulus and the exponent of the RSA key",
+                    "type": "string"
+                },
+                "use": {
+                    "type": "string"
+                },
+                "x": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_PritOriginal_problem-map-server_pkg_token.JWKS": {
+            "type": "object",
+            "properties": {
+                "keys": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_pkg_token.JWK"
+                    }
+                }
+            }
+        },
+        "internal_handler_auth.ChangePasswordRequest": {
+            "type": "object",
+            "required": [
+                "new_password",
+                "old_password"
+            ],
+            "properties": {
+                "new_password": {
+                    "type": "string",
+                    "maxLength": 64,
+                    "minLength": 8
+                },
+                "old_password": {
+                    "type": "string",
+                    "maxLength": 64,
+                    "minLength": 8
+                }
+            }
+        },
+        "internal_handler_auth.ChangePasswordResponse": {
+            "type": "object",
+            "properties": {
+                "user_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "internal_handler_auth.LogoutRequest": {
+            "type": "object",
+            "required": [
+                "refresh_token"
+            ],
+            "properties": {
+                "refresh_token": {
+                    "type": "string"
+                }
+            }
+        },
+        "internal_handler_auth.LogoutResponse": {
+            "type": "object",
+            "properties": {
+                "user_id": {
+                    "type": "integer"
                 }
             }
         },
@@ -1901,6 +4474,45 @@ const docTemplate = `{
                 }
             }
         },
+        "internal_handler_auth.RequestPasswordResetRequest": {
+            "type": "object",
+            "required": [
+                "login"
+            ],
+            "properties": {
+                "login": {
+                    "type": "string",
+                    "maxLength": 40,
+                    "minLength": 3
+                }
+            }
+        },
+        "internal_handler_auth.ResetPasswordRequest": {
+            "type": "object",
+            "required": [
+                "new_password",
+                "token"
+            ],
+            "properties": {
+                "new_password": {
+                    "type": "string",
+                    "maxLength": 64,
+                    "minLength": 8
+                },
+                "token": {
+                    "type": "string",
+                    "maxLength": 64
+                }
+            }
+        },
+        "internal_handler_auth.ResetPasswordResponse": {
+            "type": "object",
+            "properties": {
+                "user_id": {
+                    "type": "integer"
+                }
+            }
+        },
         "internal_handler_auth.SignInRequest": {
             "type": "object",
             "required": [
@@ -1972,6 +4584,17 @@ const docTemplate = `{
                 }
             }
         },
+        "internal_handler_checks.CheckTooFarResponse": {
+            "type": "object",
+            "properties": {
+                "distance": {
+                    "type": "number"
+                },
+                "max_distance": {
+                    "type": "number"
+                }
+            }
+        },
         "internal_handler_checks.GetCheckByIdResponse": {
             "type": "object",
             "properties": {
@@ -1988,6 +4611,9 @@ const docTemplate = `{
                     "items": {
                         "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_internal_models.Check"
                     }
+                },
+                "next_cursor": {
+                    "type": "string"
                 }
             }
         },
@@ -1999,6 +4625,9 @@ const docTemplate = `{
                     "items": {
                         "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_internal_models.Check"
                     }
+                },
+                "next_cursor": {
+                    "type": "string"
                 }
             }
         },
@@ -2046,6 +4675,26 @@ const docTemplate = `{
                 }
             }
         },
+        "internal_handler_map.GetMarkClustersResponse": {
+            "type": "object",
+            "properties": {
+                "clusters": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_internal_models.MarkCluster"
+                    }
+                },
+                "marks": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_internal_models.Mark"
+                    }
+                },
+                "truncated": {
+                    "type": "boolean"
+                }
+            }
+        },
         "internal_handler_map.GetRegionsResponse": {
             "type": "object",
             "properties": {
@@ -2073,6 +4722,33 @@ const docTemplate = `{
                 }
             }
         },
+        "internal_handler_marks.DeleteMarkResponse": {
+            "type": "object",
+            "properties": {
+                "mark_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "internal_handler_marks.DuplicateMarksResponse": {
+            "type": "object",
+            "properties": {
+                "marks": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_internal_models.NearbyMark"
+                    }
+                }
+            }
+        },
+        "internal_handler_marks.ExplainConsensusResponse": {
+            "type": "object",
+            "properties": {
+                "explanation": {
+                    "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_internal_models.ConsensusExplanation"
+                }
+            }
+        },
         "internal_handler_marks.GetMarkByIdResponse": {
             "type": "object",
             "properties": {
@@ -2122,6 +4798,9 @@ const docTemplate = `{
                     "items": {
                         "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_internal_models.Mark"
                     }
+                },
+                "next_cursor": {
+                    "type": "string"
                 }
             }
         },
@@ -2133,6 +4812,56 @@ const docTemplate = `{
                     "items": {
                         "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_internal_models.Mark"
                     }
+                },
+                "next_cursor": {
+                    "type": "string"
+                },
+                "truncated": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "internal_handler_marks.GetNearbyMarksResponse": {
+            "type": "object",
+            "properties": {
+                "marks": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_internal_models.NearbyMark"
+                    }
+                },
+                "next_cursor": {
+                    "type": "string"
+                }
+            }
+        },
+        "internal_handler_marks.MergeMarksRequest": {
+            "type": "object",
+            "required": [
+                "mark_ids"
+            ],
+            "properties": {
+                "mark_ids": {
+                    "type": "array",
+                    "maxItems": 50,
+                    "minItems": 1,
+                    "items": {
+                        "type": "integer"
+                    }
+                }
+            }
+        },
+        "internal_handler_marks.MergeMarksResponse": {
+            "type": "object",
+            "properties": {
+                "mark_id": {
+                    "type": "integer"
+                },
+                "merged_mark_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
                 }
             }
         },
@@ -2144,6 +4873,87 @@ const docTemplate = `{
                 }
             }
         },
+        "internal_handler_marks.SearchMarksResponse": {
+            "type": "object",
+            "properties": {
+                "marks": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_internal_models.FoundMark"
+                    }
+                },
+                "next_cursor": {
+                    "type": "string"
+                }
+            }
+        },
+        "internal_handler_marks.UpdateMarkRequest": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string",
+                    "maxLength": 256
+                },
+                "latitude": {
+                    "type": "number"
+                },
+                "longitude": {
+                    "type": "number"
+                },
+                "mark_type_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "internal_handler_marks.UpdateMarkResponse": {
+            "type": "object",
+            "properties": {
+                "mark_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "internal_handler_moderation.ClaimResponse": {
+            "type": "object",
+            "properties": {
+                "claim": {
+                    "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_internal_models.ModerationClaim"
+                }
+            }
+        },
+        "internal_handler_moderation.GetQueueResponse": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_internal_models.ModerationQueueItem"
+                    }
+                },
+                "next_cursor": {
+                    "type": "string"
+                }
+            }
+        },
+        "internal_handler_moderation.GetSimilarPhotosResponse": {
+            "type": "object",
+            "properties": {
+                "photos": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_internal_models.SimilarPhoto"
+                    }
+                }
+            }
+        },
+        "internal_handler_moderation.ReleaseResponse": {
+            "type": "object",
+            "properties": {
+                "mark_id": {
+                    "type": "integer"
+                }
+            }
+        },
         "internal_handler_tasks.AddTaskRequest": {
             "type": "object",
             "required": [
@@ -2193,6 +5003,9 @@ const docTemplate = `{
         "internal_handler_tasks.GetTasksResponse": {
             "type": "object",
             "properties": {
+                "next_cursor": {
+                    "type": "string"
+                },
                 "tasks": {
                     "type": "array",
                     "items": {
@@ -2201,6 +5014,76 @@ const docTemplate = `{
                 }
             }
         },
+        "internal_handler_uploads.AttachPhotosRequest": {
+            "type": "object",
+            "required": [
+                "keys"
+            ],
+            "properties": {
+                "keys": {
+                    "type": "array",
+                    "maxItems": 10,
+                    "minItems": 1,
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "internal_handler_uploads.AttachPhotosResponse": {
+            "type": "object",
+            "properties": {
+                "photos": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_internal_models.PhotoURLs"
+                    }
+                }
+            }
+        },
+        "internal_handler_uploads.CreateUploadsRequest": {
+            "type": "object",
+            "required": [
+                "count"
+            ],
+            "properties": {
+                "count": {
+                    "type": "integer",
+                    "maximum": 10,
+                    "minimum": 1
+                }
+            }
+        },
+        "internal_handler_uploads.CreateUploadsResponse": {
+            "type": "object",
+            "properties": {
+                "uploads": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_internal_models.PhotoUpload"
+                    }
+                }
+            }
+        },
+        "internal_handler_users.DeleteSessionResponse": {
+            "type": "object",
+            "properties": {
+                "session_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "internal_handler_users.GetSessionsResponse": {
+            "type": "object",
+            "properties": {
+                "sessions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_PritOriginal_problem-map-server_internal_models.Session"
+                    }
+                }
+            }
+        },
         "internal_handler_users.GetUserByIdResponse": {
             "type": "object",
             "properties": {
@@ -2212,6 +5095,9 @@ const docTemplate = `{
         "internal_handler_users.GetUsersResponse": {
             "type": "object",
             "properties": {
+                "next_cursor": {
+                    "type": "string"
+                },
                 "users": {
                     "type": "array",
                     "items": {
@@ -2220,6 +5106,19 @@ const docTemplate = `{
                 }
             }
         },
+        "null.Float": {
+            "type": "object",
+            "properties": {
+                "float64": {
+                    "type": "number",
+                    "format": "float64"
+                },
+                "valid": {
+                    "description": "Valid is true if Float64 is not NULL",
+                    "type": "boolean"
+                }
+            }
+        },
         "null.Int": {
             "type": "object",
             "properties": {
@@ -2243,6 +5142,26 @@ const docTemplate = `{
                     "type": "boolean"
                 }
             }
+        },
+        "time.Duration": {
+            "type": "integer",
+            "format": "int64",
+            "enum": [
+                1,
+                1000,
+                1000000,
+                1000000000,
+                60000000000,
+                3600000000000
+            ],
+            "x-enum-varnames": [
+                "Nanosecond",
+                "Microsecond",
+                "Millisecond",
+                "Second",
+                "Minute",
+                "Hour"
+            ]
         }
     },
     "tags": [
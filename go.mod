@@ -7,6 +7,7 @@ require (
 	github.com/appleboy/gin-jwt/v3 v3.5.1
 	github.com/brianvoe/gofakeit/v7 v7.9.0
 	github.com/gin-gonic/gin v1.12.0
+	github.com/golang-jwt/jwt/v5 v5.3.1
 	github.com/golang-migrate/migrate/v4 v4.19.0
 	github.com/grpc-ecosystem/go-grpc-middleware/v2 v2.3.3
 	github.com/guregu/null/v6 v6.0.0
@@ -58,7 +59,6 @@ require (
 	github.com/go-playground/validator/v10 v10.30.2 // indirect
 	github.com/goccy/go-json v0.10.6 // indirect
 	github.com/goccy/go-yaml v1.19.2 // indirect
-	github.com/golang-jwt/jwt/v5 v5.3.1 // indirect
 	github.com/google/uuid v1.6.0 // indirect
 	github.com/hashicorp/errwrap v1.1.0 // indirect
 	github.com/hashicorp/go-multierror v1.1.1 // indirect
@@ -103,7 +103,6 @@ require (
 	github.com/aws/aws-sdk-go-v2/credentials v1.18.21
 	github.com/aws/aws-sdk-go-v2/service/s3 v1.90.0
 	github.com/fatih/color v1.18.0 // indirect
-	github.com/golang-jwt/jwt v3.2.2+incompatible
 	github.com/samber/slog-gin v1.21.0
 	github.com/swaggo/gin-swagger v1.6.1
 	github.com/twpayne/go-geom v1.6.1
@@ -6,11 +6,14 @@ import (
 	"log/slog"
 	"net"
 
+	pb "github.com/PritOriginal/problem-map-protos/gen/go"
 	"github.com/PritOriginal/problem-map-server/internal/config"
 	mapgrpc "github.com/PritOriginal/problem-map-server/internal/grpc/map"
 	marksgrpc "github.com/PritOriginal/problem-map-server/internal/grpc/marks"
 	tasksgrpc "github.com/PritOriginal/problem-map-server/internal/grpc/tasks"
 	usersgrpc "github.com/PritOriginal/problem-map-server/internal/grpc/users"
+	"github.com/PritOriginal/problem-map-server/internal/middleware/rbac"
+	"github.com/PritOriginal/problem-map-server/internal/models"
 	"github.com/PritOriginal/problem-map-server/internal/storage/local"
 	"github.com/PritOriginal/problem-map-server/internal/storage/postgres"
 	"github.com/PritOriginal/problem-map-server/internal/storage/s3"
@@ -54,15 +57,27 @@ func New(log *slog.Logger, cfg *config.Config) *App {
 		}),
 	}
 
+	accessKeys, err := usecase.LoadAccessKeys(cfg.Auth)
+	if err != nil {
+		log.Error("failed load access token keys", slogger.Err(err))
+		panic(err)
+	}
+
+	// methods missing here don't require authorization
+	permissions := map[string]models.UserRole{
+		pb.Tasks_AddTask_FullMethodName: models.ModeratorRole,
+	}
+
 	gRPCServer := grpc.NewServer(grpc.ChainUnaryInterceptor(
 		recovery.UnaryServerInterceptor(recoveryOpts...),
 		logging.UnaryServerInterceptor(InterceptorLogger(log), loggingOpts...),
+		rbac.UnaryServerInterceptor(accessKeys, permissions),
 	))
 
 	var photoRepo usecase.PhotosRepository
 	switch cfg.PhotoStorage {
 	case config.Local:
-		photoRepo = local.NewPhotos()
+		photoRepo = local.NewPhotos(cfg.Local)
 	case config.S3:
 		s3Client, err := s3.New(log, cfg.Aws)
 		if err != nil {
@@ -71,18 +86,19 @@ func New(log *slog.Logger, cfg *config.Config) *App {
 		}
 		log.Info("s3 connected!")
 
-		photoRepo = s3.NewPhotos(s3Client)
+		photoRepo = s3.NewPhotos(s3Client, cfg.Aws)
 	}
 
 	mapRepo := postgres.NewMap(postgresDB.DB)
+	marksRepo := postgres.NewMarks(postgresDB.DB)
 	mapUseCase := usecase.NewMap(log, usecase.MapRepositories{
-		Map: mapRepo,
+		Map:   mapRepo,
+		Marks: marksRepo,
 	})
 	mapgrpc.Register(gRPCServer, mapUseCase)
 
-	marksRepo := postgres.NewMarks(postgresDB.DB)
 	checksRepo := postgres.NewChecks(postgresDB.DB)
-	marksUseCase := usecase.NewMarks(log, usecase.MarksRepositories{
+	marksUseCase := usecase.NewMarks(log, cfg.Marks, usecase.MarksRepositories{
 		Marks:  marksRepo,
 		Checks: checksRepo,
 		Photos: photoRepo,
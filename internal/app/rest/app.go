@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"log/slog"
 	"net/http"
+	"os"
 	"strconv"
 	"time"
 
@@ -14,8 +15,12 @@ import (
 	checksrest "github.com/PritOriginal/problem-map-server/internal/handler/checks"
 	maprest "github.com/PritOriginal/problem-map-server/internal/handler/map"
 	marksrest "github.com/PritOriginal/problem-map-server/internal/handler/marks"
+	moderationrest "github.com/PritOriginal/problem-map-server/internal/handler/moderation"
+	photosrest "github.com/PritOriginal/problem-map-server/internal/handler/photos"
 	tasksrest "github.com/PritOriginal/problem-map-server/internal/handler/tasks"
+	uploadsrest "github.com/PritOriginal/problem-map-server/internal/handler/uploads"
 	usersrest "github.com/PritOriginal/problem-map-server/internal/handler/users"
+	"github.com/PritOriginal/problem-map-server/internal/notifier"
 	"github.com/PritOriginal/problem-map-server/internal/storage/local"
 	"github.com/PritOriginal/problem-map-server/internal/storage/postgres"
 	"github.com/PritOriginal/problem-map-server/internal/storage/redis"
@@ -31,6 +36,7 @@ type App struct {
 	log    *slog.Logger
 	db     *postgres.Postgres
 	router *gin.Engine
+	auth   *usecase.Auth
 	port   int
 }
 
@@ -42,15 +48,21 @@ func New(log *slog.Logger, cfg *config.Config) *App {
 	}
 	log.Info("PostgreSQL connected!")
 
-	redis, err := redis.New(cfg.Redis)
+	redisDB, err := redis.New(cfg.Redis)
 	if err != nil {
 		log.Error("failed connection to redis", slogger.Err(err))
 		panic(err)
 	}
 	log.Info("Redis connected!")
 
+	accessKeys, err := usecase.LoadAccessKeys(cfg.Auth)
+	if err != nil {
+		log.Error("failed load access token keys", slogger.Err(err))
+		panic(err)
+	}
+
 	authMiddleware, err := jwt.New(&jwt.GinJWTMiddleware{
-		Key: []byte(cfg.Auth.JWT.Access.Key),
+		KeyFunc: accessKeys.Keyfunc,
 	})
 	if err != nil {
 		log.Error("failed create auth middleware", slogger.Err(err))
@@ -63,59 +75,91 @@ func New(log *slog.Logger, cfg *config.Config) *App {
 	}
 
 	router := handler.GetRouter(log, cfg.Env)
+	if err := router.SetTrustedProxies(cfg.REST.TrustedProxies); err != nil {
+		log.Error("invalid trusted proxies", slogger.Err(err))
+		panic(err)
+	}
 
 	handler.SetSwagger(router, cfg)
 
 	mapRepo := postgres.NewMap(postgresDB.DB)
+	marksRepo := postgres.NewMarks(postgresDB.DB)
 
-	photoRepo := initPhotosRepository(log, cfg)
+	photoRepo, uploadsRepo := initPhotosRepository(log, cfg)
+	if cfg.PhotoStorage != config.S3 {
+		photosrest.Register(router, log, local.PhotosRoute, os.DirFS(cfg.Local.Root), cfg.Local.MaxAge)
+	}
 
 	mapUseCase := usecase.NewMap(log, usecase.MapRepositories{
-		Map: mapRepo,
+		Map:   mapRepo,
+		Marks: marksRepo,
 	})
-	maprest.Register(router, log, mapUseCase, redis)
+	maprest.Register(router, log, mapUseCase, redisDB)
 
-	marksRepo := postgres.NewMarks(postgresDB.DB)
 	checksRepo := postgres.NewChecks(postgresDB.DB)
-	markStatusUpdater := usecase.NewUpdater(log, usecase.UpdaterRepositories{
+	markStatusUpdater := usecase.NewUpdater(log, cfg.Consensus, usecase.UpdaterRepositories{
 		Marks:  marksRepo,
 		Checks: checksRepo,
+		Photos: photoRepo,
 	})
-	marksUseCase := usecase.NewMarks(log, usecase.MarksRepositories{
+	marksUseCase := usecase.NewMarks(log, cfg.Marks, usecase.MarksRepositories{
 		Marks:  marksRepo,
 		Checks: checksRepo,
 		Photos: photoRepo,
 	})
 	marksrest.Register(router, log, marksrest.Params{
 		AuthMiddleware: authMiddleware,
-		Cacher:         redis,
+		Cacher:         redisDB,
 		Usecase:        marksUseCase,
 		StatusUpdater:  markStatusUpdater,
 	})
 
-	checksUseCase := usecase.NewChecks(log, markStatusUpdater, usecase.ChecksRepositories{
+	checksUseCase := usecase.NewChecks(log, cfg.Checks, markStatusUpdater, usecase.ChecksRepositories{
 		Marks:  marksRepo,
 		Checks: checksRepo,
 		Photos: photoRepo,
 	})
 	checksrest.Register(router, log, authMiddleware, checksUseCase)
 
+	if uploadsRepo != nil {
+		uploadsUseCase := usecase.NewUploads(log, cfg.Aws, usecase.UploadsRepositories{
+			Checks:  checksRepo,
+			Photos:  photoRepo,
+			Uploads: uploadsRepo,
+		})
+		uploadsrest.Register(router, log, authMiddleware, uploadsUseCase)
+	}
+
 	usersRepo := postgres.NewUsers(postgresDB.DB)
+	sessionsRepo := redis.NewSessions(redisDB)
 	usersUseCase := usecase.NewUsers(log, usecase.UsersRepositories{
-		Users: usersRepo,
+		Users:    usersRepo,
+		Sessions: sessionsRepo,
 	})
-	usersrest.Register(router, log, usersUseCase)
+	usersrest.Register(router, log, authMiddleware, usersUseCase)
 
-	authUseCase := usecase.NewAuth(log, cfg.Auth, usecase.AuthRepositories{
-		Users: usersRepo,
+	authUseCase := usecase.NewAuth(log, cfg.Auth, accessKeys, initNotifier(log, cfg), usecase.AuthRepositories{
+		Users:          usersRepo,
+		Sessions:       sessionsRepo,
+		PasswordResets: postgres.NewPasswordResets(postgresDB.DB),
+		Attempts:       redis.NewAttempts(redisDB),
 	})
-	authrest.Register(router, log, authUseCase)
+	authrest.Register(router, log, authMiddleware, authUseCase)
 
 	tasksRepo := postgres.NewTasks(postgresDB.DB)
 	tasksUseCase := usecase.NewTasks(log, usecase.TasksRepositories{
 		Tasks: tasksRepo,
 	})
-	tasksrest.Register(router, log, tasksUseCase)
+	tasksrest.Register(router, log, authMiddleware, tasksUseCase)
+
+	moderationRepo := postgres.NewModeration(postgresDB.DB)
+	moderationUseCase := usecase.NewModeration(log, cfg.Moderation, usecase.ModerationRepositories{
+		Marks:      marksRepo,
+		Checks:     checksRepo,
+		Photos:     photoRepo,
+		Moderation: moderationRepo,
+	})
+	moderationrest.Register(router, log, authMiddleware, moderationUseCase)
 
 	server := &http.Server{
 		Addr:         cfg.REST.Host + ":" + strconv.Itoa(cfg.REST.Port),
@@ -130,11 +174,13 @@ func New(log *slog.Logger, cfg *config.Config) *App {
 		log:    log,
 		db:     postgresDB,
 		router: router,
+		auth:   authUseCase,
 		port:   cfg.REST.Port,
 	}
 }
 
-func initPhotosRepository(log *slog.Logger, cfg *config.Config) usecase.PhotosRepository {
+// initPhotosRepository returns the photo storage and the direct uploads to it, the local storage has no uploads
+func initPhotosRepository(log *slog.Logger, cfg *config.Config) (usecase.PhotosRepository, usecase.UploadsRepository) {
 	switch cfg.PhotoStorage {
 	case config.S3:
 		s3Client, err := s3.New(log, cfg.Aws)
@@ -144,9 +190,19 @@ func initPhotosRepository(log *slog.Logger, cfg *config.Config) usecase.PhotosRe
 		}
 		log.Info("s3 connected!")
 
-		return s3.NewPhotos(s3Client)
+		return s3.NewPhotos(s3Client, cfg.Aws), s3.NewUploads(s3Client, cfg.Aws)
+	default:
+		return local.NewPhotos(cfg.Local), nil
+	}
+}
+
+// initNotifier returns the delivery of the notifications to the users
+func initNotifier(log *slog.Logger, cfg *config.Config) usecase.Notifier {
+	switch cfg.Notifier.Type {
+	case config.FileNotifier:
+		return notifier.NewFile(cfg.Notifier.File)
 	default:
-		return local.NewPhotos()
+		return notifier.NewLog(log)
 	}
 }
 
@@ -180,6 +236,7 @@ func (a *App) Stop() {
 	if err := a.server.Shutdown(shutdownCtx); err != nil {
 		a.log.Error("an error occurred while stopping the server", slogger.Err(err))
 	}
+	a.auth.Wait()
 
 	if err := a.db.DB.Close(); err != nil {
 		a.log.Error("an error occurred while closing the connection to the database", slogger.Err(err))
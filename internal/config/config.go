@@ -5,6 +5,7 @@ import (
 	"os"
 	"time"
 
+	"github.com/PritOriginal/problem-map-server/internal/models"
 	"github.com/PritOriginal/problem-map-server/pkg/logger"
 	"github.com/ilyakaznacheev/cleanenv"
 )
@@ -18,6 +19,12 @@ type Config struct {
 	DB           DatabaseConfig     `yaml:"db"`
 	Redis        RedisConfig        `yaml:"redis"`
 	Aws          AwsConfig          `yaml:"aws"`
+	Local        LocalConfig        `yaml:"local"`
+	Marks        MarksConfig        `yaml:"marks"`
+	Moderation   ModerationConfig   `yaml:"moderation"`
+	Consensus    ConsensusConfig    `yaml:"consensus"`
+	Checks       ChecksConfig       `yaml:"checks"`
+	Notifier     NotifierConfig     `yaml:"notifier"`
 }
 
 type PhotoStorageType string
@@ -35,6 +42,9 @@ type RESTConfig struct {
 		Read  time.Duration `yaml:"read" env:"REST_TIMEOUT_READ"`
 		Idle  time.Duration `yaml:"idle" env:"REST_TIMEOUT_IDLE"`
 	} `yaml:"timeout"`
+	// TrustedProxies are the proxies the client IP is taken from the X-Forwarded-For header of,
+	// without them the client IP is the remote address
+	TrustedProxies []string `yaml:"trusted_proxies" env:"REST_TRUSTED_PROXIES" env-separator:","`
 }
 
 type GRPCConfig struct {
@@ -45,14 +55,60 @@ type GRPCConfig struct {
 type AuthConfing struct {
 	JWT struct {
 		Access struct {
+			// Key is the HS256 secret of the access tokens, with the asymmetric keys it only verifies
+			// the tokens issued before switching to them
 			Key       string        `yaml:"key" env:"JWT_ACCESS_TOKEN_KEY"`
 			ExpiredIn time.Duration `yaml:"expired_in" env:"JWT_ACCESS_TOKEN_EXPIRED_IN"`
+			// SigningKey is the id of the key of Keys the access tokens are signed by,
+			// the tokens are signed by Key without it
+			SigningKey string `yaml:"signing_key" env:"JWT_ACCESS_TOKEN_SIGNING_KEY"`
+			// Keys are the asymmetric keys of the access tokens, all of them verify the tokens and are published in the JWKS
+			Keys []JWTKey `yaml:"keys"`
 		} `yaml:"access"`
 		Refresh struct {
 			Key       string        `yaml:"key" env:"JWT_REFRESH_TOKEN_KEY"`
 			ExpiredIn time.Duration `yaml:"expired_in" env:"JWT_REFRESH_TOKEN_EXPIRED_IN"`
 		} `yaml:"refresh"`
 	} `yaml:"jwt"`
+	PasswordReset struct {
+		// ExpiredIn is the time the password reset token is valid
+		ExpiredIn time.Duration `yaml:"expired_in" env:"PASSWORD_RESET_EXPIRED_IN" env-default:"1h"`
+		// LoginAttempts is the number of the reset requests for the login before the lockouts
+		LoginAttempts int `yaml:"login_attempts" env:"PASSWORD_RESET_LOGIN_ATTEMPTS" env-default:"3"`
+		// IPAttempts is the number of the reset requests from the IP before the lockouts
+		IPAttempts int `yaml:"ip_attempts" env:"PASSWORD_RESET_IP_ATTEMPTS" env-default:"10"`
+		// Backoff is the lockout after the reset requests over the free ones
+		Backoff struct {
+			BaseDelay time.Duration `yaml:"base_delay" env:"PASSWORD_RESET_BASE_DELAY" env-default:"1m"`
+			MaxDelay  time.Duration `yaml:"max_delay" env:"PASSWORD_RESET_MAX_DELAY" env-default:"1h"`
+			Window    time.Duration `yaml:"window" env:"PASSWORD_RESET_WINDOW" env-default:"1h"`
+		} `yaml:"backoff"`
+	} `yaml:"password_reset"`
+	SignIn struct {
+		// LoginAttempts is the number of the failed sign-ins by the login before the lockouts
+		LoginAttempts int `yaml:"login_attempts" env:"SIGNIN_LOGIN_ATTEMPTS" env-default:"5"`
+		// IPAttempts is the number of the failed sign-ins from the IP before the lockouts, it's larger as the users may share the IP
+		IPAttempts int `yaml:"ip_attempts" env:"SIGNIN_IP_ATTEMPTS" env-default:"20"`
+		// Backoff is the lockout after the failed sign-ins over the free ones
+		Backoff struct {
+			// BaseDelay is the lockout after the first failed sign-in over the free ones, it doubles with every next one
+			BaseDelay time.Duration `yaml:"base_delay" env:"SIGNIN_BASE_DELAY" env-default:"1s"`
+			MaxDelay  time.Duration `yaml:"max_delay" env:"SIGNIN_MAX_DELAY" env-default:"15m"`
+			// Window is the time the failed sign-ins are counted for since the last one
+			Window time.Duration `yaml:"window" env:"SIGNIN_WINDOW" env-default:"1h"`
+		} `yaml:"backoff"`
+	} `yaml:"signin"`
+}
+
+// JWTKey is the asymmetric key of the tokens, the key without the private key file only verifies the tokens
+type JWTKey struct {
+	// ID is the kid header of the tokens signed by the key
+	ID string `yaml:"id"`
+	// Algorithm is RS256 or EdDSA
+	Algorithm      string `yaml:"algorithm"`
+	PrivateKeyFile string `yaml:"private_key_file"`
+	// PublicKeyFile is taken from the private key when it's empty
+	PublicKeyFile string `yaml:"public_key_file"`
 }
 
 type DatabaseConfig struct {
@@ -73,6 +129,111 @@ type AwsConfig struct {
 	Key       string `yaml:"key" env:"AWS_KEY"`
 	SecretKey string `yaml:"secret_key" env:"AWS_SECRET_KEY"`
 	EndPoint  string `yaml:"endpoint" env:"AWS_ENDPOINT"`
+	// Bucket is the bucket the photos are stored in
+	Bucket string `yaml:"bucket" env:"AWS_BUCKET"`
+	// Private is set for the bucket without the public read access, the photo URLs are presigned then
+	Private bool `yaml:"private" env:"AWS_PRIVATE"`
+	// URLExpires is the time the presigned photo URLs are valid
+	URLExpires time.Duration `yaml:"url_expires" env:"AWS_URL_EXPIRES" env-default:"1h"`
+	// UploadExpires is the time the presigned photo upload URLs are valid
+	UploadExpires time.Duration `yaml:"upload_expires" env:"AWS_UPLOAD_EXPIRES" env-default:"15m"`
+	// MaxUploadSize is the max size in bytes of the photo uploaded by the presigned URL
+	MaxUploadSize int64 `yaml:"max_upload_size" env:"AWS_MAX_UPLOAD_SIZE" env-default:"10485760"`
+}
+
+type LocalConfig struct {
+	// Root is the directory the photos are stored in
+	Root string `yaml:"root" env:"LOCAL_PHOTOS_ROOT" env-default:"photos"`
+	// BaseURL is the address of the server the photo URLs start with, without it the URLs are relative
+	BaseURL string `yaml:"base_url" env:"LOCAL_PHOTOS_BASE_URL"`
+	// MaxAge is the time the clients may cache the photos
+	MaxAge time.Duration `yaml:"max_age" env:"LOCAL_PHOTOS_MAX_AGE" env-default:"24h"`
+}
+
+type MarksConfig struct {
+	Duplicates struct {
+		Radius float64       `yaml:"radius" env:"MARKS_DUPLICATES_RADIUS" env-default:"50"`
+		Window time.Duration `yaml:"window" env:"MARKS_DUPLICATES_WINDOW" env-default:"720h"`
+	} `yaml:"duplicates"`
+}
+
+type ModerationConfig struct {
+	// StallAfter is the time without checks and status changes after which the voting is stalled
+	StallAfter time.Duration `yaml:"stall_after" env:"MODERATION_STALL_AFTER" env-default:"72h"`
+	// ClaimTTL is the time a moderator keeps the claimed mark
+	ClaimTTL time.Duration `yaml:"claim_ttl" env:"MODERATION_CLAIM_TTL" env-default:"30m"`
+	// MarkTypePriorities are the priorities of the mark types from 0 to 1, missing types have 0
+	MarkTypePriorities map[int]float64 `yaml:"mark_type_priorities"`
+}
+
+type ConsensusConfig struct {
+	// Default is the rule of the mark types without their own rule
+	Default struct {
+		Threshold          float64       `yaml:"threshold" env:"CONSENSUS_THRESHOLD" env-default:"3"`
+		MinVotes           int           `yaml:"min_votes" env:"CONSENSUS_MIN_VOTES"`
+		MinDistinctUsers   int           `yaml:"min_distinct_users" env:"CONSENSUS_MIN_DISTINCT_USERS"`
+		Ratio              float64       `yaml:"ratio" env:"CONSENSUS_RATIO"`
+		HalfLife           time.Duration `yaml:"half_life" env:"CONSENSUS_HALF_LIFE"`
+		ReputationWeighted bool          `yaml:"reputation_weighted" env:"CONSENSUS_REPUTATION_WEIGHTED"`
+		// FlaggedWeight scales the weight of the flagged checks, 0 ignores them. A zero in the file is replaced
+		// by the default, the flagged checks of the default rule are ignored by CONSENSUS_FLAGGED_WEIGHT=0
+		FlaggedWeight float64 `yaml:"flagged_weight" env:"CONSENSUS_FLAGGED_WEIGHT" env-default:"0.5"`
+	} `yaml:"default"`
+	// MarkTypes are the rules of the mark types, they replace the default rule completely
+	MarkTypes map[int]models.ConsensusRule `yaml:"mark_types"`
+}
+
+// Rule returns the consensus rule of the mark type
+func (c ConsensusConfig) Rule(markTypeId int) models.ConsensusRule {
+	if rule, ok := c.MarkTypes[markTypeId]; ok {
+		return rule
+	}
+	return models.ConsensusRule(c.Default)
+}
+
+type ProximityMode string
+
+const (
+	// RejectProximity rejects the checks made too far from the mark
+	RejectProximity ProximityMode = "reject"
+	// FlagProximity accepts the checks made too far from the mark as flagged
+	FlagProximity ProximityMode = "flag"
+)
+
+type ChecksConfig struct {
+	Proximity struct {
+		Mode ProximityMode `yaml:"mode" env:"CHECKS_PROXIMITY_MODE" env-default:"flag"`
+		// RequireLocation flags or, in the reject mode, rejects the checks without the location like the ones too far
+		RequireLocation bool `yaml:"require_location" env:"CHECKS_PROXIMITY_REQUIRE_LOCATION"`
+		// MaxDistance is the max distance in metres from the user to the mark of the types without their own distance
+		MaxDistance float64 `yaml:"max_distance" env:"CHECKS_PROXIMITY_MAX_DISTANCE" env-default:"500"`
+		// MarkTypeMaxDistances are the max distances of the mark types
+		MarkTypeMaxDistances map[int]float64 `yaml:"mark_type_max_distances"`
+	} `yaml:"proximity"`
+}
+
+// MaxDistance returns the max distance in metres from the user to the mark of the type
+func (c ChecksConfig) MaxDistance(markTypeId int) float64 {
+	if distance, ok := c.Proximity.MarkTypeMaxDistances[markTypeId]; ok {
+		return distance
+	}
+	return c.Proximity.MaxDistance
+}
+
+type NotifierType string
+
+const (
+	// LogNotifier writes the notifications to the log
+	LogNotifier NotifierType = "log"
+	// FileNotifier appends the notifications to the file
+	FileNotifier NotifierType = "file"
+)
+
+// NotifierConfig is the delivery of the notifications to the users, like the password reset tokens
+type NotifierConfig struct {
+	Type NotifierType `yaml:"type" env:"NOTIFIER_TYPE" env-default:"log"`
+	// File is the file of the file notifier
+	File string `yaml:"file" env:"NOTIFIER_FILE" env-default:"notifications.log"`
 }
 
 func MustLoad() *Config {
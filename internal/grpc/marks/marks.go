@@ -2,21 +2,26 @@ package marksgrpc
 
 import (
 	"context"
-	"io"
 
 	pb "github.com/PritOriginal/problem-map-protos/gen/go"
+	"github.com/PritOriginal/problem-map-server/internal/grpc/pagination"
 	"github.com/PritOriginal/problem-map-server/internal/models"
+	"github.com/PritOriginal/problem-map-server/pkg/handlers"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/emptypb"
 )
 
+// bboxKey is the request metadata key of the bounding box of GetMarks
+const bboxKey = "bbox"
+
 type Marks interface {
-	GetMarks(ctx context.Context, filters models.GetMarksFilters) ([]models.Mark, error)
+	GetMarks(ctx context.Context, filters models.GetMarksFilters) ([]models.Mark, string, error)
 	GetMarkById(ctx context.Context, id int) (models.Mark, error)
-	GetMarksByUserId(ctx context.Context, userId int) ([]models.Mark, error)
-	AddMark(ctx context.Context, mark models.Mark, photos []io.Reader) (int64, error)
+	GetMarksByUserId(ctx context.Context, userId int, pagination models.Pagination) ([]models.Mark, string, error)
+	AddMark(ctx context.Context, mark models.Mark, photos []models.Photo, force bool) (int64, error)
 	GetMarkTypes(ctx context.Context) ([]models.MarkType, error)
 	GetMarkStatuses(ctx context.Context) ([]models.MarkStatus, error)
 }
@@ -31,8 +36,21 @@ func Register(gRPCServer *grpc.Server, uc Marks) {
 }
 
 func (s *server) GetMarks(ctx context.Context, in *emptypb.Empty) (*pb.GetMarksResponse, error) {
-	marks, err := s.uc.GetMarks(ctx, models.GetMarksFilters{})
+	// TODO: move the bbox and the page to the request and response fields once problem-map-protos has them
+	page, err := pagination.FromIncoming(ctx, models.MarksSorting)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid pagination")
+	}
+	bbox, err := bboxFromIncoming(ctx)
 	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid bbox")
+	}
+
+	marks, nextCursor, err := s.uc.GetMarks(ctx, models.GetMarksFilters{BBox: bbox, Pagination: page})
+	if err != nil {
+		return nil, status.Error(codes.Internal, "error get marks")
+	}
+	if err := pagination.SetNextCursor(ctx, nextCursor); err != nil {
 		return nil, status.Error(codes.Internal, "error get marks")
 	}
 
@@ -46,6 +64,22 @@ func (s *server) GetMarks(ctx context.Context, in *emptypb.Empty) (*pb.GetMarksR
 	}, nil
 }
 
+// bboxFromIncoming returns the bounding box "min lon,min lat,max lon,max lat" of the bbox metadata,
+// nil when it is not set
+func bboxFromIncoming(ctx context.Context) (*models.BBox, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	values := md.Get(bboxKey)
+	if len(values) == 0 || values[0] == "" {
+		return nil, nil
+	}
+
+	coords, err := handlers.ParseFloatArray(values[0])
+	if err != nil {
+		return nil, err
+	}
+	return models.NewBBox(coords)
+}
+
 func (s *server) AddMark(ctx context.Context, in *pb.AddMarkRequest) (*pb.AddMarkResponse, error) {
 	return &pb.AddMarkResponse{}, nil
 }
@@ -0,0 +1,56 @@
+// Package pagination passes the keyset pagination of the gRPC lists in the metadata,
+// as the list requests and responses of problem-map-protos have no pagination fields yet
+package pagination
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/PritOriginal/problem-map-server/internal/models"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// The request metadata keys of the page and the response header keys of the next page
+const (
+	LimitKey      = "limit"
+	CursorKey     = "cursor"
+	SortKey       = "sort"
+	OrderKey      = "order"
+	NextCursorKey = "next-cursor"
+	TruncatedKey  = "truncated"
+)
+
+// DefaultLimit is the page size of the requests without the limit
+const DefaultLimit = 100
+
+// FromIncoming returns the page requested in the incoming metadata
+func FromIncoming(ctx context.Context, sorting models.Sorting) (models.Pagination, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+
+	limit := DefaultLimit
+	if value := first(md, LimitKey); value != "" {
+		var err error
+		if limit, err = strconv.Atoi(value); err != nil || limit <= 0 {
+			return models.Pagination{}, fmt.Errorf("invalid limit %q", value)
+		}
+	}
+
+	return sorting.NewPagination(limit, first(md, CursorKey), first(md, SortKey), first(md, OrderKey))
+}
+
+// SetNextCursor reports in the response header whether the list is truncated and the cursor to its next page
+func SetNextCursor(ctx context.Context, nextCursor string) error {
+	return grpc.SetHeader(ctx, metadata.Pairs(
+		TruncatedKey, strconv.FormatBool(nextCursor != ""),
+		NextCursorKey, nextCursor,
+	))
+}
+
+func first(md metadata.MD, key string) string {
+	if values := md.Get(key); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
@@ -5,6 +5,7 @@ import (
 	"errors"
 
 	pb "github.com/PritOriginal/problem-map-protos/gen/go"
+	"github.com/PritOriginal/problem-map-server/internal/grpc/pagination"
 	"github.com/PritOriginal/problem-map-server/internal/models"
 	"github.com/PritOriginal/problem-map-server/internal/storage"
 	"google.golang.org/grpc"
@@ -14,7 +15,7 @@ import (
 )
 
 type Tasks interface {
-	GetTasks(ctx context.Context) ([]models.Task, error)
+	GetTasks(ctx context.Context, pagination models.Pagination) ([]models.Task, string, error)
 	GetTaskById(ctx context.Context, id int) (models.Task, error)
 	GetTasksByUserId(ctx context.Context, userId int) ([]models.Task, error)
 	AddTask(ctx context.Context, task models.Task) (int64, error)
@@ -29,8 +30,17 @@ func Register(gRPCServer *grpc.Server, tasks Tasks) {
 }
 
 func (s *server) GetTasks(ctx context.Context, in *emptypb.Empty) (*pb.GetTasksResponse, error) {
-	tasks, err := s.tasks.GetTasks(context.Background())
+	// TODO: move the page to the request and response fields once problem-map-protos has them
+	page, err := pagination.FromIncoming(ctx, models.TasksSorting)
 	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid pagination")
+	}
+
+	tasks, nextCursor, err := s.tasks.GetTasks(ctx, page)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "error get tasks")
+	}
+	if err := pagination.SetNextCursor(ctx, nextCursor); err != nil {
 		return nil, status.Error(codes.Internal, "error get tasks")
 	}
 
@@ -4,6 +4,7 @@ import (
 	"context"
 
 	pb "github.com/PritOriginal/problem-map-protos/gen/go"
+	"github.com/PritOriginal/problem-map-server/internal/grpc/pagination"
 	"github.com/PritOriginal/problem-map-server/internal/models"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
@@ -13,7 +14,7 @@ import (
 
 type Users interface {
 	GetUserById(ctx context.Context, id int) (models.User, error)
-	GetUsers(ctx context.Context) ([]models.User, error)
+	GetUsers(ctx context.Context, pagination models.Pagination) ([]models.User, string, error)
 }
 
 type server struct {
@@ -39,8 +40,17 @@ func (s *server) GetUserById(ctx context.Context, in *pb.GetUserByIdRequest) (*p
 }
 
 func (s *server) GetUsers(ctx context.Context, in *emptypb.Empty) (*pb.GetUsersResponse, error) {
-	users, err := s.users.GetUsers(ctx)
+	// TODO: move the page to the request and response fields once problem-map-protos has them
+	page, err := pagination.FromIncoming(ctx, models.UsersSorting)
 	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid pagination")
+	}
+
+	users, nextCursor, err := s.users.GetUsers(ctx, page)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "error get users")
+	}
+	if err := pagination.SetNextCursor(ctx, nextCursor); err != nil {
 		return nil, status.Error(codes.Internal, "error get users")
 	}
 
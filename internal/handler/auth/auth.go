@@ -4,18 +4,29 @@ import (
 	"context"
 	"errors"
 	"log/slog"
+	"net/http"
 
+	"github.com/PritOriginal/problem-map-server/internal/handler/request"
+	"github.com/PritOriginal/problem-map-server/internal/models"
 	"github.com/PritOriginal/problem-map-server/internal/storage"
 	"github.com/PritOriginal/problem-map-server/internal/usecase"
 	"github.com/PritOriginal/problem-map-server/pkg/logger"
 	"github.com/PritOriginal/problem-map-server/pkg/responses"
+	"github.com/PritOriginal/problem-map-server/pkg/token"
+	jwt "github.com/appleboy/gin-jwt/v3"
 	"github.com/gin-gonic/gin"
 )
 
 type Auth interface {
 	SignUp(ctx context.Context, username, login, password string) (int64, error)
-	SignIn(ctx context.Context, login, password string) (string, string, error)
-	RefreshTokens(ctx context.Context, refreshToken string) (string, string, error)
+	SignIn(ctx context.Context, login, password string, client models.SessionClient) (string, string, error)
+	RefreshTokens(ctx context.Context, refreshToken string, client models.SessionClient) (string, string, error)
+	Logout(ctx context.Context, refreshToken string) (int, error)
+	LogoutAll(ctx context.Context, userId int) error
+	ChangePassword(ctx context.Context, userId int, sessionId, oldPassword, newPassword string) error
+	RequestPasswordReset(ctx context.Context, login, ip string) error
+	ResetPassword(ctx context.Context, resetToken, newPassword string) (int, error)
+	JWKS() token.JWKS
 }
 
 type handler struct {
@@ -23,7 +34,7 @@ type handler struct {
 	uc  Auth
 }
 
-func Register(r *gin.Engine, log *slog.Logger, uc Auth) {
+func Register(r *gin.Engine, log *slog.Logger, authMiddleware *jwt.GinJWTMiddleware, uc Auth) {
 	handler := &handler{log: log, uc: uc}
 
 	auth := r.Group("/auth")
@@ -31,7 +42,13 @@ func Register(r *gin.Engine, log *slog.Logger, uc Auth) {
 		auth.POST("signup", handler.SignUp())
 		auth.POST("signin", handler.SignIn())
 		auth.POST("tokens/refresh", handler.RefreshTokens())
+		auth.POST("logout", handler.Logout())
+		auth.POST("logout/all", authMiddleware.MiddlewareFunc(), handler.LogoutAll())
+		auth.PUT("password", authMiddleware.MiddlewareFunc(), handler.ChangePassword())
+		auth.POST("password/reset/request", handler.RequestPasswordReset())
+		auth.POST("password/reset", handler.ResetPassword())
 	}
+	r.GET("/.well-known/jwks.json", handler.GetJWKS())
 }
 
 // SignUp sign up a new user
@@ -79,7 +96,7 @@ func (h *handler) SignUp() gin.HandlerFunc {
 // SignIn sign up a new user
 //
 //	@Summary		Sign In
-//	@Description	sign in user
+//	@Description	sign in user, every sign in starts a new session. Too many failed sign ins by the login or from the IP lock the sign in out for the time doubling with every next failure
 //	@Tags			auth
 //	@Accept			json
 //	@Produce		json
@@ -87,6 +104,8 @@ func (h *handler) SignUp() gin.HandlerFunc {
 //	@Success		200		{object}	responses.Response[authrest.SignInResponse]
 //	@Failure		400		{object}	responses.Response[any]
 //	@Failure		401		{object}	responses.Response[any]
+//	@Failure		429		{object}	responses.Response[any]
+//	@Header			429		{integer}	Retry-After	"seconds until the lockout ends"
 //	@Failure		500		{object}	responses.Response[any]
 //	@Router			/auth/signin [post]
 func (h *handler) SignIn() gin.HandlerFunc {
@@ -98,12 +117,18 @@ func (h *handler) SignIn() gin.HandlerFunc {
 			return
 		}
 
-		accessToken, refreshToken, err := h.uc.SignIn(c.Request.Context(), req.Login, req.Password)
+		client := request.GetSessionClient(c)
+		accessToken, refreshToken, err := h.uc.SignIn(c.Request.Context(), req.Login, req.Password, client)
 		if err != nil {
-			if errors.Is(err, storage.ErrNotFound) {
+			var lockedErr *usecase.LockedOutError
+			switch {
+			case errors.As(err, &lockedErr):
+				h.log.Warn("sign in is locked out", slog.String("login", req.Login), slog.String("ip", client.IP))
+				responses.TooManyRequests(c, "too many failed sign in attempts", lockedErr.RetryAfter)
+			case errors.Is(err, storage.ErrNotFound):
 				h.log.Debug("failed sign in")
 				responses.Unauthorized(c, "failed sign in")
-			} else {
+			default:
 				h.log.Error("failed sign in", logger.Err(err))
 				responses.Internal(c, "failed sign in")
 			}
@@ -120,7 +145,7 @@ func (h *handler) SignIn() gin.HandlerFunc {
 // RefreshTokens Refresh access and refresh tokens
 //
 //	@Summary		Refresh tokens
-//	@Description	refresh access and refresh tokens
+//	@Description	refresh access and refresh tokens, the refresh token can be used only once, using it again revokes its session
 //	@Tags			auth
 //	@Accept			json
 //	@Produce		json
@@ -139,7 +164,7 @@ func (h *handler) RefreshTokens() gin.HandlerFunc {
 			return
 		}
 
-		accessToken, refreshToken, err := h.uc.RefreshTokens(c.Request.Context(), req.RefreshToken)
+		accessToken, refreshToken, err := h.uc.RefreshTokens(c.Request.Context(), req.RefreshToken, request.GetSessionClient(c))
 		if err != nil {
 			if errors.Is(err, usecase.ErrUnauthorized) {
 				h.log.Debug("failed refresh tokens", slog.String("refresh_token", req.RefreshToken))
@@ -157,3 +182,229 @@ func (h *handler) RefreshTokens() gin.HandlerFunc {
 		})
 	}
 }
+
+// Logout revokes the session of the refresh token
+//
+//	@Summary		Logout
+//	@Description	revoke the session of the refresh token, the access tokens issued for it stay valid until they expire
+//	@Tags			auth
+//	@Accept			json
+//	@Produce		json
+//	@Param			request	body		authrest.LogoutRequest	true	"query params"
+//	@Success		200		{object}	responses.Response[authrest.LogoutResponse]
+//	@Failure		400		{object}	responses.Response[any]
+//	@Failure		401		{object}	responses.Response[any]
+//	@Failure		500		{object}	responses.Response[any]
+//	@Router			/auth/logout [post]
+func (h *handler) Logout() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req LogoutRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			h.log.Debug("failed binding request", logger.Err(err))
+			responses.BadRequest(c, "invalid request")
+			return
+		}
+
+		userId, err := h.uc.Logout(c.Request.Context(), req.RefreshToken)
+		if err != nil {
+			if errors.Is(err, usecase.ErrUnauthorized) {
+				h.log.Debug("failed logout", logger.Err(err))
+				responses.Unauthorized(c, "failed logout")
+			} else {
+				h.log.Error("failed logout", logger.Err(err))
+				responses.Internal(c, "failed logout")
+			}
+			return
+		}
+
+		h.log.Info("user has logged out", slog.Int("user_id", userId))
+		responses.OK(c, LogoutResponse{
+			UserId: userId,
+		})
+	}
+}
+
+// LogoutAll revokes all sessions of the user
+//
+//	@Summary		Logout all sessions
+//	@Description	revoke all sessions of the user, the access tokens issued for them stay valid until they expire
+//	@Tags			auth
+//	@Produce		json
+//	@Param			Authorization	header		string	true	"Insert your access token"	default(Bearer <Add access token here>)
+//	@Success		200				{object}	responses.Response[authrest.LogoutResponse]
+//	@Failure		401				{object}	responses.Response[any]
+//	@Failure		500				{object}	responses.Response[any]
+//	@Router			/auth/logout/all [post]
+func (h *handler) LogoutAll() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userId, err := request.GetUserId(c)
+		if err != nil {
+			h.log.Debug("invalid token", logger.Err(err))
+			responses.Unauthorized(c, "invalid token")
+			return
+		}
+
+		if err := h.uc.LogoutAll(c.Request.Context(), userId); err != nil {
+			h.log.Error("failed logout all sessions", slog.Int("user_id", userId), logger.Err(err))
+			responses.Internal(c, "failed logout all sessions")
+			return
+		}
+
+		h.log.Info("user has logged out all sessions", slog.Int("user_id", userId))
+		responses.OK(c, LogoutResponse{
+			UserId: userId,
+		})
+	}
+}
+
+// ChangePassword changes the password of the user
+//
+//	@Summary		Change password
+//	@Description	change the password of the user by the old password, the other sessions of the user are revoked
+//	@Tags			auth
+//	@Accept			json
+//	@Produce		json
+//	@Param			Authorization	header		string							true	"Insert your access token"	default(Bearer <Add access token here>)
+//	@Param			request			body		authrest.ChangePasswordRequest	true	"query params"
+//	@Success		200				{object}	responses.Response[authrest.ChangePasswordResponse]
+//	@Failure		400				{object}	responses.Response[any]
+//	@Failure		401				{object}	responses.Response[any]
+//	@Failure		403				{object}	responses.Response[any]
+//	@Failure		500				{object}	responses.Response[any]
+//	@Router			/auth/password [put]
+func (h *handler) ChangePassword() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userId, err := request.GetUserId(c)
+		if err != nil {
+			h.log.Debug("invalid token", logger.Err(err))
+			responses.Unauthorized(c, "invalid token")
+			return
+		}
+
+		var req ChangePasswordRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			h.log.Debug("failed binding request", logger.Err(err))
+			responses.BadRequest(c, "invalid request")
+			return
+		}
+
+		err = h.uc.ChangePassword(c.Request.Context(), userId, request.GetSessionId(c), req.OldPassword, req.NewPassword)
+		if err != nil {
+			switch {
+			case errors.Is(err, usecase.ErrForbidden):
+				h.log.Debug("wrong old password", slog.Int("user_id", userId))
+				responses.Forbidden(c, "wrong old password")
+			case errors.Is(err, usecase.ErrUnauthorized):
+				h.log.Debug("user not found", slog.Int("user_id", userId))
+				responses.Unauthorized(c, "invalid token")
+			default:
+				h.log.Error("failed change password", slog.Int("user_id", userId), logger.Err(err))
+				responses.Internal(c, "failed change password")
+			}
+			return
+		}
+
+		h.log.Info("user has changed password", slog.Int("user_id", userId))
+		responses.OK(c, ChangePasswordResponse{
+			UserId: userId,
+		})
+	}
+}
+
+// RequestPasswordReset sends the password reset token to the user
+//
+//	@Summary		Request password reset
+//	@Description	send the single-use password reset token to the user with the login, the request is accepted for the unknown logins too
+//	@Tags			auth
+//	@Accept			json
+//	@Produce		json
+//	@Param			request	body		authrest.RequestPasswordResetRequest	true	"query params"
+//	@Success		202		{object}	responses.Response[any]
+//	@Failure		400		{object}	responses.Response[any]
+//	@Failure		429		{object}	responses.Response[any]
+//	@Failure		500		{object}	responses.Response[any]
+//	@Router			/auth/password/reset/request [post]
+func (h *handler) RequestPasswordReset() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req RequestPasswordResetRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			h.log.Debug("failed binding request", logger.Err(err))
+			responses.BadRequest(c, "invalid request")
+			return
+		}
+
+		ip := c.ClientIP()
+		if err := h.uc.RequestPasswordReset(c.Request.Context(), req.Login, ip); err != nil {
+			var lockedErr *usecase.LockedOutError
+			switch {
+			case errors.As(err, &lockedErr):
+				h.log.Warn("password reset is locked out", slog.String("login", req.Login), slog.String("ip", ip))
+				responses.TooManyRequests(c, "too many password reset requests", lockedErr.RetryAfter)
+			default:
+				h.log.Error("failed request password reset", logger.Err(err))
+				responses.Internal(c, "failed request password reset")
+			}
+			return
+		}
+
+		responses.Success[any](c, http.StatusAccepted, nil)
+	}
+}
+
+// ResetPassword sets the new password by the password reset token
+//
+//	@Summary		Reset password
+//	@Description	set the new password by the password reset token, the token can be used only once, all sessions of the user are revoked
+//	@Tags			auth
+//	@Accept			json
+//	@Produce		json
+//	@Param			request	body		authrest.ResetPasswordRequest	true	"query params"
+//	@Success		200		{object}	responses.Response[authrest.ResetPasswordResponse]
+//	@Failure		400		{object}	responses.Response[any]
+//	@Failure		500		{object}	responses.Response[any]
+//	@Router			/auth/password/reset [post]
+func (h *handler) ResetPassword() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req ResetPasswordRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			h.log.Debug("failed binding request", logger.Err(err))
+			responses.BadRequest(c, "invalid request")
+			return
+		}
+
+		userId, err := h.uc.ResetPassword(c.Request.Context(), req.Token, req.NewPassword)
+		if err != nil {
+			if errors.Is(err, usecase.ErrInvalidInput) {
+				h.log.Debug("invalid password reset token", logger.Err(err))
+				responses.BadRequest(c, "invalid or expired token")
+			} else {
+				h.log.Error("failed reset password", logger.Err(err))
+				responses.Internal(c, "failed reset password")
+			}
+			return
+		}
+
+		h.log.Info("user has reset password", slog.Int("user_id", userId))
+		responses.OK(c, ResetPasswordResponse{
+			UserId: userId,
+		})
+	}
+}
+
+// jwksMaxAge is how long the clients may cache the JWKS, the new keys must be published for longer before signing by them
+const jwksMaxAge = "max-age=300"
+
+// GetJWKS publishes the public keys of the access tokens
+//
+//	@Summary		Get JWKS
+//	@Description	get the public keys the access tokens are verified by as the JSON Web Key Set, it isn't wrapped in the response envelope, the HS256 secret is never published
+//	@Tags			auth
+//	@Produce		json
+//	@Success		200	{object}	token.JWKS
+//	@Router			/.well-known/jwks.json [get]
+func (h *handler) GetJWKS() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Header("Cache-Control", jwksMaxAge)
+		c.JSON(http.StatusOK, h.uc.JWKS())
+	}
+}
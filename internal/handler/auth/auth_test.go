@@ -4,13 +4,17 @@ import (
 	"bytes"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	authrest "github.com/PritOriginal/problem-map-server/internal/handler/auth"
 	"github.com/PritOriginal/problem-map-server/internal/storage"
 	"github.com/PritOriginal/problem-map-server/internal/usecase"
 	"github.com/PritOriginal/problem-map-server/pkg/logger/slogdiscard"
+	"github.com/PritOriginal/problem-map-server/pkg/token"
+	jwt "github.com/appleboy/gin-jwt/v3"
 	"github.com/gin-gonic/gin"
 	mock "github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
@@ -23,6 +27,17 @@ type AuthSuite struct {
 }
 
 func (suite *AuthSuite) SetupSuite() {
+	authMiddleware, err := jwt.New(&jwt.GinJWTMiddleware{
+		Key: []byte("1234"),
+	})
+	if err != nil {
+		panic(err)
+	}
+	errInit := authMiddleware.MiddlewareInit()
+	if errInit != nil {
+		panic(errInit)
+	}
+
 	suite.uc = authrest.NewMockAuth(suite.T())
 
 	log := slogdiscard.NewDiscardLogger()
@@ -30,7 +45,7 @@ func (suite *AuthSuite) SetupSuite() {
 	gin.SetMode(gin.TestMode)
 	suite.r = gin.New()
 
-	authrest.Register(suite.r, log, suite.uc)
+	authrest.Register(suite.r, log, authMiddleware, suite.uc)
 }
 
 func TestAuth(t *testing.T) {
@@ -132,6 +147,7 @@ func (suite *AuthSuite) TestSignIn() {
 		wantErrParseReq bool
 		errSignIn       error
 		statusCode      int
+		retryAfter      string
 	}{
 		{
 			name: "Ok200",
@@ -169,6 +185,17 @@ func (suite *AuthSuite) TestSignIn() {
 			errSignIn:       storage.ErrNotFound,
 			statusCode:      401,
 		},
+		{
+			name: "Err429",
+			req: authrest.SignInRequest{
+				Login:    "username",
+				Password: "password",
+			},
+			wantErrParseReq: false,
+			errSignIn:       fmt.Errorf("usecase: %w", &usecase.LockedOutError{RetryAfter: 1500 * time.Millisecond}),
+			statusCode:      429,
+			retryAfter:      "2",
+		},
 		{
 			name: "Err500",
 			req: authrest.SignInRequest{
@@ -183,7 +210,7 @@ func (suite *AuthSuite) TestSignIn() {
 	for _, tt := range tests {
 		suite.Run(tt.name, func() {
 			if !tt.wantErrParseReq {
-				suite.uc.On("SignIn", mock.Anything, mock.AnythingOfType("string"), mock.AnythingOfType("string")).Once().
+				suite.uc.On("SignIn", mock.Anything, mock.AnythingOfType("string"), mock.AnythingOfType("string"), mock.AnythingOfType("models.SessionClient")).Once().
 					Return("accessToken", "refreshToken", tt.errSignIn)
 			}
 
@@ -203,6 +230,7 @@ func (suite *AuthSuite) TestSignIn() {
 			suite.r.ServeHTTP(w, req)
 
 			suite.Equal(tt.statusCode, w.Code)
+			suite.Equal(tt.retryAfter, w.Header().Get("Retry-After"))
 		})
 	}
 }
@@ -270,7 +298,7 @@ func (suite *AuthSuite) TestRefreshTokens() {
 	for _, tt := range tests {
 		suite.Run(tt.name, func() {
 			if !tt.wantErrParseReq {
-				suite.uc.On("RefreshTokens", mock.Anything, mock.AnythingOfType("string")).Once().
+				suite.uc.On("RefreshTokens", mock.Anything, mock.AnythingOfType("string"), mock.AnythingOfType("models.SessionClient")).Once().
 					Return("accessToken", "refreshToken", tt.errSignIn)
 			}
 
@@ -293,3 +321,322 @@ func (suite *AuthSuite) TestRefreshTokens() {
 		})
 	}
 }
+
+func (suite *AuthSuite) TestLogout() {
+	tests := []struct {
+		name            string
+		rawReq          string
+		req             authrest.LogoutRequest
+		wantErrParseReq bool
+		errLogout       error
+		statusCode      int
+	}{
+		{
+			name: "Ok200",
+			req: authrest.LogoutRequest{
+				RefreshToken: "a.b.c",
+			},
+			statusCode: 200,
+		},
+		{
+			name:            "Err400InvalidJSON",
+			rawReq:          "{",
+			wantErrParseReq: true,
+			statusCode:      400,
+		},
+		{
+			name: "Err400InvalidReq-InvalidToken",
+			req: authrest.LogoutRequest{
+				RefreshToken: "abc",
+			},
+			wantErrParseReq: true,
+			statusCode:      400,
+		},
+		{
+			name: "Err401",
+			req: authrest.LogoutRequest{
+				RefreshToken: "a.b.c",
+			},
+			errLogout:  usecase.ErrUnauthorized,
+			statusCode: 401,
+		},
+		{
+			name: "Err500",
+			req: authrest.LogoutRequest{
+				RefreshToken: "a.b.c",
+			},
+			errLogout:  errors.New(""),
+			statusCode: 500,
+		},
+	}
+	for _, tt := range tests {
+		suite.Run(tt.name, func() {
+			if !tt.wantErrParseReq {
+				suite.uc.On("Logout", mock.Anything, tt.req.RefreshToken).Once().
+					Return(1, tt.errLogout)
+			}
+
+			w := httptest.NewRecorder()
+
+			var buf *bytes.Buffer
+			if tt.rawReq == "" {
+				body, err := json.Marshal(tt.req)
+				suite.NoError(err)
+				buf = bytes.NewBuffer(body)
+			} else {
+				buf = bytes.NewBuffer([]byte(tt.rawReq))
+			}
+
+			req := httptest.NewRequest("POST", "/auth/logout", buf)
+
+			suite.r.ServeHTTP(w, req)
+
+			suite.Equal(tt.statusCode, w.Code)
+		})
+	}
+}
+
+func (suite *AuthSuite) TestLogoutAll() {
+	tests := []struct {
+		name         string
+		unauthorized bool
+		errLogoutAll error
+		statusCode   int
+	}{
+		{
+			name:       "Ok200",
+			statusCode: 200,
+		},
+		{
+			name:         "Err401",
+			unauthorized: true,
+			statusCode:   401,
+		},
+		{
+			name:         "Err500",
+			errLogoutAll: errors.New(""),
+			statusCode:   500,
+		},
+	}
+	for _, tt := range tests {
+		suite.Run(tt.name, func() {
+			if !tt.unauthorized {
+				suite.uc.On("LogoutAll", mock.Anything, 1).Once().
+					Return(tt.errLogoutAll)
+			}
+
+			w := httptest.NewRecorder()
+
+			req := httptest.NewRequest("POST", "/auth/logout/all", nil)
+			if !tt.unauthorized {
+				accessToken, err := token.CreateToken(1*time.Minute, 1, "1234")
+				suite.NoError(err)
+				req.Header.Set("Authorization", "Bearer "+accessToken)
+			}
+
+			suite.r.ServeHTTP(w, req)
+
+			suite.Equal(tt.statusCode, w.Code)
+		})
+	}
+}
+
+func (suite *AuthSuite) TestGetJWKS() {
+	jwks := token.JWKS{Keys: []token.JWK{{Kty: "OKP", Kid: "1", Use: "sig", Alg: "EdDSA", Crv: "Ed25519", X: "x"}}}
+	suite.uc.On("JWKS").Once().Return(jwks)
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest("GET", "/.well-known/jwks.json", nil)
+
+	suite.r.ServeHTTP(w, req)
+
+	suite.Equal(200, w.Code)
+	suite.NotEmpty(w.Header().Get("Cache-Control"))
+
+	var got token.JWKS
+	suite.NoError(json.Unmarshal(w.Body.Bytes(), &got))
+	suite.Equal(jwks, got)
+}
+
+func (suite *AuthSuite) TestChangePassword() {
+	tests := []struct {
+		name              string
+		req               authrest.ChangePasswordRequest
+		unauthorized      bool
+		wantErrParseReq   bool
+		errChangePassword error
+		statusCode        int
+	}{
+		{
+			name:       "Ok200",
+			req:        authrest.ChangePasswordRequest{OldPassword: "password", NewPassword: "new password"},
+			statusCode: 200,
+		},
+		{
+			name:            "Err400InvalidReq-SamePassword",
+			req:             authrest.ChangePasswordRequest{OldPassword: "password", NewPassword: "password"},
+			wantErrParseReq: true,
+			statusCode:      400,
+		},
+		{
+			name:            "Err400InvalidReq-ShortPassword",
+			req:             authrest.ChangePasswordRequest{OldPassword: "password", NewPassword: "pass"},
+			wantErrParseReq: true,
+			statusCode:      400,
+		},
+		{
+			name:         "Err401",
+			req:          authrest.ChangePasswordRequest{OldPassword: "password", NewPassword: "new password"},
+			unauthorized: true,
+			statusCode:   401,
+		},
+		{
+			name:              "Err403",
+			req:               authrest.ChangePasswordRequest{OldPassword: "password", NewPassword: "new password"},
+			errChangePassword: usecase.ErrForbidden,
+			statusCode:        403,
+		},
+		{
+			name:              "Err500",
+			req:               authrest.ChangePasswordRequest{OldPassword: "password", NewPassword: "new password"},
+			errChangePassword: errors.New(""),
+			statusCode:        500,
+		},
+	}
+	for _, tt := range tests {
+		suite.Run(tt.name, func() {
+			if !tt.unauthorized && !tt.wantErrParseReq {
+				suite.uc.On("ChangePassword", mock.Anything, 1, "s1", tt.req.OldPassword, tt.req.NewPassword).Once().
+					Return(tt.errChangePassword)
+			}
+
+			w := httptest.NewRecorder()
+
+			body, err := json.Marshal(tt.req)
+			suite.NoError(err)
+
+			req := httptest.NewRequest("PUT", "/auth/password", bytes.NewReader(body))
+			if !tt.unauthorized {
+				accessToken, err := token.CreateTokenWithClaims(1*time.Minute, 1, "1234", map[string]any{
+					token.SessionClaim: "s1",
+				})
+				suite.NoError(err)
+				req.Header.Set("Authorization", "Bearer "+accessToken)
+			}
+
+			suite.r.ServeHTTP(w, req)
+
+			suite.Equal(tt.statusCode, w.Code)
+		})
+	}
+}
+
+func (suite *AuthSuite) TestRequestPasswordReset() {
+	tests := []struct {
+		name                    string
+		req                     authrest.RequestPasswordResetRequest
+		wantErrParseReq         bool
+		errRequestPasswordReset error
+		statusCode              int
+		retryAfter              string
+	}{
+		{
+			name:       "Ok202",
+			req:        authrest.RequestPasswordResetRequest{Login: "login"},
+			statusCode: 202,
+		},
+		{
+			name:            "Err400InvalidReq",
+			req:             authrest.RequestPasswordResetRequest{},
+			wantErrParseReq: true,
+			statusCode:      400,
+		},
+		{
+			name:                    "Err429",
+			req:                     authrest.RequestPasswordResetRequest{Login: "login"},
+			errRequestPasswordReset: fmt.Errorf("usecase: %w", &usecase.LockedOutError{RetryAfter: time.Minute}),
+			statusCode:              429,
+			retryAfter:              "60",
+		},
+		{
+			name:                    "Err500",
+			req:                     authrest.RequestPasswordResetRequest{Login: "login"},
+			errRequestPasswordReset: errors.New(""),
+			statusCode:              500,
+		},
+	}
+	for _, tt := range tests {
+		suite.Run(tt.name, func() {
+			if !tt.wantErrParseReq {
+				// the client IP of httptest.NewRequest
+				suite.uc.On("RequestPasswordReset", mock.Anything, tt.req.Login, "192.0.2.1").Once().
+					Return(tt.errRequestPasswordReset)
+			}
+
+			w := httptest.NewRecorder()
+
+			body, err := json.Marshal(tt.req)
+			suite.NoError(err)
+
+			req := httptest.NewRequest("POST", "/auth/password/reset/request", bytes.NewReader(body))
+
+			suite.r.ServeHTTP(w, req)
+
+			suite.Equal(tt.statusCode, w.Code)
+			suite.Equal(tt.retryAfter, w.Header().Get("Retry-After"))
+		})
+	}
+}
+
+func (suite *AuthSuite) TestResetPassword() {
+	tests := []struct {
+		name             string
+		req              authrest.ResetPasswordRequest
+		wantErrParseReq  bool
+		errResetPassword error
+		statusCode       int
+	}{
+		{
+			name:       "Ok200",
+			req:        authrest.ResetPasswordRequest{Token: "token", NewPassword: "new password"},
+			statusCode: 200,
+		},
+		{
+			name:            "Err400InvalidReq",
+			req:             authrest.ResetPasswordRequest{NewPassword: "new password"},
+			wantErrParseReq: true,
+			statusCode:      400,
+		},
+		{
+			name:             "Err400InvalidToken",
+			req:              authrest.ResetPasswordRequest{Token: "token", NewPassword: "new password"},
+			errResetPassword: usecase.ErrInvalidInput,
+			statusCode:       400,
+		},
+		{
+			name:             "Err500",
+			req:              authrest.ResetPasswordRequest{Token: "token", NewPassword: "new password"},
+			errResetPassword: errors.New(""),
+			statusCode:       500,
+		},
+	}
+	for _, tt := range tests {
+		suite.Run(tt.name, func() {
+			if !tt.wantErrParseReq {
+				suite.uc.On("ResetPassword", mock.Anything, tt.req.Token, tt.req.NewPassword).Once().
+					Return(1, tt.errResetPassword)
+			}
+
+			w := httptest.NewRecorder()
+
+			body, err := json.Marshal(tt.req)
+			suite.NoError(err)
+
+			req := httptest.NewRequest("POST", "/auth/password/reset", bytes.NewReader(body))
+
+			suite.r.ServeHTTP(w, req)
+
+			suite.Equal(tt.statusCode, w.Code)
+		})
+	}
+}
@@ -28,3 +28,33 @@ type RefreshTokensResponse struct {
 	AccessToken  string `json:"access_token"`
 	RefreshToken string `json:"refresh_token"`
 }
+
+type LogoutRequest struct {
+	RefreshToken string `json:"refresh_token" binding:"required,jwt"`
+}
+
+type LogoutResponse struct {
+	UserId int `json:"user_id"`
+}
+
+type ChangePasswordRequest struct {
+	OldPassword string `json:"old_password" binding:"required,min=8,max=64"`
+	NewPassword string `json:"new_password" binding:"required,min=8,max=64,nefield=OldPassword"`
+}
+
+type ChangePasswordResponse struct {
+	UserId int `json:"user_id"`
+}
+
+type RequestPasswordResetRequest struct {
+	Login string `json:"login" binding:"required,min=3,max=40"`
+}
+
+type ResetPasswordRequest struct {
+	Token       string `json:"token" binding:"required,max=64"`
+	NewPassword string `json:"new_password" binding:"required,min=8,max=64"`
+}
+
+type ResetPasswordResponse struct {
+	UserId int `json:"user_id"`
+}
@@ -7,6 +7,8 @@ package authrest
 import (
 	"context"
 
+	"github.com/PritOriginal/problem-map-server/internal/models"
+	"github.com/PritOriginal/problem-map-server/pkg/token"
 	mock "github.com/stretchr/testify/mock"
 )
 
@@ -37,10 +39,252 @@ func (_m *MockAuth) EXPECT() *MockAuth_Expecter {
 	return &MockAuth_Expecter{mock: &_m.Mock}
 }
 
-// RefreshTokens provides a mock function for the type MockAuth
-func (_mock *MockAuth) RefreshTokens(ctx context.Context, refreshToken string) (string, string, error) {
+// ChangePassword provides a mock function for the type MockAuth
+func (_mock *MockAuth) ChangePassword(ctx context.Context, userId int, sessionId string, oldPassword string, newPassword string) error {
+	ret := _mock.Called(ctx, userId, sessionId, oldPassword, newPassword)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ChangePassword")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int, string, string, string) error); ok {
+		r0 = returnFunc(ctx, userId, sessionId, oldPassword, newPassword)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockAuth_ChangePassword_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ChangePassword'
+type MockAuth_ChangePassword_Call struct {
+	*mock.Call
+}
+
+// ChangePassword is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userId int
+//   - sessionId string
+//   - oldPassword string
+//   - newPassword string
+func (_e *MockAuth_Expecter) ChangePassword(ctx interface{}, userId interface{}, sessionId interface{}, oldPassword interface{}, newPassword interface{}) *MockAuth_ChangePassword_Call {
+	return &MockAuth_ChangePassword_Call{Call: _e.mock.On("ChangePassword", ctx, userId, sessionId, oldPassword, newPassword)}
+}
+
+func (_c *MockAuth_ChangePassword_Call) Run(run func(ctx context.Context, userId int, sessionId string, oldPassword string, newPassword string)) *MockAuth_ChangePassword_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int
+		if args[1] != nil {
+			arg1 = args[1].(int)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
+		}
+		var arg4 string
+		if args[4] != nil {
+			arg4 = args[4].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+			arg4,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAuth_ChangePassword_Call) Return(err error) *MockAuth_ChangePassword_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockAuth_ChangePassword_Call) RunAndReturn(run func(ctx context.Context, userId int, sessionId string, oldPassword string, newPassword string) error) *MockAuth_ChangePassword_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// JWKS provides a mock function for the type MockAuth
+func (_mock *MockAuth) JWKS() token.JWKS {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for JWKS")
+	}
+
+	var r0 token.JWKS
+	if returnFunc, ok := ret.Get(0).(func() token.JWKS); ok {
+		r0 = returnFunc()
+	} else {
+		r0 = ret.Get(0).(token.JWKS)
+	}
+	return r0
+}
+
+// MockAuth_JWKS_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'JWKS'
+type MockAuth_JWKS_Call struct {
+	*mock.Call
+}
+
+// JWKS is a helper method to define mock.On call
+func (_e *MockAuth_Expecter) JWKS() *MockAuth_JWKS_Call {
+	return &MockAuth_JWKS_Call{Call: _e.mock.On("JWKS")}
+}
+
+func (_c *MockAuth_JWKS_Call) Run(run func()) *MockAuth_JWKS_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockAuth_JWKS_Call) Return(jwks token.JWKS) *MockAuth_JWKS_Call {
+	_c.Call.Return(jwks)
+	return _c
+}
+
+func (_c *MockAuth_JWKS_Call) RunAndReturn(run func() token.JWKS) *MockAuth_JWKS_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Logout provides a mock function for the type MockAuth
+func (_mock *MockAuth) Logout(ctx context.Context, refreshToken string) (int, error) {
 	ret := _mock.Called(ctx, refreshToken)
 
+	if len(ret) == 0 {
+		panic("no return value specified for Logout")
+	}
+
+	var r0 int
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (int, error)); ok {
+		return returnFunc(ctx, refreshToken)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) int); ok {
+		r0 = returnFunc(ctx, refreshToken)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, refreshToken)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockAuth_Logout_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Logout'
+type MockAuth_Logout_Call struct {
+	*mock.Call
+}
+
+// Logout is a helper method to define mock.On call
+//   - ctx context.Context
+//   - refreshToken string
+func (_e *MockAuth_Expecter) Logout(ctx interface{}, refreshToken interface{}) *MockAuth_Logout_Call {
+	return &MockAuth_Logout_Call{Call: _e.mock.On("Logout", ctx, refreshToken)}
+}
+
+func (_c *MockAuth_Logout_Call) Run(run func(ctx context.Context, refreshToken string)) *MockAuth_Logout_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAuth_Logout_Call) Return(n int, err error) *MockAuth_Logout_Call {
+	_c.Call.Return(n, err)
+	return _c
+}
+
+func (_c *MockAuth_Logout_Call) RunAndReturn(run func(ctx context.Context, refreshToken string) (int, error)) *MockAuth_Logout_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// LogoutAll provides a mock function for the type MockAuth
+func (_mock *MockAuth) LogoutAll(ctx context.Context, userId int) error {
+	ret := _mock.Called(ctx, userId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for LogoutAll")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int) error); ok {
+		r0 = returnFunc(ctx, userId)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockAuth_LogoutAll_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'LogoutAll'
+type MockAuth_LogoutAll_Call struct {
+	*mock.Call
+}
+
+// LogoutAll is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userId int
+func (_e *MockAuth_Expecter) LogoutAll(ctx interface{}, userId interface{}) *MockAuth_LogoutAll_Call {
+	return &MockAuth_LogoutAll_Call{Call: _e.mock.On("LogoutAll", ctx, userId)}
+}
+
+func (_c *MockAuth_LogoutAll_Call) Run(run func(ctx context.Context, userId int)) *MockAuth_LogoutAll_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int
+		if args[1] != nil {
+			arg1 = args[1].(int)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAuth_LogoutAll_Call) Return(err error) *MockAuth_LogoutAll_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockAuth_LogoutAll_Call) RunAndReturn(run func(ctx context.Context, userId int) error) *MockAuth_LogoutAll_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RefreshTokens provides a mock function for the type MockAuth
+func (_mock *MockAuth) RefreshTokens(ctx context.Context, refreshToken string, client models.SessionClient) (string, string, error) {
+	ret := _mock.Called(ctx, refreshToken, client)
+
 	if len(ret) == 0 {
 		panic("no return value specified for RefreshTokens")
 	}
@@ -48,21 +292,21 @@ func (_mock *MockAuth) RefreshTokens(ctx context.Context, refreshToken string) (
 	var r0 string
 	var r1 string
 	var r2 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (string, string, error)); ok {
-		return returnFunc(ctx, refreshToken)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, models.SessionClient) (string, string, error)); ok {
+		return returnFunc(ctx, refreshToken, client)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) string); ok {
-		r0 = returnFunc(ctx, refreshToken)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, models.SessionClient) string); ok {
+		r0 = returnFunc(ctx, refreshToken, client)
 	} else {
 		r0 = ret.Get(0).(string)
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, string) string); ok {
-		r1 = returnFunc(ctx, refreshToken)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, models.SessionClient) string); ok {
+		r1 = returnFunc(ctx, refreshToken, client)
 	} else {
 		r1 = ret.Get(1).(string)
 	}
-	if returnFunc, ok := ret.Get(2).(func(context.Context, string) error); ok {
-		r2 = returnFunc(ctx, refreshToken)
+	if returnFunc, ok := ret.Get(2).(func(context.Context, string, models.SessionClient) error); ok {
+		r2 = returnFunc(ctx, refreshToken, client)
 	} else {
 		r2 = ret.Error(2)
 	}
@@ -77,11 +321,12 @@ type MockAuth_RefreshTokens_Call struct {
 // RefreshTokens is a helper method to define mock.On call
 //   - ctx context.Context
 //   - refreshToken string
-func (_e *MockAuth_Expecter) RefreshTokens(ctx interface{}, refreshToken interface{}) *MockAuth_RefreshTokens_Call {
-	return &MockAuth_RefreshTokens_Call{Call: _e.mock.On("RefreshTokens", ctx, refreshToken)}
+//   - client models.SessionClient
+func (_e *MockAuth_Expecter) RefreshTokens(ctx interface{}, refreshToken interface{}, client interface{}) *MockAuth_RefreshTokens_Call {
+	return &MockAuth_RefreshTokens_Call{Call: _e.mock.On("RefreshTokens", ctx, refreshToken, client)}
 }
 
-func (_c *MockAuth_RefreshTokens_Call) Run(run func(ctx context.Context, refreshToken string)) *MockAuth_RefreshTokens_Call {
+func (_c *MockAuth_RefreshTokens_Call) Run(run func(ctx context.Context, refreshToken string, client models.SessionClient)) *MockAuth_RefreshTokens_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -91,9 +336,14 @@ func (_c *MockAuth_RefreshTokens_Call) Run(run func(ctx context.Context, refresh
 		if args[1] != nil {
 			arg1 = args[1].(string)
 		}
+		var arg2 models.SessionClient
+		if args[2] != nil {
+			arg2 = args[2].(models.SessionClient)
+		}
 		run(
 			arg0,
 			arg1,
+			arg2,
 		)
 	})
 	return _c
@@ -104,14 +354,149 @@ func (_c *MockAuth_RefreshTokens_Call) Return(s string, s1 string, err error) *M
 	return _c
 }
 
-func (_c *MockAuth_RefreshTokens_Call) RunAndReturn(run func(ctx context.Context, refreshToken string) (string, string, error)) *MockAuth_RefreshTokens_Call {
+func (_c *MockAuth_RefreshTokens_Call) RunAndReturn(run func(ctx context.Context, refreshToken string, client models.SessionClient) (string, string, error)) *MockAuth_RefreshTokens_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RequestPasswordReset provides a mock function for the type MockAuth
+func (_mock *MockAuth) RequestPasswordReset(ctx context.Context, login string, ip string) error {
+	ret := _mock.Called(ctx, login, ip)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RequestPasswordReset")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = returnFunc(ctx, login, ip)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockAuth_RequestPasswordReset_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RequestPasswordReset'
+type MockAuth_RequestPasswordReset_Call struct {
+	*mock.Call
+}
+
+// RequestPasswordReset is a helper method to define mock.On call
+//   - ctx context.Context
+//   - login string
+//   - ip string
+func (_e *MockAuth_Expecter) RequestPasswordReset(ctx interface{}, login interface{}, ip interface{}) *MockAuth_RequestPasswordReset_Call {
+	return &MockAuth_RequestPasswordReset_Call{Call: _e.mock.On("RequestPasswordReset", ctx, login, ip)}
+}
+
+func (_c *MockAuth_RequestPasswordReset_Call) Run(run func(ctx context.Context, login string, ip string)) *MockAuth_RequestPasswordReset_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAuth_RequestPasswordReset_Call) Return(err error) *MockAuth_RequestPasswordReset_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockAuth_RequestPasswordReset_Call) RunAndReturn(run func(ctx context.Context, login string, ip string) error) *MockAuth_RequestPasswordReset_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ResetPassword provides a mock function for the type MockAuth
+func (_mock *MockAuth) ResetPassword(ctx context.Context, resetToken string, newPassword string) (int, error) {
+	ret := _mock.Called(ctx, resetToken, newPassword)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ResetPassword")
+	}
+
+	var r0 int
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) (int, error)); ok {
+		return returnFunc(ctx, resetToken, newPassword)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) int); ok {
+		r0 = returnFunc(ctx, resetToken, newPassword)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = returnFunc(ctx, resetToken, newPassword)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockAuth_ResetPassword_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ResetPassword'
+type MockAuth_ResetPassword_Call struct {
+	*mock.Call
+}
+
+// ResetPassword is a helper method to define mock.On call
+//   - ctx context.Context
+//   - resetToken string
+//   - newPassword string
+func (_e *MockAuth_Expecter) ResetPassword(ctx interface{}, resetToken interface{}, newPassword interface{}) *MockAuth_ResetPassword_Call {
+	return &MockAuth_ResetPassword_Call{Call: _e.mock.On("ResetPassword", ctx, resetToken, newPassword)}
+}
+
+func (_c *MockAuth_ResetPassword_Call) Run(run func(ctx context.Context, resetToken string, newPassword string)) *MockAuth_ResetPassword_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAuth_ResetPassword_Call) Return(n int, err error) *MockAuth_ResetPassword_Call {
+	_c.Call.Return(n, err)
+	return _c
+}
+
+func (_c *MockAuth_ResetPassword_Call) RunAndReturn(run func(ctx context.Context, resetToken string, newPassword string) (int, error)) *MockAuth_ResetPassword_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
 // SignIn provides a mock function for the type MockAuth
-func (_mock *MockAuth) SignIn(ctx context.Context, login string, password string) (string, string, error) {
-	ret := _mock.Called(ctx, login, password)
+func (_mock *MockAuth) SignIn(ctx context.Context, login string, password string, client models.SessionClient) (string, string, error) {
+	ret := _mock.Called(ctx, login, password, client)
 
 	if len(ret) == 0 {
 		panic("no return value specified for SignIn")
@@ -120,21 +505,21 @@ func (_mock *MockAuth) SignIn(ctx context.Context, login string, password string
 	var r0 string
 	var r1 string
 	var r2 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) (string, string, error)); ok {
-		return returnFunc(ctx, login, password)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, models.SessionClient) (string, string, error)); ok {
+		return returnFunc(ctx, login, password, client)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) string); ok {
-		r0 = returnFunc(ctx, login, password)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, models.SessionClient) string); ok {
+		r0 = returnFunc(ctx, login, password, client)
 	} else {
 		r0 = ret.Get(0).(string)
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string) string); ok {
-		r1 = returnFunc(ctx, login, password)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string, models.SessionClient) string); ok {
+		r1 = returnFunc(ctx, login, password, client)
 	} else {
 		r1 = ret.Get(1).(string)
 	}
-	if returnFunc, ok := ret.Get(2).(func(context.Context, string, string) error); ok {
-		r2 = returnFunc(ctx, login, password)
+	if returnFunc, ok := ret.Get(2).(func(context.Context, string, string, models.SessionClient) error); ok {
+		r2 = returnFunc(ctx, login, password, client)
 	} else {
 		r2 = ret.Error(2)
 	}
@@ -150,11 +535,12 @@ type MockAuth_SignIn_Call struct {
 //   - ctx context.Context
 //   - login string
 //   - password string
-func (_e *MockAuth_Expecter) SignIn(ctx interface{}, login interface{}, password interface{}) *MockAuth_SignIn_Call {
-	return &MockAuth_SignIn_Call{Call: _e.mock.On("SignIn", ctx, login, password)}
+//   - client models.SessionClient
+func (_e *MockAuth_Expecter) SignIn(ctx interface{}, login interface{}, password interface{}, client interface{}) *MockAuth_SignIn_Call {
+	return &MockAuth_SignIn_Call{Call: _e.mock.On("SignIn", ctx, login, password, client)}
 }
 
-func (_c *MockAuth_SignIn_Call) Run(run func(ctx context.Context, login string, password string)) *MockAuth_SignIn_Call {
+func (_c *MockAuth_SignIn_Call) Run(run func(ctx context.Context, login string, password string, client models.SessionClient)) *MockAuth_SignIn_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -168,10 +554,15 @@ func (_c *MockAuth_SignIn_Call) Run(run func(ctx context.Context, login string,
 		if args[2] != nil {
 			arg2 = args[2].(string)
 		}
+		var arg3 models.SessionClient
+		if args[3] != nil {
+			arg3 = args[3].(models.SessionClient)
+		}
 		run(
 			arg0,
 			arg1,
 			arg2,
+			arg3,
 		)
 	})
 	return _c
@@ -182,7 +573,7 @@ func (_c *MockAuth_SignIn_Call) Return(s string, s1 string, err error) *MockAuth
 	return _c
 }
 
-func (_c *MockAuth_SignIn_Call) RunAndReturn(run func(ctx context.Context, login string, password string) (string, string, error)) *MockAuth_SignIn_Call {
+func (_c *MockAuth_SignIn_Call) RunAndReturn(run func(ctx context.Context, login string, password string, client models.SessionClient) (string, string, error)) *MockAuth_SignIn_Call {
 	_c.Call.Return(run)
 	return _c
 }
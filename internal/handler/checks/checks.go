@@ -3,25 +3,26 @@ package checksrest
 import (
 	"context"
 	"errors"
-	"io"
 	"log/slog"
+	"net/http"
 	"strconv"
 
+	"github.com/PritOriginal/problem-map-server/internal/handler/request"
 	"github.com/PritOriginal/problem-map-server/internal/models"
 	"github.com/PritOriginal/problem-map-server/internal/storage"
 	"github.com/PritOriginal/problem-map-server/internal/usecase"
-	"github.com/PritOriginal/problem-map-server/pkg/handlers"
 	"github.com/PritOriginal/problem-map-server/pkg/logger"
 	"github.com/PritOriginal/problem-map-server/pkg/responses"
 	jwt "github.com/appleboy/gin-jwt/v3"
 	"github.com/gin-gonic/gin"
+	"github.com/twpayne/go-geom"
 )
 
 type Checks interface {
-	AddCheck(ctx context.Context, check models.Check, photos []io.Reader) (int64, error)
+	AddCheck(ctx context.Context, check models.Check, photos []models.Photo) (int64, error)
 	GetCheckById(ctx context.Context, id int) (models.Check, error)
-	GetChecksByMarkId(ctx context.Context, markId int) ([]models.Check, error)
-	GetChecksByUserId(ctx context.Context, userId int) ([]models.Check, error)
+	GetChecksByMarkId(ctx context.Context, markId int, pagination models.Pagination) ([]models.Check, string, error)
+	GetChecksByUserId(ctx context.Context, userId int, pagination models.Pagination) ([]models.Check, string, error)
 }
 
 type handler struct {
@@ -89,10 +90,14 @@ func (h *handler) GetCheckById() gin.HandlerFunc {
 //	@Description	get check by mark id
 //	@Tags			checks
 //	@Produce		json
-//	@Param			id	path		int	true	"mark id"
-//	@Success		200	{object}	responses.Response[checksrest.GetChecksByMarkIdResponse]
-//	@Failure		400	{object}	responses.Response[any]
-//	@Failure		500	{object}	responses.Response[any]
+//	@Param			id		path		int		true	"mark id"
+//	@Param			limit	query		int		false	"max number of checks in the page (capped by the server)"
+//	@Param			cursor	query		string	false	"cursor to the next page"
+//	@Param			sort	query		string	false	"sort field"	Enums(created_at, updated_at)
+//	@Param			order	query		string	false	"sort order"	Enums(asc, desc)
+//	@Success		200		{object}	responses.Response[checksrest.GetChecksByMarkIdResponse]
+//	@Failure		400		{object}	responses.Response[any]
+//	@Failure		500		{object}	responses.Response[any]
 //	@Router			/checks/mark/{id} [get]
 func (h *handler) GetChecksByMarkId() gin.HandlerFunc {
 	return func(c *gin.Context) {
@@ -103,7 +108,20 @@ func (h *handler) GetChecksByMarkId() gin.HandlerFunc {
 			return
 		}
 
-		checks, err := h.uc.GetChecksByMarkId(c.Request.Context(), markId)
+		var query request.PaginationQuery
+		if err := c.ShouldBindQuery(&query); err != nil {
+			h.log.Debug("failed parse pagination", logger.Err(err))
+			responses.BadRequest(c, "invalid pagination")
+			return
+		}
+		pagination, err := models.ChecksSorting.NewPagination(query.Limit, query.Cursor, query.Sort, query.Order)
+		if err != nil {
+			h.log.Debug("invalid pagination", logger.Err(err))
+			responses.BadRequest(c, "invalid pagination")
+			return
+		}
+
+		checks, nextCursor, err := h.uc.GetChecksByMarkId(c.Request.Context(), markId, pagination)
 		if err != nil {
 			h.log.Error("error get checks by mark id", logger.Err(err))
 			responses.Internal(c, "error get checks by mark id")
@@ -111,7 +129,8 @@ func (h *handler) GetChecksByMarkId() gin.HandlerFunc {
 		}
 
 		responses.OK(c, GetChecksByMarkIdResponse{
-			Checks: checks,
+			Checks:     checks,
+			NextCursor: nextCursor,
 		})
 	}
 }
@@ -122,10 +141,14 @@ func (h *handler) GetChecksByMarkId() gin.HandlerFunc {
 //	@Description	get checks by user id
 //	@Tags			checks
 //	@Produce		json
-//	@Param			id	path		int	true	"user id"
-//	@Success		200	{object}	responses.Response[checksrest.GetChecksByUserIdResponse]
-//	@Failure		400	{object}	responses.Response[any]
-//	@Failure		500	{object}	responses.Response[any]
+//	@Param			id		path		int		true	"user id"
+//	@Param			limit	query		int		false	"max number of checks in the page (capped by the server)"
+//	@Param			cursor	query		string	false	"cursor to the next page"
+//	@Param			sort	query		string	false	"sort field"	Enums(created_at, updated_at)
+//	@Param			order	query		string	false	"sort order"	Enums(asc, desc)
+//	@Success		200		{object}	responses.Response[checksrest.GetChecksByUserIdResponse]
+//	@Failure		400		{object}	responses.Response[any]
+//	@Failure		500		{object}	responses.Response[any]
 //	@Router			/checks/user/{id} [get]
 func (h *handler) GetChecksByUserId() gin.HandlerFunc {
 	return func(c *gin.Context) {
@@ -136,7 +159,20 @@ func (h *handler) GetChecksByUserId() gin.HandlerFunc {
 			return
 		}
 
-		checks, err := h.uc.GetChecksByUserId(c.Request.Context(), userId)
+		var query request.PaginationQuery
+		if err := c.ShouldBindQuery(&query); err != nil {
+			h.log.Debug("failed parse pagination", logger.Err(err))
+			responses.BadRequest(c, "invalid pagination")
+			return
+		}
+		pagination, err := models.ChecksSorting.NewPagination(query.Limit, query.Cursor, query.Sort, query.Order)
+		if err != nil {
+			h.log.Debug("invalid pagination", logger.Err(err))
+			responses.BadRequest(c, "invalid pagination")
+			return
+		}
+
+		checks, nextCursor, err := h.uc.GetChecksByUserId(c.Request.Context(), userId, pagination)
 		if err != nil {
 			h.log.Error("error get checks by user id", logger.Err(err))
 			responses.Internal(c, "error get checks by user id")
@@ -144,7 +180,8 @@ func (h *handler) GetChecksByUserId() gin.HandlerFunc {
 		}
 
 		responses.OK(c, GetChecksByUserIdResponse{
-			Checks: checks,
+			Checks:     checks,
+			NextCursor: nextCursor,
 		})
 	}
 }
@@ -152,13 +189,15 @@ func (h *handler) GetChecksByUserId() gin.HandlerFunc {
 // AddCheck add check
 //
 //	@Summary		Add check
-//	@Description	add check
+//	@Description	add check, the photos are optional and may be uploaded directly to the S3 photo storage and attached to the check afterwards
 //	@Tags			checks
 //	@Accept			mpfd
 //	@Produce		json
 //	@Param			Authorization	header		string	true	"Insert your access token"	default(Bearer <Add access token here>)
 //	@Success		201				{object}	responses.Response[checksrest.AddCheckResponse]
 //	@Failure		400				{object}	responses.Response[any]
+//	@Failure		409				{object}	responses.Response[any]
+//	@Failure		422				{object}	responses.Response[checksrest.CheckTooFarResponse]
 //	@Failure		500				{object}	responses.Response[any]
 //	@Router			/checks [post]
 func (h *handler) AddCheck() gin.HandlerFunc {
@@ -170,22 +209,14 @@ func (h *handler) AddCheck() gin.HandlerFunc {
 			return
 		}
 
-		photos, err := handlers.ParsePhotos(req.Photos)
+		photos, err := request.ParsePhotos(req.Photos)
 		if err != nil {
 			h.log.Error("error parse photos", logger.Err(err))
 			responses.Internal(c, "error parse photos")
 			return
 		}
 
-		claims := jwt.ExtractClaims(c)
-
-		userIdStr, err := claims.GetSubject()
-		if err != nil {
-			h.log.Debug("invalid token", logger.Err(err))
-			responses.Unauthorized(c, "invalid token")
-			return
-		}
-		userId, err := strconv.Atoi(userIdStr)
+		userId, err := request.GetUserId(c)
 		if err != nil {
 			h.log.Debug("invalid token", logger.Err(err))
 			responses.Unauthorized(c, "invalid token")
@@ -198,16 +229,29 @@ func (h *handler) AddCheck() gin.HandlerFunc {
 			Result:  req.Result,
 			Comment: req.Comment,
 		}
+		if req.Longitude != nil {
+			check.Location = models.NewPoint(geom.Coord{*req.Longitude, *req.Latitude})
+		}
 		checkId, err := h.uc.AddCheck(c.Request.Context(), check, photos)
 		if err != nil {
-			switch err {
-			case usecase.ErrNotFound:
+			var tooFarErr *usecase.CheckTooFarError
+			switch {
+			case errors.Is(err, usecase.ErrNotFound):
 				h.log.Debug("mark not found", slog.Int("mark_id", req.MarkID))
 				responses.BadRequest(c, "mark not found")
 				return
-			case usecase.ErrConflict:
+			case errors.Is(err, usecase.ErrConflict):
 				h.log.Debug("user has already completed the check", slog.Int("mark_id", req.MarkID), slog.Int("user_id", userId))
 				responses.Conflict(c, "user has already completed the check")
+			case errors.As(err, &tooFarErr):
+				h.log.Debug("check is too far from the mark", slog.Int("mark_id", req.MarkID), slog.Float64("distance", tooFarErr.Distance))
+				responses.FailWithData(c, http.StatusUnprocessableEntity, "check is too far from the mark", CheckTooFarResponse{
+					Distance:    tooFarErr.Distance,
+					MaxDistance: tooFarErr.MaxDistance,
+				})
+			case errors.Is(err, usecase.ErrInvalidInput):
+				h.log.Debug("check location is required", slog.Int("mark_id", req.MarkID))
+				responses.BadRequest(c, "location is required")
 			default:
 				h.log.Error("error add check", logger.Err(err))
 				responses.Internal(c, "error add check")
@@ -3,6 +3,7 @@ package checksrest_test
 import (
 	"bytes"
 	"errors"
+	"fmt"
 	"io"
 	"mime/multipart"
 	"net/http/httptest"
@@ -142,8 +143,8 @@ func (suite *ChecksSuite) TestGetChecksByMarkId() {
 	for _, tt := range tests {
 		suite.Run(tt.name, func() {
 			if !tt.wantErrParseId {
-				suite.uc.On("GetChecksByMarkId", mock.Anything, mock.AnythingOfType("int")).Once().
-					Return([]models.Check{}, tt.errGetChecksByMarkId)
+				suite.uc.On("GetChecksByMarkId", mock.Anything, mock.AnythingOfType("int"), mock.AnythingOfType("models.Pagination")).Once().
+					Return([]models.Check{}, "", tt.errGetChecksByMarkId)
 			}
 
 			w := httptest.NewRecorder()
@@ -189,8 +190,8 @@ func (suite *ChecksSuite) TestGetChecksByUserId() {
 	for _, tt := range tests {
 		suite.Run(tt.name, func() {
 			if !tt.wantErrParseId {
-				suite.uc.On("GetChecksByUserId", mock.Anything, mock.AnythingOfType("int")).Once().
-					Return([]models.Check{}, tt.errGetChecksByUserId)
+				suite.uc.On("GetChecksByUserId", mock.Anything, mock.AnythingOfType("int"), mock.AnythingOfType("models.Pagination")).Once().
+					Return([]models.Check{}, "", tt.errGetChecksByUserId)
 			}
 
 			w := httptest.NewRecorder()
@@ -204,9 +205,12 @@ func (suite *ChecksSuite) TestGetChecksByUserId() {
 }
 
 func (suite *ChecksSuite) TestAddCheck() {
+	longitude, latitude := 37.6173, 55.7558
+
 	tests := []struct {
 		name            string
 		req             checksrest.AddCheckRequest
+		withoutPhotos   bool
 		wantErrParseReq bool
 		errAddCheck     error
 		statusCode      int
@@ -222,6 +226,15 @@ func (suite *ChecksSuite) TestAddCheck() {
 			errAddCheck:     nil,
 			statusCode:      201,
 		},
+		{
+			name: "Ok201WithoutPhotos",
+			req: checksrest.AddCheckRequest{
+				MarkID: 1,
+				Result: true,
+			},
+			withoutPhotos: true,
+			statusCode:    201,
+		},
 		{
 			name: "Err400InvalidReq",
 			req: checksrest.AddCheckRequest{
@@ -252,6 +265,46 @@ func (suite *ChecksSuite) TestAddCheck() {
 			errAddCheck: usecase.ErrConflict,
 			statusCode:  409,
 		},
+		{
+			name: "Ok201Location",
+			req: checksrest.AddCheckRequest{
+				MarkID:    1,
+				Result:    true,
+				Longitude: &longitude,
+				Latitude:  &latitude,
+			},
+			statusCode: 201,
+		},
+		{
+			name: "Err400InvalidLocation",
+			req: checksrest.AddCheckRequest{
+				MarkID:    1,
+				Result:    true,
+				Longitude: &longitude,
+			},
+			wantErrParseReq: true,
+			statusCode:      400,
+		},
+		{
+			name: "Err400LocationRequired",
+			req: checksrest.AddCheckRequest{
+				MarkID: 1,
+				Result: true,
+			},
+			errAddCheck: fmt.Errorf("%w: the location is required", usecase.ErrInvalidInput),
+			statusCode:  400,
+		},
+		{
+			name: "Err422TooFar",
+			req: checksrest.AddCheckRequest{
+				MarkID:    1,
+				Result:    true,
+				Longitude: &longitude,
+				Latitude:  &latitude,
+			},
+			errAddCheck: &usecase.CheckTooFarError{Distance: 1000, MaxDistance: 500},
+			statusCode:  422,
+		},
 		{
 			name: "Err500",
 			req: checksrest.AddCheckRequest{
@@ -267,7 +320,14 @@ func (suite *ChecksSuite) TestAddCheck() {
 	for _, tt := range tests {
 		suite.Run(tt.name, func() {
 			if !tt.wantErrParseReq {
-				suite.uc.On("AddCheck", mock.Anything, mock.Anything, mock.Anything).Once().
+				isRequestLocation := func(check models.Check) bool {
+					if tt.req.Longitude == nil {
+						return check.Location == nil
+					}
+					coords := check.Location.Ewkb.Coords()
+					return coords.X() == *tt.req.Longitude && coords.Y() == *tt.req.Latitude
+				}
+				suite.uc.On("AddCheck", mock.Anything, mock.MatchedBy(isRequestLocation), mock.Anything).Once().
 					Return(int64(1), tt.errAddCheck)
 			}
 
@@ -278,11 +338,19 @@ func (suite *ChecksSuite) TestAddCheck() {
 			mpw.WriteField("mark_id", strconv.Itoa(tt.req.MarkID))
 			mpw.WriteField("result", strconv.FormatBool(tt.req.Result))
 			mpw.WriteField("comment", tt.req.Comment)
+			if tt.req.Longitude != nil {
+				mpw.WriteField("longitude", strconv.FormatFloat(*tt.req.Longitude, 'f', -1, 64))
+			}
+			if tt.req.Latitude != nil {
+				mpw.WriteField("latitude", strconv.FormatFloat(*tt.req.Latitude, 'f', -1, 64))
+			}
 
-			image := gofakeit.ImageJpeg(10, 10)
-			fw, err := mpw.CreateFormFile("photos", "test.jpg")
-			suite.NoError(err)
-			io.Copy(fw, bytes.NewBuffer(image))
+			if !tt.withoutPhotos {
+				image := gofakeit.ImageJpeg(10, 10)
+				fw, err := mpw.CreateFormFile("photos", "test.jpg")
+				suite.NoError(err)
+				io.Copy(fw, bytes.NewBuffer(image))
+			}
 
 			mpw.Close()
 
@@ -11,20 +11,29 @@ type GetCheckByIdResponse struct {
 }
 
 type GetChecksByMarkIdResponse struct {
-	Checks []models.Check `json:"checks"`
+	Checks     []models.Check `json:"checks"`
+	NextCursor string         `json:"next_cursor,omitempty"`
 }
 
 type GetChecksByUserIdResponse struct {
-	Checks []models.Check `json:"checks"`
+	Checks     []models.Check `json:"checks"`
+	NextCursor string         `json:"next_cursor,omitempty"`
 }
 
 type AddCheckRequest struct {
-	Photos  []*multipart.FileHeader `form:"photos" binding:"required"`
-	MarkID  int                     `form:"mark_id" binding:"required"`
-	Result  bool                    `form:"result"`
-	Comment string                  `form:"comment"`
+	Photos    []*multipart.FileHeader `form:"photos"`
+	MarkID    int                     `form:"mark_id" binding:"required"`
+	Result    bool                    `form:"result"`
+	Comment   string                  `form:"comment"`
+	Longitude *float64                `form:"longitude" binding:"required_with=Latitude,omitempty,longitude"`
+	Latitude  *float64                `form:"latitude" binding:"required_with=Longitude,omitempty,latitude"`
 }
 
 type AddCheckResponse struct {
 	CheckId int `json:"check_id"`
 }
+
+type CheckTooFarResponse struct {
+	Distance    float64 `json:"distance"`
+	MaxDistance float64 `json:"max_distance"`
+}
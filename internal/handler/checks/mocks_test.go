@@ -6,7 +6,6 @@ package checksrest
 
 import (
 	"context"
-	"io"
 
 	"github.com/PritOriginal/problem-map-server/internal/models"
 	mock "github.com/stretchr/testify/mock"
@@ -40,7 +39,7 @@ func (_m *MockChecks) EXPECT() *MockChecks_Expecter {
 }
 
 // AddCheck provides a mock function for the type MockChecks
-func (_mock *MockChecks) AddCheck(ctx context.Context, check models.Check, photos []io.Reader) (int64, error) {
+func (_mock *MockChecks) AddCheck(ctx context.Context, check models.Check, photos []models.Photo) (int64, error) {
 	ret := _mock.Called(ctx, check, photos)
 
 	if len(ret) == 0 {
@@ -49,15 +48,15 @@ func (_mock *MockChecks) AddCheck(ctx context.Context, check models.Check, photo
 
 	var r0 int64
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, models.Check, []io.Reader) (int64, error)); ok {
+	if returnFunc, ok := ret.Get(0).(func(context.Context, models.Check, []models.Photo) (int64, error)); ok {
 		return returnFunc(ctx, check, photos)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, models.Check, []io.Reader) int64); ok {
+	if returnFunc, ok := ret.Get(0).(func(context.Context, models.Check, []models.Photo) int64); ok {
 		r0 = returnFunc(ctx, check, photos)
 	} else {
 		r0 = ret.Get(0).(int64)
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, models.Check, []io.Reader) error); ok {
+	if returnFunc, ok := ret.Get(1).(func(context.Context, models.Check, []models.Photo) error); ok {
 		r1 = returnFunc(ctx, check, photos)
 	} else {
 		r1 = ret.Error(1)
@@ -73,12 +72,12 @@ type MockChecks_AddCheck_Call struct {
 // AddCheck is a helper method to define mock.On call
 //   - ctx context.Context
 //   - check models.Check
-//   - photos []io.Reader
+//   - photos []models.Photo
 func (_e *MockChecks_Expecter) AddCheck(ctx interface{}, check interface{}, photos interface{}) *MockChecks_AddCheck_Call {
 	return &MockChecks_AddCheck_Call{Call: _e.mock.On("AddCheck", ctx, check, photos)}
 }
 
-func (_c *MockChecks_AddCheck_Call) Run(run func(ctx context.Context, check models.Check, photos []io.Reader)) *MockChecks_AddCheck_Call {
+func (_c *MockChecks_AddCheck_Call) Run(run func(ctx context.Context, check models.Check, photos []models.Photo)) *MockChecks_AddCheck_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -88,9 +87,9 @@ func (_c *MockChecks_AddCheck_Call) Run(run func(ctx context.Context, check mode
 		if args[1] != nil {
 			arg1 = args[1].(models.Check)
 		}
-		var arg2 []io.Reader
+		var arg2 []models.Photo
 		if args[2] != nil {
-			arg2 = args[2].([]io.Reader)
+			arg2 = args[2].([]models.Photo)
 		}
 		run(
 			arg0,
@@ -106,7 +105,7 @@ func (_c *MockChecks_AddCheck_Call) Return(n int64, err error) *MockChecks_AddCh
 	return _c
 }
 
-func (_c *MockChecks_AddCheck_Call) RunAndReturn(run func(ctx context.Context, check models.Check, photos []io.Reader) (int64, error)) *MockChecks_AddCheck_Call {
+func (_c *MockChecks_AddCheck_Call) RunAndReturn(run func(ctx context.Context, check models.Check, photos []models.Photo) (int64, error)) *MockChecks_AddCheck_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -178,31 +177,37 @@ func (_c *MockChecks_GetCheckById_Call) RunAndReturn(run func(ctx context.Contex
 }
 
 // GetChecksByMarkId provides a mock function for the type MockChecks
-func (_mock *MockChecks) GetChecksByMarkId(ctx context.Context, markId int) ([]models.Check, error) {
-	ret := _mock.Called(ctx, markId)
+func (_mock *MockChecks) GetChecksByMarkId(ctx context.Context, markId int, pagination models.Pagination) ([]models.Check, string, error) {
+	ret := _mock.Called(ctx, markId, pagination)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetChecksByMarkId")
 	}
 
 	var r0 []models.Check
-	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, int) ([]models.Check, error)); ok {
-		return returnFunc(ctx, markId)
+	var r1 string
+	var r2 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int, models.Pagination) ([]models.Check, string, error)); ok {
+		return returnFunc(ctx, markId, pagination)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, int) []models.Check); ok {
-		r0 = returnFunc(ctx, markId)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int, models.Pagination) []models.Check); ok {
+		r0 = returnFunc(ctx, markId, pagination)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]models.Check)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, int) error); ok {
-		r1 = returnFunc(ctx, markId)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int, models.Pagination) string); ok {
+		r1 = returnFunc(ctx, markId, pagination)
 	} else {
-		r1 = ret.Error(1)
+		r1 = ret.Get(1).(string)
 	}
-	return r0, r1
+	if returnFunc, ok := ret.Get(2).(func(context.Context, int, models.Pagination) error); ok {
+		r2 = returnFunc(ctx, markId, pagination)
+	} else {
+		r2 = ret.Error(2)
+	}
+	return r0, r1, r2
 }
 
 // MockChecks_GetChecksByMarkId_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetChecksByMarkId'
@@ -213,11 +218,12 @@ type MockChecks_GetChecksByMarkId_Call struct {
 // GetChecksByMarkId is a helper method to define mock.On call
 //   - ctx context.Context
 //   - markId int
-func (_e *MockChecks_Expecter) GetChecksByMarkId(ctx interface{}, markId interface{}) *MockChecks_GetChecksByMarkId_Call {
-	return &MockChecks_GetChecksByMarkId_Call{Call: _e.mock.On("GetChecksByMarkId", ctx, markId)}
+//   - pagination models.Pagination
+func (_e *MockChecks_Expecter) GetChecksByMarkId(ctx interface{}, markId interface{}, pagination interface{}) *MockChecks_GetChecksByMarkId_Call {
+	return &MockChecks_GetChecksByMarkId_Call{Call: _e.mock.On("GetChecksByMarkId", ctx, markId, pagination)}
 }
 
-func (_c *MockChecks_GetChecksByMarkId_Call) Run(run func(ctx context.Context, markId int)) *MockChecks_GetChecksByMarkId_Call {
+func (_c *MockChecks_GetChecksByMarkId_Call) Run(run func(ctx context.Context, markId int, pagination models.Pagination)) *MockChecks_GetChecksByMarkId_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -227,50 +233,61 @@ func (_c *MockChecks_GetChecksByMarkId_Call) Run(run func(ctx context.Context, m
 		if args[1] != nil {
 			arg1 = args[1].(int)
 		}
+		var arg2 models.Pagination
+		if args[2] != nil {
+			arg2 = args[2].(models.Pagination)
+		}
 		run(
 			arg0,
 			arg1,
+			arg2,
 		)
 	})
 	return _c
 }
 
-func (_c *MockChecks_GetChecksByMarkId_Call) Return(checks []models.Check, err error) *MockChecks_GetChecksByMarkId_Call {
-	_c.Call.Return(checks, err)
+func (_c *MockChecks_GetChecksByMarkId_Call) Return(checks []models.Check, s string, err error) *MockChecks_GetChecksByMarkId_Call {
+	_c.Call.Return(checks, s, err)
 	return _c
 }
 
-func (_c *MockChecks_GetChecksByMarkId_Call) RunAndReturn(run func(ctx context.Context, markId int) ([]models.Check, error)) *MockChecks_GetChecksByMarkId_Call {
+func (_c *MockChecks_GetChecksByMarkId_Call) RunAndReturn(run func(ctx context.Context, markId int, pagination models.Pagination) ([]models.Check, string, error)) *MockChecks_GetChecksByMarkId_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
 // GetChecksByUserId provides a mock function for the type MockChecks
-func (_mock *MockChecks) GetChecksByUserId(ctx context.Context, userId int) ([]models.Check, error) {
-	ret := _mock.Called(ctx, userId)
+func (_mock *MockChecks) GetChecksByUserId(ctx context.Context, userId int, pagination models.Pagination) ([]models.Check, string, error) {
+	ret := _mock.Called(ctx, userId, pagination)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetChecksByUserId")
 	}
 
 	var r0 []models.Check
-	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, int) ([]models.Check, error)); ok {
-		return returnFunc(ctx, userId)
+	var r1 string
+	var r2 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int, models.Pagination) ([]models.Check, string, error)); ok {
+		return returnFunc(ctx, userId, pagination)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, int) []models.Check); ok {
-		r0 = returnFunc(ctx, userId)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int, models.Pagination) []models.Check); ok {
+		r0 = returnFunc(ctx, userId, pagination)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]models.Check)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, int) error); ok {
-		r1 = returnFunc(ctx, userId)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int, models.Pagination) string); ok {
+		r1 = returnFunc(ctx, userId, pagination)
 	} else {
-		r1 = ret.Error(1)
+		r1 = ret.Get(1).(string)
 	}
-	return r0, r1
+	if returnFunc, ok := ret.Get(2).(func(context.Context, int, models.Pagination) error); ok {
+		r2 = returnFunc(ctx, userId, pagination)
+	} else {
+		r2 = ret.Error(2)
+	}
+	return r0, r1, r2
 }
 
 // MockChecks_GetChecksByUserId_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetChecksByUserId'
@@ -281,11 +298,12 @@ type MockChecks_GetChecksByUserId_Call struct {
 // GetChecksByUserId is a helper method to define mock.On call
 //   - ctx context.Context
 //   - userId int
-func (_e *MockChecks_Expecter) GetChecksByUserId(ctx interface{}, userId interface{}) *MockChecks_GetChecksByUserId_Call {
-	return &MockChecks_GetChecksByUserId_Call{Call: _e.mock.On("GetChecksByUserId", ctx, userId)}
+//   - pagination models.Pagination
+func (_e *MockChecks_Expecter) GetChecksByUserId(ctx interface{}, userId interface{}, pagination interface{}) *MockChecks_GetChecksByUserId_Call {
+	return &MockChecks_GetChecksByUserId_Call{Call: _e.mock.On("GetChecksByUserId", ctx, userId, pagination)}
 }
 
-func (_c *MockChecks_GetChecksByUserId_Call) Run(run func(ctx context.Context, userId int)) *MockChecks_GetChecksByUserId_Call {
+func (_c *MockChecks_GetChecksByUserId_Call) Run(run func(ctx context.Context, userId int, pagination models.Pagination)) *MockChecks_GetChecksByUserId_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -295,20 +313,25 @@ func (_c *MockChecks_GetChecksByUserId_Call) Run(run func(ctx context.Context, u
 		if args[1] != nil {
 			arg1 = args[1].(int)
 		}
+		var arg2 models.Pagination
+		if args[2] != nil {
+			arg2 = args[2].(models.Pagination)
+		}
 		run(
 			arg0,
 			arg1,
+			arg2,
 		)
 	})
 	return _c
 }
 
-func (_c *MockChecks_GetChecksByUserId_Call) Return(checks []models.Check, err error) *MockChecks_GetChecksByUserId_Call {
-	_c.Call.Return(checks, err)
+func (_c *MockChecks_GetChecksByUserId_Call) Return(checks []models.Check, s string, err error) *MockChecks_GetChecksByUserId_Call {
+	_c.Call.Return(checks, s, err)
 	return _c
 }
 
-func (_c *MockChecks_GetChecksByUserId_Call) RunAndReturn(run func(ctx context.Context, userId int) ([]models.Check, error)) *MockChecks_GetChecksByUserId_Call {
+func (_c *MockChecks_GetChecksByUserId_Call) RunAndReturn(run func(ctx context.Context, userId int, pagination models.Pagination) ([]models.Check, string, error)) *MockChecks_GetChecksByUserId_Call {
 	_c.Call.Return(run)
 	return _c
 }
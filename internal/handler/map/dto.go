@@ -10,6 +10,12 @@ type GetAdminBoundariesMarksCountResponse struct {
 	AdminBoundaries []models.AdminBoundaryMarksCount `json:"admin_boundaries"`
 }
 
+type GetMarkClustersResponse struct {
+	Clusters  []models.MarkCluster `json:"clusters"`
+	Marks     []models.Mark        `json:"marks"`
+	Truncated bool                 `json:"truncated"`
+}
+
 type GetRegionsResponse struct {
 	Regions []models.Region `json:"regions"`
 }
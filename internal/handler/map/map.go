@@ -2,7 +2,11 @@ package maprest
 
 import (
 	"context"
+	"errors"
 	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
 	"time"
 
 	mwcache "github.com/PritOriginal/problem-map-server/internal/middleware/cache"
@@ -16,11 +20,15 @@ import (
 type Map interface {
 	GetAdminBoundaries(ctx context.Context, filters models.GetAdminBoundaryFilters) ([]models.AdminBoundary, error)
 	GetAdminBoundariesMarksCount(ctx context.Context, filters models.GetAdminBoundaryMarksCountFilters) ([]models.AdminBoundaryMarksCount, error)
+	GetMarkClusters(ctx context.Context, filters models.GetMarkClustersFilters) ([]models.MarkCluster, []models.Mark, bool, error)
+	GetTile(ctx context.Context, layer models.TileLayer, tile models.Tile) ([]byte, error)
 	GetRegions(ctx context.Context) ([]models.Region, error)
 	GetCities(ctx context.Context) ([]models.City, error)
 	GetDistricts(ctx context.Context) ([]models.District, error)
 }
 
+const mvtContentType = "application/vnd.mapbox-vector-tile"
+
 type handler struct {
 	log *slog.Logger
 	uc  Map
@@ -32,6 +40,12 @@ func Register(r *gin.Engine, log *slog.Logger, uc Map, cacher mwcache.Cacher) {
 	mapRoute := r.Group("/map")
 	{
 		mapRoute.GET("admin-boundaries/marks/count", handler.GetAdminBoundariesMarksCount())
+		mapRoute.GET("marks/clusters", handler.GetMarkClusters())
+		tiles := mapRoute.Group("tiles")
+		{
+			tiles.Use(mwcache.NewWithContentType(cacher, 5*time.Minute, mvtContentType))
+			tiles.GET(":layer/:z/:x/:y", handler.GetTile())
+		}
 		cache := mapRoute.Group("")
 		{
 			cache.Use(mwcache.New(cacher, 24*time.Hour))
@@ -127,6 +141,128 @@ func (h *handler) GetAdminBoundariesMarksCount() gin.HandlerFunc {
 	}
 }
 
+// GetMarkClusters groups marks inside the bounding box into clusters depending on the zoom level
+//
+//	@Summary		Mark clusters
+//	@Description	clusters of marks inside the bounding box with the count of marks by status, individual marks are returned starting from zoom 16, truncated reports that the bounding box has more marks than returned
+//	@Tags			map
+//	@Accept			json
+//	@Produce		json
+//	@Param			bbox			query		[]number	true	"bounding box: min lon, min lat, max lon, max lat"
+//	@Param			zoom			query		int			true	"map zoom level"
+//	@Param			mark_type_ids	query		[]number	false	"filter by mark type"
+//	@Success		200				{object}	responses.Response[maprest.GetMarkClustersResponse]
+//	@Failure		400				{object}	responses.Response[any]
+//	@Failure		500				{object}	responses.Response[any]
+//	@Router			/map/marks/clusters [get]
+func (h *handler) GetMarkClusters() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		bboxStr := c.Query("bbox")
+		coords, err := handlers.ParseFloatArray(bboxStr)
+		if err != nil {
+			h.log.Debug("failed parse bbox", logger.Err(err))
+			responses.BadRequest(c, "failed parse bbox")
+			return
+		}
+		bbox, err := models.NewBBox(coords)
+		if err != nil {
+			h.log.Debug("invalid bbox", logger.Err(err))
+			responses.BadRequest(c, "invalid bbox")
+			return
+		}
+
+		zoomStr := c.Query("zoom")
+		zoom, err := strconv.Atoi(zoomStr)
+		if err != nil || zoom < 0 || zoom > models.MaxTileZoom {
+			h.log.Debug("failed parse zoom", slog.String("zoom", zoomStr))
+			responses.BadRequest(c, "failed parse zoom")
+			return
+		}
+
+		markTypeIdsStr := c.Query("mark_type_ids")
+		markTypeIds, err := handlers.ParseIntArray(markTypeIdsStr)
+		if err != nil {
+			h.log.Debug("failed parse mark type ids", logger.Err(err))
+			responses.BadRequest(c, "failed parse mark type ids")
+			return
+		}
+
+		clusters, marks, truncated, err := h.uc.GetMarkClusters(c.Request.Context(), models.GetMarkClustersFilters{
+			BBox:        *bbox,
+			Zoom:        zoom,
+			MarkTypeIds: markTypeIds,
+		})
+		if err != nil {
+			h.log.Error("error get mark clusters", logger.Err(err))
+			responses.Internal(c, "error get mark clusters")
+			return
+		}
+
+		responses.OK(c, GetMarkClustersResponse{
+			Clusters:  clusters,
+			Marks:     marks,
+			Truncated: truncated,
+		})
+	}
+}
+
+// GetTile returns a layer tile in the Mapbox Vector Tile format
+//
+//	@Summary		Vector tile
+//	@Description	mapbox vector tile of the marks or admin_boundaries layer
+//	@Tags			map
+//	@Produce		application/vnd.mapbox-vector-tile
+//	@Param			layer	path		string	true	"tile layer"	Enums(marks, admin_boundaries)
+//	@Param			z		path		int		true	"zoom level"
+//	@Param			x		path		int		true	"tile column"
+//	@Param			y		path		int		true	"tile row"
+//	@Success		200		{file}		binary
+//	@Failure		400		{object}	responses.Response[any]
+//	@Failure		404		{object}	responses.Response[any]
+//	@Failure		500		{object}	responses.Response[any]
+//	@Router			/map/tiles/{layer}/{z}/{x}/{y}.mvt [get]
+func (h *handler) GetTile() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		layer := models.TileLayer(c.Param("layer"))
+		if layer != models.TileLayerMarks && layer != models.TileLayerAdminBoundaries {
+			h.log.Debug("tile layer not found", slog.String("layer", string(layer)))
+			responses.NotFound(c, "tile layer not found")
+			return
+		}
+
+		yStr, ok := strings.CutSuffix(c.Param("y"), ".mvt")
+		if !ok {
+			h.log.Debug("tile format not found", slog.String("y", c.Param("y")))
+			responses.NotFound(c, "tile format not found")
+			return
+		}
+
+		z, errZ := strconv.Atoi(c.Param("z"))
+		x, errX := strconv.Atoi(c.Param("x"))
+		y, errY := strconv.Atoi(yStr)
+		if err := errors.Join(errZ, errX, errY); err != nil {
+			h.log.Debug("failed parse tile coordinates", logger.Err(err))
+			responses.BadRequest(c, "failed parse tile coordinates")
+			return
+		}
+		tile, err := models.NewTile(z, x, y)
+		if err != nil {
+			h.log.Debug("invalid tile coordinates", logger.Err(err))
+			responses.BadRequest(c, "invalid tile coordinates")
+			return
+		}
+
+		mvt, err := h.uc.GetTile(c.Request.Context(), layer, *tile)
+		if err != nil {
+			h.log.Error("error get tile", logger.Err(err))
+			responses.Internal(c, "error get tile")
+			return
+		}
+
+		c.Data(http.StatusOK, mvtContentType, mvt)
+	}
+}
+
 // GetCities lists all existing regions
 //
 //	@Summary		List regions
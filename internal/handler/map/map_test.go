@@ -184,6 +184,156 @@ func (suite *MapSuite) TestGetAdminBoundariesMarksCount() {
 	}
 }
 
+func (suite *MapSuite) TestGetMarkClusters() {
+	tests := []struct {
+		name               string
+		query              string
+		wantErrParse       bool
+		errGetMarkClusters error
+		statusCode         int
+	}{
+		{
+			name:       "Ok200",
+			query:      "?bbox=41.3,52.6,41.6,52.8&zoom=12",
+			statusCode: http.StatusOK,
+		},
+		{
+			name:       "Ok200",
+			query:      "?bbox=41.3,52.6,41.6,52.8&zoom=17&mark_type_ids=1,2",
+			statusCode: http.StatusOK,
+		},
+		{
+			name:         "Err400",
+			query:        "?zoom=12",
+			wantErrParse: true,
+			statusCode:   http.StatusBadRequest,
+		},
+		{
+			name:         "Err400",
+			query:        "?bbox=41.6,52.6,41.3,52.8&zoom=12",
+			wantErrParse: true,
+			statusCode:   http.StatusBadRequest,
+		},
+		{
+			name:         "Err400",
+			query:        "?bbox=41.3,52.6,41.6,52.8",
+			wantErrParse: true,
+			statusCode:   http.StatusBadRequest,
+		},
+		{
+			name:         "Err400",
+			query:        "?bbox=41.3,52.6,41.6,52.8&zoom=30",
+			wantErrParse: true,
+			statusCode:   http.StatusBadRequest,
+		},
+		{
+			name:         "Err400",
+			query:        "?bbox=41.3,52.6,41.6,52.8&zoom=12&mark_type_ids=a",
+			wantErrParse: true,
+			statusCode:   http.StatusBadRequest,
+		},
+		{
+			name:               "Err500",
+			query:              "?bbox=41.3,52.6,41.6,52.8&zoom=12",
+			errGetMarkClusters: errors.New(""),
+			statusCode:         http.StatusInternalServerError,
+		},
+	}
+	for _, tt := range tests {
+		suite.Run(tt.name, func() {
+			if !tt.wantErrParse {
+				suite.uc.On("GetMarkClusters", mock.Anything, mock.AnythingOfType("models.GetMarkClustersFilters")).Once().
+					Return([]models.MarkCluster{}, []models.Mark{}, false, tt.errGetMarkClusters)
+			}
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("GET", "/map/marks/clusters"+tt.query, nil)
+
+			suite.r.ServeHTTP(w, req)
+
+			suite.Equal(tt.statusCode, w.Code)
+		})
+	}
+}
+
+func (suite *MapSuite) TestGetTile() {
+	tests := []struct {
+		name         string
+		path         string
+		wantErrParse bool
+		errGetTile   error
+		statusCode   int
+	}{
+		{
+			name:       "Ok200",
+			path:       "/marks/12/2519/1343.mvt",
+			statusCode: http.StatusOK,
+		},
+		{
+			name:       "Ok200",
+			path:       "/admin_boundaries/8/157/83.mvt",
+			statusCode: http.StatusOK,
+		},
+		{
+			name:         "Err404",
+			path:         "/roads/12/2519/1343.mvt",
+			wantErrParse: true,
+			statusCode:   http.StatusNotFound,
+		},
+		{
+			name:         "Err404",
+			path:         "/marks/12/2519/1343.png",
+			wantErrParse: true,
+			statusCode:   http.StatusNotFound,
+		},
+		{
+			name:         "Err400",
+			path:         "/marks/12/a/1343.mvt",
+			wantErrParse: true,
+			statusCode:   http.StatusBadRequest,
+		},
+		{
+			name:         "Err400",
+			path:         "/marks/2/4/0.mvt",
+			wantErrParse: true,
+			statusCode:   http.StatusBadRequest,
+		},
+		{
+			name:       "Err500",
+			path:       "/marks/12/2519/1343.mvt",
+			errGetTile: errors.New(""),
+			statusCode: http.StatusInternalServerError,
+		},
+	}
+	for _, tt := range tests {
+		suite.Run(tt.name, func() {
+			suite.cacher.
+				On("GetBytes", mock.Anything, mock.AnythingOfType("string")).Once().
+				Return([]byte{}, errors.New(""))
+			if tt.statusCode >= 200 && tt.statusCode < 300 {
+				suite.cacher.
+					On("Set", mock.Anything, mock.AnythingOfType("string"), mock.Anything, mock.Anything).Once().
+					Return(nil)
+			}
+
+			if !tt.wantErrParse {
+				suite.uc.On("GetTile", mock.Anything, mock.AnythingOfType("models.TileLayer"), mock.AnythingOfType("models.Tile")).Once().
+					Return([]byte{}, tt.errGetTile)
+			}
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("GET", "/map/tiles"+tt.path, nil)
+
+			suite.r.ServeHTTP(w, req)
+
+			suite.Equal(tt.statusCode, w.Code)
+			if tt.statusCode == http.StatusOK {
+				suite.Equal("application/vnd.mapbox-vector-tile", w.Header().Get("Content-Type"))
+			}
+		})
+	}
+}
+
 func (suite *MapSuite) TestGetRegions() {
 	tests := []struct {
 		name          string
@@ -298,6 +298,88 @@ func (_c *MockMap_GetDistricts_Call) RunAndReturn(run func(ctx context.Context)
 	return _c
 }
 
+// GetMarkClusters provides a mock function for the type MockMap
+func (_mock *MockMap) GetMarkClusters(ctx context.Context, filters models.GetMarkClustersFilters) ([]models.MarkCluster, []models.Mark, bool, error) {
+	ret := _mock.Called(ctx, filters)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetMarkClusters")
+	}
+
+	var r0 []models.MarkCluster
+	var r1 []models.Mark
+	var r2 bool
+	var r3 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, models.GetMarkClustersFilters) ([]models.MarkCluster, []models.Mark, bool, error)); ok {
+		return returnFunc(ctx, filters)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, models.GetMarkClustersFilters) []models.MarkCluster); ok {
+		r0 = returnFunc(ctx, filters)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.MarkCluster)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, models.GetMarkClustersFilters) []models.Mark); ok {
+		r1 = returnFunc(ctx, filters)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).([]models.Mark)
+		}
+	}
+	if returnFunc, ok := ret.Get(2).(func(context.Context, models.GetMarkClustersFilters) bool); ok {
+		r2 = returnFunc(ctx, filters)
+	} else {
+		r2 = ret.Get(2).(bool)
+	}
+	if returnFunc, ok := ret.Get(3).(func(context.Context, models.GetMarkClustersFilters) error); ok {
+		r3 = returnFunc(ctx, filters)
+	} else {
+		r3 = ret.Error(3)
+	}
+	return r0, r1, r2, r3
+}
+
+// MockMap_GetMarkClusters_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetMarkClusters'
+type MockMap_GetMarkClusters_Call struct {
+	*mock.Call
+}
+
+// GetMarkClusters is a helper method to define mock.On call
+//   - ctx context.Context
+//   - filters models.GetMarkClustersFilters
+func (_e *MockMap_Expecter) GetMarkClusters(ctx interface{}, filters interface{}) *MockMap_GetMarkClusters_Call {
+	return &MockMap_GetMarkClusters_Call{Call: _e.mock.On("GetMarkClusters", ctx, filters)}
+}
+
+func (_c *MockMap_GetMarkClusters_Call) Run(run func(ctx context.Context, filters models.GetMarkClustersFilters)) *MockMap_GetMarkClusters_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 models.GetMarkClustersFilters
+		if args[1] != nil {
+			arg1 = args[1].(models.GetMarkClustersFilters)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockMap_GetMarkClusters_Call) Return(markClusters []models.MarkCluster, marks []models.Mark, b bool, err error) *MockMap_GetMarkClusters_Call {
+	_c.Call.Return(markClusters, marks, b, err)
+	return _c
+}
+
+func (_c *MockMap_GetMarkClusters_Call) RunAndReturn(run func(ctx context.Context, filters models.GetMarkClustersFilters) ([]models.MarkCluster, []models.Mark, bool, error)) *MockMap_GetMarkClusters_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetRegions provides a mock function for the type MockMap
 func (_mock *MockMap) GetRegions(ctx context.Context) ([]models.Region, error) {
 	ret := _mock.Called(ctx)
@@ -359,3 +441,77 @@ func (_c *MockMap_GetRegions_Call) RunAndReturn(run func(ctx context.Context) ([
 	_c.Call.Return(run)
 	return _c
 }
+
+// GetTile provides a mock function for the type MockMap
+func (_mock *MockMap) GetTile(ctx context.Context, layer models.TileLayer, tile models.Tile) ([]byte, error) {
+	ret := _mock.Called(ctx, layer, tile)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTile")
+	}
+
+	var r0 []byte
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, models.TileLayer, models.Tile) ([]byte, error)); ok {
+		return returnFunc(ctx, layer, tile)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, models.TileLayer, models.Tile) []byte); ok {
+		r0 = returnFunc(ctx, layer, tile)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]byte)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, models.TileLayer, models.Tile) error); ok {
+		r1 = returnFunc(ctx, layer, tile)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockMap_GetTile_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTile'
+type MockMap_GetTile_Call struct {
+	*mock.Call
+}
+
+// GetTile is a helper method to define mock.On call
+//   - ctx context.Context
+//   - layer models.TileLayer
+//   - tile models.Tile
+func (_e *MockMap_Expecter) GetTile(ctx interface{}, layer interface{}, tile interface{}) *MockMap_GetTile_Call {
+	return &MockMap_GetTile_Call{Call: _e.mock.On("GetTile", ctx, layer, tile)}
+}
+
+func (_c *MockMap_GetTile_Call) Run(run func(ctx context.Context, layer models.TileLayer, tile models.Tile)) *MockMap_GetTile_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 models.TileLayer
+		if args[1] != nil {
+			arg1 = args[1].(models.TileLayer)
+		}
+		var arg2 models.Tile
+		if args[2] != nil {
+			arg2 = args[2].(models.Tile)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockMap_GetTile_Call) Return(bytes []byte, err error) *MockMap_GetTile_Call {
+	_c.Call.Return(bytes, err)
+	return _c
+}
+
+func (_c *MockMap_GetTile_Call) RunAndReturn(run func(ctx context.Context, layer models.TileLayer, tile models.Tile) ([]byte, error)) *MockMap_GetTile_Call {
+	_c.Call.Return(run)
+	return _c
+}
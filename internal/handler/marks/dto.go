@@ -3,6 +3,7 @@ package marksrest
 import (
 	"mime/multipart"
 
+	"github.com/PritOriginal/problem-map-server/internal/handler/request"
 	"github.com/PritOriginal/problem-map-server/internal/models"
 )
 
@@ -11,11 +12,41 @@ type GetMarkByIdResponse struct {
 }
 
 type GetMarksByUserIdResponse struct {
-	Marks []models.Mark `json:"marks"`
+	Marks      []models.Mark `json:"marks"`
+	NextCursor string        `json:"next_cursor,omitempty"`
 }
 
 type GetMarksResponse struct {
-	Marks []models.Mark `json:"marks"`
+	Marks      []models.Mark `json:"marks"`
+	Truncated  bool          `json:"truncated"`
+	NextCursor string        `json:"next_cursor,omitempty"`
+}
+
+type GetNearbyMarksRequest struct {
+	Longitude     *float64 `form:"longitude" binding:"required,longitude"`
+	Latitude      *float64 `form:"latitude" binding:"required,latitude"`
+	Radius        float64  `form:"radius" binding:"omitempty,gt=0,max=50000"`
+	MarkTypeIds   string   `form:"mark_type_ids"`
+	MarkStatusIds string   `form:"mark_status_ids"`
+	request.PaginationQuery
+}
+
+type GetNearbyMarksResponse struct {
+	Marks      []models.NearbyMark `json:"marks"`
+	NextCursor string              `json:"next_cursor,omitempty"`
+}
+
+type SearchMarksRequest struct {
+	Query         string `form:"q" binding:"required,max=256"`
+	MarkTypeIds   string `form:"mark_type_ids"`
+	MarkStatusIds string `form:"mark_status_ids"`
+	BBox          string `form:"bbox"`
+	request.PaginationQuery
+}
+
+type SearchMarksResponse struct {
+	Marks      []models.FoundMark `json:"marks"`
+	NextCursor string             `json:"next_cursor,omitempty"`
 }
 
 type GetMarkTypesResponse struct {
@@ -28,16 +59,42 @@ type GetMarkStatusesResponse struct {
 
 type AddMarkRequest struct {
 	Photos      []*multipart.FileHeader `form:"photos" binding:"required"`
-	Longitude   float64                 `form:"longitude" binding:"required,longitude"`
-	Latitude    float64                 `form:"latitude" binding:"required,latitude"`
+	Longitude   *float64                `form:"longitude" binding:"required,longitude"`
+	Latitude    *float64                `form:"latitude" binding:"required,latitude"`
 	MarkTypeID  int                     `form:"mark_type_id" binding:"required"`
 	Description string                  `form:"description" binding:"max=256"`
+	Force       bool                    `form:"force"`
 }
 
 type AddMarkResponse struct {
 	MarkId int `json:"mark_id"`
 }
 
+type FindDuplicateMarksRequest struct {
+	Longitude  *float64 `form:"longitude" binding:"required,longitude"`
+	Latitude   *float64 `form:"latitude" binding:"required,latitude"`
+	MarkTypeID int      `form:"mark_type_id" binding:"required,gt=0"`
+}
+
+type DuplicateMarksResponse struct {
+	Marks []models.NearbyMark `json:"marks"`
+}
+
+type UpdateMarkRequest struct {
+	Description *string  `json:"description" binding:"omitempty,max=256"`
+	MarkTypeID  *int     `json:"mark_type_id" binding:"omitempty,gt=0"`
+	Longitude   *float64 `json:"longitude" binding:"required_with=Latitude,omitempty,longitude"`
+	Latitude    *float64 `json:"latitude" binding:"required_with=Longitude,omitempty,latitude"`
+}
+
+type UpdateMarkResponse struct {
+	MarkId int `json:"mark_id"`
+}
+
+type DeleteMarkResponse struct {
+	MarkId int `json:"mark_id"`
+}
+
 type GetMarkStatusHistoryByMarkIdRequest struct {
 	MarkId     int  `uri:"id" binding:"required"`
 	WithChecks bool `form:"withChecks" default:"false"`
@@ -47,6 +104,19 @@ type GetMarkStatusHistoryByMarkIdResponse struct {
 	HistoryItems []models.MarkStatusHistoryItem `json:"items"`
 }
 
+type ExplainConsensusResponse struct {
+	Explanation models.ConsensusExplanation `json:"explanation"`
+}
+
+type MergeMarksRequest struct {
+	MarkIds []int `json:"mark_ids" binding:"required,min=1,max=50,dive,gt=0"`
+}
+
+type MergeMarksResponse struct {
+	MarkId        int   `json:"mark_id"`
+	MergedMarkIds []int `json:"merged_mark_ids"`
+}
+
 type ConfirmResponse struct {
 	NewMarkStausId models.MarkStatusType `json:"new_mark_staus_id"`
 }
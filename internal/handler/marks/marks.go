@@ -3,12 +3,14 @@ package marksrest
 import (
 	"context"
 	"errors"
-	"io"
 	"log/slog"
+	"net/http"
 	"strconv"
 	"time"
 
+	"github.com/PritOriginal/problem-map-server/internal/handler/request"
 	mwcache "github.com/PritOriginal/problem-map-server/internal/middleware/cache"
+	"github.com/PritOriginal/problem-map-server/internal/middleware/rbac"
 	"github.com/PritOriginal/problem-map-server/internal/models"
 	"github.com/PritOriginal/problem-map-server/internal/storage"
 	"github.com/PritOriginal/problem-map-server/internal/usecase"
@@ -21,10 +23,15 @@ import (
 )
 
 type Marks interface {
-	GetMarks(ctx context.Context, filters models.GetMarksFilters) ([]models.Mark, error)
+	GetMarks(ctx context.Context, filters models.GetMarksFilters) ([]models.Mark, string, error)
+	GetNearbyMarks(ctx context.Context, filters models.GetNearbyMarksFilters) ([]models.NearbyMark, string, error)
+	SearchMarks(ctx context.Context, filters models.SearchMarksFilters) ([]models.FoundMark, string, error)
 	GetMarkById(ctx context.Context, id int) (models.Mark, error)
-	GetMarksByUserId(ctx context.Context, userId int) ([]models.Mark, error)
-	AddMark(ctx context.Context, mark models.Mark, photos []io.Reader) (int64, error)
+	GetMarksByUserId(ctx context.Context, userId int, pagination models.Pagination) ([]models.Mark, string, error)
+	FindDuplicateMarks(ctx context.Context, mark models.Mark) ([]models.NearbyMark, error)
+	AddMark(ctx context.Context, mark models.Mark, photos []models.Photo, force bool) (int64, error)
+	UpdateMark(ctx context.Context, userId, markId int, update models.MarkUpdate) error
+	DeleteMark(ctx context.Context, userId, markId int) error
 	GetMarkTypes(ctx context.Context) ([]models.MarkType, error)
 	GetMarkStatuses(ctx context.Context) ([]models.MarkStatus, error)
 	GetMarkStatusHistoryByMarkId(ctx context.Context, markId int, withChecks bool) ([]models.MarkStatusHistoryItem, error)
@@ -33,6 +40,8 @@ type Marks interface {
 type StatusUpdater interface {
 	Confirm(ctx context.Context, markId int) (models.MarkStatusType, error)
 	Reject(ctx context.Context, markId int) (models.MarkStatusType, error)
+	Merge(ctx context.Context, markId int, mergedMarkIds []int) error
+	Explain(ctx context.Context, markId int) (models.ConsensusExplanation, error)
 }
 
 type handler struct {
@@ -58,14 +67,24 @@ func Register(r *gin.Engine, log *slog.Logger, params Params) {
 	marks := r.Group("/marks")
 	{
 		marks.GET("", handler.GetMarks())
+		marks.GET("nearby", handler.GetNearbyMarks())
+		marks.GET("search", handler.SearchMarks())
+		marks.GET("duplicates", handler.FindDuplicateMarks())
 		id := marks.Group(":id")
 		{
 			id.GET("", handler.GetMarkById())
 			id.GET("status-history", handler.GetMarkStatusHistoryByMarkId())
+			id.GET("consensus", handler.ExplainConsensus())
 			auth := id.Group("", params.AuthMiddleware.MiddlewareFunc())
 			{
-				auth.POST("confirm", handler.Confirm())
-				auth.POST("reject", handler.Reject())
+				auth.PATCH("", handler.UpdateMark())
+				auth.DELETE("", handler.DeleteMark())
+				moderator := auth.Group("", rbac.RequireRole(models.ModeratorRole))
+				{
+					moderator.POST("confirm", handler.Confirm())
+					moderator.POST("reject", handler.Reject())
+					moderator.POST("merge", handler.MergeMarks())
+				}
 			}
 		}
 		marks.GET("user/:userId", handler.GetMarksByUserId())
@@ -91,6 +110,11 @@ func Register(r *gin.Engine, log *slog.Logger, params Params) {
 //	@Produce		json
 //	@Param			mark_type_ids	query		[]number	false	"filter by mark types"
 //	@Param			mark_status_ids	query		[]number	false	"filter by mark statuses"
+//	@Param			bbox			query		[]number	false	"filter by bounding box: min lon, min lat, max lon, max lat"
+//	@Param			limit			query		int			false	"max number of marks in the page (capped by the server)"
+//	@Param			cursor			query		string		false	"cursor to the next page"
+//	@Param			sort			query		string		false	"sort field"	Enums(created_at, updated_at)
+//	@Param			order			query		string		false	"sort order"	Enums(asc, desc)
 //	@Success		200				{object}	responses.Response[marksrest.GetMarksResponse]
 //	@Failure		400				{object}	responses.Response[any]
 //	@Failure		500				{object}	responses.Response[any]
@@ -112,10 +136,40 @@ func (h *handler) GetMarks() gin.HandlerFunc {
 			return
 		}
 
-		marks, err := h.uc.GetMarks(c.Request.Context(), models.GetMarksFilters{
+		filters := models.GetMarksFilters{
 			MarkTypeIds:   markTypeIds,
 			MarkStatusIds: markStatusIds,
-		})
+		}
+
+		if bboxStr := c.Query("bbox"); bboxStr != "" {
+			coords, err := handlers.ParseFloatArray(bboxStr)
+			if err != nil {
+				h.log.Debug("failed parse bbox", logger.Err(err))
+				responses.BadRequest(c, "failed parse bbox")
+				return
+			}
+			filters.BBox, err = models.NewBBox(coords)
+			if err != nil {
+				h.log.Debug("invalid bbox", logger.Err(err))
+				responses.BadRequest(c, "invalid bbox")
+				return
+			}
+		}
+
+		var query request.PaginationQuery
+		if err := c.ShouldBindQuery(&query); err != nil {
+			h.log.Debug("failed parse pagination", logger.Err(err))
+			responses.BadRequest(c, "invalid pagination")
+			return
+		}
+		filters.Pagination, err = models.MarksSorting.NewPagination(query.Limit, query.Cursor, query.Sort, query.Order)
+		if err != nil {
+			h.log.Debug("invalid pagination", logger.Err(err))
+			responses.BadRequest(c, "invalid pagination")
+			return
+		}
+
+		marks, nextCursor, err := h.uc.GetMarks(c.Request.Context(), filters)
 		if err != nil {
 			h.log.Error("error get marks", logger.Err(err))
 			responses.Internal(c, "error get marks")
@@ -123,7 +177,159 @@ func (h *handler) GetMarks() gin.HandlerFunc {
 		}
 
 		responses.OK(c, GetMarksResponse{
-			Marks: marks,
+			Marks:      marks,
+			Truncated:  nextCursor != "",
+			NextCursor: nextCursor,
+		})
+	}
+}
+
+// GetNearbyMarks lists markers around the point
+//
+//	@Summary		List nearby markers
+//	@Description	get markers around the point ordered by distance (in metres)
+//	@Tags			marks
+//	@Accept			json
+//	@Produce		json
+//	@Param			longitude		query		number		true	"longitude of the point"
+//	@Param			latitude		query		number		true	"latitude of the point"
+//	@Param			radius			query		number		false	"search radius in metres"
+//	@Param			limit			query		int			false	"max number of marks in the page (capped by the server)"
+//	@Param			cursor			query		string		false	"cursor to the next page"
+//	@Param			order			query		string		false	"sort order by distance"	Enums(asc, desc)
+//	@Param			mark_type_ids	query		[]number	false	"filter by mark types"
+//	@Param			mark_status_ids	query		[]number	false	"filter by mark statuses"
+//	@Success		200				{object}	responses.Response[marksrest.GetNearbyMarksResponse]
+//	@Failure		400				{object}	responses.Response[any]
+//	@Failure		500				{object}	responses.Response[any]
+//	@Router			/marks/nearby [get]
+func (h *handler) GetNearbyMarks() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req GetNearbyMarksRequest
+		if err := c.ShouldBindQuery(&req); err != nil {
+			h.log.Debug("failed parse query params", logger.Err(err))
+			responses.BadRequest(c, "failed parse query params")
+			return
+		}
+
+		markTypeIds, err := handlers.ParseIntArray(req.MarkTypeIds)
+		if err != nil {
+			h.log.Debug("failed parse mark type ids", logger.Err(err))
+			responses.BadRequest(c, "failed parse mark type ids")
+			return
+		}
+		markStatusIds, err := handlers.ParseIntArray(req.MarkStatusIds)
+		if err != nil {
+			h.log.Debug("failed parse mark status ids", logger.Err(err))
+			responses.BadRequest(c, "failed parse mark status ids")
+			return
+		}
+
+		pagination, err := models.NearbyMarksSorting.NewPagination(req.Limit, req.Cursor, req.Sort, req.Order)
+		if err != nil {
+			h.log.Debug("invalid pagination", logger.Err(err))
+			responses.BadRequest(c, "invalid pagination")
+			return
+		}
+
+		marks, nextCursor, err := h.uc.GetNearbyMarks(c.Request.Context(), models.GetNearbyMarksFilters{
+			Longitude:     *req.Longitude,
+			Latitude:      *req.Latitude,
+			Radius:        req.Radius,
+			MarkTypeIds:   markTypeIds,
+			MarkStatusIds: markStatusIds,
+			Pagination:    pagination,
+		})
+		if err != nil {
+			h.log.Error("error get nearby marks", logger.Err(err))
+			responses.Internal(c, "error get nearby marks")
+			return
+		}
+
+		responses.OK(c, GetNearbyMarksResponse{
+			Marks:      marks,
+			NextCursor: nextCursor,
+		})
+	}
+}
+
+// SearchMarks searches markers by text
+//
+//	@Summary		Search markers
+//	@Description	full-text search (russian) over mark descriptions and check comments, ordered by rank
+//	@Tags			marks
+//	@Accept			json
+//	@Produce		json
+//	@Param			q				query		string		true	"search query"
+//	@Param			mark_type_ids	query		[]number	false	"filter by mark types"
+//	@Param			mark_status_ids	query		[]number	false	"filter by mark statuses"
+//	@Param			bbox			query		[]number	false	"filter by bounding box: min lon, min lat, max lon, max lat"
+//	@Param			limit			query		int			false	"max number of marks in the page (capped by the server)"
+//	@Param			cursor			query		string		false	"cursor to the next page"
+//	@Success		200				{object}	responses.Response[marksrest.SearchMarksResponse]
+//	@Failure		400				{object}	responses.Response[any]
+//	@Failure		500				{object}	responses.Response[any]
+//	@Router			/marks/search [get]
+func (h *handler) SearchMarks() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req SearchMarksRequest
+		if err := c.ShouldBindQuery(&req); err != nil {
+			h.log.Debug("failed parse query params", logger.Err(err))
+			responses.BadRequest(c, "failed parse query params")
+			return
+		}
+
+		markTypeIds, err := handlers.ParseIntArray(req.MarkTypeIds)
+		if err != nil {
+			h.log.Debug("failed parse mark type ids", logger.Err(err))
+			responses.BadRequest(c, "failed parse mark type ids")
+			return
+		}
+		markStatusIds, err := handlers.ParseIntArray(req.MarkStatusIds)
+		if err != nil {
+			h.log.Debug("failed parse mark status ids", logger.Err(err))
+			responses.BadRequest(c, "failed parse mark status ids")
+			return
+		}
+
+		filters := models.SearchMarksFilters{
+			Query:         req.Query,
+			MarkTypeIds:   markTypeIds,
+			MarkStatusIds: markStatusIds,
+		}
+
+		if req.BBox != "" {
+			coords, err := handlers.ParseFloatArray(req.BBox)
+			if err != nil {
+				h.log.Debug("failed parse bbox", logger.Err(err))
+				responses.BadRequest(c, "failed parse bbox")
+				return
+			}
+			filters.BBox, err = models.NewBBox(coords)
+			if err != nil {
+				h.log.Debug("invalid bbox", logger.Err(err))
+				responses.BadRequest(c, "invalid bbox")
+				return
+			}
+		}
+
+		filters.Pagination, err = models.SearchMarksSorting.NewPagination(req.Limit, req.Cursor, req.Sort, req.Order)
+		if err != nil {
+			h.log.Debug("invalid pagination", logger.Err(err))
+			responses.BadRequest(c, "invalid pagination")
+			return
+		}
+
+		marks, nextCursor, err := h.uc.SearchMarks(c.Request.Context(), filters)
+		if err != nil {
+			h.log.Error("error search marks", slog.String("query", req.Query), logger.Err(err))
+			responses.Internal(c, "error search marks")
+			return
+		}
+
+		responses.OK(c, SearchMarksResponse{
+			Marks:      marks,
+			NextCursor: nextCursor,
 		})
 	}
 }
@@ -174,10 +380,14 @@ func (h *handler) GetMarkById() gin.HandlerFunc {
 //	@Description	get markers by user id
 //	@Tags			marks
 //	@Produce		json
-//	@Param			id	path		int	true	"user id"
-//	@Success		200	{object}	responses.Response[marksrest.GetMarksByUserIdResponse]
-//	@Failure		400	{object}	responses.Response[any]
-//	@Failure		500	{object}	responses.Response[any]
+//	@Param			id		path		int		true	"user id"
+//	@Param			limit	query		int		false	"max number of marks in the page (capped by the server)"
+//	@Param			cursor	query		string	false	"cursor to the next page"
+//	@Param			sort	query		string	false	"sort field"	Enums(created_at, updated_at)
+//	@Param			order	query		string	false	"sort order"	Enums(asc, desc)
+//	@Success		200		{object}	responses.Response[marksrest.GetMarksByUserIdResponse]
+//	@Failure		400		{object}	responses.Response[any]
+//	@Failure		500		{object}	responses.Response[any]
 //	@Router			/marks/user/{id} [get]
 func (h *handler) GetMarksByUserId() gin.HandlerFunc {
 	return func(c *gin.Context) {
@@ -188,7 +398,20 @@ func (h *handler) GetMarksByUserId() gin.HandlerFunc {
 			return
 		}
 
-		marks, err := h.uc.GetMarksByUserId(c.Request.Context(), userId)
+		var query request.PaginationQuery
+		if err := c.ShouldBindQuery(&query); err != nil {
+			h.log.Debug("failed parse pagination", logger.Err(err))
+			responses.BadRequest(c, "invalid pagination")
+			return
+		}
+		pagination, err := models.MarksSorting.NewPagination(query.Limit, query.Cursor, query.Sort, query.Order)
+		if err != nil {
+			h.log.Debug("invalid pagination", logger.Err(err))
+			responses.BadRequest(c, "invalid pagination")
+			return
+		}
+
+		marks, nextCursor, err := h.uc.GetMarksByUserId(c.Request.Context(), userId, pagination)
 		if err != nil {
 			h.log.Error("error get marks by user id", slog.Int("user_id", userId), logger.Err(err))
 			responses.Internal(c, "error get marks by user id")
@@ -196,7 +419,8 @@ func (h *handler) GetMarksByUserId() gin.HandlerFunc {
 		}
 
 		responses.OK(c, GetMarksByUserIdResponse{
-			Marks: marks,
+			Marks:      marks,
+			NextCursor: nextCursor,
 		})
 	}
 }
@@ -209,8 +433,10 @@ func (h *handler) GetMarksByUserId() gin.HandlerFunc {
 //	@Accept			mpfd
 //	@Produce		json
 //	@Param			Authorization	header		string	true	"Insert your access token"	default(Bearer <Add access token here>)
+//	@Param			force			formData	bool	false	"add the mark even if possible duplicates were found"
 //	@Success		201				{object}	responses.Response[marksrest.AddMarkResponse]
 //	@Failure		400				{object}	responses.Response[any]
+//	@Failure		409				{object}	responses.Response[marksrest.DuplicateMarksResponse]
 //	@Failure		500				{object}	responses.Response[any]
 //	@Router			/marks [post]
 func (h *handler) AddMark() gin.HandlerFunc {
@@ -222,22 +448,14 @@ func (h *handler) AddMark() gin.HandlerFunc {
 			return
 		}
 
-		photos, err := handlers.ParsePhotos(req.Photos)
+		photos, err := request.ParsePhotos(req.Photos)
 		if err != nil {
 			h.log.Error("error parse photos", logger.Err(err))
 			responses.Internal(c, "error parse photos")
 			return
 		}
 
-		claims := jwt.ExtractClaims(c)
-
-		userIdStr, err := claims.GetSubject()
-		if err != nil {
-			h.log.Debug("invalid token", logger.Err(err))
-			responses.Unauthorized(c, "invalid token")
-			return
-		}
-		userId, err := strconv.Atoi(userIdStr)
+		userId, err := request.GetUserId(c)
 		if err != nil {
 			h.log.Debug("invalid token", logger.Err(err))
 			responses.Unauthorized(c, "invalid token")
@@ -245,23 +463,31 @@ func (h *handler) AddMark() gin.HandlerFunc {
 		}
 
 		newMark := models.Mark{
-			Geom:        models.NewPoint(geom.Coord{req.Latitude, req.Longitude}),
+			Geom:        models.NewPoint(geom.Coord{*req.Longitude, *req.Latitude}),
 			MarkTypeID:  req.MarkTypeID,
 			UserID:      userId,
 			Description: req.Description,
 		}
-		markId, err := h.uc.AddMark(c.Request.Context(), newMark, photos)
+		markId, err := h.uc.AddMark(c.Request.Context(), newMark, photos, req.Force)
 		if err != nil {
-			h.log.Error("error add mark", logger.Err(err))
-			responses.Internal(c, "error add mark")
+			var duplicatesErr *usecase.DuplicateMarksError
+			if errors.As(err, &duplicatesErr) {
+				h.log.Debug("possible duplicate marks found", slog.Int("user_id", userId), slog.Int("duplicates", len(duplicatesErr.Marks)))
+				responses.FailWithData(c, http.StatusConflict, "possible duplicate marks found", DuplicateMarksResponse{
+					Marks: duplicatesErr.Marks,
+				})
+			} else {
+				h.log.Error("error add mark", logger.Err(err))
+				responses.Internal(c, "error add mark")
+			}
 			return
 		}
 
 		h.log.Info("add new mark",
 			slog.Int64("mark_id", markId),
 			slog.Int("user_id", userId),
-			slog.Float64("longitude", req.Longitude),
-			slog.Float64("latitude", req.Latitude),
+			slog.Float64("longitude", *req.Longitude),
+			slog.Float64("latitude", *req.Latitude),
 			slog.Int("photos", len(photos)),
 		)
 		responses.Created(c, AddMarkResponse{
@@ -270,6 +496,182 @@ func (h *handler) AddMark() gin.HandlerFunc {
 	}
 }
 
+// FindDuplicateMarks lists possible duplicates of a new mark
+//
+//	@Summary		Find duplicate markers
+//	@Description	dry run of the duplicate check done when adding a mark: open marks of the same type created nearby recently
+//	@Tags			marks
+//	@Accept			json
+//	@Produce		json
+//	@Param			longitude		query		number	true	"longitude of the new mark"
+//	@Param			latitude		query		number	true	"latitude of the new mark"
+//	@Param			mark_type_id	query		int		true	"type of the new mark"
+//	@Success		200				{object}	responses.Response[marksrest.DuplicateMarksResponse]
+//	@Failure		400				{object}	responses.Response[any]
+//	@Failure		500				{object}	responses.Response[any]
+//	@Router			/marks/duplicates [get]
+func (h *handler) FindDuplicateMarks() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req FindDuplicateMarksRequest
+		if err := c.ShouldBindQuery(&req); err != nil {
+			h.log.Debug("failed parse query params", logger.Err(err))
+			responses.BadRequest(c, "failed parse query params")
+			return
+		}
+
+		mark := models.Mark{
+			Geom:       models.NewPoint(geom.Coord{*req.Longitude, *req.Latitude}),
+			MarkTypeID: req.MarkTypeID,
+		}
+		duplicates, err := h.uc.FindDuplicateMarks(c.Request.Context(), mark)
+		if err != nil {
+			h.log.Error("error find duplicate marks", logger.Err(err))
+			responses.Internal(c, "error find duplicate marks")
+			return
+		}
+
+		responses.OK(c, DuplicateMarksResponse{
+			Marks: duplicates,
+		})
+	}
+}
+
+// UpdateMark edits the mark by its author
+//
+//	@Summary		Update mark
+//	@Description	edit description, type or correct the location of the unconfirmed mark, allowed only for the author
+//	@Tags			marks
+//	@Accept			json
+//	@Produce		json
+//	@Param			Authorization	header		string						true	"Insert your access token"	default(Bearer <Add access token here>)
+//	@Param			id				path		int							true	"mark id"
+//	@Param			request			body		marksrest.UpdateMarkRequest	true	"mark fields to update"
+//	@Success		200				{object}	responses.Response[marksrest.UpdateMarkResponse]
+//	@Failure		400				{object}	responses.Response[any]
+//	@Failure		401				{object}	responses.Response[any]
+//	@Failure		403				{object}	responses.Response[any]
+//	@Failure		404				{object}	responses.Response[any]
+//	@Failure		409				{object}	responses.Response[any]
+//	@Failure		500				{object}	responses.Response[any]
+//	@Router			/marks/{id} [patch]
+func (h *handler) UpdateMark() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			h.log.Debug("failed parse id", logger.Err(err))
+			responses.BadRequest(c, "failed parse id")
+			return
+		}
+
+		var req UpdateMarkRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			h.log.Debug("failed binding request", logger.Err(err))
+			responses.BadRequest(c, "invalid request")
+			return
+		}
+		if req.Description == nil && req.MarkTypeID == nil && req.Longitude == nil {
+			h.log.Debug("empty update request", slog.Int("mark_id", id))
+			responses.BadRequest(c, "nothing to update")
+			return
+		}
+
+		userId, err := request.GetUserId(c)
+		if err != nil {
+			h.log.Debug("invalid token", logger.Err(err))
+			responses.Unauthorized(c, "invalid token")
+			return
+		}
+
+		update := models.MarkUpdate{
+			Description: req.Description,
+			MarkTypeID:  req.MarkTypeID,
+		}
+		if req.Longitude != nil {
+			update.Geom = models.NewPoint(geom.Coord{*req.Longitude, *req.Latitude})
+		}
+
+		if err := h.uc.UpdateMark(c.Request.Context(), userId, id, update); err != nil {
+			switch {
+			case errors.Is(err, storage.ErrNotFound):
+				h.log.Debug("mark not found", slog.Int("mark_id", id))
+				responses.NotFound(c, "mark not found")
+			case errors.Is(err, usecase.ErrForbidden):
+				h.log.Debug("user is not the author of the mark", slog.Int("mark_id", id), slog.Int("user_id", userId))
+				responses.Forbidden(c, "only the author can edit the mark")
+			case errors.Is(err, usecase.ErrConflict):
+				h.log.Debug("mark is no longer unconfirmed", slog.Int("mark_id", id))
+				responses.Conflict(c, "only unconfirmed marks can be edited")
+			case errors.Is(err, usecase.ErrInvalidInput):
+				h.log.Debug("geometry correction is too large", slog.Int("mark_id", id))
+				responses.BadRequest(c, "geometry correction is too large")
+			default:
+				h.log.Error("error update mark", slog.Int("mark_id", id), logger.Err(err))
+				responses.Internal(c, "error update mark")
+			}
+			return
+		}
+
+		h.log.Info("mark has been updated", slog.Int("mark_id", id), slog.Int("user_id", userId))
+		responses.OK(c, UpdateMarkResponse{
+			MarkId: id,
+		})
+	}
+}
+
+// DeleteMark deletes the mark by its author
+//
+//	@Summary		Delete mark
+//	@Description	delete the mark, allowed only for the author
+//	@Tags			marks
+//	@Accept			json
+//	@Produce		json
+//	@Param			Authorization	header		string	true	"Insert your access token"	default(Bearer <Add access token here>)
+//	@Param			id				path		int		true	"mark id"
+//	@Success		200				{object}	responses.Response[marksrest.DeleteMarkResponse]
+//	@Failure		400				{object}	responses.Response[any]
+//	@Failure		401				{object}	responses.Response[any]
+//	@Failure		403				{object}	responses.Response[any]
+//	@Failure		404				{object}	responses.Response[any]
+//	@Failure		500				{object}	responses.Response[any]
+//	@Router			/marks/{id} [delete]
+func (h *handler) DeleteMark() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			h.log.Debug("failed parse id", logger.Err(err))
+			responses.BadRequest(c, "failed parse id")
+			return
+		}
+
+		userId, err := request.GetUserId(c)
+		if err != nil {
+			h.log.Debug("invalid token", logger.Err(err))
+			responses.Unauthorized(c, "invalid token")
+			return
+		}
+
+		if err := h.uc.DeleteMark(c.Request.Context(), userId, id); err != nil {
+			switch {
+			case errors.Is(err, storage.ErrNotFound):
+				h.log.Debug("mark not found", slog.Int("mark_id", id))
+				responses.NotFound(c, "mark not found")
+			case errors.Is(err, usecase.ErrForbidden):
+				h.log.Debug("user is not the author of the mark", slog.Int("mark_id", id), slog.Int("user_id", userId))
+				responses.Forbidden(c, "only the author can delete the mark")
+			default:
+				h.log.Error("error delete mark", slog.Int("mark_id", id), logger.Err(err))
+				responses.Internal(c, "error delete mark")
+			}
+			return
+		}
+
+		h.log.Info("mark has been deleted", slog.Int("mark_id", id), slog.Int("user_id", userId))
+		responses.OK(c, DeleteMarkResponse{
+			MarkId: id,
+		})
+	}
+}
+
 // GetMarkTypes lists all existing mark types
 //
 //	@Summary		List mark types
@@ -363,17 +765,121 @@ func (h *handler) GetMarkStatusHistoryByMarkId() gin.HandlerFunc {
 	}
 }
 
+// ExplainConsensus explains why the mark status would or would not change
+//
+//	@Summary		Explain the mark consensus
+//	@Description	dry run of the consensus rule of the mark type on the checks of the current mark status, the status is not changed
+//	@Tags			marks
+//	@Produce		json
+//	@Param			id	path		int	true	"mark id"
+//	@Success		200	{object}	responses.Response[marksrest.ExplainConsensusResponse]
+//	@Failure		400	{object}	responses.Response[any]
+//	@Failure		404	{object}	responses.Response[any]
+//	@Failure		500	{object}	responses.Response[any]
+//	@Router			/marks/{id}/consensus [get]
+func (h *handler) ExplainConsensus() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			h.log.Debug("failed parse id", logger.Err(err))
+			responses.BadRequest(c, "failed parse id")
+			return
+		}
+
+		explanation, err := h.statusUpdater.Explain(c.Request.Context(), id)
+		if err != nil {
+			switch {
+			case errors.Is(err, storage.ErrNotFound):
+				h.log.Debug("mark not found", slog.Int("mark_id", id))
+				responses.NotFound(c, "mark not found")
+			default:
+				h.log.Error("error explain consensus", slog.Int("mark_id", id), logger.Err(err))
+				responses.Internal(c, "error explain consensus")
+			}
+			return
+		}
+
+		responses.OK(c, ExplainConsensusResponse{
+			Explanation: explanation,
+		})
+	}
+}
+
+// MergeMarks merges the duplicate marks into the mark
+//
+//	@Summary		Merge duplicate marks
+//	@Description	merge the duplicate marks into the mark, their checks and photos are moved to the mark and their ids resolve to it, allowed only for moderators
+//	@Tags			marks
+//	@Accept			json
+//	@Produce		json
+//	@Param			Authorization	header		string						true	"Insert your access token"	default(Bearer <Add access token here>)
+//	@Param			id				path		int							true	"canonical mark id"
+//	@Param			request			body		marksrest.MergeMarksRequest	true	"merged marks"
+//	@Success		200				{object}	responses.Response[marksrest.MergeMarksResponse]
+//	@Failure		400				{object}	responses.Response[any]
+//	@Failure		401				{object}	responses.Response[any]
+//	@Failure		403				{object}	responses.Response[any]
+//	@Failure		404				{object}	responses.Response[any]
+//	@Failure		409				{object}	responses.Response[any]
+//	@Failure		500				{object}	responses.Response[any]
+//	@Router			/marks/{id}/merge [post]
+func (h *handler) MergeMarks() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			h.log.Debug("failed parse id", logger.Err(err))
+			responses.BadRequest(c, "failed parse id")
+			return
+		}
+
+		var req MergeMarksRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			h.log.Debug("failed binding request", logger.Err(err))
+			responses.BadRequest(c, "invalid request")
+			return
+		}
+
+		if err := h.statusUpdater.Merge(c.Request.Context(), id, req.MarkIds); err != nil {
+			switch {
+			case errors.Is(err, storage.ErrNotFound):
+				h.log.Debug("mark not found", slog.Int("mark_id", id))
+				responses.NotFound(c, "mark not found")
+			case errors.Is(err, usecase.ErrInvalidInput):
+				h.log.Debug("invalid merged marks", slog.Int("mark_id", id), slog.Any("merged_mark_ids", req.MarkIds))
+				responses.BadRequest(c, "invalid merged marks")
+			case errors.Is(err, usecase.ErrConflict):
+				h.log.Debug("marks are not open", slog.Int("mark_id", id), slog.Any("merged_mark_ids", req.MarkIds))
+				responses.Conflict(c, "only open marks can be merged")
+			default:
+				h.log.Error("error merge marks", slog.Int("mark_id", id), logger.Err(err))
+				responses.Internal(c, "error merge marks")
+			}
+			return
+		}
+
+		h.log.Info("marks have been merged", slog.Int("mark_id", id), slog.Any("merged_mark_ids", req.MarkIds))
+		responses.OK(c, MergeMarksResponse{
+			MarkId:        id,
+			MergedMarkIds: req.MarkIds,
+		})
+	}
+}
+
 // Confirm сonfirm the mark and moves it to a new status
 //
 //	@Summary		Confirm the mark
-//	@Description	сonfirm the mark and moves it to a new status
+//	@Description	сonfirm the mark and moves it to a new status, allowed only for moderators
 //	@Tags			marks
 //	@Accept			json
 //	@Produce		json
-//	@Success		200	{object}	responses.Response[marksrest.ConfirmResponse]
-//	@Failure		400	{object}	responses.Response[any]
-//	@Failure		409	{object}	responses.Response[any]
-//	@Failure		500	{object}	responses.Response[any]
+//	@Param			Authorization	header		string	true	"Insert your access token"	default(Bearer <Add access token here>)
+//	@Param			id				path		int		true	"mark id"
+//	@Success		200				{object}	responses.Response[marksrest.ConfirmResponse]
+//	@Failure		400				{object}	responses.Response[any]
+//	@Failure		401				{object}	responses.Response[any]
+//	@Failure		403				{object}	responses.Response[any]
+//	@Failure		409				{object}	responses.Response[any]
+//	@Failure		500				{object}	responses.Response[any]
 //	@Router			/marks/{id}/confirm [post]
 func (h *handler) Confirm() gin.HandlerFunc {
 	return func(c *gin.Context) {
@@ -386,10 +892,10 @@ func (h *handler) Confirm() gin.HandlerFunc {
 
 		newStatusId, err := h.statusUpdater.Confirm(c.Request.Context(), id)
 		if err != nil {
-			switch err {
-			case usecase.ErrConflict:
+			switch {
+			case errors.Is(err, usecase.ErrConflict):
 				h.log.Debug("unable to update the mark status", slog.Int("mark_id", id))
-				responses.Conflict(c, "user already exists")
+				responses.Conflict(c, "unable to update the mark status")
 			default:
 				h.log.Error("error confirm mark", slog.Int("mark_id", id), logger.Err(err))
 				responses.Internal(c, "error confirm mark")
@@ -407,14 +913,18 @@ func (h *handler) Confirm() gin.HandlerFunc {
 // Reject reject the mark and moves it to a new status
 //
 //	@Summary		Reject the mark
-//	@Description	reject the mark and moves it to a new status
+//	@Description	reject the mark and moves it to a new status, allowed only for moderators
 //	@Tags			marks
 //	@Accept			json
 //	@Produce		json
-//	@Success		200	{object}	responses.Response[marksrest.RejectResponse]
-//	@Failure		400	{object}	responses.Response[any]
-//	@Failure		409	{object}	responses.Response[any]
-//	@Failure		500	{object}	responses.Response[any]
+//	@Param			Authorization	header		string	true	"Insert your access token"	default(Bearer <Add access token here>)
+//	@Param			id				path		int		true	"mark id"
+//	@Success		200				{object}	responses.Response[marksrest.RejectResponse]
+//	@Failure		400				{object}	responses.Response[any]
+//	@Failure		401				{object}	responses.Response[any]
+//	@Failure		403				{object}	responses.Response[any]
+//	@Failure		409				{object}	responses.Response[any]
+//	@Failure		500				{object}	responses.Response[any]
 //	@Router			/marks/{id}/reject [post]
 func (h *handler) Reject() gin.HandlerFunc {
 	return func(c *gin.Context) {
@@ -427,10 +937,10 @@ func (h *handler) Reject() gin.HandlerFunc {
 
 		newStatus, err := h.statusUpdater.Reject(c.Request.Context(), id)
 		if err != nil {
-			switch err {
-			case usecase.ErrConflict:
+			switch {
+			case errors.Is(err, usecase.ErrConflict):
 				h.log.Debug("unable to update the mark status", slog.Int("mark_id", id))
-				responses.Conflict(c, "user already exists")
+				responses.Conflict(c, "unable to update the mark status")
 			default:
 				h.log.Error("error confirm mark", slog.Int("mark_id", id), logger.Err(err))
 				responses.Internal(c, "error confirm mark")
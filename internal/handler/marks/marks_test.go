@@ -3,6 +3,7 @@ package marksrest_test
 import (
 	"bytes"
 	"errors"
+	"fmt"
 	"io"
 	"mime/multipart"
 	"net/http"
@@ -73,6 +74,8 @@ func (suite *MarksSuite) TestGetMarks() {
 		query                     string
 		wantErrParseMarkTypeIds   bool
 		wantErrParseMarkStatusIds bool
+		wantErrParseBBox          bool
+		wantErrPagination         bool
 		errGetMarks               error
 		statusCode                int
 	}{
@@ -112,6 +115,64 @@ func (suite *MarksSuite) TestGetMarks() {
 			wantErrParseMarkTypeIds: true,
 			statusCode:              http.StatusBadRequest,
 		},
+		{
+			name:       "Ok200",
+			query:      "?bbox=41.3,52.6,41.6,52.8&limit=100",
+			statusCode: http.StatusOK,
+		},
+		{
+			name:             "Ok400",
+			query:            "?bbox=a,52.6,41.6,52.8",
+			wantErrParseBBox: true,
+			statusCode:       http.StatusBadRequest,
+		},
+		{
+			name:             "Ok400",
+			query:            "?bbox=41.3,52.6,41.6",
+			wantErrParseBBox: true,
+			statusCode:       http.StatusBadRequest,
+		},
+		{
+			name:             "Ok400",
+			query:            "?bbox=41.6,52.6,41.3,52.8",
+			wantErrParseBBox: true,
+			statusCode:       http.StatusBadRequest,
+		},
+		{
+			name:             "Ok400",
+			query:            "?bbox=NaN,52.6,41.6,52.8",
+			wantErrParseBBox: true,
+			statusCode:       http.StatusBadRequest,
+		},
+		{
+			name:              "Ok400",
+			query:             "?limit=-1",
+			wantErrPagination: true,
+			statusCode:        http.StatusBadRequest,
+		},
+		{
+			name:       "Ok200",
+			query:      "?limit=10&sort=updated_at&order=asc",
+			statusCode: http.StatusOK,
+		},
+		{
+			name:              "Ok400",
+			query:             "?sort=distance",
+			wantErrPagination: true,
+			statusCode:        http.StatusBadRequest,
+		},
+		{
+			name:              "Ok400",
+			query:             "?order=up",
+			wantErrPagination: true,
+			statusCode:        http.StatusBadRequest,
+		},
+		{
+			name:              "Ok400",
+			query:             "?cursor=invalid",
+			wantErrPagination: true,
+			statusCode:        http.StatusBadRequest,
+		},
 		{
 			name:        "Err500",
 			errGetMarks: errors.New(""),
@@ -120,9 +181,9 @@ func (suite *MarksSuite) TestGetMarks() {
 	}
 	for _, tt := range tests {
 		suite.Run(tt.name, func() {
-			if !tt.wantErrParseMarkStatusIds && !tt.wantErrParseMarkTypeIds {
+			if !tt.wantErrParseMarkStatusIds && !tt.wantErrParseMarkTypeIds && !tt.wantErrParseBBox && !tt.wantErrPagination {
 				suite.uc.On("GetMarks", mock.Anything, mock.Anything).Once().
-					Return([]models.Mark{}, tt.errGetMarks)
+					Return([]models.Mark{}, "", tt.errGetMarks)
 			}
 
 			w := httptest.NewRecorder()
@@ -135,6 +196,211 @@ func (suite *MarksSuite) TestGetMarks() {
 	}
 }
 
+func (suite *MarksSuite) TestGetNearbyMarks() {
+	tests := []struct {
+		name              string
+		query             string
+		wantErrParse      bool
+		errGetNearbyMarks error
+		statusCode        int
+	}{
+		{
+			name:       "Ok200",
+			query:      "?longitude=41.46&latitude=52.71",
+			statusCode: http.StatusOK,
+		},
+		{
+			name:       "Ok200",
+			query:      "?longitude=41.46&latitude=52.71&radius=500&limit=20&mark_type_ids=1,2",
+			statusCode: http.StatusOK,
+		},
+		{
+			name:       "Ok200",
+			query:      "?longitude=0&latitude=0",
+			statusCode: http.StatusOK,
+		},
+		{
+			name:         "Err400",
+			query:        "?longitude=41.46",
+			wantErrParse: true,
+			statusCode:   http.StatusBadRequest,
+		},
+		{
+			name:         "Err400",
+			query:        "?longitude=41.46&latitude=100",
+			wantErrParse: true,
+			statusCode:   http.StatusBadRequest,
+		},
+		{
+			name:         "Err400",
+			query:        "?longitude=41.46&latitude=52.71&radius=-1",
+			wantErrParse: true,
+			statusCode:   http.StatusBadRequest,
+		},
+		{
+			name:         "Err400",
+			query:        "?longitude=41.46&latitude=52.71&mark_status_ids=a",
+			wantErrParse: true,
+			statusCode:   http.StatusBadRequest,
+		},
+		{
+			name:         "Err400",
+			query:        "?longitude=41.46&latitude=52.71&sort=created_at",
+			wantErrParse: true,
+			statusCode:   http.StatusBadRequest,
+		},
+		{
+			name:              "Err500",
+			query:             "?longitude=41.46&latitude=52.71",
+			errGetNearbyMarks: errors.New(""),
+			statusCode:        http.StatusInternalServerError,
+		},
+	}
+	for _, tt := range tests {
+		suite.Run(tt.name, func() {
+			if !tt.wantErrParse {
+				suite.uc.On("GetNearbyMarks", mock.Anything, mock.AnythingOfType("models.GetNearbyMarksFilters")).Once().
+					Return([]models.NearbyMark{}, "", tt.errGetNearbyMarks)
+			}
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("GET", "/marks/nearby"+tt.query, nil)
+
+			suite.r.ServeHTTP(w, req)
+
+			suite.Equal(tt.statusCode, w.Code)
+		})
+	}
+}
+
+func (suite *MarksSuite) TestSearchMarks() {
+	tests := []struct {
+		name           string
+		query          string
+		wantErrParse   bool
+		errSearchMarks error
+		statusCode     int
+	}{
+		{
+			name:       "Ok200",
+			query:      "?q=яма",
+			statusCode: http.StatusOK,
+		},
+		{
+			name:       "Ok200",
+			query:      "?q=яма&mark_type_ids=1,2&mark_status_ids=1&bbox=41.3,52.6,41.6,52.8&limit=10",
+			statusCode: http.StatusOK,
+		},
+		{
+			name:         "Err400",
+			wantErrParse: true,
+			statusCode:   http.StatusBadRequest,
+		},
+		{
+			name:         "Err400",
+			query:        "?q=яма&mark_type_ids=a",
+			wantErrParse: true,
+			statusCode:   http.StatusBadRequest,
+		},
+		{
+			name:         "Err400",
+			query:        "?q=яма&bbox=41.6,52.6,41.3,52.8",
+			wantErrParse: true,
+			statusCode:   http.StatusBadRequest,
+		},
+		{
+			name:         "Err400",
+			query:        "?q=яма&sort=created_at",
+			wantErrParse: true,
+			statusCode:   http.StatusBadRequest,
+		},
+		{
+			name:           "Err500",
+			query:          "?q=яма",
+			errSearchMarks: errors.New(""),
+			statusCode:     http.StatusInternalServerError,
+		},
+	}
+	for _, tt := range tests {
+		suite.Run(tt.name, func() {
+			if !tt.wantErrParse {
+				suite.uc.On("SearchMarks", mock.Anything, mock.AnythingOfType("models.SearchMarksFilters")).Once().
+					Return([]models.FoundMark{}, "", tt.errSearchMarks)
+			}
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("GET", "/marks/search"+tt.query, nil)
+
+			suite.r.ServeHTTP(w, req)
+
+			suite.Equal(tt.statusCode, w.Code)
+		})
+	}
+}
+
+func (suite *MarksSuite) TestFindDuplicateMarks() {
+	tests := []struct {
+		name                  string
+		query                 string
+		longitude, latitude   float64
+		wantErrParse          bool
+		errFindDuplicateMarks error
+		statusCode            int
+	}{
+		{
+			name:       "Ok200",
+			query:      "?longitude=41.46&latitude=52.71&mark_type_id=1",
+			longitude:  41.46,
+			latitude:   52.71,
+			statusCode: http.StatusOK,
+		},
+		{
+			name:       "Ok200",
+			query:      "?longitude=0&latitude=0&mark_type_id=1",
+			statusCode: http.StatusOK,
+		},
+		{
+			name:         "Err400",
+			query:        "?longitude=41.46&latitude=52.71",
+			wantErrParse: true,
+			statusCode:   http.StatusBadRequest,
+		},
+		{
+			name:         "Err400",
+			query:        "?longitude=200&latitude=52.71&mark_type_id=1",
+			wantErrParse: true,
+			statusCode:   http.StatusBadRequest,
+		},
+		{
+			name:                  "Err500",
+			query:                 "?longitude=41.46&latitude=52.71&mark_type_id=1",
+			longitude:             41.46,
+			latitude:              52.71,
+			errFindDuplicateMarks: errors.New(""),
+			statusCode:            http.StatusInternalServerError,
+		},
+	}
+	for _, tt := range tests {
+		suite.Run(tt.name, func() {
+			if !tt.wantErrParse {
+				isRequestPoint := func(mark models.Mark) bool {
+					coords := mark.Geom.Ewkb.Coords()
+					return coords.X() == tt.longitude && coords.Y() == tt.latitude
+				}
+				suite.uc.On("FindDuplicateMarks", mock.Anything, mock.MatchedBy(isRequestPoint)).Once().
+					Return([]models.NearbyMark{}, tt.errFindDuplicateMarks)
+			}
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("GET", "/marks/duplicates"+tt.query, nil)
+
+			suite.r.ServeHTTP(w, req)
+
+			suite.Equal(tt.statusCode, w.Code)
+		})
+	}
+}
+
 func (suite *MarksSuite) TestGetMarkById() {
 	tests := []struct {
 		name           string
@@ -222,8 +488,8 @@ func (suite *MarksSuite) TestGetMarksByUserId() {
 	for _, tt := range tests {
 		suite.Run(tt.name, func() {
 			if !tt.wantErrParseId {
-				suite.uc.On("GetMarksByUserId", mock.Anything, mock.AnythingOfType("int")).Once().
-					Return([]models.Mark{}, tt.errGetMarksByUserId)
+				suite.uc.On("GetMarksByUserId", mock.Anything, mock.AnythingOfType("int"), mock.AnythingOfType("models.Pagination")).Once().
+					Return([]models.Mark{}, "", tt.errGetMarksByUserId)
 			}
 
 			w := httptest.NewRecorder()
@@ -247,8 +513,8 @@ func (suite *MarksSuite) TestAddMark() {
 		{
 			name: "Ok201",
 			req: marksrest.AddMarkRequest{
-				Longitude:   42,
-				Latitude:    52,
+				Longitude:   ptr(42.0),
+				Latitude:    ptr(52.0),
 				MarkTypeID:  1,
 				Description: "",
 			},
@@ -256,11 +522,22 @@ func (suite *MarksSuite) TestAddMark() {
 			errAddCheck:     nil,
 			statusCode:      201,
 		},
+		{
+			name: "Ok201ZeroCoordinates",
+			req: marksrest.AddMarkRequest{
+				Longitude:  ptr(0.0),
+				Latitude:   ptr(0.0),
+				MarkTypeID: 1,
+			},
+			wantErrParseReq: false,
+			errAddCheck:     nil,
+			statusCode:      201,
+		},
 		{
 			name: "Err400InvalidReq-1",
 			req: marksrest.AddMarkRequest{
-				Longitude: 42,
-				Latitude:  52,
+				Longitude: ptr(42.0),
+				Latitude:  ptr(52.0),
 			},
 			wantErrParseReq: true,
 			errAddCheck:     nil,
@@ -269,7 +546,7 @@ func (suite *MarksSuite) TestAddMark() {
 		{
 			name: "Err400InvalidReq-2",
 			req: marksrest.AddMarkRequest{
-				Longitude:   42,
+				Longitude:   ptr(42.0),
 				MarkTypeID:  1,
 				Description: "",
 			},
@@ -280,8 +557,8 @@ func (suite *MarksSuite) TestAddMark() {
 		{
 			name: "Err400InvalidReq-3",
 			req: marksrest.AddMarkRequest{
-				Longitude:   42,
-				Latitude:    52,
+				Longitude:   ptr(42.0),
+				Latitude:    ptr(52.0),
 				MarkTypeID:  1,
 				Description: strings.Repeat("A", 257),
 			},
@@ -292,8 +569,8 @@ func (suite *MarksSuite) TestAddMark() {
 		{
 			name: "Err500",
 			req: marksrest.AddMarkRequest{
-				Longitude:   42,
-				Latitude:    52,
+				Longitude:   ptr(42.0),
+				Latitude:    ptr(52.0),
 				MarkTypeID:  1,
 				Description: "",
 			},
@@ -301,11 +578,38 @@ func (suite *MarksSuite) TestAddMark() {
 			errAddCheck:     errors.New(""),
 			statusCode:      500,
 		},
+		{
+			name: "Err409",
+			req: marksrest.AddMarkRequest{
+				Longitude:  ptr(42.0),
+				Latitude:   ptr(52.0),
+				MarkTypeID: 1,
+			},
+			wantErrParseReq: false,
+			errAddCheck:     fmt.Errorf("%w", &usecase.DuplicateMarksError{Marks: []models.NearbyMark{{}}}),
+			statusCode:      409,
+		},
+		{
+			name: "Ok201Force",
+			req: marksrest.AddMarkRequest{
+				Longitude:  ptr(42.0),
+				Latitude:   ptr(52.0),
+				MarkTypeID: 1,
+				Force:      true,
+			},
+			wantErrParseReq: false,
+			errAddCheck:     nil,
+			statusCode:      201,
+		},
 	}
 	for _, tt := range tests {
 		suite.Run(tt.name, func() {
 			if !tt.wantErrParseReq {
-				suite.uc.On("AddMark", mock.Anything, mock.Anything, mock.Anything).Once().
+				isRequestPoint := func(mark models.Mark) bool {
+					coords := mark.Geom.Ewkb.Coords()
+					return coords.X() == *tt.req.Longitude && coords.Y() == *tt.req.Latitude
+				}
+				suite.uc.On("AddMark", mock.Anything, mock.MatchedBy(isRequestPoint), mock.Anything, tt.req.Force).Once().
 					Return(int64(1), tt.errAddCheck)
 			}
 
@@ -314,10 +618,17 @@ func (suite *MarksSuite) TestAddMark() {
 			b := &bytes.Buffer{}
 			mpw := multipart.NewWriter(b)
 
-			mpw.WriteField("longitude", strconv.FormatFloat(tt.req.Longitude, 'f', -1, 64))
-			mpw.WriteField("latitude", strconv.FormatFloat(tt.req.Latitude, 'f', -1, 64))
+			if tt.req.Longitude != nil {
+				mpw.WriteField("longitude", strconv.FormatFloat(*tt.req.Longitude, 'f', -1, 64))
+			}
+			if tt.req.Latitude != nil {
+				mpw.WriteField("latitude", strconv.FormatFloat(*tt.req.Latitude, 'f', -1, 64))
+			}
 			mpw.WriteField("mark_type_id", strconv.Itoa(tt.req.MarkTypeID))
 			mpw.WriteField("description", tt.req.Description)
+			if tt.req.Force {
+				mpw.WriteField("force", "true")
+			}
 
 			image := gofakeit.ImageJpeg(10, 10)
 			fw, err := mpw.CreateFormFile("photos", "test.jpg")
@@ -486,6 +797,305 @@ func (suite *MarksSuite) TestGetMarkStatusHistoryByMarkId() {
 	}
 }
 
+func (suite *MarksSuite) TestUpdateMark() {
+	tests := []struct {
+		name          string
+		id            string
+		body          string
+		wantErrParse  bool
+		errUpdateMark error
+		statusCode    int
+	}{
+		{
+			name:       "Ok200",
+			id:         "1",
+			body:       `{"description":"new description","mark_type_id":2}`,
+			statusCode: http.StatusOK,
+		},
+		{
+			name:       "Ok200",
+			id:         "1",
+			body:       `{"longitude":41.4631,"latitude":52.7183}`,
+			statusCode: http.StatusOK,
+		},
+		{
+			name:         "Err400",
+			id:           "a",
+			body:         `{"description":"new description"}`,
+			wantErrParse: true,
+			statusCode:   http.StatusBadRequest,
+		},
+		{
+			name:         "Err400",
+			id:           "1",
+			body:         `{}`,
+			wantErrParse: true,
+			statusCode:   http.StatusBadRequest,
+		},
+		{
+			name:         "Err400",
+			id:           "1",
+			body:         `{"longitude":41.4631}`,
+			wantErrParse: true,
+			statusCode:   http.StatusBadRequest,
+		},
+		{
+			name:          "Err400",
+			id:            "1",
+			body:          `{"longitude":41.4631,"latitude":52.7183}`,
+			errUpdateMark: usecase.ErrInvalidInput,
+			statusCode:    http.StatusBadRequest,
+		},
+		{
+			name:          "Err403",
+			id:            "1",
+			body:          `{"description":"new description"}`,
+			errUpdateMark: usecase.ErrForbidden,
+			statusCode:    http.StatusForbidden,
+		},
+		{
+			name:          "Err404",
+			id:            "1",
+			body:          `{"description":"new description"}`,
+			errUpdateMark: storage.ErrNotFound,
+			statusCode:    http.StatusNotFound,
+		},
+		{
+			name:          "Err409",
+			id:            "1",
+			body:          `{"description":"new description"}`,
+			errUpdateMark: usecase.ErrConflict,
+			statusCode:    http.StatusConflict,
+		},
+		{
+			name:          "Err500",
+			id:            "1",
+			body:          `{"description":"new description"}`,
+			errUpdateMark: errors.New(""),
+			statusCode:    http.StatusInternalServerError,
+		},
+	}
+	for _, tt := range tests {
+		suite.Run(tt.name, func() {
+			if !tt.wantErrParse {
+				suite.uc.On("UpdateMark", mock.Anything, 1, mock.AnythingOfType("int"), mock.AnythingOfType("models.MarkUpdate")).Once().
+					Return(tt.errUpdateMark)
+			}
+			w := httptest.NewRecorder()
+
+			accessToken, err := token.CreateToken(1*time.Minute, 1, "1234")
+			suite.NoError(err)
+
+			req := httptest.NewRequest("PATCH", "/marks/"+tt.id, strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set("Authorization", "Bearer "+accessToken)
+
+			suite.r.ServeHTTP(w, req)
+
+			suite.Equal(tt.statusCode, w.Code)
+		})
+	}
+}
+
+func (suite *MarksSuite) TestDeleteMark() {
+	tests := []struct {
+		name           string
+		id             string
+		wantErrParseId bool
+		errDeleteMark  error
+		statusCode     int
+	}{
+		{
+			name:       "Ok200",
+			id:         "1",
+			statusCode: http.StatusOK,
+		},
+		{
+			name:           "Err400",
+			id:             "a",
+			wantErrParseId: true,
+			statusCode:     http.StatusBadRequest,
+		},
+		{
+			name:          "Err403",
+			id:            "1",
+			errDeleteMark: usecase.ErrForbidden,
+			statusCode:    http.StatusForbidden,
+		},
+		{
+			name:          "Err404",
+			id:            "1",
+			errDeleteMark: storage.ErrNotFound,
+			statusCode:    http.StatusNotFound,
+		},
+		{
+			name:          "Err500",
+			id:            "1",
+			errDeleteMark: errors.New(""),
+			statusCode:    http.StatusInternalServerError,
+		},
+	}
+	for _, tt := range tests {
+		suite.Run(tt.name, func() {
+			if !tt.wantErrParseId {
+				suite.uc.On("DeleteMark", mock.Anything, 1, mock.AnythingOfType("int")).Once().
+					Return(tt.errDeleteMark)
+			}
+			w := httptest.NewRecorder()
+
+			accessToken, err := token.CreateToken(1*time.Minute, 1, "1234")
+			suite.NoError(err)
+
+			req := httptest.NewRequest("DELETE", "/marks/"+tt.id, nil)
+			req.Header.Set("Authorization", "Bearer "+accessToken)
+
+			suite.r.ServeHTTP(w, req)
+
+			suite.Equal(tt.statusCode, w.Code)
+		})
+	}
+}
+
+func (suite *MarksSuite) TestExplainConsensus() {
+	tests := []struct {
+		name           string
+		id             string
+		wantErrParseId bool
+		errExplain     error
+		statusCode     int
+	}{
+		{
+			name:       "Ok200",
+			id:         "1",
+			statusCode: http.StatusOK,
+		},
+		{
+			name:           "Err400",
+			id:             "a",
+			wantErrParseId: true,
+			statusCode:     http.StatusBadRequest,
+		},
+		{
+			name:       "Err404",
+			id:         "1",
+			errExplain: storage.ErrNotFound,
+			statusCode: http.StatusNotFound,
+		},
+		{
+			name:       "Err500",
+			id:         "1",
+			errExplain: errors.New(""),
+			statusCode: http.StatusInternalServerError,
+		},
+	}
+	for _, tt := range tests {
+		suite.Run(tt.name, func() {
+			if !tt.wantErrParseId {
+				suite.statusUpdater.On("Explain", mock.Anything, 1).Once().
+					Return(models.ConsensusExplanation{}, tt.errExplain)
+			}
+			w := httptest.NewRecorder()
+
+			req := httptest.NewRequest("GET", "/marks/"+tt.id+"/consensus", nil)
+
+			suite.r.ServeHTTP(w, req)
+
+			suite.Equal(tt.statusCode, w.Code)
+		})
+	}
+}
+
+func (suite *MarksSuite) TestMergeMarks() {
+	tests := []struct {
+		name       string
+		id         string
+		body       string
+		wantMerge  bool
+		errMerge   error
+		statusCode int
+	}{
+		{
+			name:       "Ok200",
+			id:         "1",
+			body:       `{"mark_ids":[2,3]}`,
+			wantMerge:  true,
+			statusCode: http.StatusOK,
+		},
+		{
+			name:       "Err400-ParseId",
+			id:         "a",
+			body:       `{"mark_ids":[2,3]}`,
+			statusCode: http.StatusBadRequest,
+		},
+		{
+			name:       "Err400-NoMarkIds",
+			id:         "1",
+			body:       `{"mark_ids":[]}`,
+			statusCode: http.StatusBadRequest,
+		},
+		{
+			name:       "Err400-InvalidMarkId",
+			id:         "1",
+			body:       `{"mark_ids":[0]}`,
+			statusCode: http.StatusBadRequest,
+		},
+		{
+			name:       "Err400-InvalidInput",
+			id:         "1",
+			body:       `{"mark_ids":[1]}`,
+			wantMerge:  true,
+			errMerge:   usecase.ErrInvalidInput,
+			statusCode: http.StatusBadRequest,
+		},
+		{
+			name:       "Err404",
+			id:         "1",
+			body:       `{"mark_ids":[2,3]}`,
+			wantMerge:  true,
+			errMerge:   storage.ErrNotFound,
+			statusCode: http.StatusNotFound,
+		},
+		{
+			name:       "Err409",
+			id:         "1",
+			body:       `{"mark_ids":[2,3]}`,
+			wantMerge:  true,
+			errMerge:   usecase.ErrConflict,
+			statusCode: http.StatusConflict,
+		},
+		{
+			name:       "Err500",
+			id:         "1",
+			body:       `{"mark_ids":[2,3]}`,
+			wantMerge:  true,
+			errMerge:   errors.New(""),
+			statusCode: http.StatusInternalServerError,
+		},
+	}
+	for _, tt := range tests {
+		suite.Run(tt.name, func() {
+			if tt.wantMerge {
+				suite.statusUpdater.On("Merge", mock.Anything, 1, mock.AnythingOfType("[]int")).Once().
+					Return(tt.errMerge)
+			}
+			w := httptest.NewRecorder()
+
+			accessToken, err := token.CreateTokenWithClaims(1*time.Minute, 1, "1234", map[string]any{
+				token.RoleClaim: models.ModeratorRole.String(),
+			})
+			suite.NoError(err)
+
+			req := httptest.NewRequest("POST", "/marks/"+tt.id+"/merge", strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set("Authorization", "Bearer "+accessToken)
+
+			suite.r.ServeHTTP(w, req)
+
+			suite.Equal(tt.statusCode, w.Code)
+		})
+	}
+}
+
 func (suite *MarksSuite) TestConfirm() {
 	tests := []struct {
 		name           string
@@ -526,7 +1136,9 @@ func (suite *MarksSuite) TestConfirm() {
 			}
 			w := httptest.NewRecorder()
 
-			accessToken, err := token.CreateToken(1*time.Minute, 1, "1234")
+			accessToken, err := token.CreateTokenWithClaims(1*time.Minute, 1, "1234", map[string]any{
+				token.RoleClaim: models.ModeratorRole.String(),
+			})
 			suite.NoError(err)
 
 			req := httptest.NewRequest("POST", "/marks/"+tt.id+"/confirm", nil)
@@ -579,7 +1191,9 @@ func (suite *MarksSuite) TestReject() {
 			}
 			w := httptest.NewRecorder()
 
-			accessToken, err := token.CreateToken(1*time.Minute, 1, "1234")
+			accessToken, err := token.CreateTokenWithClaims(1*time.Minute, 1, "1234", map[string]any{
+				token.RoleClaim: models.ModeratorRole.String(),
+			})
 			suite.NoError(err)
 
 			req := httptest.NewRequest("POST", "/marks/"+tt.id+"/reject", nil)
@@ -591,3 +1205,47 @@ func (suite *MarksSuite) TestReject() {
 		})
 	}
 }
+
+func (suite *MarksSuite) TestModerationForbidden() {
+	tests := []struct {
+		name string
+		path string
+		body string
+	}{
+		{
+			name: "Confirm",
+			path: "/marks/1/confirm",
+		},
+		{
+			name: "Reject",
+			path: "/marks/1/reject",
+		},
+		{
+			name: "Merge",
+			path: "/marks/1/merge",
+			body: `{"mark_ids":[2]}`,
+		},
+	}
+	for _, tt := range tests {
+		suite.Run(tt.name, func() {
+			w := httptest.NewRecorder()
+
+			accessToken, err := token.CreateTokenWithClaims(1*time.Minute, 1, "1234", map[string]any{
+				token.RoleClaim: models.CitizenRole.String(),
+			})
+			suite.NoError(err)
+
+			req := httptest.NewRequest("POST", tt.path, strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set("Authorization", "Bearer "+accessToken)
+
+			suite.r.ServeHTTP(w, req)
+
+			suite.Equal(http.StatusForbidden, w.Code)
+		})
+	}
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
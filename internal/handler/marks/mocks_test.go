@@ -6,7 +6,6 @@ package marksrest
 
 import (
 	"context"
-	"io"
 
 	"github.com/PritOriginal/problem-map-server/internal/models"
 	mock "github.com/stretchr/testify/mock"
@@ -40,8 +39,8 @@ func (_m *MockMarks) EXPECT() *MockMarks_Expecter {
 }
 
 // AddMark provides a mock function for the type MockMarks
-func (_mock *MockMarks) AddMark(ctx context.Context, mark models.Mark, photos []io.Reader) (int64, error) {
-	ret := _mock.Called(ctx, mark, photos)
+func (_mock *MockMarks) AddMark(ctx context.Context, mark models.Mark, photos []models.Photo, force bool) (int64, error) {
+	ret := _mock.Called(ctx, mark, photos, force)
 
 	if len(ret) == 0 {
 		panic("no return value specified for AddMark")
@@ -49,16 +48,16 @@ func (_mock *MockMarks) AddMark(ctx context.Context, mark models.Mark, photos []
 
 	var r0 int64
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, models.Mark, []io.Reader) (int64, error)); ok {
-		return returnFunc(ctx, mark, photos)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, models.Mark, []models.Photo, bool) (int64, error)); ok {
+		return returnFunc(ctx, mark, photos, force)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, models.Mark, []io.Reader) int64); ok {
-		r0 = returnFunc(ctx, mark, photos)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, models.Mark, []models.Photo, bool) int64); ok {
+		r0 = returnFunc(ctx, mark, photos, force)
 	} else {
 		r0 = ret.Get(0).(int64)
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, models.Mark, []io.Reader) error); ok {
-		r1 = returnFunc(ctx, mark, photos)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, models.Mark, []models.Photo, bool) error); ok {
+		r1 = returnFunc(ctx, mark, photos, force)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -73,12 +72,13 @@ type MockMarks_AddMark_Call struct {
 // AddMark is a helper method to define mock.On call
 //   - ctx context.Context
 //   - mark models.Mark
-//   - photos []io.Reader
-func (_e *MockMarks_Expecter) AddMark(ctx interface{}, mark interface{}, photos interface{}) *MockMarks_AddMark_Call {
-	return &MockMarks_AddMark_Call{Call: _e.mock.On("AddMark", ctx, mark, photos)}
+//   - photos []models.Photo
+//   - force bool
+func (_e *MockMarks_Expecter) AddMark(ctx interface{}, mark interface{}, photos interface{}, force interface{}) *MockMarks_AddMark_Call {
+	return &MockMarks_AddMark_Call{Call: _e.mock.On("AddMark", ctx, mark, photos, force)}
 }
 
-func (_c *MockMarks_AddMark_Call) Run(run func(ctx context.Context, mark models.Mark, photos []io.Reader)) *MockMarks_AddMark_Call {
+func (_c *MockMarks_AddMark_Call) Run(run func(ctx context.Context, mark models.Mark, photos []models.Photo, force bool)) *MockMarks_AddMark_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -88,14 +88,19 @@ func (_c *MockMarks_AddMark_Call) Run(run func(ctx context.Context, mark models.
 		if args[1] != nil {
 			arg1 = args[1].(models.Mark)
 		}
-		var arg2 []io.Reader
+		var arg2 []models.Photo
 		if args[2] != nil {
-			arg2 = args[2].([]io.Reader)
+			arg2 = args[2].([]models.Photo)
+		}
+		var arg3 bool
+		if args[3] != nil {
+			arg3 = args[3].(bool)
 		}
 		run(
 			arg0,
 			arg1,
 			arg2,
+			arg3,
 		)
 	})
 	return _c
@@ -106,7 +111,138 @@ func (_c *MockMarks_AddMark_Call) Return(n int64, err error) *MockMarks_AddMark_
 	return _c
 }
 
-func (_c *MockMarks_AddMark_Call) RunAndReturn(run func(ctx context.Context, mark models.Mark, photos []io.Reader) (int64, error)) *MockMarks_AddMark_Call {
+func (_c *MockMarks_AddMark_Call) RunAndReturn(run func(ctx context.Context, mark models.Mark, photos []models.Photo, force bool) (int64, error)) *MockMarks_AddMark_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteMark provides a mock function for the type MockMarks
+func (_mock *MockMarks) DeleteMark(ctx context.Context, userId int, markId int) error {
+	ret := _mock.Called(ctx, userId, markId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteMark")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int, int) error); ok {
+		r0 = returnFunc(ctx, userId, markId)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockMarks_DeleteMark_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteMark'
+type MockMarks_DeleteMark_Call struct {
+	*mock.Call
+}
+
+// DeleteMark is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userId int
+//   - markId int
+func (_e *MockMarks_Expecter) DeleteMark(ctx interface{}, userId interface{}, markId interface{}) *MockMarks_DeleteMark_Call {
+	return &MockMarks_DeleteMark_Call{Call: _e.mock.On("DeleteMark", ctx, userId, markId)}
+}
+
+func (_c *MockMarks_DeleteMark_Call) Run(run func(ctx context.Context, userId int, markId int)) *MockMarks_DeleteMark_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int
+		if args[1] != nil {
+			arg1 = args[1].(int)
+		}
+		var arg2 int
+		if args[2] != nil {
+			arg2 = args[2].(int)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockMarks_DeleteMark_Call) Return(err error) *MockMarks_DeleteMark_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockMarks_DeleteMark_Call) RunAndReturn(run func(ctx context.Context, userId int, markId int) error) *MockMarks_DeleteMark_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FindDuplicateMarks provides a mock function for the type MockMarks
+func (_mock *MockMarks) FindDuplicateMarks(ctx context.Context, mark models.Mark) ([]models.NearbyMark, error) {
+	ret := _mock.Called(ctx, mark)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindDuplicateMarks")
+	}
+
+	var r0 []models.NearbyMark
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, models.Mark) ([]models.NearbyMark, error)); ok {
+		return returnFunc(ctx, mark)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, models.Mark) []models.NearbyMark); ok {
+		r0 = returnFunc(ctx, mark)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.NearbyMark)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, models.Mark) error); ok {
+		r1 = returnFunc(ctx, mark)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockMarks_FindDuplicateMarks_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindDuplicateMarks'
+type MockMarks_FindDuplicateMarks_Call struct {
+	*mock.Call
+}
+
+// FindDuplicateMarks is a helper method to define mock.On call
+//   - ctx context.Context
+//   - mark models.Mark
+func (_e *MockMarks_Expecter) FindDuplicateMarks(ctx interface{}, mark interface{}) *MockMarks_FindDuplicateMarks_Call {
+	return &MockMarks_FindDuplicateMarks_Call{Call: _e.mock.On("FindDuplicateMarks", ctx, mark)}
+}
+
+func (_c *MockMarks_FindDuplicateMarks_Call) Run(run func(ctx context.Context, mark models.Mark)) *MockMarks_FindDuplicateMarks_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 models.Mark
+		if args[1] != nil {
+			arg1 = args[1].(models.Mark)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockMarks_FindDuplicateMarks_Call) Return(nearbyMarks []models.NearbyMark, err error) *MockMarks_FindDuplicateMarks_Call {
+	_c.Call.Return(nearbyMarks, err)
+	return _c
+}
+
+func (_c *MockMarks_FindDuplicateMarks_Call) RunAndReturn(run func(ctx context.Context, mark models.Mark) ([]models.NearbyMark, error)) *MockMarks_FindDuplicateMarks_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -376,7 +512,7 @@ func (_c *MockMarks_GetMarkTypes_Call) RunAndReturn(run func(ctx context.Context
 }
 
 // GetMarks provides a mock function for the type MockMarks
-func (_mock *MockMarks) GetMarks(ctx context.Context, filters models.GetMarksFilters) ([]models.Mark, error) {
+func (_mock *MockMarks) GetMarks(ctx context.Context, filters models.GetMarksFilters) ([]models.Mark, string, error) {
 	ret := _mock.Called(ctx, filters)
 
 	if len(ret) == 0 {
@@ -384,8 +520,9 @@ func (_mock *MockMarks) GetMarks(ctx context.Context, filters models.GetMarksFil
 	}
 
 	var r0 []models.Mark
-	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, models.GetMarksFilters) ([]models.Mark, error)); ok {
+	var r1 string
+	var r2 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, models.GetMarksFilters) ([]models.Mark, string, error)); ok {
 		return returnFunc(ctx, filters)
 	}
 	if returnFunc, ok := ret.Get(0).(func(context.Context, models.GetMarksFilters) []models.Mark); ok {
@@ -395,12 +532,17 @@ func (_mock *MockMarks) GetMarks(ctx context.Context, filters models.GetMarksFil
 			r0 = ret.Get(0).([]models.Mark)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, models.GetMarksFilters) error); ok {
+	if returnFunc, ok := ret.Get(1).(func(context.Context, models.GetMarksFilters) string); ok {
 		r1 = returnFunc(ctx, filters)
 	} else {
-		r1 = ret.Error(1)
+		r1 = ret.Get(1).(string)
 	}
-	return r0, r1
+	if returnFunc, ok := ret.Get(2).(func(context.Context, models.GetMarksFilters) error); ok {
+		r2 = returnFunc(ctx, filters)
+	} else {
+		r2 = ret.Error(2)
+	}
+	return r0, r1, r2
 }
 
 // MockMarks_GetMarks_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetMarks'
@@ -433,42 +575,48 @@ func (_c *MockMarks_GetMarks_Call) Run(run func(ctx context.Context, filters mod
 	return _c
 }
 
-func (_c *MockMarks_GetMarks_Call) Return(marks []models.Mark, err error) *MockMarks_GetMarks_Call {
-	_c.Call.Return(marks, err)
+func (_c *MockMarks_GetMarks_Call) Return(marks []models.Mark, s string, err error) *MockMarks_GetMarks_Call {
+	_c.Call.Return(marks, s, err)
 	return _c
 }
 
-func (_c *MockMarks_GetMarks_Call) RunAndReturn(run func(ctx context.Context, filters models.GetMarksFilters) ([]models.Mark, error)) *MockMarks_GetMarks_Call {
+func (_c *MockMarks_GetMarks_Call) RunAndReturn(run func(ctx context.Context, filters models.GetMarksFilters) ([]models.Mark, string, error)) *MockMarks_GetMarks_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
 // GetMarksByUserId provides a mock function for the type MockMarks
-func (_mock *MockMarks) GetMarksByUserId(ctx context.Context, userId int) ([]models.Mark, error) {
-	ret := _mock.Called(ctx, userId)
+func (_mock *MockMarks) GetMarksByUserId(ctx context.Context, userId int, pagination models.Pagination) ([]models.Mark, string, error) {
+	ret := _mock.Called(ctx, userId, pagination)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetMarksByUserId")
 	}
 
 	var r0 []models.Mark
-	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, int) ([]models.Mark, error)); ok {
-		return returnFunc(ctx, userId)
+	var r1 string
+	var r2 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int, models.Pagination) ([]models.Mark, string, error)); ok {
+		return returnFunc(ctx, userId, pagination)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, int) []models.Mark); ok {
-		r0 = returnFunc(ctx, userId)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int, models.Pagination) []models.Mark); ok {
+		r0 = returnFunc(ctx, userId, pagination)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]models.Mark)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, int) error); ok {
-		r1 = returnFunc(ctx, userId)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int, models.Pagination) string); ok {
+		r1 = returnFunc(ctx, userId, pagination)
 	} else {
-		r1 = ret.Error(1)
+		r1 = ret.Get(1).(string)
 	}
-	return r0, r1
+	if returnFunc, ok := ret.Get(2).(func(context.Context, int, models.Pagination) error); ok {
+		r2 = returnFunc(ctx, userId, pagination)
+	} else {
+		r2 = ret.Error(2)
+	}
+	return r0, r1, r2
 }
 
 // MockMarks_GetMarksByUserId_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetMarksByUserId'
@@ -479,11 +627,12 @@ type MockMarks_GetMarksByUserId_Call struct {
 // GetMarksByUserId is a helper method to define mock.On call
 //   - ctx context.Context
 //   - userId int
-func (_e *MockMarks_Expecter) GetMarksByUserId(ctx interface{}, userId interface{}) *MockMarks_GetMarksByUserId_Call {
-	return &MockMarks_GetMarksByUserId_Call{Call: _e.mock.On("GetMarksByUserId", ctx, userId)}
+//   - pagination models.Pagination
+func (_e *MockMarks_Expecter) GetMarksByUserId(ctx interface{}, userId interface{}, pagination interface{}) *MockMarks_GetMarksByUserId_Call {
+	return &MockMarks_GetMarksByUserId_Call{Call: _e.mock.On("GetMarksByUserId", ctx, userId, pagination)}
 }
 
-func (_c *MockMarks_GetMarksByUserId_Call) Run(run func(ctx context.Context, userId int)) *MockMarks_GetMarksByUserId_Call {
+func (_c *MockMarks_GetMarksByUserId_Call) Run(run func(ctx context.Context, userId int, pagination models.Pagination)) *MockMarks_GetMarksByUserId_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -493,6 +642,85 @@ func (_c *MockMarks_GetMarksByUserId_Call) Run(run func(ctx context.Context, use
 		if args[1] != nil {
 			arg1 = args[1].(int)
 		}
+		var arg2 models.Pagination
+		if args[2] != nil {
+			arg2 = args[2].(models.Pagination)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockMarks_GetMarksByUserId_Call) Return(marks []models.Mark, s string, err error) *MockMarks_GetMarksByUserId_Call {
+	_c.Call.Return(marks, s, err)
+	return _c
+}
+
+func (_c *MockMarks_GetMarksByUserId_Call) RunAndReturn(run func(ctx context.Context, userId int, pagination models.Pagination) ([]models.Mark, string, error)) *MockMarks_GetMarksByUserId_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetNearbyMarks provides a mock function for the type MockMarks
+func (_mock *MockMarks) GetNearbyMarks(ctx context.Context, filters models.GetNearbyMarksFilters) ([]models.NearbyMark, string, error) {
+	ret := _mock.Called(ctx, filters)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetNearbyMarks")
+	}
+
+	var r0 []models.NearbyMark
+	var r1 string
+	var r2 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, models.GetNearbyMarksFilters) ([]models.NearbyMark, string, error)); ok {
+		return returnFunc(ctx, filters)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, models.GetNearbyMarksFilters) []models.NearbyMark); ok {
+		r0 = returnFunc(ctx, filters)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.NearbyMark)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, models.GetNearbyMarksFilters) string); ok {
+		r1 = returnFunc(ctx, filters)
+	} else {
+		r1 = ret.Get(1).(string)
+	}
+	if returnFunc, ok := ret.Get(2).(func(context.Context, models.GetNearbyMarksFilters) error); ok {
+		r2 = returnFunc(ctx, filters)
+	} else {
+		r2 = ret.Error(2)
+	}
+	return r0, r1, r2
+}
+
+// MockMarks_GetNearbyMarks_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetNearbyMarks'
+type MockMarks_GetNearbyMarks_Call struct {
+	*mock.Call
+}
+
+// GetNearbyMarks is a helper method to define mock.On call
+//   - ctx context.Context
+//   - filters models.GetNearbyMarksFilters
+func (_e *MockMarks_Expecter) GetNearbyMarks(ctx interface{}, filters interface{}) *MockMarks_GetNearbyMarks_Call {
+	return &MockMarks_GetNearbyMarks_Call{Call: _e.mock.On("GetNearbyMarks", ctx, filters)}
+}
+
+func (_c *MockMarks_GetNearbyMarks_Call) Run(run func(ctx context.Context, filters models.GetNearbyMarksFilters)) *MockMarks_GetNearbyMarks_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 models.GetNearbyMarksFilters
+		if args[1] != nil {
+			arg1 = args[1].(models.GetNearbyMarksFilters)
+		}
 		run(
 			arg0,
 			arg1,
@@ -501,12 +729,155 @@ func (_c *MockMarks_GetMarksByUserId_Call) Run(run func(ctx context.Context, use
 	return _c
 }
 
-func (_c *MockMarks_GetMarksByUserId_Call) Return(marks []models.Mark, err error) *MockMarks_GetMarksByUserId_Call {
-	_c.Call.Return(marks, err)
+func (_c *MockMarks_GetNearbyMarks_Call) Return(nearbyMarks []models.NearbyMark, s string, err error) *MockMarks_GetNearbyMarks_Call {
+	_c.Call.Return(nearbyMarks, s, err)
 	return _c
 }
 
-func (_c *MockMarks_GetMarksByUserId_Call) RunAndReturn(run func(ctx context.Context, userId int) ([]models.Mark, error)) *MockMarks_GetMarksByUserId_Call {
+func (_c *MockMarks_GetNearbyMarks_Call) RunAndReturn(run func(ctx context.Context, filters models.GetNearbyMarksFilters) ([]models.NearbyMark, string, error)) *MockMarks_GetNearbyMarks_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SearchMarks provides a mock function for the type MockMarks
+func (_mock *MockMarks) SearchMarks(ctx context.Context, filters models.SearchMarksFilters) ([]models.FoundMark, string, error) {
+	ret := _mock.Called(ctx, filters)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SearchMarks")
+	}
+
+	var r0 []models.FoundMark
+	var r1 string
+	var r2 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, models.SearchMarksFilters) ([]models.FoundMark, string, error)); ok {
+		return returnFunc(ctx, filters)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, models.SearchMarksFilters) []models.FoundMark); ok {
+		r0 = returnFunc(ctx, filters)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.FoundMark)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, models.SearchMarksFilters) string); ok {
+		r1 = returnFunc(ctx, filters)
+	} else {
+		r1 = ret.Get(1).(string)
+	}
+	if returnFunc, ok := ret.Get(2).(func(context.Context, models.SearchMarksFilters) error); ok {
+		r2 = returnFunc(ctx, filters)
+	} else {
+		r2 = ret.Error(2)
+	}
+	return r0, r1, r2
+}
+
+// MockMarks_SearchMarks_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SearchMarks'
+type MockMarks_SearchMarks_Call struct {
+	*mock.Call
+}
+
+// SearchMarks is a helper method to define mock.On call
+//   - ctx context.Context
+//   - filters models.SearchMarksFilters
+func (_e *MockMarks_Expecter) SearchMarks(ctx interface{}, filters interface{}) *MockMarks_SearchMarks_Call {
+	return &MockMarks_SearchMarks_Call{Call: _e.mock.On("SearchMarks", ctx, filters)}
+}
+
+func (_c *MockMarks_SearchMarks_Call) Run(run func(ctx context.Context, filters models.SearchMarksFilters)) *MockMarks_SearchMarks_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 models.SearchMarksFilters
+		if args[1] != nil {
+			arg1 = args[1].(models.SearchMarksFilters)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockMarks_SearchMarks_Call) Return(foundMarks []models.FoundMark, s string, err error) *MockMarks_SearchMarks_Call {
+	_c.Call.Return(foundMarks, s, err)
+	return _c
+}
+
+func (_c *MockMarks_SearchMarks_Call) RunAndReturn(run func(ctx context.Context, filters models.SearchMarksFilters) ([]models.FoundMark, string, error)) *MockMarks_SearchMarks_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateMark provides a mock function for the type MockMarks
+func (_mock *MockMarks) UpdateMark(ctx context.Context, userId int, markId int, update models.MarkUpdate) error {
+	ret := _mock.Called(ctx, userId, markId, update)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateMark")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int, int, models.MarkUpdate) error); ok {
+		r0 = returnFunc(ctx, userId, markId, update)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockMarks_UpdateMark_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateMark'
+type MockMarks_UpdateMark_Call struct {
+	*mock.Call
+}
+
+// UpdateMark is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userId int
+//   - markId int
+//   - update models.MarkUpdate
+func (_e *MockMarks_Expecter) UpdateMark(ctx interface{}, userId interface{}, markId interface{}, update interface{}) *MockMarks_UpdateMark_Call {
+	return &MockMarks_UpdateMark_Call{Call: _e.mock.On("UpdateMark", ctx, userId, markId, update)}
+}
+
+func (_c *MockMarks_UpdateMark_Call) Run(run func(ctx context.Context, userId int, markId int, update models.MarkUpdate)) *MockMarks_UpdateMark_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int
+		if args[1] != nil {
+			arg1 = args[1].(int)
+		}
+		var arg2 int
+		if args[2] != nil {
+			arg2 = args[2].(int)
+		}
+		var arg3 models.MarkUpdate
+		if args[3] != nil {
+			arg3 = args[3].(models.MarkUpdate)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockMarks_UpdateMark_Call) Return(err error) *MockMarks_UpdateMark_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockMarks_UpdateMark_Call) RunAndReturn(run func(ctx context.Context, userId int, markId int, update models.MarkUpdate) error) *MockMarks_UpdateMark_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -604,6 +975,135 @@ func (_c *MockStatusUpdater_Confirm_Call) RunAndReturn(run func(ctx context.Cont
 	return _c
 }
 
+// Explain provides a mock function for the type MockStatusUpdater
+func (_mock *MockStatusUpdater) Explain(ctx context.Context, markId int) (models.ConsensusExplanation, error) {
+	ret := _mock.Called(ctx, markId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Explain")
+	}
+
+	var r0 models.ConsensusExplanation
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int) (models.ConsensusExplanation, error)); ok {
+		return returnFunc(ctx, markId)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int) models.ConsensusExplanation); ok {
+		r0 = returnFunc(ctx, markId)
+	} else {
+		r0 = ret.Get(0).(models.ConsensusExplanation)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = returnFunc(ctx, markId)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockStatusUpdater_Explain_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Explain'
+type MockStatusUpdater_Explain_Call struct {
+	*mock.Call
+}
+
+// Explain is a helper method to define mock.On call
+//   - ctx context.Context
+//   - markId int
+func (_e *MockStatusUpdater_Expecter) Explain(ctx interface{}, markId interface{}) *MockStatusUpdater_Explain_Call {
+	return &MockStatusUpdater_Explain_Call{Call: _e.mock.On("Explain", ctx, markId)}
+}
+
+func (_c *MockStatusUpdater_Explain_Call) Run(run func(ctx context.Context, markId int)) *MockStatusUpdater_Explain_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int
+		if args[1] != nil {
+			arg1 = args[1].(int)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockStatusUpdater_Explain_Call) Return(consensusExplanation models.ConsensusExplanation, err error) *MockStatusUpdater_Explain_Call {
+	_c.Call.Return(consensusExplanation, err)
+	return _c
+}
+
+func (_c *MockStatusUpdater_Explain_Call) RunAndReturn(run func(ctx context.Context, markId int) (models.ConsensusExplanation, error)) *MockStatusUpdater_Explain_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Merge provides a mock function for the type MockStatusUpdater
+func (_mock *MockStatusUpdater) Merge(ctx context.Context, markId int, mergedMarkIds []int) error {
+	ret := _mock.Called(ctx, markId, mergedMarkIds)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Merge")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int, []int) error); ok {
+		r0 = returnFunc(ctx, markId, mergedMarkIds)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockStatusUpdater_Merge_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Merge'
+type MockStatusUpdater_Merge_Call struct {
+	*mock.Call
+}
+
+// Merge is a helper method to define mock.On call
+//   - ctx context.Context
+//   - markId int
+//   - mergedMarkIds []int
+func (_e *MockStatusUpdater_Expecter) Merge(ctx interface{}, markId interface{}, mergedMarkIds interface{}) *MockStatusUpdater_Merge_Call {
+	return &MockStatusUpdater_Merge_Call{Call: _e.mock.On("Merge", ctx, markId, mergedMarkIds)}
+}
+
+func (_c *MockStatusUpdater_Merge_Call) Run(run func(ctx context.Context, markId int, mergedMarkIds []int)) *MockStatusUpdater_Merge_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int
+		if args[1] != nil {
+			arg1 = args[1].(int)
+		}
+		var arg2 []int
+		if args[2] != nil {
+			arg2 = args[2].([]int)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockStatusUpdater_Merge_Call) Return(err error) *MockStatusUpdater_Merge_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockStatusUpdater_Merge_Call) RunAndReturn(run func(ctx context.Context, markId int, mergedMarkIds []int) error) *MockStatusUpdater_Merge_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Reject provides a mock function for the type MockStatusUpdater
 func (_mock *MockStatusUpdater) Reject(ctx context.Context, markId int) (models.MarkStatusType, error) {
 	ret := _mock.Called(ctx, markId)
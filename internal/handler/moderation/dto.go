@@ -0,0 +1,20 @@
+package moderationrest
+
+import "github.com/PritOriginal/problem-map-server/internal/models"
+
+type GetQueueResponse struct {
+	Items      []models.ModerationQueueItem `json:"items"`
+	NextCursor string                       `json:"next_cursor,omitempty"`
+}
+
+type ClaimResponse struct {
+	Claim models.ModerationClaim `json:"claim"`
+}
+
+type ReleaseResponse struct {
+	MarkId int `json:"mark_id"`
+}
+
+type GetSimilarPhotosResponse struct {
+	Photos []models.SimilarPhoto `json:"photos"`
+}
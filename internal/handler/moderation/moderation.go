@@ -0,0 +1,259 @@
+package moderationrest
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"strconv"
+
+	"github.com/PritOriginal/problem-map-server/internal/handler/request"
+	"github.com/PritOriginal/problem-map-server/internal/middleware/rbac"
+	"github.com/PritOriginal/problem-map-server/internal/models"
+	"github.com/PritOriginal/problem-map-server/internal/storage"
+	"github.com/PritOriginal/problem-map-server/internal/usecase"
+	"github.com/PritOriginal/problem-map-server/pkg/logger"
+	"github.com/PritOriginal/problem-map-server/pkg/responses"
+	jwt "github.com/appleboy/gin-jwt/v3"
+	"github.com/gin-gonic/gin"
+)
+
+type Moderation interface {
+	GetQueue(ctx context.Context, moderatorId int, pagination models.Pagination) ([]models.ModerationQueueItem, string, error)
+	Claim(ctx context.Context, moderatorId, markId int) (models.ModerationClaim, error)
+	Release(ctx context.Context, moderatorId, markId int) error
+	GetSimilarPhotos(ctx context.Context, checkId, number int) ([]models.SimilarPhoto, error)
+}
+
+type handler struct {
+	log *slog.Logger
+	uc  Moderation
+}
+
+func Register(r *gin.Engine, log *slog.Logger, authMiddleware *jwt.GinJWTMiddleware, uc Moderation) {
+	handler := &handler{log: log, uc: uc}
+
+	moderation := r.Group("/moderation", authMiddleware.MiddlewareFunc(), rbac.RequireRole(models.ModeratorRole))
+	{
+		queue := moderation.Group("queue")
+		{
+			queue.GET("", handler.GetQueue())
+			queue.POST(":markId/claim", handler.Claim())
+			queue.DELETE(":markId/claim", handler.Release())
+		}
+		moderation.GET("checks/:id/photos/:number/similar", handler.GetSimilarPhotos())
+	}
+}
+
+// GetQueue lists the marks waiting for a moderator decision
+//
+//	@Summary		List moderation queue
+//	@Description	get the unconfirmed and under review marks with split votes or stalled voting ordered by priority, the marks claimed by other moderators are skipped, allowed only for moderators
+//	@Tags			moderation
+//	@Produce		json
+//	@Param			Authorization	header		string	true	"Insert your access token"	default(Bearer <Add access token here>)
+//	@Param			limit			query		int		false	"max number of marks in the page (capped by the server)"
+//	@Param			cursor			query		string	false	"cursor to the next page"
+//	@Param			order			query		string	false	"sort order by priority"	Enums(asc, desc)
+//	@Success		200				{object}	responses.Response[moderationrest.GetQueueResponse]
+//	@Failure		400				{object}	responses.Response[any]
+//	@Failure		401				{object}	responses.Response[any]
+//	@Failure		403				{object}	responses.Response[any]
+//	@Failure		500				{object}	responses.Response[any]
+//	@Router			/moderation/queue [get]
+func (h *handler) GetQueue() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userId, err := request.GetUserId(c)
+		if err != nil {
+			h.log.Debug("invalid token", logger.Err(err))
+			responses.Unauthorized(c, "invalid token")
+			return
+		}
+
+		var query request.PaginationQuery
+		if err := c.ShouldBindQuery(&query); err != nil {
+			h.log.Debug("failed parse pagination", logger.Err(err))
+			responses.BadRequest(c, "invalid pagination")
+			return
+		}
+		pagination, err := models.ModerationQueueSorting.NewPagination(query.Limit, query.Cursor, query.Sort, query.Order)
+		if err != nil {
+			h.log.Debug("invalid pagination", logger.Err(err))
+			responses.BadRequest(c, "invalid pagination")
+			return
+		}
+
+		items, nextCursor, err := h.uc.GetQueue(c.Request.Context(), userId, pagination)
+		if err != nil {
+			switch {
+			case errors.Is(err, usecase.ErrInvalidInput):
+				h.log.Debug("invalid pagination", logger.Err(err))
+				responses.BadRequest(c, "invalid pagination")
+			default:
+				h.log.Error("error get moderation queue", logger.Err(err))
+				responses.Internal(c, "error get moderation queue")
+			}
+			return
+		}
+
+		responses.OK(c, GetQueueResponse{
+			Items:      items,
+			NextCursor: nextCursor,
+		})
+	}
+}
+
+// Claim claims the mark for the moderator
+//
+//	@Summary		Claim the mark
+//	@Description	reserve the mark for the moderator for a limited time, claiming the own mark again extends the claim, allowed only for moderators
+//	@Tags			moderation
+//	@Produce		json
+//	@Param			Authorization	header		string	true	"Insert your access token"	default(Bearer <Add access token here>)
+//	@Param			markId			path		int		true	"mark id"
+//	@Success		200				{object}	responses.Response[moderationrest.ClaimResponse]
+//	@Failure		400				{object}	responses.Response[any]
+//	@Failure		401				{object}	responses.Response[any]
+//	@Failure		403				{object}	responses.Response[any]
+//	@Failure		404				{object}	responses.Response[any]
+//	@Failure		409				{object}	responses.Response[any]
+//	@Failure		500				{object}	responses.Response[any]
+//	@Router			/moderation/queue/{markId}/claim [post]
+func (h *handler) Claim() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		markId, err := strconv.Atoi(c.Param("markId"))
+		if err != nil {
+			h.log.Debug("failed parse mark id", logger.Err(err))
+			responses.BadRequest(c, "failed parse mark id")
+			return
+		}
+
+		userId, err := request.GetUserId(c)
+		if err != nil {
+			h.log.Debug("invalid token", logger.Err(err))
+			responses.Unauthorized(c, "invalid token")
+			return
+		}
+
+		claim, err := h.uc.Claim(c.Request.Context(), userId, markId)
+		if err != nil {
+			switch {
+			case errors.Is(err, storage.ErrNotFound):
+				h.log.Debug("mark not found", slog.Int("mark_id", markId))
+				responses.NotFound(c, "mark not found")
+			case errors.Is(err, usecase.ErrConflict):
+				h.log.Debug("mark can't be claimed", slog.Int("mark_id", markId), slog.Int("user_id", userId))
+				responses.Conflict(c, "mark is claimed by another moderator or doesn't need moderation")
+			default:
+				h.log.Error("error claim mark", slog.Int("mark_id", markId), logger.Err(err))
+				responses.Internal(c, "error claim mark")
+			}
+			return
+		}
+
+		h.log.Info("mark has been claimed", slog.Int("mark_id", markId), slog.Int("user_id", userId))
+		responses.OK(c, ClaimResponse{
+			Claim: claim,
+		})
+	}
+}
+
+// Release releases the mark claimed by the moderator
+//
+//	@Summary		Release the mark
+//	@Description	remove the moderator's claim of the mark, allowed only for moderators
+//	@Tags			moderation
+//	@Produce		json
+//	@Param			Authorization	header		string	true	"Insert your access token"	default(Bearer <Add access token here>)
+//	@Param			markId			path		int		true	"mark id"
+//	@Success		200				{object}	responses.Response[moderationrest.ReleaseResponse]
+//	@Failure		400				{object}	responses.Response[any]
+//	@Failure		401				{object}	responses.Response[any]
+//	@Failure		403				{object}	responses.Response[any]
+//	@Failure		404				{object}	responses.Response[any]
+//	@Failure		500				{object}	responses.Response[any]
+//	@Router			/moderation/queue/{markId}/claim [delete]
+func (h *handler) Release() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		markId, err := strconv.Atoi(c.Param("markId"))
+		if err != nil {
+			h.log.Debug("failed parse mark id", logger.Err(err))
+			responses.BadRequest(c, "failed parse mark id")
+			return
+		}
+
+		userId, err := request.GetUserId(c)
+		if err != nil {
+			h.log.Debug("invalid token", logger.Err(err))
+			responses.Unauthorized(c, "invalid token")
+			return
+		}
+
+		if err := h.uc.Release(c.Request.Context(), userId, markId); err != nil {
+			switch {
+			case errors.Is(err, usecase.ErrNotFound):
+				h.log.Debug("claim not found", slog.Int("mark_id", markId), slog.Int("user_id", userId))
+				responses.NotFound(c, "claim not found")
+			default:
+				h.log.Error("error release mark", slog.Int("mark_id", markId), logger.Err(err))
+				responses.Internal(c, "error release mark")
+			}
+			return
+		}
+
+		h.log.Info("mark has been released", slog.Int("mark_id", markId), slog.Int("user_id", userId))
+		responses.OK(c, ReleaseResponse{
+			MarkId: markId,
+		})
+	}
+}
+
+// GetSimilarPhotos lists the photos similar to the check photo
+//
+//	@Summary		List similar photos
+//	@Description	get the photos of the other checks near-identical to the check photo by the perceptual hash ordered by similarity, allowed only for moderators
+//	@Tags			moderation
+//	@Produce		json
+//	@Param			Authorization	header		string	true	"Insert your access token"	default(Bearer <Add access token here>)
+//	@Param			id				path		int		true	"check id"
+//	@Param			number			path		int		true	"photo number in the check"
+//	@Success		200				{object}	responses.Response[moderationrest.GetSimilarPhotosResponse]
+//	@Failure		400				{object}	responses.Response[any]
+//	@Failure		401				{object}	responses.Response[any]
+//	@Failure		403				{object}	responses.Response[any]
+//	@Failure		404				{object}	responses.Response[any]
+//	@Failure		500				{object}	responses.Response[any]
+//	@Router			/moderation/checks/{id}/photos/{number}/similar [get]
+func (h *handler) GetSimilarPhotos() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		checkId, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			h.log.Debug("failed parse check id", logger.Err(err))
+			responses.BadRequest(c, "failed parse check id")
+			return
+		}
+
+		number, err := strconv.Atoi(c.Param("number"))
+		if err != nil {
+			h.log.Debug("failed parse photo number", logger.Err(err))
+			responses.BadRequest(c, "failed parse photo number")
+			return
+		}
+
+		photos, err := h.uc.GetSimilarPhotos(c.Request.Context(), checkId, number)
+		if err != nil {
+			switch {
+			case errors.Is(err, usecase.ErrNotFound):
+				h.log.Debug("photo not found", slog.Int("check_id", checkId), slog.Int("number", number))
+				responses.NotFound(c, "photo not found")
+			default:
+				h.log.Error("error get similar photos", slog.Int("check_id", checkId), slog.Int("number", number), logger.Err(err))
+				responses.Internal(c, "error get similar photos")
+			}
+			return
+		}
+
+		responses.OK(c, GetSimilarPhotosResponse{
+			Photos: photos,
+		})
+	}
+}
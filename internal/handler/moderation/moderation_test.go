@@ -0,0 +1,347 @@
+package moderationrest_test
+
+import (
+	"errors"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	moderationrest "github.com/PritOriginal/problem-map-server/internal/handler/moderation"
+	"github.com/PritOriginal/problem-map-server/internal/models"
+	"github.com/PritOriginal/problem-map-server/internal/storage"
+	"github.com/PritOriginal/problem-map-server/internal/usecase"
+	"github.com/PritOriginal/problem-map-server/pkg/logger/slogdiscard"
+	"github.com/PritOriginal/problem-map-server/pkg/token"
+	jwt "github.com/appleboy/gin-jwt/v3"
+	"github.com/gin-gonic/gin"
+	mock "github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type ModerationSuite struct {
+	suite.Suite
+	r  *gin.Engine
+	uc *moderationrest.MockModeration
+}
+
+func (suite *ModerationSuite) SetupSuite() {
+	authMiddleware, err := jwt.New(&jwt.GinJWTMiddleware{
+		Key: []byte("1234"),
+	})
+	if err != nil {
+		panic(err)
+	}
+	errInit := authMiddleware.MiddlewareInit()
+	if errInit != nil {
+		panic(errInit)
+	}
+
+	suite.uc = moderationrest.NewMockModeration(suite.T())
+
+	log := slogdiscard.NewDiscardLogger()
+
+	gin.SetMode(gin.TestMode)
+	suite.r = gin.New()
+
+	moderationrest.Register(suite.r, log, authMiddleware, suite.uc)
+}
+
+func TestModeration(t *testing.T) {
+	suite.Run(t, new(ModerationSuite))
+}
+
+func (suite *ModerationSuite) newRequest(method, target string, role models.UserRole) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(method, target, nil)
+	if role != 0 {
+		accessToken, err := token.CreateTokenWithClaims(1*time.Minute, 1, "1234", map[string]any{
+			token.RoleClaim: role.String(),
+		})
+		suite.NoError(err)
+		req.Header.Set("Authorization", "Bearer "+accessToken)
+	}
+
+	suite.r.ServeHTTP(w, req)
+
+	return w
+}
+
+func (suite *ModerationSuite) TestGetQueue() {
+	tests := []struct {
+		name        string
+		query       string
+		role        models.UserRole
+		wantCall    bool
+		errGetQueue error
+		statusCode  int
+	}{
+		{
+			name:       "Ok200",
+			role:       models.ModeratorRole,
+			wantCall:   true,
+			statusCode: 200,
+		},
+		{
+			name:       "Ok200-Pagination",
+			query:      "?limit=10&order=asc",
+			role:       models.AdminRole,
+			wantCall:   true,
+			statusCode: 200,
+		},
+		{
+			name:       "Err400InvalidPagination",
+			query:      "?sort=created_at",
+			role:       models.ModeratorRole,
+			statusCode: 400,
+		},
+		{
+			name:        "Err400InvalidCursor",
+			role:        models.ModeratorRole,
+			wantCall:    true,
+			errGetQueue: usecase.ErrInvalidInput,
+			statusCode:  400,
+		},
+		{
+			name:       "Err401",
+			statusCode: 401,
+		},
+		{
+			name:       "Err403",
+			role:       models.CitizenRole,
+			statusCode: 403,
+		},
+		{
+			name:        "Err500",
+			role:        models.ModeratorRole,
+			wantCall:    true,
+			errGetQueue: errors.New(""),
+			statusCode:  500,
+		},
+	}
+	for _, tt := range tests {
+		suite.Run(tt.name, func() {
+			if tt.wantCall {
+				suite.uc.On("GetQueue", mock.Anything, 1, mock.AnythingOfType("models.Pagination")).Once().
+					Return([]models.ModerationQueueItem{}, "", tt.errGetQueue)
+			}
+
+			w := suite.newRequest("GET", "/moderation/queue"+tt.query, tt.role)
+
+			suite.Equal(tt.statusCode, w.Code)
+		})
+	}
+}
+
+func (suite *ModerationSuite) TestClaim() {
+	tests := []struct {
+		name       string
+		markId     string
+		role       models.UserRole
+		wantCall   bool
+		errClaim   error
+		statusCode int
+	}{
+		{
+			name:       "Ok200",
+			markId:     "1",
+			role:       models.ModeratorRole,
+			wantCall:   true,
+			statusCode: 200,
+		},
+		{
+			name:       "Err400",
+			markId:     "a",
+			role:       models.ModeratorRole,
+			statusCode: 400,
+		},
+		{
+			name:       "Err401",
+			markId:     "1",
+			statusCode: 401,
+		},
+		{
+			name:       "Err403",
+			markId:     "1",
+			role:       models.CitizenRole,
+			statusCode: 403,
+		},
+		{
+			name:       "Err404",
+			markId:     "1",
+			role:       models.ModeratorRole,
+			wantCall:   true,
+			errClaim:   storage.ErrNotFound,
+			statusCode: 404,
+		},
+		{
+			name:       "Err409",
+			markId:     "1",
+			role:       models.ModeratorRole,
+			wantCall:   true,
+			errClaim:   usecase.ErrConflict,
+			statusCode: 409,
+		},
+		{
+			name:       "Err500",
+			markId:     "1",
+			role:       models.ModeratorRole,
+			wantCall:   true,
+			errClaim:   errors.New(""),
+			statusCode: 500,
+		},
+	}
+	for _, tt := range tests {
+		suite.Run(tt.name, func() {
+			if tt.wantCall {
+				suite.uc.On("Claim", mock.Anything, 1, 1).Once().
+					Return(models.ModerationClaim{}, tt.errClaim)
+			}
+
+			w := suite.newRequest("POST", "/moderation/queue/"+tt.markId+"/claim", tt.role)
+
+			suite.Equal(tt.statusCode, w.Code)
+		})
+	}
+}
+
+func (suite *ModerationSuite) TestRelease() {
+	tests := []struct {
+		name       string
+		markId     string
+		role       models.UserRole
+		wantCall   bool
+		errRelease error
+		statusCode int
+	}{
+		{
+			name:       "Ok200",
+			markId:     "1",
+			role:       models.ModeratorRole,
+			wantCall:   true,
+			statusCode: 200,
+		},
+		{
+			name:       "Err400",
+			markId:     "a",
+			role:       models.ModeratorRole,
+			statusCode: 400,
+		},
+		{
+			name:       "Err401",
+			markId:     "1",
+			statusCode: 401,
+		},
+		{
+			name:       "Err403",
+			markId:     "1",
+			role:       models.CitizenRole,
+			statusCode: 403,
+		},
+		{
+			name:       "Err404",
+			markId:     "1",
+			role:       models.ModeratorRole,
+			wantCall:   true,
+			errRelease: usecase.ErrNotFound,
+			statusCode: 404,
+		},
+		{
+			name:       "Err500",
+			markId:     "1",
+			role:       models.ModeratorRole,
+			wantCall:   true,
+			errRelease: errors.New(""),
+			statusCode: 500,
+		},
+	}
+	for _, tt := range tests {
+		suite.Run(tt.name, func() {
+			if tt.wantCall {
+				suite.uc.On("Release", mock.Anything, 1, 1).Once().
+					Return(tt.errRelease)
+			}
+
+			w := suite.newRequest("DELETE", "/moderation/queue/"+tt.markId+"/claim", tt.role)
+
+			suite.Equal(tt.statusCode, w.Code)
+		})
+	}
+}
+
+func (suite *ModerationSuite) TestGetSimilarPhotos() {
+	tests := []struct {
+		name                string
+		checkId             string
+		number              string
+		role                models.UserRole
+		wantCall            bool
+		errGetSimilarPhotos error
+		statusCode          int
+	}{
+		{
+			name:       "Ok200",
+			checkId:    "1",
+			number:     "2",
+			role:       models.ModeratorRole,
+			wantCall:   true,
+			statusCode: 200,
+		},
+		{
+			name:       "Err400InvalidCheckId",
+			checkId:    "a",
+			number:     "2",
+			role:       models.ModeratorRole,
+			statusCode: 400,
+		},
+		{
+			name:       "Err400InvalidNumber",
+			checkId:    "1",
+			number:     "a",
+			role:       models.ModeratorRole,
+			statusCode: 400,
+		},
+		{
+			name:       "Err401",
+			checkId:    "1",
+			number:     "2",
+			statusCode: 401,
+		},
+		{
+			name:       "Err403",
+			checkId:    "1",
+			number:     "2",
+			role:       models.CitizenRole,
+			statusCode: 403,
+		},
+		{
+			name:                "Err404",
+			checkId:             "1",
+			number:              "2",
+			role:                models.ModeratorRole,
+			wantCall:            true,
+			errGetSimilarPhotos: usecase.ErrNotFound,
+			statusCode:          404,
+		},
+		{
+			name:                "Err500",
+			checkId:             "1",
+			number:              "2",
+			role:                models.ModeratorRole,
+			wantCall:            true,
+			errGetSimilarPhotos: errors.New(""),
+			statusCode:          500,
+		},
+	}
+	for _, tt := range tests {
+		suite.Run(tt.name, func() {
+			if tt.wantCall {
+				suite.uc.On("GetSimilarPhotos", mock.Anything, 1, 2).Once().
+					Return([]models.SimilarPhoto{}, tt.errGetSimilarPhotos)
+			}
+
+			w := suite.newRequest("GET", "/moderation/checks/"+tt.checkId+"/photos/"+tt.number+"/similar", tt.role)
+
+			suite.Equal(tt.statusCode, w.Code)
+		})
+	}
+}
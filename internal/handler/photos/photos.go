@@ -0,0 +1,65 @@
+package photosrest
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/PritOriginal/problem-map-server/internal/storage"
+	"github.com/PritOriginal/problem-map-server/pkg/logger"
+	"github.com/PritOriginal/problem-map-server/pkg/responses"
+	"github.com/gin-gonic/gin"
+)
+
+type handler struct {
+	log    *slog.Logger
+	photos fs.FS
+	maxAge time.Duration
+}
+
+// Register serves the photos of the local photo storage, the photos are cached by the clients for maxAge
+func Register(r *gin.Engine, log *slog.Logger, route string, photos fs.FS, maxAge time.Duration) {
+	handler := &handler{log: log, photos: photos, maxAge: maxAge}
+
+	r.GET(route+"/*key", handler.GetPhoto())
+}
+
+// GetPhoto get photo
+//
+//	@Summary		Get photo
+//	@Description	get the photo from the local photo storage
+//	@Tags			photos
+//	@Produce		jpeg
+//	@Param			key	path		string	true	"photo key, marks/{markId}/{checkId}/{n}.jpg or marks/{markId}/{checkId}/{n}_{variant}.jpg"
+//	@Success		200	{file}		file
+//	@Failure		404	{object}	responses.Response[any]
+//	@Failure		500	{object}	responses.Response[any]
+//	@Router			/photos/{key} [get]
+func (h *handler) GetPhoto() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		key := strings.TrimPrefix(c.Param("key"), "/")
+		if _, _, _, _, err := storage.ParsePhotoKey(key); err != nil {
+			h.log.Debug("invalid photo key", slog.String("key", key))
+			responses.NotFound(c, "photo not found")
+			return
+		}
+
+		if _, err := fs.Stat(h.photos, key); err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				h.log.Debug("photo not found", slog.String("key", key))
+				responses.NotFound(c, "photo not found")
+				return
+			}
+			h.log.Error("error get photo", logger.Err(err))
+			responses.Internal(c, "error get photo")
+			return
+		}
+
+		c.Header("Cache-Control", fmt.Sprintf("public, max-age=%d", int(h.maxAge.Seconds())))
+		http.ServeFileFS(c.Writer, c.Request, h.photos, key)
+	}
+}
@@ -0,0 +1,89 @@
+package photosrest_test
+
+import (
+	"net/http/httptest"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	photosrest "github.com/PritOriginal/problem-map-server/internal/handler/photos"
+	"github.com/PritOriginal/problem-map-server/pkg/logger/slogdiscard"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/suite"
+)
+
+type PhotosSuite struct {
+	suite.Suite
+	r *gin.Engine
+}
+
+func (suite *PhotosSuite) SetupSuite() {
+	photos := fstest.MapFS{
+		"marks/1/2/1.jpg":           {Data: []byte("original"), ModTime: time.Now()},
+		"marks/1/2/1_thumbnail.jpg": {Data: []byte("thumbnail"), ModTime: time.Now()},
+		"marks/1/2/notes.txt":       {Data: []byte("notes"), ModTime: time.Now()},
+	}
+
+	log := slogdiscard.NewDiscardLogger()
+
+	gin.SetMode(gin.TestMode)
+	suite.r = gin.New()
+
+	photosrest.Register(suite.r, log, "/photos", photos, time.Hour)
+}
+
+func TestPhotos(t *testing.T) {
+	suite.Run(t, new(PhotosSuite))
+}
+
+func (suite *PhotosSuite) TestGetPhoto() {
+	tests := []struct {
+		name       string
+		target     string
+		statusCode int
+		wantBody   string
+	}{
+		{
+			name:       "Ok200",
+			target:     "/photos/marks/1/2/1.jpg",
+			statusCode: 200,
+			wantBody:   "original",
+		},
+		{
+			name:       "Ok200Variant",
+			target:     "/photos/marks/1/2/1_thumbnail.jpg",
+			statusCode: 200,
+			wantBody:   "thumbnail",
+		},
+		{
+			name:       "Err404NotFound",
+			target:     "/photos/marks/1/2/2.jpg",
+			statusCode: 404,
+		},
+		{
+			name:       "Err404NotPhoto",
+			target:     "/photos/marks/1/2/notes.txt",
+			statusCode: 404,
+		},
+		{
+			name:       "Err404Dir",
+			target:     "/photos/marks/1/2",
+			statusCode: 404,
+		},
+	}
+	for _, tt := range tests {
+		suite.Run(tt.name, func() {
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("GET", tt.target, nil)
+
+			suite.r.ServeHTTP(w, req)
+
+			suite.Equal(tt.statusCode, w.Code)
+			if tt.statusCode < 300 {
+				suite.Equal(tt.wantBody, w.Body.String())
+				suite.Equal("public, max-age=3600", w.Header().Get("Cache-Control"))
+				suite.NotEmpty(w.Header().Get("Last-Modified"))
+			}
+		})
+	}
+}
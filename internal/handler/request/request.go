@@ -0,0 +1,85 @@
+// Package request reads the authorized user, the client, the pagination and the uploaded photos
+// of the REST requests
+package request
+
+import (
+	"io"
+	"mime/multipart"
+	"strconv"
+	"strings"
+
+	"github.com/PritOriginal/problem-map-server/internal/models"
+	"github.com/PritOriginal/problem-map-server/internal/storage"
+	"github.com/PritOriginal/problem-map-server/pkg/token"
+	jwt "github.com/appleboy/gin-jwt/v3"
+	"github.com/gin-gonic/gin"
+)
+
+// PaginationQuery holds the query params of keyset paginated lists
+type PaginationQuery struct {
+	Limit  int    `form:"limit" binding:"omitempty,gt=0"`
+	Cursor string `form:"cursor"`
+	Sort   string `form:"sort"`
+	Order  string `form:"order" binding:"omitempty,oneof=asc desc"`
+}
+
+// GetUserId returns the id of the authorized user from the JWT subject
+func GetUserId(c *gin.Context) (int, error) {
+	claims := jwt.ExtractClaims(c)
+
+	userIdStr, err := claims.GetSubject()
+	if err != nil {
+		return 0, err
+	}
+	return strconv.Atoi(userIdStr)
+}
+
+// GetSessionId returns the id of the session the access token was issued for
+func GetSessionId(c *gin.Context) string {
+	sessionId, _ := jwt.ExtractClaims(c)[token.SessionClaim].(string)
+	return sessionId
+}
+
+// maxUserAgentLength is the max length of the user agent kept in the session
+const maxUserAgentLength = 256
+
+// GetSessionClient returns the client of the request
+func GetSessionClient(c *gin.Context) models.SessionClient {
+	userAgent := c.Request.UserAgent()
+	if len(userAgent) > maxUserAgentLength {
+		userAgent = strings.ToValidUTF8(userAgent[:maxUserAgentLength], "")
+	}
+
+	return models.SessionClient{
+		UserAgent: userAgent,
+		IP:        c.ClientIP(),
+	}
+}
+
+// ParsePhotos decodes the uploaded images with storage.DecodePhoto
+func ParsePhotos(fheaders []*multipart.FileHeader) ([]models.Photo, error) {
+	var photos []models.Photo
+	for _, header := range fheaders {
+		photo, err := parsePhoto(header)
+		if err != nil {
+			return photos, err
+		}
+		photos = append(photos, photo)
+	}
+	return photos, nil
+}
+
+func parsePhoto(header *multipart.FileHeader) (models.Photo, error) {
+	file, err := header.Open()
+	if err != nil {
+		return models.Photo{}, err
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(file)
+	if err != nil {
+		return models.Photo{}, err
+	}
+
+	return storage.DecodePhoto(data)
+}
@@ -3,7 +3,8 @@ package tasksrest
 import "github.com/PritOriginal/problem-map-server/internal/models"
 
 type GetTasksResponse struct {
-	Tasks []models.Task `json:"tasks"`
+	Tasks      []models.Task `json:"tasks"`
+	NextCursor string        `json:"next_cursor,omitempty"`
 }
 
 type GetTaskByIdResponse struct {
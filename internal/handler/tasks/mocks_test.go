@@ -171,31 +171,37 @@ func (_c *MockTasks_GetTaskById_Call) RunAndReturn(run func(ctx context.Context,
 }
 
 // GetTasks provides a mock function for the type MockTasks
-func (_mock *MockTasks) GetTasks(ctx context.Context) ([]models.Task, error) {
-	ret := _mock.Called(ctx)
+func (_mock *MockTasks) GetTasks(ctx context.Context, pagination models.Pagination) ([]models.Task, string, error) {
+	ret := _mock.Called(ctx, pagination)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetTasks")
 	}
 
 	var r0 []models.Task
-	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context) ([]models.Task, error)); ok {
-		return returnFunc(ctx)
+	var r1 string
+	var r2 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, models.Pagination) ([]models.Task, string, error)); ok {
+		return returnFunc(ctx, pagination)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context) []models.Task); ok {
-		r0 = returnFunc(ctx)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, models.Pagination) []models.Task); ok {
+		r0 = returnFunc(ctx, pagination)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]models.Task)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
-		r1 = returnFunc(ctx)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, models.Pagination) string); ok {
+		r1 = returnFunc(ctx, pagination)
 	} else {
-		r1 = ret.Error(1)
+		r1 = ret.Get(1).(string)
 	}
-	return r0, r1
+	if returnFunc, ok := ret.Get(2).(func(context.Context, models.Pagination) error); ok {
+		r2 = returnFunc(ctx, pagination)
+	} else {
+		r2 = ret.Error(2)
+	}
+	return r0, r1, r2
 }
 
 // MockTasks_GetTasks_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTasks'
@@ -205,29 +211,35 @@ type MockTasks_GetTasks_Call struct {
 
 // GetTasks is a helper method to define mock.On call
 //   - ctx context.Context
-func (_e *MockTasks_Expecter) GetTasks(ctx interface{}) *MockTasks_GetTasks_Call {
-	return &MockTasks_GetTasks_Call{Call: _e.mock.On("GetTasks", ctx)}
+//   - pagination models.Pagination
+func (_e *MockTasks_Expecter) GetTasks(ctx interface{}, pagination interface{}) *MockTasks_GetTasks_Call {
+	return &MockTasks_GetTasks_Call{Call: _e.mock.On("GetTasks", ctx, pagination)}
 }
 
-func (_c *MockTasks_GetTasks_Call) Run(run func(ctx context.Context)) *MockTasks_GetTasks_Call {
+func (_c *MockTasks_GetTasks_Call) Run(run func(ctx context.Context, pagination models.Pagination)) *MockTasks_GetTasks_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
+		var arg1 models.Pagination
+		if args[1] != nil {
+			arg1 = args[1].(models.Pagination)
+		}
 		run(
 			arg0,
+			arg1,
 		)
 	})
 	return _c
 }
 
-func (_c *MockTasks_GetTasks_Call) Return(tasks []models.Task, err error) *MockTasks_GetTasks_Call {
-	_c.Call.Return(tasks, err)
+func (_c *MockTasks_GetTasks_Call) Return(tasks []models.Task, s string, err error) *MockTasks_GetTasks_Call {
+	_c.Call.Return(tasks, s, err)
 	return _c
 }
 
-func (_c *MockTasks_GetTasks_Call) RunAndReturn(run func(ctx context.Context) ([]models.Task, error)) *MockTasks_GetTasks_Call {
+func (_c *MockTasks_GetTasks_Call) RunAndReturn(run func(ctx context.Context, pagination models.Pagination) ([]models.Task, string, error)) *MockTasks_GetTasks_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -6,15 +6,18 @@ import (
 	"log/slog"
 	"strconv"
 
+	"github.com/PritOriginal/problem-map-server/internal/handler/request"
+	"github.com/PritOriginal/problem-map-server/internal/middleware/rbac"
 	"github.com/PritOriginal/problem-map-server/internal/models"
 	"github.com/PritOriginal/problem-map-server/internal/storage"
 	"github.com/PritOriginal/problem-map-server/pkg/logger"
 	"github.com/PritOriginal/problem-map-server/pkg/responses"
+	jwt "github.com/appleboy/gin-jwt/v3"
 	"github.com/gin-gonic/gin"
 )
 
 type Tasks interface {
-	GetTasks(ctx context.Context) ([]models.Task, error)
+	GetTasks(ctx context.Context, pagination models.Pagination) ([]models.Task, string, error)
 	GetTaskById(ctx context.Context, id int) (models.Task, error)
 	GetTasksByUserId(ctx context.Context, userId int) ([]models.Task, error)
 	AddTask(ctx context.Context, task models.Task) (int64, error)
@@ -25,7 +28,7 @@ type handler struct {
 	uc  Tasks
 }
 
-func Register(r *gin.Engine, log *slog.Logger, uc Tasks) {
+func Register(r *gin.Engine, log *slog.Logger, authMiddleware *jwt.GinJWTMiddleware, uc Tasks) {
 	handler := &handler{log: log, uc: uc}
 
 	tasks := r.Group("/tasks")
@@ -33,7 +36,10 @@ func Register(r *gin.Engine, log *slog.Logger, uc Tasks) {
 		tasks.GET("", handler.GetTasks())
 		tasks.GET(":id", handler.GetTaskById())
 		tasks.GET("user/:id", handler.GetTasksByUserId())
-		tasks.POST("", handler.AddTask())
+		moderator := tasks.Group("", authMiddleware.MiddlewareFunc(), rbac.RequireRole(models.ModeratorRole))
+		{
+			moderator.POST("", handler.AddTask())
+		}
 	}
 }
 
@@ -43,12 +49,29 @@ func Register(r *gin.Engine, log *slog.Logger, uc Tasks) {
 //	@Description	get tasks
 //	@Tags			tasks
 //	@Produce		json
-//	@Success		200	{object}	responses.Response[tasksrest.GetTasksResponse]
-//	@Failure		500	{object}	responses.Response[any]
+//	@Param			limit	query		int		false	"max number of tasks in the page (capped by the server)"
+//	@Param			cursor	query		string	false	"cursor to the next page"
+//	@Param			order	query		string	false	"sort order by id"	Enums(asc, desc)
+//	@Success		200		{object}	responses.Response[tasksrest.GetTasksResponse]
+//	@Failure		400		{object}	responses.Response[any]
+//	@Failure		500		{object}	responses.Response[any]
 //	@Router			/tasks [get]
 func (h *handler) GetTasks() gin.HandlerFunc {
 	return func(c *gin.Context) {
-		tasks, err := h.uc.GetTasks(c.Request.Context())
+		var query request.PaginationQuery
+		if err := c.ShouldBindQuery(&query); err != nil {
+			h.log.Debug("failed parse pagination", logger.Err(err))
+			responses.BadRequest(c, "invalid pagination")
+			return
+		}
+		pagination, err := models.TasksSorting.NewPagination(query.Limit, query.Cursor, query.Sort, query.Order)
+		if err != nil {
+			h.log.Debug("invalid pagination", logger.Err(err))
+			responses.BadRequest(c, "invalid pagination")
+			return
+		}
+
+		tasks, nextCursor, err := h.uc.GetTasks(c.Request.Context(), pagination)
 		if err != nil {
 			h.log.Error("error get tasks", logger.Err(err))
 			responses.Internal(c, "error get tasks")
@@ -56,7 +79,8 @@ func (h *handler) GetTasks() gin.HandlerFunc {
 		}
 
 		responses.OK(c, GetTasksResponse{
-			Tasks: tasks,
+			Tasks:      tasks,
+			NextCursor: nextCursor,
 		})
 	}
 }
@@ -136,13 +160,16 @@ func (h *handler) GetTasksByUserId() gin.HandlerFunc {
 // AddTask add new task
 //
 //	@Summary		Add task
-//	@Description	add new task
+//	@Description	add new task, allowed only for moderators
 //	@Tags			tasks
 //	@Produce		json
-//	@Param			request	body		tasksrest.AddTaskRequest	true	"query params"
-//	@Success		201		{object}	responses.Response[tasksrest.AddTaskResponse]
-//	@Failure		400		{object}	responses.Response[any]
-//	@Failure		500		{object}	responses.Response[any]
+//	@Param			Authorization	header		string						true	"Insert your access token"	default(Bearer <Add access token here>)
+//	@Param			request			body		tasksrest.AddTaskRequest	true	"query params"
+//	@Success		201				{object}	responses.Response[tasksrest.AddTaskResponse]
+//	@Failure		400				{object}	responses.Response[any]
+//	@Failure		401				{object}	responses.Response[any]
+//	@Failure		403				{object}	responses.Response[any]
+//	@Failure		500				{object}	responses.Response[any]
 //	@Router			/tasks [post]
 func (h *handler) AddTask() gin.HandlerFunc {
 	return func(c *gin.Context) {
@@ -6,11 +6,14 @@ import (
 	"errors"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	tasksrest "github.com/PritOriginal/problem-map-server/internal/handler/tasks"
 	"github.com/PritOriginal/problem-map-server/internal/models"
 	"github.com/PritOriginal/problem-map-server/internal/storage"
 	"github.com/PritOriginal/problem-map-server/pkg/logger/slogdiscard"
+	"github.com/PritOriginal/problem-map-server/pkg/token"
+	jwt "github.com/appleboy/gin-jwt/v3"
 	"github.com/gin-gonic/gin"
 	mock "github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
@@ -23,6 +26,17 @@ type TasksSuite struct {
 }
 
 func (suite *TasksSuite) SetupSuite() {
+	authMiddleware, err := jwt.New(&jwt.GinJWTMiddleware{
+		Key: []byte("1234"),
+	})
+	if err != nil {
+		panic(err)
+	}
+	errInit := authMiddleware.MiddlewareInit()
+	if errInit != nil {
+		panic(errInit)
+	}
+
 	suite.uc = tasksrest.NewMockTasks(suite.T())
 
 	log := slogdiscard.NewDiscardLogger()
@@ -30,7 +44,7 @@ func (suite *TasksSuite) SetupSuite() {
 	gin.SetMode(gin.TestMode)
 	suite.r = gin.New()
 
-	tasksrest.Register(suite.r, log, suite.uc)
+	tasksrest.Register(suite.r, log, authMiddleware, suite.uc)
 }
 
 func TestUsers(t *testing.T) {
@@ -39,9 +53,11 @@ func TestUsers(t *testing.T) {
 
 func (suite *TasksSuite) TestGetTasks() {
 	tests := []struct {
-		name        string
-		errGetTasks error
-		statusCode  int
+		name              string
+		query             string
+		wantErrPagination bool
+		errGetTasks       error
+		statusCode        int
 	}{
 		{
 			name:        "Ok200",
@@ -53,14 +69,27 @@ func (suite *TasksSuite) TestGetTasks() {
 			errGetTasks: errors.New(""),
 			statusCode:  500,
 		},
+		{
+			name:       "Ok200",
+			query:      "?limit=10&order=desc",
+			statusCode: 200,
+		},
+		{
+			name:              "Err400",
+			query:             "?cursor=invalid",
+			wantErrPagination: true,
+			statusCode:        400,
+		},
 	}
 	for _, tt := range tests {
 		suite.Run(tt.name, func() {
-			suite.uc.On("GetTasks", mock.Anything).Once().
-				Return([]models.Task{}, tt.errGetTasks)
+			if !tt.wantErrPagination {
+				suite.uc.On("GetTasks", mock.Anything, mock.AnythingOfType("models.Pagination")).Once().
+					Return([]models.Task{}, "", tt.errGetTasks)
+			}
 
 			w := httptest.NewRecorder()
-			req := httptest.NewRequest("GET", "/tasks", nil)
+			req := httptest.NewRequest("GET", "/tasks"+tt.query, nil)
 
 			suite.r.ServeHTTP(w, req)
 
@@ -175,6 +204,7 @@ func (suite *TasksSuite) TestAddTask() {
 		name            string
 		rawReq          string
 		req             tasksrest.AddTaskRequest
+		role            models.UserRole
 		wantErrParseReq bool
 		errAddTask      error
 		statusCode      int
@@ -186,13 +216,48 @@ func (suite *TasksSuite) TestAddTask() {
 				UserID: 1,
 				MarkID: 1,
 			},
+			role:            models.ModeratorRole,
+			wantErrParseReq: false,
+			errAddTask:      nil,
+			statusCode:      201,
+		},
+		{
+			name: "Ok201-Admin",
+			req: tasksrest.AddTaskRequest{
+				Name:   "test",
+				UserID: 1,
+				MarkID: 1,
+			},
+			role:            models.AdminRole,
 			wantErrParseReq: false,
 			errAddTask:      nil,
 			statusCode:      201,
 		},
+		{
+			name: "Err401",
+			req: tasksrest.AddTaskRequest{
+				Name:   "test",
+				UserID: 1,
+				MarkID: 1,
+			},
+			wantErrParseReq: true,
+			statusCode:      401,
+		},
+		{
+			name: "Err403",
+			req: tasksrest.AddTaskRequest{
+				Name:   "test",
+				UserID: 1,
+				MarkID: 1,
+			},
+			role:            models.CitizenRole,
+			wantErrParseReq: true,
+			statusCode:      403,
+		},
 		{
 			name:            "Err400InvalidJSON",
 			rawReq:          "{",
+			role:            models.ModeratorRole,
 			wantErrParseReq: true,
 			errAddTask:      nil,
 			statusCode:      400,
@@ -202,6 +267,7 @@ func (suite *TasksSuite) TestAddTask() {
 			req: tasksrest.AddTaskRequest{
 				Name: "test",
 			},
+			role:            models.ModeratorRole,
 			wantErrParseReq: true,
 			errAddTask:      nil,
 			statusCode:      400,
@@ -213,6 +279,7 @@ func (suite *TasksSuite) TestAddTask() {
 				UserID: 1,
 				MarkID: 1,
 			},
+			role:            models.ModeratorRole,
 			wantErrParseReq: false,
 			errAddTask:      errors.New(""),
 			statusCode:      500,
@@ -237,6 +304,13 @@ func (suite *TasksSuite) TestAddTask() {
 			}
 
 			req := httptest.NewRequest("POST", "/tasks", buf)
+			if tt.role != 0 {
+				accessToken, err := token.CreateTokenWithClaims(1*time.Minute, 1, "1234", map[string]any{
+					token.RoleClaim: tt.role.String(),
+				})
+				suite.NoError(err)
+				req.Header.Set("Authorization", "Bearer "+accessToken)
+			}
 
 			suite.r.ServeHTTP(w, req)
 
@@ -0,0 +1,19 @@
+package uploadsrest
+
+import "github.com/PritOriginal/problem-map-server/internal/models"
+
+type CreateUploadsRequest struct {
+	Count int `json:"count" binding:"required,min=1,max=10"`
+}
+
+type CreateUploadsResponse struct {
+	Uploads []models.PhotoUpload `json:"uploads"`
+}
+
+type AttachPhotosRequest struct {
+	Keys []string `json:"keys" binding:"required,min=1,max=10,dive,required"`
+}
+
+type AttachPhotosResponse struct {
+	Photos []models.PhotoURLs `json:"photos"`
+}
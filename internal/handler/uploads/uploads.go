@@ -0,0 +1,151 @@
+package uploadsrest
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"strconv"
+
+	"github.com/PritOriginal/problem-map-server/internal/handler/request"
+	"github.com/PritOriginal/problem-map-server/internal/models"
+	"github.com/PritOriginal/problem-map-server/internal/usecase"
+	"github.com/PritOriginal/problem-map-server/pkg/logger"
+	"github.com/PritOriginal/problem-map-server/pkg/responses"
+	jwt "github.com/appleboy/gin-jwt/v3"
+	"github.com/gin-gonic/gin"
+)
+
+type Uploads interface {
+	CreateUploads(ctx context.Context, userId, count int) ([]models.PhotoUpload, error)
+	AttachPhotos(ctx context.Context, userId, checkId int, keys []string) ([]models.PhotoURLs, error)
+}
+
+type handler struct {
+	log *slog.Logger
+	uc  Uploads
+}
+
+// Register registers the direct photo uploads, they are available only with the S3 photo storage
+func Register(r *gin.Engine, log *slog.Logger, authMiddleware *jwt.GinJWTMiddleware, uc Uploads) {
+	handler := &handler{log: log, uc: uc}
+
+	auth := r.Group("", authMiddleware.MiddlewareFunc())
+	{
+		auth.POST("/photos/uploads", handler.CreateUploads())
+		auth.POST("/checks/:id/photos", handler.AttachPhotos())
+	}
+}
+
+// CreateUploads create photo uploads
+//
+//	@Summary		Create photo uploads
+//	@Description	get the presigned URLs the photos are uploaded by directly to the S3 photo storage with PUT, the uploaded photos are attached to a check afterwards
+//	@Tags			photos
+//	@Accept			json
+//	@Produce		json
+//	@Param			Authorization	header		string								true	"Insert your access token"	default(Bearer <Add access token here>)
+//	@Param			request			body		uploadsrest.CreateUploadsRequest	true	"number of photos"
+//	@Success		201				{object}	responses.Response[uploadsrest.CreateUploadsResponse]
+//	@Failure		400				{object}	responses.Response[any]
+//	@Failure		401				{object}	responses.Response[any]
+//	@Failure		500				{object}	responses.Response[any]
+//	@Router			/photos/uploads [post]
+func (h *handler) CreateUploads() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req CreateUploadsRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			h.log.Debug("failed binding request", logger.Err(err))
+			responses.BadRequest(c, "invalid request")
+			return
+		}
+
+		userId, err := request.GetUserId(c)
+		if err != nil {
+			h.log.Debug("invalid token", logger.Err(err))
+			responses.Unauthorized(c, "invalid token")
+			return
+		}
+
+		uploads, err := h.uc.CreateUploads(c.Request.Context(), userId, req.Count)
+		if err != nil {
+			if errors.Is(err, usecase.ErrInvalidInput) {
+				h.log.Debug("invalid number of uploads", slog.Int("count", req.Count))
+				responses.BadRequest(c, "invalid number of uploads")
+			} else {
+				h.log.Error("error create uploads", logger.Err(err))
+				responses.Internal(c, "error create uploads")
+			}
+			return
+		}
+
+		responses.Created(c, CreateUploadsResponse{
+			Uploads: uploads,
+		})
+	}
+}
+
+// AttachPhotos attach uploaded photos to check
+//
+//	@Summary		Attach uploaded photos to check
+//	@Description	validate the photos uploaded by the presigned URLs and attach them to the check, allowed only for the author of the check
+//	@Tags			checks
+//	@Accept			json
+//	@Produce		json
+//	@Param			Authorization	header		string							true	"Insert your access token"	default(Bearer <Add access token here>)
+//	@Param			id				path		int								true	"check id"
+//	@Param			request			body		uploadsrest.AttachPhotosRequest	true	"keys of the uploads"
+//	@Success		201				{object}	responses.Response[uploadsrest.AttachPhotosResponse]
+//	@Failure		400				{object}	responses.Response[any]
+//	@Failure		401				{object}	responses.Response[any]
+//	@Failure		403				{object}	responses.Response[any]
+//	@Failure		404				{object}	responses.Response[any]
+//	@Failure		500				{object}	responses.Response[any]
+//	@Router			/checks/{id}/photos [post]
+func (h *handler) AttachPhotos() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		checkId, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			h.log.Debug("failed parse id", logger.Err(err))
+			responses.BadRequest(c, "failed parse id")
+			return
+		}
+
+		var req AttachPhotosRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			h.log.Debug("failed binding request", logger.Err(err))
+			responses.BadRequest(c, "invalid request")
+			return
+		}
+
+		userId, err := request.GetUserId(c)
+		if err != nil {
+			h.log.Debug("invalid token", logger.Err(err))
+			responses.Unauthorized(c, "invalid token")
+			return
+		}
+
+		photos, err := h.uc.AttachPhotos(c.Request.Context(), userId, checkId, req.Keys)
+		if err != nil {
+			switch {
+			case errors.Is(err, usecase.ErrNotFound):
+				h.log.Debug("check not found", slog.Int("check_id", checkId))
+				responses.NotFound(c, "check not found")
+			case errors.Is(err, usecase.ErrForbidden):
+				h.log.Debug("user is not the author of the check or the uploads", slog.Int("check_id", checkId), slog.Int("user_id", userId))
+				responses.Forbidden(c, "only the author can attach the photos to the check")
+			case errors.Is(err, usecase.ErrInvalidInput):
+				h.log.Debug("invalid uploads", slog.Int("check_id", checkId), logger.Err(err))
+				responses.BadRequest(c, "invalid uploads")
+			default:
+				h.log.Error("error attach photos", logger.Err(err))
+				responses.Internal(c, "error attach photos")
+			}
+			return
+		}
+
+		h.log.Info("photos have been attached", slog.Int("check_id", checkId), slog.Int("photos", len(req.Keys)))
+		responses.Created(c, AttachPhotosResponse{
+			Photos: photos,
+		})
+	}
+}
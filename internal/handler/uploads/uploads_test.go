@@ -0,0 +1,188 @@
+package uploadsrest_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	uploadsrest "github.com/PritOriginal/problem-map-server/internal/handler/uploads"
+	"github.com/PritOriginal/problem-map-server/internal/models"
+	"github.com/PritOriginal/problem-map-server/internal/usecase"
+	"github.com/PritOriginal/problem-map-server/pkg/logger/slogdiscard"
+	"github.com/PritOriginal/problem-map-server/pkg/token"
+	jwt "github.com/appleboy/gin-jwt/v3"
+	"github.com/gin-gonic/gin"
+	mock "github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type UploadsSuite struct {
+	suite.Suite
+	r  *gin.Engine
+	uc *uploadsrest.MockUploads
+}
+
+func (suite *UploadsSuite) SetupSuite() {
+	authMiddleware, err := jwt.New(&jwt.GinJWTMiddleware{
+		Key: []byte("1234"),
+	})
+	if err != nil {
+		panic(err)
+	}
+	errInit := authMiddleware.MiddlewareInit()
+	if errInit != nil {
+		panic(errInit)
+	}
+
+	suite.uc = uploadsrest.NewMockUploads(suite.T())
+
+	log := slogdiscard.NewDiscardLogger()
+
+	gin.SetMode(gin.TestMode)
+	suite.r = gin.New()
+
+	uploadsrest.Register(suite.r, log, authMiddleware, suite.uc)
+}
+
+func TestUploads(t *testing.T) {
+	suite.Run(t, new(UploadsSuite))
+}
+
+func (suite *UploadsSuite) TestCreateUploads() {
+	tests := []struct {
+		name             string
+		req              uploadsrest.CreateUploadsRequest
+		wantErrParseReq  bool
+		errCreateUploads error
+		statusCode       int
+	}{
+		{
+			name:       "Ok201",
+			req:        uploadsrest.CreateUploadsRequest{Count: 2},
+			statusCode: 201,
+		},
+		{
+			name:            "Err400InvalidReq",
+			req:             uploadsrest.CreateUploadsRequest{Count: usecase.MaxUploads + 1},
+			wantErrParseReq: true,
+			statusCode:      400,
+		},
+		{
+			name:             "Err500",
+			req:              uploadsrest.CreateUploadsRequest{Count: 1},
+			errCreateUploads: errors.New(""),
+			statusCode:       500,
+		},
+	}
+	for _, tt := range tests {
+		suite.Run(tt.name, func() {
+			if !tt.wantErrParseReq {
+				suite.uc.On("CreateUploads", mock.Anything, 1, tt.req.Count).Once().
+					Return([]models.PhotoUpload{}, tt.errCreateUploads)
+			}
+
+			w := httptest.NewRecorder()
+
+			body, err := json.Marshal(tt.req)
+			suite.NoError(err)
+
+			accessToken, err := token.CreateToken(1*time.Minute, 1, "1234")
+			suite.NoError(err)
+
+			req := httptest.NewRequest("POST", "/photos/uploads", bytes.NewReader(body))
+			req.Header.Set("Authorization", "Bearer "+accessToken)
+			req.Header.Set("Content-Type", "application/json")
+
+			suite.r.ServeHTTP(w, req)
+
+			suite.Equal(tt.statusCode, w.Code)
+		})
+	}
+}
+
+func (suite *UploadsSuite) TestAttachPhotos() {
+	tests := []struct {
+		name            string
+		id              string
+		req             uploadsrest.AttachPhotosRequest
+		wantErrParseReq bool
+		errAttachPhotos error
+		statusCode      int
+	}{
+		{
+			name:       "Ok201",
+			id:         "1",
+			req:        uploadsrest.AttachPhotosRequest{Keys: []string{"uploads/1/a"}},
+			statusCode: 201,
+		},
+		{
+			name:            "Err400InvalidId",
+			id:              "a",
+			req:             uploadsrest.AttachPhotosRequest{Keys: []string{"uploads/1/a"}},
+			wantErrParseReq: true,
+			statusCode:      400,
+		},
+		{
+			name:            "Err400InvalidReq",
+			id:              "1",
+			req:             uploadsrest.AttachPhotosRequest{},
+			wantErrParseReq: true,
+			statusCode:      400,
+		},
+		{
+			name:            "Err400InvalidUploads",
+			id:              "1",
+			req:             uploadsrest.AttachPhotosRequest{Keys: []string{"uploads/1/a"}},
+			errAttachPhotos: usecase.ErrInvalidInput,
+			statusCode:      400,
+		},
+		{
+			name:            "Err403",
+			id:              "1",
+			req:             uploadsrest.AttachPhotosRequest{Keys: []string{"uploads/2/a"}},
+			errAttachPhotos: usecase.ErrForbidden,
+			statusCode:      403,
+		},
+		{
+			name:            "Err404",
+			id:              "1",
+			req:             uploadsrest.AttachPhotosRequest{Keys: []string{"uploads/1/a"}},
+			errAttachPhotos: usecase.ErrNotFound,
+			statusCode:      404,
+		},
+		{
+			name:            "Err500",
+			id:              "1",
+			req:             uploadsrest.AttachPhotosRequest{Keys: []string{"uploads/1/a"}},
+			errAttachPhotos: errors.New(""),
+			statusCode:      500,
+		},
+	}
+	for _, tt := range tests {
+		suite.Run(tt.name, func() {
+			if !tt.wantErrParseReq {
+				suite.uc.On("AttachPhotos", mock.Anything, 1, 1, tt.req.Keys).Once().
+					Return([]models.PhotoURLs{}, tt.errAttachPhotos)
+			}
+
+			w := httptest.NewRecorder()
+
+			body, err := json.Marshal(tt.req)
+			suite.NoError(err)
+
+			accessToken, err := token.CreateToken(1*time.Minute, 1, "1234")
+			suite.NoError(err)
+
+			req := httptest.NewRequest("POST", "/checks/"+tt.id+"/photos", bytes.NewReader(body))
+			req.Header.Set("Authorization", "Bearer "+accessToken)
+			req.Header.Set("Content-Type", "application/json")
+
+			suite.r.ServeHTTP(w, req)
+
+			suite.Equal(tt.statusCode, w.Code)
+		})
+	}
+}
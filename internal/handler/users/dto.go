@@ -3,9 +3,18 @@ package usersrest
 import "github.com/PritOriginal/problem-map-server/internal/models"
 
 type GetUsersResponse struct {
-	Users []models.User `json:"users"`
+	Users      []models.User `json:"users"`
+	NextCursor string        `json:"next_cursor,omitempty"`
 }
 
 type GetUserByIdResponse struct {
 	User models.User `json:"user"`
 }
+
+type GetSessionsResponse struct {
+	Sessions []models.Session `json:"sessions"`
+}
+
+type DeleteSessionResponse struct {
+	SessionId string `json:"session_id"`
+}
@@ -38,6 +38,143 @@ func (_m *MockUsers) EXPECT() *MockUsers_Expecter {
 	return &MockUsers_Expecter{mock: &_m.Mock}
 }
 
+// DeleteSession provides a mock function for the type MockUsers
+func (_mock *MockUsers) DeleteSession(ctx context.Context, userId int, sessionId string) error {
+	ret := _mock.Called(ctx, userId, sessionId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteSession")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int, string) error); ok {
+		r0 = returnFunc(ctx, userId, sessionId)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockUsers_DeleteSession_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteSession'
+type MockUsers_DeleteSession_Call struct {
+	*mock.Call
+}
+
+// DeleteSession is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userId int
+//   - sessionId string
+func (_e *MockUsers_Expecter) DeleteSession(ctx interface{}, userId interface{}, sessionId interface{}) *MockUsers_DeleteSession_Call {
+	return &MockUsers_DeleteSession_Call{Call: _e.mock.On("DeleteSession", ctx, userId, sessionId)}
+}
+
+func (_c *MockUsers_DeleteSession_Call) Run(run func(ctx context.Context, userId int, sessionId string)) *MockUsers_DeleteSession_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int
+		if args[1] != nil {
+			arg1 = args[1].(int)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockUsers_DeleteSession_Call) Return(err error) *MockUsers_DeleteSession_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockUsers_DeleteSession_Call) RunAndReturn(run func(ctx context.Context, userId int, sessionId string) error) *MockUsers_DeleteSession_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetSessions provides a mock function for the type MockUsers
+func (_mock *MockUsers) GetSessions(ctx context.Context, userId int, currentSessionId string) ([]models.Session, error) {
+	ret := _mock.Called(ctx, userId, currentSessionId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetSessions")
+	}
+
+	var r0 []models.Session
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int, string) ([]models.Session, error)); ok {
+		return returnFunc(ctx, userId, currentSessionId)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int, string) []models.Session); ok {
+		r0 = returnFunc(ctx, userId, currentSessionId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.Session)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int, string) error); ok {
+		r1 = returnFunc(ctx, userId, currentSessionId)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockUsers_GetSessions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetSessions'
+type MockUsers_GetSessions_Call struct {
+	*mock.Call
+}
+
+// GetSessions is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userId int
+//   - currentSessionId string
+func (_e *MockUsers_Expecter) GetSessions(ctx interface{}, userId interface{}, currentSessionId interface{}) *MockUsers_GetSessions_Call {
+	return &MockUsers_GetSessions_Call{Call: _e.mock.On("GetSessions", ctx, userId, currentSessionId)}
+}
+
+func (_c *MockUsers_GetSessions_Call) Run(run func(ctx context.Context, userId int, currentSessionId string)) *MockUsers_GetSessions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int
+		if args[1] != nil {
+			arg1 = args[1].(int)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockUsers_GetSessions_Call) Return(sessions []models.Session, err error) *MockUsers_GetSessions_Call {
+	_c.Call.Return(sessions, err)
+	return _c
+}
+
+func (_c *MockUsers_GetSessions_Call) RunAndReturn(run func(ctx context.Context, userId int, currentSessionId string) ([]models.Session, error)) *MockUsers_GetSessions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetUserById provides a mock function for the type MockUsers
 func (_mock *MockUsers) GetUserById(ctx context.Context, id int) (models.User, error) {
 	ret := _mock.Called(ctx, id)
@@ -105,31 +242,37 @@ func (_c *MockUsers_GetUserById_Call) RunAndReturn(run func(ctx context.Context,
 }
 
 // GetUsers provides a mock function for the type MockUsers
-func (_mock *MockUsers) GetUsers(ctx context.Context) ([]models.User, error) {
-	ret := _mock.Called(ctx)
+func (_mock *MockUsers) GetUsers(ctx context.Context, pagination models.Pagination) ([]models.User, string, error) {
+	ret := _mock.Called(ctx, pagination)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetUsers")
 	}
 
 	var r0 []models.User
-	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context) ([]models.User, error)); ok {
-		return returnFunc(ctx)
+	var r1 string
+	var r2 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, models.Pagination) ([]models.User, string, error)); ok {
+		return returnFunc(ctx, pagination)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context) []models.User); ok {
-		r0 = returnFunc(ctx)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, models.Pagination) []models.User); ok {
+		r0 = returnFunc(ctx, pagination)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]models.User)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
-		r1 = returnFunc(ctx)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, models.Pagination) string); ok {
+		r1 = returnFunc(ctx, pagination)
 	} else {
-		r1 = ret.Error(1)
+		r1 = ret.Get(1).(string)
 	}
-	return r0, r1
+	if returnFunc, ok := ret.Get(2).(func(context.Context, models.Pagination) error); ok {
+		r2 = returnFunc(ctx, pagination)
+	} else {
+		r2 = ret.Error(2)
+	}
+	return r0, r1, r2
 }
 
 // MockUsers_GetUsers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUsers'
@@ -139,29 +282,35 @@ type MockUsers_GetUsers_Call struct {
 
 // GetUsers is a helper method to define mock.On call
 //   - ctx context.Context
-func (_e *MockUsers_Expecter) GetUsers(ctx interface{}) *MockUsers_GetUsers_Call {
-	return &MockUsers_GetUsers_Call{Call: _e.mock.On("GetUsers", ctx)}
+//   - pagination models.Pagination
+func (_e *MockUsers_Expecter) GetUsers(ctx interface{}, pagination interface{}) *MockUsers_GetUsers_Call {
+	return &MockUsers_GetUsers_Call{Call: _e.mock.On("GetUsers", ctx, pagination)}
 }
 
-func (_c *MockUsers_GetUsers_Call) Run(run func(ctx context.Context)) *MockUsers_GetUsers_Call {
+func (_c *MockUsers_GetUsers_Call) Run(run func(ctx context.Context, pagination models.Pagination)) *MockUsers_GetUsers_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
+		var arg1 models.Pagination
+		if args[1] != nil {
+			arg1 = args[1].(models.Pagination)
+		}
 		run(
 			arg0,
+			arg1,
 		)
 	})
 	return _c
 }
 
-func (_c *MockUsers_GetUsers_Call) Return(users []models.User, err error) *MockUsers_GetUsers_Call {
-	_c.Call.Return(users, err)
+func (_c *MockUsers_GetUsers_Call) Return(users []models.User, s string, err error) *MockUsers_GetUsers_Call {
+	_c.Call.Return(users, s, err)
 	return _c
 }
 
-func (_c *MockUsers_GetUsers_Call) RunAndReturn(run func(ctx context.Context) ([]models.User, error)) *MockUsers_GetUsers_Call {
+func (_c *MockUsers_GetUsers_Call) RunAndReturn(run func(ctx context.Context, pagination models.Pagination) ([]models.User, string, error)) *MockUsers_GetUsers_Call {
 	_c.Call.Return(run)
 	return _c
 }
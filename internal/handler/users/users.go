@@ -6,16 +6,21 @@ import (
 	"log/slog"
 	"strconv"
 
+	"github.com/PritOriginal/problem-map-server/internal/handler/request"
 	"github.com/PritOriginal/problem-map-server/internal/models"
 	"github.com/PritOriginal/problem-map-server/internal/storage"
+	"github.com/PritOriginal/problem-map-server/internal/usecase"
 	"github.com/PritOriginal/problem-map-server/pkg/logger"
 	"github.com/PritOriginal/problem-map-server/pkg/responses"
+	jwt "github.com/appleboy/gin-jwt/v3"
 	"github.com/gin-gonic/gin"
 )
 
 type Users interface {
 	GetUserById(ctx context.Context, id int) (models.User, error)
-	GetUsers(ctx context.Context) ([]models.User, error)
+	GetUsers(ctx context.Context, pagination models.Pagination) ([]models.User, string, error)
+	GetSessions(ctx context.Context, userId int, currentSessionId string) ([]models.Session, error)
+	DeleteSession(ctx context.Context, userId int, sessionId string) error
 }
 
 type handler struct {
@@ -23,13 +28,18 @@ type handler struct {
 	uc  Users
 }
 
-func Register(r *gin.Engine, log *slog.Logger, uc Users) {
+func Register(r *gin.Engine, log *slog.Logger, authMiddleware *jwt.GinJWTMiddleware, uc Users) {
 	handler := &handler{log: log, uc: uc}
 
 	users := r.Group("/users")
 	{
 		users.GET("", handler.GetUsers())
 		users.GET(":id", handler.GetUserById())
+		sessions := users.Group("me/sessions", authMiddleware.MiddlewareFunc())
+		{
+			sessions.GET("", handler.GetSessions())
+			sessions.DELETE(":sessionId", handler.DeleteSession())
+		}
 	}
 }
 
@@ -78,12 +88,29 @@ func (h *handler) GetUserById() gin.HandlerFunc {
 //	@Description	get users
 //	@Tags			users
 //	@Produce		json
-//	@Success		200	{object}	responses.Response[usersrest.GetUsersResponse]
-//	@Failure		500	{object}	responses.Response[any]
+//	@Param			limit	query		int		false	"max number of users in the page (capped by the server)"
+//	@Param			cursor	query		string	false	"cursor to the next page"
+//	@Param			order	query		string	false	"sort order by id"	Enums(asc, desc)
+//	@Success		200		{object}	responses.Response[usersrest.GetUsersResponse]
+//	@Failure		400		{object}	responses.Response[any]
+//	@Failure		500		{object}	responses.Response[any]
 //	@Router			/users [get]
 func (h *handler) GetUsers() gin.HandlerFunc {
 	return func(c *gin.Context) {
-		users, err := h.uc.GetUsers(c.Request.Context())
+		var query request.PaginationQuery
+		if err := c.ShouldBindQuery(&query); err != nil {
+			h.log.Debug("failed parse pagination", logger.Err(err))
+			responses.BadRequest(c, "invalid pagination")
+			return
+		}
+		pagination, err := models.UsersSorting.NewPagination(query.Limit, query.Cursor, query.Sort, query.Order)
+		if err != nil {
+			h.log.Debug("invalid pagination", logger.Err(err))
+			responses.BadRequest(c, "invalid pagination")
+			return
+		}
+
+		users, nextCursor, err := h.uc.GetUsers(c.Request.Context(), pagination)
 		if err != nil {
 			h.log.Error("error get users", logger.Err(err))
 			responses.Internal(c, "error get users")
@@ -91,7 +118,84 @@ func (h *handler) GetUsers() gin.HandlerFunc {
 		}
 
 		responses.OK(c, GetUsersResponse{
-			Users: users,
+			Users:      users,
+			NextCursor: nextCursor,
+		})
+	}
+}
+
+// GetSessions lists the sessions of the user
+//
+//	@Summary		List own sessions
+//	@Description	get the active sessions of the user ordered by the last use, the session of the access token is marked as current
+//	@Tags			users
+//	@Produce		json
+//	@Param			Authorization	header		string	true	"Insert your access token"	default(Bearer <Add access token here>)
+//	@Success		200				{object}	responses.Response[usersrest.GetSessionsResponse]
+//	@Failure		401				{object}	responses.Response[any]
+//	@Failure		500				{object}	responses.Response[any]
+//	@Router			/users/me/sessions [get]
+func (h *handler) GetSessions() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userId, err := request.GetUserId(c)
+		if err != nil {
+			h.log.Debug("invalid token", logger.Err(err))
+			responses.Unauthorized(c, "invalid token")
+			return
+		}
+
+		sessions, err := h.uc.GetSessions(c.Request.Context(), userId, request.GetSessionId(c))
+		if err != nil {
+			h.log.Error("error get sessions", slog.Int("user_id", userId), logger.Err(err))
+			responses.Internal(c, "error get sessions")
+			return
+		}
+
+		responses.OK(c, GetSessionsResponse{
+			Sessions: sessions,
+		})
+	}
+}
+
+// DeleteSession revokes the session of the user
+//
+//	@Summary		Revoke own session
+//	@Description	revoke the session of the user, its refresh token can't be used anymore, the access tokens issued for it stay valid until they expire
+//	@Tags			users
+//	@Produce		json
+//	@Param			Authorization	header		string	true	"Insert your access token"	default(Bearer <Add access token here>)
+//	@Param			sessionId		path		string	true	"session id"
+//	@Success		200				{object}	responses.Response[usersrest.DeleteSessionResponse]
+//	@Failure		401				{object}	responses.Response[any]
+//	@Failure		404				{object}	responses.Response[any]
+//	@Failure		500				{object}	responses.Response[any]
+//	@Router			/users/me/sessions/{sessionId} [delete]
+func (h *handler) DeleteSession() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		sessionId := c.Param("sessionId")
+
+		userId, err := request.GetUserId(c)
+		if err != nil {
+			h.log.Debug("invalid token", logger.Err(err))
+			responses.Unauthorized(c, "invalid token")
+			return
+		}
+
+		if err := h.uc.DeleteSession(c.Request.Context(), userId, sessionId); err != nil {
+			switch {
+			case errors.Is(err, usecase.ErrNotFound):
+				h.log.Debug("session not found", slog.Int("user_id", userId), slog.String("session_id", sessionId))
+				responses.NotFound(c, "session not found")
+			default:
+				h.log.Error("error delete session", slog.Int("user_id", userId), logger.Err(err))
+				responses.Internal(c, "error delete session")
+			}
+			return
+		}
+
+		h.log.Info("session has been revoked", slog.Int("user_id", userId), slog.String("session_id", sessionId))
+		responses.OK(c, DeleteSessionResponse{
+			SessionId: sessionId,
 		})
 	}
 }
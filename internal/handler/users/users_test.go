@@ -4,11 +4,15 @@ import (
 	"errors"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	usersrest "github.com/PritOriginal/problem-map-server/internal/handler/users"
 	"github.com/PritOriginal/problem-map-server/internal/models"
 	"github.com/PritOriginal/problem-map-server/internal/storage"
+	"github.com/PritOriginal/problem-map-server/internal/usecase"
 	"github.com/PritOriginal/problem-map-server/pkg/logger/slogdiscard"
+	"github.com/PritOriginal/problem-map-server/pkg/token"
+	jwt "github.com/appleboy/gin-jwt/v3"
 	"github.com/gin-gonic/gin"
 	mock "github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
@@ -21,6 +25,17 @@ type UsersSuite struct {
 }
 
 func (suite *UsersSuite) SetupSuite() {
+	authMiddleware, err := jwt.New(&jwt.GinJWTMiddleware{
+		Key: []byte("1234"),
+	})
+	if err != nil {
+		panic(err)
+	}
+	errInit := authMiddleware.MiddlewareInit()
+	if errInit != nil {
+		panic(errInit)
+	}
+
 	suite.uc = usersrest.NewMockUsers(suite.T())
 
 	log := slogdiscard.NewDiscardLogger()
@@ -28,7 +43,7 @@ func (suite *UsersSuite) SetupSuite() {
 	gin.SetMode(gin.TestMode)
 	suite.r = gin.New()
 
-	usersrest.Register(suite.r, log, suite.uc)
+	usersrest.Register(suite.r, log, authMiddleware, suite.uc)
 }
 
 func TestUsers(t *testing.T) {
@@ -91,9 +106,11 @@ func (suite *UsersSuite) TestGetUserById() {
 
 func (suite *UsersSuite) TestGetUsers() {
 	tests := []struct {
-		name        string
-		errGetUsers error
-		statusCode  int
+		name              string
+		query             string
+		wantErrPagination bool
+		errGetUsers       error
+		statusCode        int
 	}{
 		{
 			name:        "Ok",
@@ -105,14 +122,27 @@ func (suite *UsersSuite) TestGetUsers() {
 			errGetUsers: errors.New(""),
 			statusCode:  500,
 		},
+		{
+			name:       "Ok",
+			query:      "?limit=10&order=desc",
+			statusCode: 200,
+		},
+		{
+			name:              "Err400",
+			query:             "?cursor=invalid",
+			wantErrPagination: true,
+			statusCode:        400,
+		},
 	}
 	for _, tt := range tests {
 		suite.Run(tt.name, func() {
-			suite.uc.On("GetUsers", mock.Anything).Once().
-				Return([]models.User{}, tt.errGetUsers)
+			if !tt.wantErrPagination {
+				suite.uc.On("GetUsers", mock.Anything, mock.AnythingOfType("models.Pagination")).Once().
+					Return([]models.User{}, "", tt.errGetUsers)
+			}
 
 			w := httptest.NewRecorder()
-			req := httptest.NewRequest("GET", "/users", nil)
+			req := httptest.NewRequest("GET", "/users"+tt.query, nil)
 
 			suite.r.ServeHTTP(w, req)
 
@@ -120,3 +150,97 @@ func (suite *UsersSuite) TestGetUsers() {
 		})
 	}
 }
+
+func (suite *UsersSuite) newRequest(method, target string, authorized bool) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+
+	req := httptest.NewRequest(method, target, nil)
+	if authorized {
+		accessToken, err := token.CreateTokenWithClaims(1*time.Minute, 1, "1234", map[string]any{
+			token.SessionClaim: "s1",
+		})
+		suite.NoError(err)
+		req.Header.Set("Authorization", "Bearer "+accessToken)
+	}
+
+	suite.r.ServeHTTP(w, req)
+
+	return w
+}
+
+func (suite *UsersSuite) TestGetSessions() {
+	tests := []struct {
+		name           string
+		unauthorized   bool
+		errGetSessions error
+		statusCode     int
+	}{
+		{
+			name:       "Ok200",
+			statusCode: 200,
+		},
+		{
+			name:         "Err401",
+			unauthorized: true,
+			statusCode:   401,
+		},
+		{
+			name:           "Err500",
+			errGetSessions: errors.New(""),
+			statusCode:     500,
+		},
+	}
+	for _, tt := range tests {
+		suite.Run(tt.name, func() {
+			if !tt.unauthorized {
+				suite.uc.On("GetSessions", mock.Anything, 1, "s1").Once().
+					Return([]models.Session{}, tt.errGetSessions)
+			}
+
+			w := suite.newRequest("GET", "/users/me/sessions", !tt.unauthorized)
+
+			suite.Equal(tt.statusCode, w.Code)
+		})
+	}
+}
+
+func (suite *UsersSuite) TestDeleteSession() {
+	tests := []struct {
+		name             string
+		unauthorized     bool
+		errDeleteSession error
+		statusCode       int
+	}{
+		{
+			name:       "Ok200",
+			statusCode: 200,
+		},
+		{
+			name:         "Err401",
+			unauthorized: true,
+			statusCode:   401,
+		},
+		{
+			name:             "Err404",
+			errDeleteSession: usecase.ErrNotFound,
+			statusCode:       404,
+		},
+		{
+			name:             "Err500",
+			errDeleteSession: errors.New(""),
+			statusCode:       500,
+		},
+	}
+	for _, tt := range tests {
+		suite.Run(tt.name, func() {
+			if !tt.unauthorized {
+				suite.uc.On("DeleteSession", mock.Anything, 1, "s2").Once().
+					Return(tt.errDeleteSession)
+			}
+
+			w := suite.newRequest("DELETE", "/users/me/sessions/s2", !tt.unauthorized)
+
+			suite.Equal(tt.statusCode, w.Code)
+		})
+	}
+}
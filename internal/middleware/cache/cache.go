@@ -17,12 +17,17 @@ type Cacher interface {
 }
 
 func New(cacher Cacher, ttl time.Duration) gin.HandlerFunc {
+	return NewWithContentType(cacher, ttl, "application/json")
+}
+
+// NewWithContentType caches responses that are served with the given content type instead of JSON
+func NewWithContentType(cacher Cacher, ttl time.Duration, contentType string) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		cacheKey := fmt.Sprintf("http:%s:%s", c.Request.Method, c.Request.URL.String())
 
 		cachedResponse, err := cacher.GetBytes(c.Request.Context(), cacheKey)
 		if err == nil {
-			c.Data(http.StatusOK, "application/json", cachedResponse)
+			c.Data(http.StatusOK, contentType, cachedResponse)
 			c.Abort()
 			return
 		}
@@ -0,0 +1,88 @@
+package rbac
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/PritOriginal/problem-map-server/internal/models"
+	"github.com/PritOriginal/problem-map-server/pkg/responses"
+	"github.com/PritOriginal/problem-map-server/pkg/token"
+	jwt "github.com/appleboy/gin-jwt/v3"
+	"github.com/gin-gonic/gin"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// RequireRole allows the request only to the users with the role or a role including it.
+// It must run after the JWT middleware.
+func RequireRole(role models.UserRole) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userRole, err := roleFromClaims(jwt.ExtractClaims(c))
+		if err != nil {
+			responses.Unauthorized(c, "invalid token")
+			c.Abort()
+			return
+		}
+		if !userRole.Includes(role) {
+			responses.Forbidden(c, "insufficient permissions")
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// UnaryServerInterceptor checks the role of the access token of the methods listed in permissions,
+// other methods don't require authorization
+func UnaryServerInterceptor(accessKeys *token.KeySet, permissions map[string]models.UserRole) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		role, ok := permissions[info.FullMethod]
+		if !ok {
+			return handler(ctx, req)
+		}
+
+		userRole, err := roleFromMetadata(ctx, accessKeys)
+		if err != nil {
+			return nil, status.Error(codes.Unauthenticated, "invalid token")
+		}
+		if !userRole.Includes(role) {
+			return nil, status.Error(codes.PermissionDenied, "insufficient permissions")
+		}
+
+		return handler(ctx, req)
+	}
+}
+
+func roleFromMetadata(ctx context.Context, accessKeys *token.KeySet) (models.UserRole, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	authorization := md.Get("authorization")
+	if len(authorization) == 0 {
+		return 0, errors.New("missing token")
+	}
+
+	claims, err := accessKeys.ParseClaims(strings.TrimPrefix(authorization[0], "Bearer "))
+	if err != nil {
+		return 0, err
+	}
+
+	return roleFromClaims(claims)
+}
+
+// roleFromClaims returns the role from the token claims, tokens without the role claim belong to citizens
+func roleFromClaims(claims map[string]any) (models.UserRole, error) {
+	roleClaim, ok := claims[token.RoleClaim]
+	if !ok {
+		return models.CitizenRole, nil
+	}
+
+	roleName, ok := roleClaim.(string)
+	if !ok {
+		return 0, errors.New("invalid role claim")
+	}
+
+	return models.ParseUserRole(roleName)
+}
@@ -0,0 +1,93 @@
+package rbac_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/PritOriginal/problem-map-server/internal/middleware/rbac"
+	"github.com/PritOriginal/problem-map-server/internal/models"
+	"github.com/PritOriginal/problem-map-server/pkg/token"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func TestUnaryServerInterceptor(t *testing.T) {
+	const key = "1234"
+	const restrictedMethod = "/tasks.Tasks/AddTask"
+
+	interceptor := rbac.UnaryServerInterceptor(token.NewHMACKeySet(key), map[string]models.UserRole{
+		restrictedMethod: models.ModeratorRole,
+	})
+
+	tokenWithRole := func(role models.UserRole) string {
+		accessToken, err := token.CreateTokenWithClaims(time.Minute, 1, key, map[string]any{
+			token.RoleClaim: role.String(),
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return "Bearer " + accessToken
+	}
+
+	tests := []struct {
+		name          string
+		method        string
+		authorization string
+		want          codes.Code
+	}{
+		{
+			name:   "Ok-PublicMethod",
+			method: "/tasks.Tasks/GetTasks",
+			want:   codes.OK,
+		},
+		{
+			name:          "Ok-Moderator",
+			method:        restrictedMethod,
+			authorization: tokenWithRole(models.ModeratorRole),
+			want:          codes.OK,
+		},
+		{
+			name:          "Ok-Admin",
+			method:        restrictedMethod,
+			authorization: tokenWithRole(models.AdminRole),
+			want:          codes.OK,
+		},
+		{
+			name:   "Err-MissingToken",
+			method: restrictedMethod,
+			want:   codes.Unauthenticated,
+		},
+		{
+			name:          "Err-InvalidToken",
+			method:        restrictedMethod,
+			authorization: "Bearer invalid",
+			want:          codes.Unauthenticated,
+		},
+		{
+			name:          "Err-Citizen",
+			method:        restrictedMethod,
+			authorization: tokenWithRole(models.CitizenRole),
+			want:          codes.PermissionDenied,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tt.authorization != "" {
+				ctx = metadata.NewIncomingContext(ctx, metadata.Pairs("authorization", tt.authorization))
+			}
+
+			handler := func(ctx context.Context, req any) (any, error) {
+				return nil, nil
+			}
+			_, err := interceptor(ctx, nil, &grpc.UnaryServerInfo{FullMethod: tt.method}, handler)
+
+			if got := status.Code(err); got != tt.want {
+				t.Errorf("interceptor() code = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,17 @@
+package models
+
+import "time"
+
+// AttemptsLimit is the number of the free attempts counted by the key, every attempt over them locks the key out
+type AttemptsLimit struct {
+	Key          string
+	FreeAttempts int
+}
+
+// Backoff is the lockout after the attempts over the free ones, it starts with BaseDelay and doubles
+// with every next attempt up to MaxDelay. The attempts are counted for Window since the last one.
+type Backoff struct {
+	BaseDelay time.Duration
+	MaxDelay  time.Duration
+	Window    time.Duration
+}
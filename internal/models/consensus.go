@@ -0,0 +1,58 @@
+package models
+
+import (
+	"time"
+
+	"github.com/guregu/null/v6"
+)
+
+// ConsensusRule configures when the checks of the current mark status are enough to change it
+type ConsensusRule struct {
+	// Threshold is the net weight of the checks for or against needed to change the status
+	Threshold float64 `json:"threshold" yaml:"threshold"`
+	// MinVotes is the min number of the checks, 0 disables it
+	MinVotes int `json:"min_votes" yaml:"min_votes"`
+	// MinDistinctUsers is the min number of the users who checked the mark, 0 disables it
+	MinDistinctUsers int `json:"min_distinct_users" yaml:"min_distinct_users"`
+	// Ratio is the min share of the winning side in the weight of all checks, 0 disables it
+	Ratio float64 `json:"ratio" yaml:"ratio"`
+	// HalfLife is the age of the check at which its weight halves, 0 disables the time decay
+	HalfLife time.Duration `json:"half_life" yaml:"half_life"`
+	// ReputationWeighted weights the checks by the rating of their users
+	ReputationWeighted bool `json:"reputation_weighted" yaml:"reputation_weighted"`
+	// FlaggedWeight scales the weight of the flagged checks, 0 ignores them
+	FlaggedWeight float64 `json:"flagged_weight" yaml:"flagged_weight"`
+}
+
+type ConsensusDecision string
+
+const (
+	NoDecision      ConsensusDecision = "none"
+	ConfirmDecision ConsensusDecision = "confirm"
+	RejectDecision  ConsensusDecision = "reject"
+)
+
+// ConsensusResult is the outcome of the voting on the current mark status
+type ConsensusResult struct {
+	Decision      ConsensusDecision `json:"decision"`
+	Votes         int               `json:"votes"`
+	DistinctUsers int               `json:"distinct_users"`
+	WeightFor     float64           `json:"weight_for"`
+	WeightAgainst float64           `json:"weight_against"`
+	// Score is the net weight of the checks, positive for confirming
+	Score float64 `json:"score"`
+	// Ratio is the share of the leading side in the weight of all checks
+	Ratio float64 `json:"ratio"`
+	// Reasons explain the decision
+	Reasons []string `json:"reasons"`
+}
+
+// ConsensusExplanation explains why the mark status would or would not change
+type ConsensusExplanation struct {
+	MarkID          int                        `json:"mark_id"`
+	MarkTypeID      int                        `json:"mark_type_id"`
+	MarkStatusID    MarkStatusType             `json:"mark_status_id"`
+	NewMarkStatusID null.Value[MarkStatusType] `json:"new_mark_status_id"`
+	Rule            ConsensusRule              `json:"rule"`
+	ConsensusResult
+}
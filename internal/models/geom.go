@@ -3,6 +3,7 @@ package models
 import (
 	"database/sql/driver"
 	"fmt"
+	"math"
 
 	pb "github.com/PritOriginal/problem-map-protos/gen/go"
 	"github.com/twpayne/go-geom"
@@ -61,6 +62,17 @@ func (p *Point) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
+const earthRadius = 6371000
+
+// Distance returns the great-circle distance to the other point in metres
+func (p *Point) Distance(other *Point) float64 {
+	lon1, lat1 := p.Ewkb.Coords().X()*math.Pi/180, p.Ewkb.Coords().Y()*math.Pi/180
+	lon2, lat2 := other.Ewkb.Coords().X()*math.Pi/180, other.Ewkb.Coords().Y()*math.Pi/180
+
+	a := math.Pow(math.Sin((lat2-lat1)/2), 2) + math.Cos(lat1)*math.Cos(lat2)*math.Pow(math.Sin((lon2-lon1)/2), 2)
+	return 2 * earthRadius * math.Asin(math.Sqrt(a))
+}
+
 func (p *Point) ToProtobufObject() *pb.Point {
 	return &pb.Point{
 		Type: "Point",
@@ -179,3 +191,69 @@ func (p *MultiPolygon) UnmarshalJSON(data []byte) error {
 
 	return nil
 }
+
+type BBox struct {
+	MinLon float64
+	MinLat float64
+	MaxLon float64
+	MaxLat float64
+}
+
+// NewBBox creates a bounding box from coordinates in the order min lon, min lat, max lon, max lat
+func NewBBox(coords []float64) (*BBox, error) {
+	if len(coords) != 4 {
+		return nil, fmt.Errorf("bbox must contain 4 coordinates")
+	}
+	for _, coord := range coords {
+		// the comparisons with NaN are false, so it would pass the range checks below
+		if math.IsNaN(coord) || math.IsInf(coord, 0) {
+			return nil, fmt.Errorf("bbox coordinates must be finite numbers")
+		}
+	}
+
+	bbox := &BBox{
+		MinLon: coords[0],
+		MinLat: coords[1],
+		MaxLon: coords[2],
+		MaxLat: coords[3],
+	}
+
+	if bbox.MinLon < -180 || bbox.MaxLon > 180 || bbox.MinLat < -90 || bbox.MaxLat > 90 {
+		return nil, fmt.Errorf("bbox coordinates out of range")
+	}
+	if bbox.MinLon > bbox.MaxLon || bbox.MinLat > bbox.MaxLat {
+		return nil, fmt.Errorf("bbox min coordinates are greater than max coordinates")
+	}
+
+	return bbox, nil
+}
+
+type TileLayer string
+
+const (
+	TileLayerMarks           TileLayer = "marks"
+	TileLayerAdminBoundaries TileLayer = "admin_boundaries"
+)
+
+// MaxTileZoom is the max zoom level for which vector tiles are generated
+const MaxTileZoom = 22
+
+// Tile identifies a tile of the web mercator tile grid
+type Tile struct {
+	Z int
+	X int
+	Y int
+}
+
+// NewTile creates a tile and checks that its coordinates exist on the zoom level
+func NewTile(z, x, y int) (*Tile, error) {
+	if z < 0 || z > MaxTileZoom {
+		return nil, fmt.Errorf("tile zoom out of range")
+	}
+	n := 1 << z
+	if x < 0 || x >= n || y < 0 || y >= n {
+		return nil, fmt.Errorf("tile coordinates out of range")
+	}
+
+	return &Tile{Z: z, X: x, Y: y}, nil
+}
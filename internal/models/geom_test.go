@@ -1,6 +1,7 @@
 package models
 
 import (
+	"math"
 	"reflect"
 	"testing"
 
@@ -143,3 +144,140 @@ func TestMultiPolygon_MarshalJSON(t *testing.T) {
 		t.Errorf("MultiPolygons not equal")
 	}
 }
+
+func TestNewBBox(t *testing.T) {
+	tests := []struct {
+		name    string
+		coords  []float64
+		want    *BBox
+		wantErr bool
+	}{
+		{
+			name:   "Ok",
+			coords: []float64{41.3, 52.6, 41.6, 52.8},
+			want:   &BBox{MinLon: 41.3, MinLat: 52.6, MaxLon: 41.6, MaxLat: 52.8},
+		},
+		{
+			name:    "ErrCount",
+			coords:  []float64{41.3, 52.6, 41.6},
+			wantErr: true,
+		},
+		{
+			name:    "ErrRange",
+			coords:  []float64{-181, 52.6, 41.6, 52.8},
+			wantErr: true,
+		},
+		{
+			name:    "ErrMinGreaterMax",
+			coords:  []float64{41.6, 52.6, 41.3, 52.8},
+			wantErr: true,
+		},
+		{
+			name:    "ErrNaN",
+			coords:  []float64{math.NaN(), 52.6, 41.6, 52.8},
+			wantErr: true,
+		},
+		{
+			name:    "ErrInf",
+			coords:  []float64{41.3, 52.6, 41.6, math.Inf(1)},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewBBox(tt.coords)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NewBBox() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("NewBBox() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewTile(t *testing.T) {
+	tests := []struct {
+		name    string
+		z, x, y int
+		want    *Tile
+		wantErr bool
+	}{
+		{
+			name: "Ok",
+			z:    12, x: 2519, y: 1343,
+			want: &Tile{Z: 12, X: 2519, Y: 1343},
+		},
+		{
+			name: "OkZeroZoom",
+			z:    0, x: 0, y: 0,
+			want: &Tile{Z: 0, X: 0, Y: 0},
+		},
+		{
+			name: "ErrZoom",
+			z:    23, x: 0, y: 0,
+			wantErr: true,
+		},
+		{
+			name: "ErrCoords",
+			z:    2, x: 4, y: 0,
+			wantErr: true,
+		},
+		{
+			name: "ErrNegative",
+			z:    2, x: 0, y: -1,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewTile(tt.z, tt.x, tt.y)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NewTile() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("NewTile() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPoint_Distance(t *testing.T) {
+	tests := []struct {
+		name  string
+		a, b  geom.Coord
+		want  float64
+		delta float64
+	}{
+		{
+			name: "Same",
+			a:    geom.Coord{41.463077, 52.718319},
+			b:    geom.Coord{41.463077, 52.718319},
+			want: 0,
+		},
+		{
+			name:  "Latitude",
+			a:     geom.Coord{41.463077, 52.718319},
+			b:     geom.Coord{41.463077, 52.719319},
+			want:  111.2,
+			delta: 0.1,
+		},
+		{
+			name:  "Longitude",
+			a:     geom.Coord{41.463077, 52.718319},
+			b:     geom.Coord{41.464077, 52.718319},
+			want:  67.4,
+			delta: 0.1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := NewPoint(tt.a).Distance(NewPoint(tt.b))
+			if math.Abs(got-tt.want) > tt.delta {
+				t.Errorf("Point.Distance() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
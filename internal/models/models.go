@@ -34,6 +34,22 @@ type GetAdminBoundaryMarksCountFilters struct {
 	MarkTypeIds []int
 }
 
+type MarkCluster struct {
+	Geom             *Point `json:"geom" db:"geom"`
+	TotalCount       int    `json:"total_count" db:"total_count"`
+	UnconfirmedCount int    `json:"unconfirmed_count" db:"unconfirmed_count"`
+	ConfirmedCount   int    `json:"confirmed_count" db:"confirmed_count"`
+	UnderReviewCount int    `json:"under_review_count" db:"under_review_count"`
+	ClosedCount      int    `json:"closed_count" db:"closed_count"`
+}
+
+type GetMarkClustersFilters struct {
+	BBox        BBox
+	Zoom        int
+	GridSize    float64
+	MarkTypeIds []int
+}
+
 type Region struct {
 	ID   int      `json:"region_id" db:"region_id"`
 	Name string   `json:"name"`
@@ -103,9 +119,66 @@ func (m *Mark) ToProtobufObject() *pb.Mark {
 	}
 }
 
+func (m Mark) SortKey(field SortField) (string, int) {
+	switch field {
+	case SortByUpdatedAt:
+		return timeSortKey(m.UpdatedAt), m.ID
+	default:
+		return timeSortKey(m.CreatedAt), m.ID
+	}
+}
+
 type GetMarksFilters struct {
 	MarkTypeIds   []int
 	MarkStatusIds []int
+	BBox          *BBox
+	Pagination    Pagination
+}
+
+type GetNearbyMarksFilters struct {
+	Longitude     float64
+	Latitude      float64
+	Radius        float64
+	MarkTypeIds   []int
+	MarkStatusIds []int
+	// CreatedAfter limits the search to the marks created after it, the zero value disables the limit
+	CreatedAfter time.Time
+	Pagination   Pagination
+}
+
+type NearbyMark struct {
+	Mark
+	Distance float64 `json:"distance" db:"distance"`
+}
+
+func (m NearbyMark) SortKey(field SortField) (string, int) {
+	return floatSortKey(m.Distance), m.ID
+}
+
+type SearchMarksFilters struct {
+	Query         string
+	MarkTypeIds   []int
+	MarkStatusIds []int
+	BBox          *BBox
+	Pagination    Pagination
+}
+
+// FoundMark is a mark matched by the full-text search, its rank sums the ranks
+// of the mark description and the best matching check comment
+type FoundMark struct {
+	Mark
+	Rank float64 `json:"rank" db:"rank"`
+}
+
+func (m FoundMark) SortKey(field SortField) (string, int) {
+	return floatSortKey(m.Rank), m.ID
+}
+
+// MarkUpdate holds the mark fields that can be edited by the author, nil fields are left unchanged
+type MarkUpdate struct {
+	Description *string
+	MarkTypeID  *int
+	Geom        *Point
 }
 
 type MarkType struct {
@@ -131,6 +204,11 @@ const (
 	RefutedStatus
 )
 
+// IsTerminal reports whether the mark can't change the status anymore
+func (s MarkStatusType) IsTerminal() bool {
+	return s == ClosedStatus || s == RefutedStatus
+}
+
 type MarkStatus struct {
 	ID       int      `json:"mark_status_id" db:"mark_status_id"`
 	ParentId null.Int `json:"parent_id" db:"parent_id"`
@@ -151,24 +229,50 @@ type MarkStatusHistoryItem struct {
 	NewMarkStatusID MarkStatusType             `json:"new_mark_status_id" db:"new_mark_status_id"`
 	ChangedAt       time.Time                  `json:"changed_at" db:"changed_at"`
 	PrevId          null.Int                   `json:"prev_id" db:"prev_id"`
+	MergedMarkId    null.Int                   `json:"merged_mark_id" db:"merged_mark_id"`
 
 	Checks []Check `json:"checks"`
 }
 
+// Check is the user's vote on the current mark status.
+// Location is where the user was when checking, Distance is the distance from it to the mark in metres.
+// Flagged checks were made too far from the mark or without the required location, their weight is reduced.
 type Check struct {
 	ID                      int            `json:"check_id" db:"check_id"`
 	UserID                  int            `json:"user_id" db:"user_id"`
 	Username                string         `json:"username" db:"username"`
+	UserRating              int            `json:"-" db:"user_rating"`
 	MarkID                  int            `json:"mark_id" db:"mark_id"`
 	MarkStatusId            MarkStatusType `json:"mark_status_id" db:"mark_status_id"`
 	MarkStatusHistoryItemId int            `json:"mark_status_history_id" db:"mark_status_history_id"`
 	Result                  bool           `json:"result" db:"result"`
 	Comment                 string         `json:"comment" db:"comment"`
-	Photos                  []string       `json:"photos"`
+	Photos                  []PhotoURLs    `json:"photos"`
+	Location                *Point         `json:"location" db:"location"`
+	Distance                null.Float     `json:"distance" db:"distance"`
+	Flagged                 bool           `json:"flagged" db:"flagged"`
 	CreatedAt               time.Time      `json:"created_at" db:"created_at"`
 	UpdatedAt               time.Time      `json:"updated_at" db:"updated_at"`
 }
 
+func (c Check) SortKey(field SortField) (string, int) {
+	switch field {
+	case SortByUpdatedAt:
+		return timeSortKey(c.UpdatedAt), c.ID
+	default:
+		return timeSortKey(c.CreatedAt), c.ID
+	}
+}
+
+// photoURLs returns the URLs of the original photos
+func (c *Check) photoURLs() []string {
+	urls := make([]string, len(c.Photos))
+	for i, photo := range c.Photos {
+		urls[i] = photo.Original
+	}
+	return urls
+}
+
 func (c *Check) ToProtobufObject() *pb.Check {
 	return &pb.Check{
 		Id:        int64(c.ID),
@@ -177,7 +281,7 @@ func (c *Check) ToProtobufObject() *pb.Check {
 		MarkId:    int64(c.MarkID),
 		Result:    c.Result,
 		Comment:   c.Comment,
-		Photos:    c.Photos,
+		Photos:    c.photoURLs(),
 		CreatedAt: timestamppb.New(c.CreatedAt),
 		UpdatedAt: timestamppb.New(c.UpdatedAt),
 	}
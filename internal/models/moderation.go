@@ -0,0 +1,68 @@
+package models
+
+import (
+	"time"
+
+	"github.com/guregu/null/v6"
+)
+
+// ModerationReason explains why the mark needs a moderator decision
+type ModerationReason string
+
+const (
+	// SplitVotesReason means the checks of the current status disagree
+	SplitVotesReason ModerationReason = "split_votes"
+	// StalledReason means there were no checks or status changes for a long time
+	StalledReason ModerationReason = "stalled"
+	// DuplicatePhotosReason means a check of the current status has a photo near-identical to a photo of another check
+	DuplicatePhotosReason ModerationReason = "duplicate_photos"
+)
+
+type ModerationQueueItem struct {
+	Mark
+	StatusChangedAt   time.Time          `json:"status_changed_at" db:"status_changed_at"`
+	LastActivityAt    time.Time          `json:"last_activity_at" db:"last_activity_at"`
+	VotesFor          int                `json:"votes_for" db:"votes_for"`
+	VotesAgainst      int                `json:"votes_against" db:"votes_against"`
+	PopulationDensity float64            `json:"population_density" db:"population_density"`
+	DuplicatePhotos   bool               `json:"duplicate_photos" db:"duplicate_photos"`
+	ClaimedBy         null.Int           `json:"claimed_by" db:"claimed_by"`
+	ClaimExpiresAt    null.Time          `json:"claim_expires_at" db:"claim_expires_at"`
+	Reasons           []ModerationReason `json:"reasons"`
+	Priority          float64            `json:"priority" db:"priority"`
+}
+
+func (i ModerationQueueItem) SortKey(field SortField) (string, int) {
+	return floatSortKey(i.Priority), i.ID
+}
+
+type GetModerationQueueFilters struct {
+	MarkStatusIds []int
+	// ModeratorId is the moderator whose claimed marks stay in the queue, marks claimed by others are skipped
+	ModeratorId int
+	// StalledBefore is the time of the last activity before which the voting is stalled
+	StalledBefore time.Time
+	// MaxCandidates caps the marks the queue is prioritized from, the marks with the oldest status go first
+	MaxCandidates int
+	Priority      ModerationPriority
+	Pagination    Pagination
+}
+
+// ModerationPriority holds the parameters of the queue priority. The priority is the sum of the age
+// of the mark status, the votes disagreement, the mark type, the population density and the duplicate
+// photos parts, each from 0 to 1.
+type ModerationPriority struct {
+	// MaxAge is the age of the mark status with the full age part, the age is taken at Pagination.Snapshot
+	MaxAge time.Duration
+	// MaxPopulationDensity is the population density per km² with the full density part
+	MaxPopulationDensity float64
+	// MarkTypePriorities are the parts of the mark types, missing types have 0
+	MarkTypePriorities map[int]float64
+}
+
+type ModerationClaim struct {
+	MarkID    int       `json:"mark_id" db:"mark_id"`
+	UserID    int       `json:"user_id" db:"user_id"`
+	ClaimedAt time.Time `json:"claimed_at" db:"claimed_at"`
+	ExpiresAt time.Time `json:"expires_at" db:"expires_at"`
+}
@@ -0,0 +1,174 @@
+package models
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strconv"
+	"time"
+)
+
+// MaxPageLimit is the hard cap on the number of items in one page
+const MaxPageLimit = 1000
+
+type SortField string
+
+const (
+	SortByCreatedAt SortField = "created_at"
+	SortByUpdatedAt SortField = "updated_at"
+	SortByDistance  SortField = "distance"
+	SortByRank      SortField = "rank"
+	SortById        SortField = "id"
+	SortByPriority  SortField = "priority"
+)
+
+type SortOrder string
+
+const (
+	SortAsc  SortOrder = "asc"
+	SortDesc SortOrder = "desc"
+)
+
+// Pagination describes the requested page of a keyset paginated list
+type Pagination struct {
+	Limit  int
+	SortBy SortField
+	Order  SortOrder
+	Cursor *Cursor
+	// Snapshot is the time the sort values changing with time are computed at,
+	// the cursor keeps it so the next pages are sorted as the first one
+	Snapshot time.Time
+}
+
+// Cursor points to the last item of the previous page
+type Cursor struct {
+	SortBy   SortField `json:"s"`
+	Order    SortOrder `json:"o"`
+	Value    string    `json:"v,omitempty"`
+	ID       int       `json:"i"`
+	Snapshot time.Time `json:"t,omitzero"`
+}
+
+// Encode returns the cursor as an opaque string
+func (c Cursor) Encode() string {
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// DecodeCursor parses the cursor returned by Encode
+func DecodeCursor(s string) (*Cursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, fmt.Errorf("invalid cursor: %w", err)
+	}
+
+	var cursor Cursor
+	if err := json.Unmarshal(data, &cursor); err != nil {
+		return nil, fmt.Errorf("invalid cursor: %w", err)
+	}
+
+	return &cursor, nil
+}
+
+// Paginated is implemented by the items of keyset paginated lists
+type Paginated interface {
+	// SortKey returns the value of the sort field and the unique id of the item
+	SortKey(field SortField) (string, int)
+}
+
+// Sorting lists the sort fields supported by a list, the first one is the default
+type Sorting struct {
+	Fields       []SortField
+	DefaultOrder SortOrder
+}
+
+var (
+	MarksSorting = Sorting{
+		Fields:       []SortField{SortByCreatedAt, SortByUpdatedAt},
+		DefaultOrder: SortDesc,
+	}
+	NearbyMarksSorting = Sorting{
+		Fields:       []SortField{SortByDistance},
+		DefaultOrder: SortAsc,
+	}
+	SearchMarksSorting = Sorting{
+		Fields:       []SortField{SortByRank},
+		DefaultOrder: SortDesc,
+	}
+	ChecksSorting = Sorting{
+		Fields:       []SortField{SortByCreatedAt, SortByUpdatedAt},
+		DefaultOrder: SortAsc,
+	}
+	TasksSorting = Sorting{
+		Fields:       []SortField{SortById},
+		DefaultOrder: SortAsc,
+	}
+	UsersSorting = Sorting{
+		Fields:       []SortField{SortById},
+		DefaultOrder: SortAsc,
+	}
+	ModerationQueueSorting = Sorting{
+		Fields:       []SortField{SortByPriority},
+		DefaultOrder: SortDesc,
+	}
+)
+
+// NewPagination validates the requested page, empty values fall back to the defaults of the sorting
+func (s Sorting) NewPagination(limit int, cursor, sortBy, order string) (Pagination, error) {
+	p := Pagination{
+		Limit:  limit,
+		SortBy: SortField(sortBy),
+		Order:  SortOrder(order),
+	}
+
+	if p.Limit < 0 {
+		return p, fmt.Errorf("limit must be positive")
+	}
+	if p.SortBy == "" {
+		p.SortBy = s.Fields[0]
+	}
+	if !slices.Contains(s.Fields, p.SortBy) {
+		return p, fmt.Errorf("unsupported sort field %q", sortBy)
+	}
+	if p.Order == "" {
+		p.Order = s.DefaultOrder
+	}
+	if p.Order != SortAsc && p.Order != SortDesc {
+		return p, fmt.Errorf("unsupported sort order %q", order)
+	}
+
+	if cursor != "" {
+		c, err := DecodeCursor(cursor)
+		if err != nil {
+			return p, err
+		}
+		if c.SortBy != p.SortBy || c.Order != p.Order {
+			return p, fmt.Errorf("cursor does not match the sorting")
+		}
+		p.Cursor = c
+		p.Snapshot = c.Snapshot
+	}
+
+	return p, nil
+}
+
+// NextCursor returns the cursor pointing to the item
+func (p Pagination) NextCursor(item Paginated) Cursor {
+	value, id := item.SortKey(p.SortBy)
+	return Cursor{
+		SortBy:   p.SortBy,
+		Order:    p.Order,
+		Value:    value,
+		ID:       id,
+		Snapshot: p.Snapshot,
+	}
+}
+
+func timeSortKey(t time.Time) string {
+	return t.Format(time.RFC3339Nano)
+}
+
+func floatSortKey(f float64) string {
+	return strconv.FormatFloat(f, 'g', -1, 64)
+}
@@ -0,0 +1,106 @@
+package models
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestSorting_NewPagination(t *testing.T) {
+	cursor := Cursor{SortBy: SortByUpdatedAt, Order: SortAsc, Value: "2025-01-01T00:00:00Z", ID: 5}
+
+	tests := []struct {
+		name    string
+		limit   int
+		cursor  string
+		sortBy  string
+		order   string
+		want    Pagination
+		wantErr bool
+	}{
+		{
+			name: "Defaults",
+			want: Pagination{SortBy: SortByCreatedAt, Order: SortDesc},
+		},
+		{
+			name:   "WithCursor",
+			limit:  10,
+			cursor: cursor.Encode(),
+			sortBy: "updated_at",
+			order:  "asc",
+			want:   Pagination{Limit: 10, SortBy: SortByUpdatedAt, Order: SortAsc, Cursor: &cursor},
+		},
+		{
+			name:    "NegativeLimit",
+			limit:   -1,
+			wantErr: true,
+		},
+		{
+			name:    "UnsupportedSortField",
+			sortBy:  "distance",
+			wantErr: true,
+		},
+		{
+			name:    "UnsupportedOrder",
+			order:   "up",
+			wantErr: true,
+		},
+		{
+			name:    "InvalidCursor",
+			cursor:  "invalid",
+			wantErr: true,
+		},
+		{
+			name:    "CursorOfOtherSorting",
+			cursor:  cursor.Encode(),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := MarksSorting.NewPagination(tt.limit, tt.cursor, tt.sortBy, tt.order)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Sorting.NewPagination() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Sorting.NewPagination() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPagination_NextCursor(t *testing.T) {
+	createdAt := time.Date(2025, 1, 1, 12, 30, 0, 0, time.UTC)
+	mark := Mark{ID: 7, CreatedAt: createdAt}
+
+	p := Pagination{SortBy: SortByCreatedAt, Order: SortDesc}
+	encoded := p.NextCursor(mark).Encode()
+
+	got, err := DecodeCursor(encoded)
+	if err != nil {
+		t.Fatalf("DecodeCursor() error = %v", err)
+	}
+	want := &Cursor{SortBy: SortByCreatedAt, Order: SortDesc, Value: "2025-01-01T12:30:00Z", ID: 7}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("DecodeCursor() = %+v, want %+v", got, want)
+	}
+}
+
+func TestPagination_NextCursorSnapshot(t *testing.T) {
+	snapshot := time.Date(2025, 1, 1, 12, 30, 0, 0, time.UTC)
+	item := ModerationQueueItem{Mark: Mark{ID: 7}, Priority: 1.5}
+
+	p := Pagination{SortBy: SortByPriority, Order: SortDesc, Snapshot: snapshot}
+	encoded := p.NextCursor(item).Encode()
+
+	got, err := ModerationQueueSorting.NewPagination(10, encoded, "", "")
+	if err != nil {
+		t.Fatalf("Sorting.NewPagination() error = %v", err)
+	}
+	if !got.Snapshot.Equal(snapshot) {
+		t.Errorf("Sorting.NewPagination() snapshot = %v, want %v", got.Snapshot, snapshot)
+	}
+	if got.Cursor.Value != "1.5" || got.Cursor.ID != 7 {
+		t.Errorf("Sorting.NewPagination() cursor = %+v", got.Cursor)
+	}
+}
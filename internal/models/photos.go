@@ -0,0 +1,108 @@
+package models
+
+import (
+	"io"
+	"time"
+
+	"github.com/guregu/null/v6"
+)
+
+// PhotoMetadata is the evidence taken from the EXIF and the pixels of the uploaded photo
+type PhotoMetadata struct {
+	CapturedAt  null.Time  `json:"captured_at" db:"captured_at"`
+	Latitude    null.Float `json:"latitude" db:"latitude"`
+	Longitude   null.Float `json:"longitude" db:"longitude"`
+	Orientation int        `json:"orientation" db:"orientation"`
+	// PHash is the perceptual hash of the photo (dHash) stored as the signed bits, the photos uploaded before it have none
+	PHash null.Int `json:"-" db:"phash"`
+	// Duplicate is set when the photo is near-identical to a photo of another check
+	Duplicate bool `json:"duplicate" db:"duplicate"`
+}
+
+// Photo is the uploaded photo re-encoded as JPEG without the EXIF and the metadata taken from it
+type Photo struct {
+	Data     io.Reader
+	Metadata PhotoMetadata
+}
+
+// PhotoVariant is the size of the stored photo
+type PhotoVariant string
+
+const (
+	OriginalVariant  PhotoVariant = "original"
+	MediumVariant    PhotoVariant = "medium"
+	ThumbnailVariant PhotoVariant = "thumbnail"
+)
+
+// PhotoVariants are the variants stored for every photo
+var PhotoVariants = []PhotoVariant{OriginalVariant, MediumVariant, ThumbnailVariant}
+
+// PhotoURLs are the URLs of the photo variants
+type PhotoURLs struct {
+	Original  string `json:"original"`
+	Medium    string `json:"medium"`
+	Thumbnail string `json:"thumbnail"`
+}
+
+// Set sets the URL of the variant
+func (p *PhotoURLs) Set(variant PhotoVariant, url string) {
+	switch variant {
+	case OriginalVariant:
+		p.Original = url
+	case MediumVariant:
+		p.Medium = url
+	case ThumbnailVariant:
+		p.Thumbnail = url
+	}
+}
+
+// Fill sets the URLs of the missing variants to the original one, the photos uploaded before the variants
+// have only the original
+func (p *PhotoURLs) Fill() {
+	if p.Medium == "" {
+		p.Medium = p.Original
+	}
+	if p.Thumbnail == "" {
+		p.Thumbnail = p.Original
+	}
+}
+
+// PhotoRecord is the stored file of the photo variant
+type PhotoRecord struct {
+	Key     string       `db:"key"`
+	MarkID  int          `db:"mark_id"`
+	CheckID int          `db:"check_id"`
+	Number  int          `db:"number"`
+	Variant PhotoVariant `db:"variant"`
+	Size    int64        `db:"size"`
+	Width   int          `db:"width"`
+	Height  int          `db:"height"`
+	// Hash is the hex SHA-256 of the file
+	Hash string `db:"hash"`
+}
+
+// PhotoUpload is the presigned URL the photo is uploaded by directly to the photo storage
+type PhotoUpload struct {
+	Key       string    `json:"key"`
+	URL       string    `json:"url"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// SimilarPhoto is the photo near-identical to the other one
+type SimilarPhoto struct {
+	MarkID  int `json:"mark_id" db:"mark_id"`
+	CheckID int `json:"check_id" db:"check_id"`
+	Number  int `json:"number" db:"number"`
+	// Distance is the Hamming distance between the perceptual hashes of the photos
+	Distance int       `json:"distance" db:"distance"`
+	URLs     PhotoURLs `json:"urls" db:"-"`
+}
+
+type GetSimilarPhotosFilters struct {
+	PHash int64
+	// MaxDistance is the max Hamming distance between the perceptual hashes of the similar photos
+	MaxDistance int
+	// ExcludeCheckId is the check whose photos are skipped
+	ExcludeCheckId int
+	Limit          int
+}
@@ -0,0 +1,25 @@
+package models
+
+import "time"
+
+// Session is the sign-in of the user. The refresh tokens issued by refreshing the session form its token family,
+// only the last of them is valid.
+type Session struct {
+	ID     string `json:"id"`
+	UserID int    `json:"user_id"`
+	// TokenID is the id of the last refresh token of the session
+	TokenID string `json:"-"`
+	SessionClient
+	CreatedAt time.Time `json:"created_at"`
+	// LastUsedAt is the time of the sign-in or the last refresh of the session
+	LastUsedAt time.Time `json:"last_used_at"`
+	ExpiresAt  time.Time `json:"expires_at"`
+	// Current is set for the session of the request
+	Current bool `json:"current"`
+}
+
+// SessionClient is the client the session was last used from
+type SessionClient struct {
+	UserAgent string `json:"user_agent"`
+	IP        string `json:"ip"`
+}
@@ -1,14 +1,74 @@
 package models
 
-import pb "github.com/PritOriginal/problem-map-protos/gen/go"
+import (
+	"fmt"
+
+	pb "github.com/PritOriginal/problem-map-protos/gen/go"
+)
 
 type User struct {
-	Id           int    `json:"user_id" db:"user_id"`
-	Name         string `json:"username" db:"name"`
-	Login        string `json:"login" db:"login"`
-	PasswordHash string `json:"-" db:"password_hash"`
-	HomePoint    *Point `json:"home_point" db:"home_point"`
-	Rating       int    `json:"rating" db:"rating"`
+	Id           int      `json:"user_id" db:"user_id"`
+	Name         string   `json:"username" db:"name"`
+	Login        string   `json:"login" db:"login"`
+	PasswordHash string   `json:"-" db:"password_hash"`
+	HomePoint    *Point   `json:"home_point" db:"home_point"`
+	Rating       int      `json:"rating" db:"rating"`
+	RoleID       UserRole `json:"role_id" db:"user_role_id"`
+}
+
+// UserRole is the role of the user, every next role has all permissions of the previous ones
+type UserRole int
+
+const (
+	CitizenRole UserRole = iota + 1
+	ModeratorRole
+	AdminRole
+)
+
+var userRoleNames = map[UserRole]string{
+	CitizenRole:   "citizen",
+	ModeratorRole: "moderator",
+	AdminRole:     "admin",
+}
+
+func (r UserRole) String() string {
+	return userRoleNames[r]
+}
+
+// ParseUserRole parses the role by its name
+func ParseUserRole(name string) (UserRole, error) {
+	for role, roleName := range userRoleNames {
+		if roleName == name {
+			return role, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown user role %q", name)
+}
+
+// Includes reports whether the role has all permissions of the other role
+func (r UserRole) Includes(other UserRole) bool {
+	return r >= other
+}
+
+// NeutralRating is the rating of the users without resolved checks, their checks have the weight 1
+const NeutralRating = 50
+
+// RatingWeight returns the weight of the checks of the user with the rating, from 0 to 2
+func RatingWeight(rating int) float64 {
+	return float64(rating) / NeutralRating
+}
+
+// UserCheckOutcome counts the checks of the user on a resolved mark
+type UserCheckOutcome struct {
+	UserID int
+	// Resolved is the number of the checks of statuses with a known decision
+	Resolved int
+	// Agreed is the number of the checks that agreed with the decision
+	Agreed int
+}
+
+func (u User) SortKey(field SortField) (string, int) {
+	return "", u.Id
 }
 
 func (u *User) ToProtobufObject() *pb.User {
@@ -29,6 +89,10 @@ type Task struct {
 	StatusID int    `json:"status_id" db:"status_id"`
 }
 
+func (t Task) SortKey(field SortField) (string, int) {
+	return "", t.ID
+}
+
 func (t *Task) ToProtobufObject() *pb.Task {
 	return &pb.Task{
 		Id:       int64(t.ID),
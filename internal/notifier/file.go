@@ -0,0 +1,70 @@
+package notifier
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/PritOriginal/problem-map-server/internal/models"
+)
+
+// Notification is the line of the notifications file
+type Notification struct {
+	Type      string    `json:"type"`
+	UserID    int       `json:"user_id"`
+	Login     string    `json:"login"`
+	Token     string    `json:"token"`
+	ExpiresAt time.Time `json:"expires_at"`
+	SentAt    time.Time `json:"sent_at"`
+}
+
+const PasswordResetNotification = "password_reset"
+
+// File appends the notifications to the file as JSON lines instead of delivering them,
+// it's meant for the local development and the tests
+type File struct {
+	mu   sync.Mutex
+	name string
+}
+
+func NewFile(name string) *File {
+	return &File{name: name}
+}
+
+func (n *File) SendPasswordReset(ctx context.Context, user models.User, token string, expiresAt time.Time) error {
+	return n.write(Notification{
+		Type:      PasswordResetNotification,
+		UserID:    user.Id,
+		Login:     user.Login,
+		Token:     token,
+		ExpiresAt: expiresAt,
+		SentAt:    time.Now(),
+	})
+}
+
+func (n *File) write(notification Notification) error {
+	const op = "notifier.File.write"
+
+	line, err := json.Marshal(notification)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	f, err := os.OpenFile(n.name, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	defer f.Close()
+
+	if _, err := f.Write(append(line, '\n')); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return f.Close()
+}
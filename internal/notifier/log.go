@@ -0,0 +1,29 @@
+package notifier
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/PritOriginal/problem-map-server/internal/models"
+)
+
+// Log writes the notifications to the log instead of delivering them, it's meant for the local development
+// since the log gets the secret tokens
+type Log struct {
+	log *slog.Logger
+}
+
+func NewLog(log *slog.Logger) *Log {
+	return &Log{log: log}
+}
+
+func (n *Log) SendPasswordReset(ctx context.Context, user models.User, token string, expiresAt time.Time) error {
+	n.log.Info("password reset",
+		slog.Int("user_id", user.Id),
+		slog.String("login", user.Login),
+		slog.String("token", token),
+		slog.Time("expires_at", expiresAt),
+	)
+	return nil
+}
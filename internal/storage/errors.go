@@ -5,4 +5,6 @@ import "errors"
 var (
 	ErrNotFound = errors.New("Not found")
 	ErrExists   = errors.New("Exists")
+	// ErrConflict means the stored state doesn't match the expected one
+	ErrConflict = errors.New("Conflict")
 )
@@ -2,41 +2,183 @@ package local
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"io"
+	"io/fs"
 	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/PritOriginal/problem-map-server/internal/config"
+	"github.com/PritOriginal/problem-map-server/internal/models"
+	"github.com/PritOriginal/problem-map-server/internal/storage"
 )
 
+// PhotosRoute is the route the photos are served by, the photo URL is the route followed by the photo key
+const PhotosRoute = "/photos"
+
+// PhotosRepo stores the photos in the root directory with the same keys as in S3
 type PhotosRepo struct {
+	root    string
+	baseURL string
 }
 
-func NewPhotos() *PhotosRepo {
-	return &PhotosRepo{}
+func NewPhotos(cfg config.LocalConfig) *PhotosRepo {
+	return &PhotosRepo{
+		root:    cfg.Root,
+		baseURL: strings.TrimSuffix(cfg.BaseURL, "/"),
+	}
 }
 
-func (repo *PhotosRepo) AddPhotos(ctx context.Context, markId, reviewId int, photos []io.Reader) error {
-	for _, photo := range photos {
-		file, err := os.CreateTemp("photos", "p")
+// AddPhotos stores the variants of the photos numbered from first and returns their records
+func (repo *PhotosRepo) AddPhotos(ctx context.Context, markId, checkId, first int, photos []io.Reader) ([]models.PhotoRecord, error) {
+	const op = "storage.local.AddPhotos"
+
+	var records []models.PhotoRecord
+	for i, photo := range photos {
+		files, err := storage.EncodePhotoVariants(photo)
 		if err != nil {
-			return err
+			return nil, fmt.Errorf("%s: %w", op, err)
 		}
-		defer file.Close()
 
-		if _, err := io.Copy(file, photo); err != nil {
-			return err
+		for _, file := range files {
+			record := file.Record(markId, checkId, first+i)
+			if err := repo.writeFile(record.Key, file.Data); err != nil {
+				return nil, fmt.Errorf("%s: %w", op, err)
+			}
+			records = append(records, record)
+		}
+	}
+
+	return records, nil
+}
+
+// URL returns the URL of the photo with the key
+func (repo *PhotosRepo) URL(ctx context.Context, key string) (string, error) {
+	return repo.baseURL + PhotosRoute + "/" + key, nil
+}
+
+// ListPhotos returns the keys of the photos with the prefix
+func (repo *PhotosRepo) ListPhotos(ctx context.Context, prefix string) ([]string, error) {
+	const op = "storage.local.ListPhotos"
+
+	dir, _ := path.Split(prefix)
+	keys, err := repo.listKeys(dir)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return slices.DeleteFunc(keys, func(key string) bool {
+		return !strings.HasPrefix(key, prefix)
+	}), nil
+}
+
+// GetPhoto returns the content of the photo with the key
+func (repo *PhotosRepo) GetPhoto(ctx context.Context, key string) (io.ReadCloser, error) {
+	const op = "storage.local.GetPhoto"
+
+	file, err := os.Open(repo.path(key))
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, storage.ErrNotFound
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return file, nil
+}
+
+// CopyPhotos copies the photos of the mark to the other mark, remapping the keys
+// marks/{fromMarkId}/{checkId}/n.jpg to marks/{toMarkId}/{checkId}/n.jpg.
+// It returns the keys of the copies, also the ones made before an error.
+func (repo *PhotosRepo) CopyPhotos(ctx context.Context, fromMarkId, toMarkId int) ([]string, error) {
+	const op = "storage.local.CopyPhotos"
+
+	fromDir := fmt.Sprintf("marks/%v", fromMarkId)
+	toDir := fmt.Sprintf("marks/%v", toMarkId)
+
+	keys, err := repo.listKeys(fromDir)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	var copied []string
+	for _, key := range keys {
+		data, err := os.ReadFile(repo.path(key))
+		if err != nil {
+			return copied, fmt.Errorf("%s: %w", op, err)
+		}
+		copyKey := toDir + strings.TrimPrefix(key, fromDir)
+		if err := repo.writeFile(copyKey, data); err != nil {
+			return copied, fmt.Errorf("%s: %w", op, err)
+		}
+		copied = append(copied, copyKey)
+	}
+
+	return copied, nil
+}
+
+// DeletePhotos deletes the photos with the keys, the missing photos are skipped
+func (repo *PhotosRepo) DeletePhotos(ctx context.Context, keys []string) error {
+	const op = "storage.local.DeletePhotos"
+
+	for _, key := range keys {
+		if err := os.Remove(repo.path(key)); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return fmt.Errorf("%s: %w", op, err)
 		}
 	}
 
 	return nil
 }
 
-func (repo *PhotosRepo) GetPhotos(ctx context.Context) (map[int]map[int][]string, error) {
-	return map[int]map[int][]string{}, nil
+func (repo *PhotosRepo) DeletePhotosByMarkId(ctx context.Context, markId int) error {
+	const op = "storage.local.DeletePhotosByMarkId"
+
+	if err := os.RemoveAll(repo.path(fmt.Sprintf("marks/%v", markId))); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
 }
 
-func (repo *PhotosRepo) GetPhotosByMarkId(ctx context.Context, arkId int) (map[int]map[int][]string, error) {
-	return map[int]map[int][]string{}, nil
+// listKeys returns the keys of the files in the directory and its subdirectories, the missing directory has no keys
+func (repo *PhotosRepo) listKeys(dir string) ([]string, error) {
+	var keys []string
+
+	err := filepath.WalkDir(repo.path(dir), func(name string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+
+		rel, err := filepath.Rel(repo.root, name)
+		if err != nil {
+			return err
+		}
+		keys = append(keys, filepath.ToSlash(rel))
+
+		return nil
+	})
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, err
+	}
+
+	return keys, nil
+}
+
+func (repo *PhotosRepo) writeFile(key string, data []byte) error {
+	name := repo.path(key)
+	if err := os.MkdirAll(filepath.Dir(name), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(name, data, 0o644)
 }
 
-func (repo *PhotosRepo) GetPhotosByCheckId(ctx context.Context, markId, checkId int) ([]string, error) {
-	return []string{}, nil
+func (repo *PhotosRepo) path(key string) string {
+	return filepath.Join(repo.root, filepath.FromSlash(path.Clean(key)))
 }
@@ -0,0 +1,223 @@
+package storage
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"image"
+	"image/jpeg"
+	"io"
+	"path"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/PritOriginal/problem-map-server/internal/models"
+	"github.com/PritOriginal/problem-map-server/pkg/exif"
+	"github.com/PritOriginal/problem-map-server/pkg/imaging"
+	"github.com/guregu/null/v6"
+)
+
+// PhotoVariantSizes are the max sides in pixels of the downscaled photo variants
+var PhotoVariantSizes = map[models.PhotoVariant]int{
+	models.MediumVariant:    1280,
+	models.ThumbnailVariant: 320,
+}
+
+// PhotoKey returns the key of the photo variant, marks/{markId}/{checkId}/{n}.jpg for the original
+// and marks/{markId}/{checkId}/{n}_{variant}.jpg for the others
+func PhotoKey(markId, checkId, n int, variant models.PhotoVariant) string {
+	if variant == models.OriginalVariant {
+		return fmt.Sprintf("marks/%d/%d/%d.jpg", markId, checkId, n)
+	}
+	return fmt.Sprintf("marks/%d/%d/%d_%s.jpg", markId, checkId, n, variant)
+}
+
+// ParsePhotoKey parses the key made by PhotoKey, the keys of unknown variants are invalid
+func ParsePhotoKey(key string) (markId, checkId, n int, variant models.PhotoVariant, err error) {
+	parts := strings.Split(key, "/")
+	if len(parts) != 4 || parts[0] != "marks" || path.Ext(parts[3]) != ".jpg" {
+		return 0, 0, 0, "", fmt.Errorf("invalid photo key %q", key)
+	}
+
+	if markId, err = strconv.Atoi(parts[1]); err != nil {
+		return 0, 0, 0, "", fmt.Errorf("invalid photo key %q: %w", key, err)
+	}
+	if checkId, err = strconv.Atoi(parts[2]); err != nil {
+		return 0, 0, 0, "", fmt.Errorf("invalid photo key %q: %w", key, err)
+	}
+
+	name, suffix, found := strings.Cut(strings.TrimSuffix(parts[3], ".jpg"), "_")
+	variant = models.OriginalVariant
+	if found {
+		variant = models.PhotoVariant(suffix)
+	}
+	if n, err = strconv.Atoi(name); err != nil {
+		return 0, 0, 0, "", fmt.Errorf("invalid photo key %q: %w", key, err)
+	}
+	if !slices.Contains(models.PhotoVariants, variant) || PhotoKey(markId, checkId, n, variant) != key {
+		return 0, 0, 0, "", fmt.Errorf("invalid photo key %q", key)
+	}
+
+	return markId, checkId, n, variant, nil
+}
+
+// UploadKey returns the key of the photo uploaded by the user directly to the photo storage, uploads/{userId}/{name}
+func UploadKey(userId int, name string) string {
+	return fmt.Sprintf("uploads/%d/%s", userId, name)
+}
+
+// ParseUploadKey parses the key made by UploadKey and returns the id of the user who uploaded the photo
+func ParseUploadKey(key string) (userId int, err error) {
+	parts := strings.Split(key, "/")
+	if len(parts) != 3 || parts[0] != "uploads" || parts[2] == "" || parts[2] == "." || parts[2] == ".." {
+		return 0, fmt.Errorf("invalid upload key %q", key)
+	}
+
+	if userId, err = strconv.Atoi(parts[1]); err != nil {
+		return 0, fmt.Errorf("invalid upload key %q: %w", key, err)
+	}
+
+	return userId, nil
+}
+
+// DecodePhoto decodes the uploaded image and re-encodes it as JPEG turned by the EXIF orientation.
+// The re-encoded photo has no EXIF, the capture time, GPS and orientation are kept in the photo metadata
+// along with the perceptual hash of the image.
+func DecodePhoto(data []byte) (models.Photo, error) {
+	metadata, err := exif.Decode(bytes.NewReader(data))
+	if err != nil {
+		// the photos without the EXIF or with a broken one are kept without the metadata
+		metadata = exif.Metadata{Orientation: 1}
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return models.Photo{}, err
+	}
+
+	img = exif.Orient(img, metadata.Orientation)
+
+	data, err = encodeJPEG(img)
+	if err != nil {
+		return models.Photo{}, err
+	}
+
+	return models.Photo{
+		Data: bytes.NewReader(data),
+		Metadata: models.PhotoMetadata{
+			CapturedAt:  metadata.CapturedAt,
+			Latitude:    metadata.Latitude,
+			Longitude:   metadata.Longitude,
+			Orientation: metadata.Orientation,
+			PHash:       null.IntFrom(int64(imaging.DHash(img))),
+		},
+	}, nil
+}
+
+// PhotoFile is the encoded file of the photo variant
+type PhotoFile struct {
+	Variant models.PhotoVariant
+	Data    []byte
+	Width   int
+	Height  int
+}
+
+// Record returns the record of the file stored with the key made by PhotoKey
+func (f PhotoFile) Record(markId, checkId, n int) models.PhotoRecord {
+	hash := sha256.Sum256(f.Data)
+
+	return models.PhotoRecord{
+		Key:     PhotoKey(markId, checkId, n, f.Variant),
+		MarkID:  markId,
+		CheckID: checkId,
+		Number:  n,
+		Variant: f.Variant,
+		Size:    int64(len(f.Data)),
+		Width:   f.Width,
+		Height:  f.Height,
+		Hash:    hex.EncodeToString(hash[:]),
+	}
+}
+
+// NewPhotoRecord returns the record of the photo file stored with the key made by PhotoKey
+func NewPhotoRecord(key string, data []byte) (models.PhotoRecord, error) {
+	markId, checkId, n, variant, err := ParsePhotoKey(key)
+	if err != nil {
+		return models.PhotoRecord{}, err
+	}
+
+	img, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return models.PhotoRecord{}, fmt.Errorf("invalid photo %q: %w", key, err)
+	}
+
+	file := PhotoFile{Variant: variant, Data: data, Width: img.Width, Height: img.Height}
+	return file.Record(markId, checkId, n), nil
+}
+
+// EncodePhotoVariants returns the JPEG photo as the original variant followed by its downscaled variants
+func EncodePhotoVariants(photo io.Reader) ([]PhotoFile, error) {
+	original, err := io.ReadAll(photo)
+	if err != nil {
+		return nil, err
+	}
+
+	img, err := jpeg.Decode(bytes.NewReader(original))
+	if err != nil {
+		return nil, err
+	}
+
+	b := img.Bounds()
+	files := []PhotoFile{{Variant: models.OriginalVariant, Data: original, Width: b.Dx(), Height: b.Dy()}}
+	for _, variant := range models.PhotoVariants[1:] {
+		resized := imaging.Fit(img, PhotoVariantSizes[variant])
+
+		data, err := encodeJPEG(resized)
+		if err != nil {
+			return nil, err
+		}
+		b := resized.Bounds()
+		files = append(files, PhotoFile{Variant: variant, Data: data, Width: b.Dx(), Height: b.Dy()})
+	}
+
+	return files, nil
+}
+
+func encodeJPEG(img image.Image) ([]byte, error) {
+	buf := new(bytes.Buffer)
+	if err := jpeg.Encode(buf, img, nil); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// GroupPhotoURLs groups the URLs of the photo variants by check, the photos of every check are ordered by number.
+// The urls map the keys of the records to the URLs.
+func GroupPhotoURLs(records []models.PhotoRecord, urls map[string]string) map[int][]models.PhotoURLs {
+	type photoKey struct{ checkId, n int }
+
+	grouped := make(map[photoKey]*models.PhotoURLs)
+	numbers := make(map[int][]int)
+	for _, record := range records {
+		k := photoKey{record.CheckID, record.Number}
+		if grouped[k] == nil {
+			grouped[k] = &models.PhotoURLs{}
+			numbers[k.checkId] = append(numbers[k.checkId], k.n)
+		}
+		grouped[k].Set(record.Variant, urls[record.Key])
+	}
+
+	photos := make(map[int][]models.PhotoURLs, len(numbers))
+	for checkId, ns := range numbers {
+		slices.Sort(ns)
+		for _, n := range ns {
+			p := grouped[photoKey{checkId, n}]
+			p.Fill()
+			photos[checkId] = append(photos[checkId], *p)
+		}
+	}
+
+	return photos
+}
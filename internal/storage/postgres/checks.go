@@ -8,8 +8,17 @@ import (
 	"github.com/PritOriginal/problem-map-server/internal/models"
 	"github.com/PritOriginal/problem-map-server/internal/storage"
 	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
 )
 
+// checkColumns lists the checks columns mapped to models.Check
+const checkColumns = "c.check_id, c.user_id, c.mark_id, c.mark_status_id, c.mark_status_history_id, c.result, c.comment, ST_AsEWKB(c.location) AS location, c.distance, c.flagged, c.created_at, c.updated_at"
+
+var checksSortColumns = map[models.SortField]string{
+	models.SortByCreatedAt: "c.created_at",
+	models.SortByUpdatedAt: "c.updated_at",
+}
+
 type ChecksRepository struct {
 	Conn *sqlx.DB
 }
@@ -25,24 +34,230 @@ func (r *ChecksRepository) AddCheck(ctx context.Context, check models.Check) (in
 
 	query := `
 			INSERT INTO 
-				checks (user_id, mark_id, mark_status_id, mark_status_history_id, comment, result) 
+				checks (user_id, mark_id, mark_status_id, mark_status_history_id, comment, result, location, distance, flagged) 
 			VALUES 
-				(:user_id, :mark_id, :mark_status_id, :mark_status_history_id, :comment, :result)
+				($1, $2, $3, $4, $5, $6, ST_GeomFromEWKB($7), $8, $9)
 			RETURNING check_id
 			`
 
-	stmt, err := r.Conn.PrepareNamedContext(ctx, query)
+	stmt, err := r.Conn.PreparexContext(ctx, query)
 	if err != nil {
 		return 0, fmt.Errorf("%s: %w", op, err)
 	}
 
-	if err := stmt.GetContext(ctx, &id, check); err != nil {
+	// a nil point can't be encoded, the check without the location is stored with NULL
+	var location any
+	if check.Location != nil {
+		location = check.Location
+	}
+
+	if err := stmt.GetContext(ctx, &id,
+		check.UserID, check.MarkID, check.MarkStatusId, check.MarkStatusHistoryItemId, check.Comment, check.Result,
+		location, check.Distance, check.Flagged,
+	); err != nil {
 		return 0, fmt.Errorf("%s: %w", op, err)
 	}
 
 	return id, nil
 }
 
+// AddPhotosMetadata stores the metadata of the check photos, the photos are numbered from first in the order of the metadata
+func (r *ChecksRepository) AddPhotosMetadata(ctx context.Context, checkId, first int, metadata []models.PhotoMetadata) error {
+	const op = "storage.postgres.AddPhotosMetadata"
+
+	if len(metadata) == 0 {
+		return nil
+	}
+
+	type photoMetadata struct {
+		CheckID int `db:"check_id"`
+		Number  int `db:"number"`
+		models.PhotoMetadata
+	}
+
+	rows := make([]photoMetadata, len(metadata))
+	for i, m := range metadata {
+		rows[i] = photoMetadata{CheckID: checkId, Number: first + i, PhotoMetadata: m}
+	}
+
+	query := `
+		INSERT INTO 
+			photos_metadata (check_id, number, captured_at, latitude, longitude, orientation, phash, duplicate) 
+		VALUES 
+			(:check_id, :number, :captured_at, :latitude, :longitude, :orientation, :phash, :duplicate)`
+
+	if _, err := r.Conn.NamedExecContext(ctx, query, rows); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// AddPhotoRecords stores the records of the photo files
+func (r *ChecksRepository) AddPhotoRecords(ctx context.Context, records []models.PhotoRecord) error {
+	const op = "storage.postgres.AddPhotoRecords"
+
+	if len(records) == 0 {
+		return nil
+	}
+
+	query := `
+		INSERT INTO 
+			photos (key, mark_id, check_id, number, variant, size, width, height, hash) 
+		VALUES 
+			(:key, :mark_id, :check_id, :number, :variant, :size, :width, :height, :hash)`
+
+	if _, err := r.Conn.NamedExecContext(ctx, query, records); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// AddMissingPhotoRecords stores the records of the photo files the photos table has no records of.
+// The records of the files not matching a check of their mark are skipped.
+// It returns the number of the stored records.
+func (r *ChecksRepository) AddMissingPhotoRecords(ctx context.Context, records []models.PhotoRecord) (int64, error) {
+	const op = "storage.postgres.AddMissingPhotoRecords"
+
+	if len(records) == 0 {
+		return 0, nil
+	}
+
+	query := `
+		INSERT INTO 
+			photos (key, mark_id, check_id, number, variant, size, width, height, hash) 
+		SELECT 
+			$1::varchar, $2::integer, $3::integer, $4::integer, $5::varchar, $6::bigint, $7::integer, $8::integer, $9::char(64) 
+		WHERE 
+			EXISTS (SELECT 1 FROM checks WHERE check_id = $3::integer AND mark_id = $2::integer) 
+		ON CONFLICT DO NOTHING`
+
+	var added int64
+	for _, record := range records {
+		res, err := r.Conn.ExecContext(ctx, query,
+			record.Key, record.MarkID, record.CheckID, record.Number, record.Variant,
+			record.Size, record.Width, record.Height, record.Hash,
+		)
+		if err != nil {
+			return added, fmt.Errorf("%s: %w", op, err)
+		}
+		n, err := res.RowsAffected()
+		if err != nil {
+			return added, fmt.Errorf("%s: %w", op, err)
+		}
+		added += n
+	}
+
+	return added, nil
+}
+
+// GetPhotoRecordsByCheckIds returns the records of the photo files of the checks ordered by check and photo number
+func (r *ChecksRepository) GetPhotoRecordsByCheckIds(ctx context.Context, checkIds []int) ([]models.PhotoRecord, error) {
+	const op = "storage.postgres.GetPhotoRecordsByCheckIds"
+
+	records := []models.PhotoRecord{}
+	if len(checkIds) == 0 {
+		return records, nil
+	}
+
+	query := `
+		SELECT 
+			key, mark_id, check_id, number, variant, size, width, height, hash 
+		FROM 
+			photos 
+		WHERE 
+			check_id = ANY($1) 
+		ORDER BY 
+			check_id, number`
+
+	if err := r.Conn.SelectContext(ctx, &records, query, pq.Array(checkIds)); err != nil {
+		return records, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return records, nil
+}
+
+// GetPhotoMetadata returns the metadata of the check photo with the number
+func (r *ChecksRepository) GetPhotoMetadata(ctx context.Context, checkId, number int) (models.PhotoMetadata, error) {
+	const op = "storage.postgres.GetPhotoMetadata"
+
+	var metadata models.PhotoMetadata
+
+	query := `
+		SELECT 
+			captured_at, latitude, longitude, orientation, phash, duplicate 
+		FROM 
+			photos_metadata 
+		WHERE 
+			check_id = $1 AND number = $2`
+
+	if err := r.Conn.GetContext(ctx, &metadata, query, checkId, number); err != nil {
+		switch err {
+		case sql.ErrNoRows:
+			return metadata, storage.ErrNotFound
+		default:
+			return metadata, fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	return metadata, nil
+}
+
+// GetSimilarPhotos returns the photos whose perceptual hashes are within the max Hamming distance of the hash
+// ordered by the distance. The distances below the number of the hash bands are prefiltered by the phash_bands
+// index, the larger ones compare all the hashes by a sequential scan.
+func (r *ChecksRepository) GetSimilarPhotos(ctx context.Context, filters models.GetSimilarPhotosFilters) ([]models.SimilarPhoto, error) {
+	const op = "storage.postgres.GetSimilarPhotos"
+
+	photos := []models.SimilarPhoto{}
+
+	query := `
+		SELECT 
+			c.mark_id, pm.check_id, pm.number, bit_count((pm.phash # $?)::bit(64)) AS distance 
+		FROM 
+			photos_metadata AS pm 
+		JOIN 
+			checks AS c ON c.check_id = pm.check_id 
+		WHERE 
+			pm.phash IS NOT NULL AND pm.check_id <> $? AND bit_count((pm.phash # $?)::bit(64)) <= $?`
+	args := []any{filters.PHash, filters.ExcludeCheckId, filters.PHash, filters.MaxDistance}
+
+	// without the bands prefilter the larger distances scan all the hashes
+	if filters.MaxDistance < len(phashBandWidths) {
+		query += " AND pm.phash_bands && $?::integer[]"
+		args = append(args, pq.Array(phashBands(filters.PHash)))
+	}
+
+	query += `
+		ORDER BY 
+			distance, pm.check_id, pm.number 
+		LIMIT $?`
+	args = append(args, filters.Limit)
+	query = bindPlaceholders(query)
+
+	if err := r.Conn.SelectContext(ctx, &photos, query, args...); err != nil {
+		return photos, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return photos, nil
+}
+
+// phashBandWidths are the bit widths of the bands the perceptual hash is split into, as in the phash_bands column.
+// The hashes within the Hamming distance less than the number of the bands share at least one band.
+var phashBandWidths = []int{6, 6, 6, 6, 6, 6, 6, 6, 6, 5, 5}
+
+// phashBands returns the bands of the hash, the band number in the high bits and the band value in the low 6 bits
+func phashBands(phash int64) []int64 {
+	bands := make([]int64, len(phashBandWidths))
+	offset := 0
+	for i, width := range phashBandWidths {
+		bands[i] = int64(i)<<6 | int64(uint64(phash)>>offset&(1<<width-1))
+		offset += width
+	}
+	return bands
+}
+
 func (r *ChecksRepository) GetCheckById(ctx context.Context, id int) (models.Check, error) {
 	const op = "storage.postgres.GetCheckById"
 
@@ -50,7 +265,7 @@ func (r *ChecksRepository) GetCheckById(ctx context.Context, id int) (models.Che
 
 	query := `
 		SELECT 
-			c.*, u.name as username 
+			` + checkColumns + `, u.name as username 
 		FROM 
 			checks as c 
 		JOIN 
@@ -70,45 +285,66 @@ func (r *ChecksRepository) GetCheckById(ctx context.Context, id int) (models.Che
 	return check, nil
 }
 
-func (r *ChecksRepository) GetChecksByMarkId(ctx context.Context, markId int) ([]models.Check, error) {
+func (r *ChecksRepository) GetChecksByMarkId(ctx context.Context, markId int, pagination models.Pagination) ([]models.Check, error) {
 	const op = "storage.postgres.GetChecksByMarkId"
 
 	checks := []models.Check{}
 
 	query := `
 		SELECT 
-			c.*, u.name as username 
+			` + checkColumns + `, u.name as username 
 		FROM 
 			checks as c 
 		JOIN 
 			users AS u ON c.user_id = u.user_id 
 		WHERE 
-			mark_id = $1
-		ORDER BY created_at ASC`
+			mark_id = $?`
+	args := []any{markId}
+
+	keyset := newKeyset(pagination, models.ChecksSorting, checksSortColumns, "c.check_id")
+	if condition, conditionArgs := keyset.condition(); condition != "" {
+		query += " AND " + condition
+		args = append(args, conditionArgs...)
+	}
+	orderBy, orderByArgs := keyset.orderBy()
+	query += orderBy
+	args = append(args, orderByArgs...)
+	query = bindPlaceholders(query)
 
-	if err := r.Conn.SelectContext(ctx, &checks, query, markId); err != nil {
+	if err := r.Conn.SelectContext(ctx, &checks, query, args...); err != nil {
 		return checks, fmt.Errorf("%s: %w", op, err)
 	}
 
 	return checks, nil
 }
 
-func (r *ChecksRepository) GetChecksByUserId(ctx context.Context, userId int) ([]models.Check, error) {
+func (r *ChecksRepository) GetChecksByUserId(ctx context.Context, userId int, pagination models.Pagination) ([]models.Check, error) {
 	const op = "storage.postgres.GetChecksByUserId"
 
 	checks := []models.Check{}
 
 	query := `
 		SELECT 
-			c.*, u.name as username 
+			` + checkColumns + `, u.name as username 
 		FROM 
 			checks as c 
 		JOIN 
 			users AS u ON c.user_id = u.user_id 
 		WHERE 
-			c.user_id = $1`
+			c.user_id = $?`
+	args := []any{userId}
+
+	keyset := newKeyset(pagination, models.ChecksSorting, checksSortColumns, "c.check_id")
+	if condition, conditionArgs := keyset.condition(); condition != "" {
+		query += " AND " + condition
+		args = append(args, conditionArgs...)
+	}
+	orderBy, orderByArgs := keyset.orderBy()
+	query += orderBy
+	args = append(args, orderByArgs...)
+	query = bindPlaceholders(query)
 
-	if err := r.Conn.SelectContext(ctx, &checks, query, userId); err != nil {
+	if err := r.Conn.SelectContext(ctx, &checks, query, args...); err != nil {
 		return checks, fmt.Errorf("%s: %w", op, err)
 	}
 
@@ -122,7 +358,7 @@ func (r *ChecksRepository) GetChecksByMarkHistoryId(ctx context.Context, markHis
 
 	query := `
 		SELECT 
-			c.*, u.name as username 
+			` + checkColumns + `, u.name as username, u.rating as user_rating 
 		FROM 
 			checks as c 
 		JOIN 
@@ -159,7 +395,7 @@ func (r *ChecksRepository) GetUserMarkCheck(ctx context.Context, userId int, mar
 		)
 
 		SELECT 
-			c.*, u.name as username 
+			` + checkColumns + `, u.name as username 
 		FROM 
 			checks as c 
 		JOIN 
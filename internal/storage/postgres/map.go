@@ -64,7 +64,7 @@ func (repo *MapRepository) GetAdminBoundariesMarksCount(ctx context.Context, fil
 		FROM
 			admin_boundaries b
 		LEFT JOIN
-			marks m ON ST_Contains(b.geom, m.geom)
+			marks m ON ST_Contains(b.geom, m.geom) AND m.deleted_at IS NULL
 		WHERE 
 			1=1	
 		GROUP BY
@@ -98,6 +98,111 @@ func (repo *MapRepository) GetAdminBoundariesMarksCount(ctx context.Context, fil
 	return boundariesCount, nil
 }
 
+func (repo *MapRepository) GetMarkClusters(ctx context.Context, filters models.GetMarkClustersFilters) ([]models.MarkCluster, error) {
+	const op = "storage.postgres.GetMarkClusters"
+
+	clusters := []models.MarkCluster{}
+	var conditions []string
+	var args []any
+
+	query :=
+		`
+		SELECT
+			ST_AsEWKB(ST_Centroid(ST_Collect(geom))) AS geom,
+			COUNT(*) AS total_count,
+			COUNT(*) FILTER (WHERE mark_status_id = 1) AS unconfirmed_count,
+			COUNT(*) FILTER (WHERE mark_status_id IN (2,4)) AS confirmed_count,
+			COUNT(*) FILTER (WHERE mark_status_id = 3) AS under_review_count,
+			COUNT(*) FILTER (WHERE mark_status_id = 5) AS closed_count
+		FROM
+			marks
+		WHERE
+			deleted_at IS NULL AND geom && ST_MakeEnvelope($?, $?, $?, $?, 4326)
+	`
+	args = append(args, filters.BBox.MinLon, filters.BBox.MinLat, filters.BBox.MaxLon, filters.BBox.MaxLat)
+
+	if len(filters.MarkTypeIds) > 0 {
+		conditions = append(conditions, "type_mark_id = ANY($?)")
+		args = append(args, pq.Array(filters.MarkTypeIds))
+	}
+
+	for _, condition := range conditions {
+		query += " AND " + condition
+	}
+	query += " GROUP BY ST_SnapToGrid(geom, $?)"
+	args = append(args, filters.GridSize)
+	query = bindPlaceholders(query)
+
+	if err := repo.Conn.SelectContext(ctx, &clusters, query, args...); err != nil {
+		return clusters, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return clusters, nil
+}
+
+func (repo *MapRepository) GetMarksTile(ctx context.Context, tile models.Tile) ([]byte, error) {
+	const op = "storage.postgres.GetMarksTile"
+
+	var mvt []byte
+
+	query :=
+		`
+		WITH bounds AS (
+			SELECT ST_TileEnvelope($1, $2, $3) AS geom
+		),
+		mvtgeom AS (
+			SELECT
+				ST_AsMVTGeom(ST_Transform(m.geom, 3857), bounds.geom) AS geom,
+				m.mark_id,
+				m.type_mark_id AS mark_type_id,
+				m.mark_status_id,
+				m.created_at
+			FROM
+				marks m, bounds
+			WHERE
+				m.deleted_at IS NULL AND m.geom && ST_Transform(bounds.geom, 4326)
+		)
+		SELECT ST_AsMVT(mvtgeom.*, 'marks', 4096, 'geom', 'mark_id') FROM mvtgeom
+	`
+
+	if err := repo.Conn.GetContext(ctx, &mvt, query, tile.Z, tile.X, tile.Y); err != nil {
+		return mvt, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return mvt, nil
+}
+
+func (repo *MapRepository) GetAdminBoundariesTile(ctx context.Context, tile models.Tile) ([]byte, error) {
+	const op = "storage.postgres.GetAdminBoundariesTile"
+
+	var mvt []byte
+
+	query :=
+		`
+		WITH bounds AS (
+			SELECT ST_TileEnvelope($1, $2, $3) AS geom
+		),
+		mvtgeom AS (
+			SELECT
+				ST_AsMVTGeom(ST_Transform(b.geom, 3857), bounds.geom) AS geom,
+				b.id,
+				b.name,
+				b.admin_level
+			FROM
+				admin_boundaries b, bounds
+			WHERE
+				b.geom && ST_Transform(bounds.geom, 4326)
+		)
+		SELECT ST_AsMVT(mvtgeom.*, 'admin_boundaries', 4096, 'geom', 'id') FROM mvtgeom
+	`
+
+	if err := repo.Conn.GetContext(ctx, &mvt, query, tile.Z, tile.X, tile.Y); err != nil {
+		return mvt, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return mvt, nil
+}
+
 func (repo *MapRepository) GetRegions(ctx context.Context) ([]models.Region, error) {
 	const op = "storage.postgres.GetRegions"
 
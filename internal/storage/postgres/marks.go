@@ -12,6 +12,11 @@ import (
 	"github.com/lib/pq"
 )
 
+var marksSortColumns = map[models.SortField]string{
+	models.SortByCreatedAt: "created_at",
+	models.SortByUpdatedAt: "updated_at",
+}
+
 type MarksRepository struct {
 	Conn *sqlx.DB
 }
@@ -33,9 +38,65 @@ func (repo *MarksRepository) GetMarks(ctx context.Context, filters models.GetMar
 			FROM 
 				marks
 			WHERE
-				1=1
+				deleted_at IS NULL
+			`
+
+	if len(filters.MarkStatusIds) > 0 {
+		conditions = append(conditions, "mark_status_id = ANY($?)")
+		args = append(args, pq.Array(filters.MarkStatusIds))
+	}
+	if len(filters.MarkTypeIds) > 0 {
+		conditions = append(conditions, "type_mark_id = ANY($?)")
+		args = append(args, pq.Array(filters.MarkTypeIds))
+	}
+	if filters.BBox != nil {
+		conditions = append(conditions, "geom && ST_MakeEnvelope($?, $?, $?, $?, 4326)")
+		args = append(args, filters.BBox.MinLon, filters.BBox.MinLat, filters.BBox.MaxLon, filters.BBox.MaxLat)
+	}
+	keyset := newKeyset(filters.Pagination, models.MarksSorting, marksSortColumns, "mark_id")
+	if condition, conditionArgs := keyset.condition(); condition != "" {
+		conditions = append(conditions, condition)
+		args = append(args, conditionArgs...)
+	}
+
+	for _, condition := range conditions {
+		query += " AND " + condition
+	}
+	orderBy, orderByArgs := keyset.orderBy()
+	query += orderBy
+	args = append(args, orderByArgs...)
+	query = bindPlaceholders(query)
+
+	if err := repo.Conn.SelectContext(ctx, &marks, query, args...); err != nil {
+		return marks, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return marks, nil
+}
+
+func (repo *MarksRepository) GetNearbyMarks(ctx context.Context, filters models.GetNearbyMarksFilters) ([]models.NearbyMark, error) {
+	const op = "storage.postgres.GetNearbyMarks"
+
+	marks := []models.NearbyMark{}
+
+	var conditions []string
+	var args []any
+	point := "ST_SetSRID(ST_MakePoint($?, $?), 4326)"
+	query := `
+			SELECT 
+				mark_id, description, ST_AsEWKB(geom) AS geom, type_mark_id, mark_status_id, user_id, created_at, updated_at,
+				ST_Distance(geom::geography, ` + point + `::geography) AS distance
+			FROM 
+				marks
+			WHERE
+				deleted_at IS NULL
 			`
+	args = append(args, filters.Longitude, filters.Latitude)
 
+	if filters.Radius > 0 {
+		conditions = append(conditions, "ST_DWithin(geom::geography, "+point+"::geography, $?)")
+		args = append(args, filters.Longitude, filters.Latitude, filters.Radius)
+	}
 	if len(filters.MarkStatusIds) > 0 {
 		conditions = append(conditions, "mark_status_id = ANY($?)")
 		args = append(args, pq.Array(filters.MarkStatusIds))
@@ -44,11 +105,90 @@ func (repo *MarksRepository) GetMarks(ctx context.Context, filters models.GetMar
 		conditions = append(conditions, "type_mark_id = ANY($?)")
 		args = append(args, pq.Array(filters.MarkTypeIds))
 	}
+	if !filters.CreatedAfter.IsZero() {
+		conditions = append(conditions, "created_at >= $?")
+		args = append(args, filters.CreatedAfter)
+	}
 
-	for i, condition := range conditions {
+	for _, condition := range conditions {
 		query += " AND " + condition
-		query = strings.Replace(query, "$?", fmt.Sprintf("$%d", len(args)-len(conditions)+i+1), 1)
 	}
+
+	// the distance is known only after the select, so the page is taken from the subquery
+	query = "SELECT * FROM (" + query + ") AS nearby WHERE 1=1"
+	keyset := newKeyset(filters.Pagination, models.NearbyMarksSorting, map[models.SortField]string{
+		models.SortByDistance: "distance",
+	}, "mark_id")
+	if condition, conditionArgs := keyset.condition(); condition != "" {
+		query += " AND " + condition
+		args = append(args, conditionArgs...)
+	}
+	orderBy, orderByArgs := keyset.orderBy()
+	query += orderBy
+	args = append(args, orderByArgs...)
+	query = bindPlaceholders(query)
+
+	if err := repo.Conn.SelectContext(ctx, &marks, query, args...); err != nil {
+		return marks, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return marks, nil
+}
+
+func (repo *MarksRepository) SearchMarks(ctx context.Context, filters models.SearchMarksFilters) ([]models.FoundMark, error) {
+	const op = "storage.postgres.SearchMarks"
+
+	marks := []models.FoundMark{}
+
+	var conditions []string
+	var args []any
+	query := `
+			SELECT
+				m.mark_id, m.description, ST_AsEWKB(m.geom) AS geom, m.type_mark_id, m.mark_status_id, m.user_id, m.created_at, m.updated_at,
+				(ts_rank(m.search_vector, q.query) + COALESCE(MAX(ts_rank(c.search_vector, q.query)), 0))::float8 AS rank
+			FROM
+				marks AS m
+			CROSS JOIN
+				websearch_to_tsquery('russian', $?) AS q(query)
+			LEFT JOIN
+				checks AS c ON c.mark_id = m.mark_id AND c.search_vector @@ q.query
+			WHERE
+				m.deleted_at IS NULL AND (m.search_vector @@ q.query OR c.check_id IS NOT NULL)
+			`
+	args = append(args, filters.Query)
+
+	if len(filters.MarkStatusIds) > 0 {
+		conditions = append(conditions, "m.mark_status_id = ANY($?)")
+		args = append(args, pq.Array(filters.MarkStatusIds))
+	}
+	if len(filters.MarkTypeIds) > 0 {
+		conditions = append(conditions, "m.type_mark_id = ANY($?)")
+		args = append(args, pq.Array(filters.MarkTypeIds))
+	}
+	if filters.BBox != nil {
+		conditions = append(conditions, "m.geom && ST_MakeEnvelope($?, $?, $?, $?, 4326)")
+		args = append(args, filters.BBox.MinLon, filters.BBox.MinLat, filters.BBox.MaxLon, filters.BBox.MaxLat)
+	}
+
+	for _, condition := range conditions {
+		query += " AND " + condition
+	}
+	query += " GROUP BY m.mark_id, q.query"
+
+	// the rank is known only after the grouping, so the page is taken from the subquery
+	query = "SELECT * FROM (" + query + ") AS found WHERE 1=1"
+	keyset := newKeyset(filters.Pagination, models.SearchMarksSorting, map[models.SortField]string{
+		models.SortByRank: "rank",
+	}, "mark_id")
+	if condition, conditionArgs := keyset.condition(); condition != "" {
+		query += " AND " + condition
+		args = append(args, conditionArgs...)
+	}
+	orderBy, orderByArgs := keyset.orderBy()
+	query += orderBy
+	args = append(args, orderByArgs...)
+	query = bindPlaceholders(query)
+
 	if err := repo.Conn.SelectContext(ctx, &marks, query, args...); err != nil {
 		return marks, fmt.Errorf("%s: %w", op, err)
 	}
@@ -66,7 +206,7 @@ func (repo *MarksRepository) GetMarkById(ctx context.Context, id int) (models.Ma
 			FROM 
 				marks 
 			WHERE 
-				mark_id = $1
+				mark_id = $1 AND deleted_at IS NULL
 			`
 
 	if err := repo.Conn.GetContext(ctx, &mark, query, id); err != nil {
@@ -81,7 +221,27 @@ func (repo *MarksRepository) GetMarkById(ctx context.Context, id int) (models.Ma
 	return mark, nil
 }
 
-func (repo *MarksRepository) GetMarksByUserId(ctx context.Context, userId int) ([]models.Mark, error) {
+// GetMergedMarkId returns the id of the mark the merged mark was merged into
+func (repo *MarksRepository) GetMergedMarkId(ctx context.Context, id int) (int, error) {
+	const op = "storage.postgres.GetMergedMarkId"
+
+	var mergedIntoId int
+
+	query := "SELECT merged_into_mark_id FROM marks WHERE mark_id = $1 AND merged_into_mark_id IS NOT NULL"
+
+	if err := repo.Conn.GetContext(ctx, &mergedIntoId, query, id); err != nil {
+		switch err {
+		case sql.ErrNoRows:
+			return 0, storage.ErrNotFound
+		default:
+			return 0, fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	return mergedIntoId, nil
+}
+
+func (repo *MarksRepository) GetMarksByUserId(ctx context.Context, userId int, pagination models.Pagination) ([]models.Mark, error) {
 	const op = "storage.postgres.GetMarksByUserId"
 
 	marks := []models.Mark{}
@@ -91,10 +251,21 @@ func (repo *MarksRepository) GetMarksByUserId(ctx context.Context, userId int) (
 			FROM 
 				marks 
 			WHERE 
-				user_id = $1
+				user_id = $? AND deleted_at IS NULL
 			`
+	args := []any{userId}
+
+	keyset := newKeyset(pagination, models.MarksSorting, marksSortColumns, "mark_id")
+	if condition, conditionArgs := keyset.condition(); condition != "" {
+		query += " AND " + condition
+		args = append(args, conditionArgs...)
+	}
+	orderBy, orderByArgs := keyset.orderBy()
+	query += orderBy
+	args = append(args, orderByArgs...)
+	query = bindPlaceholders(query)
 
-	if err := repo.Conn.SelectContext(ctx, &marks, query, userId); err != nil {
+	if err := repo.Conn.SelectContext(ctx, &marks, query, args...); err != nil {
 		return marks, fmt.Errorf("%s: %w", op, err)
 	}
 
@@ -126,6 +297,194 @@ func (repo *MarksRepository) AddMark(ctx context.Context, mark models.Mark) (int
 	return id, nil
 }
 
+// UpdateMark updates the set fields of the mark while it is unconfirmed. It returns storage.ErrNotFound
+// if the mark is deleted or no longer unconfirmed.
+func (repo *MarksRepository) UpdateMark(ctx context.Context, markId int, update models.MarkUpdate) error {
+	const op = "storage.postgres.UpdateMark"
+
+	var sets []string
+	var args []any
+
+	if update.Description != nil {
+		sets = append(sets, "description = $?")
+		args = append(args, *update.Description)
+	}
+	if update.MarkTypeID != nil {
+		sets = append(sets, "type_mark_id = $?")
+		args = append(args, *update.MarkTypeID)
+	}
+	if update.Geom != nil {
+		sets = append(sets, "geom = ST_GeomFromEWKB($?)")
+		args = append(args, update.Geom)
+	}
+	if len(sets) == 0 {
+		return nil
+	}
+
+	query := "UPDATE marks SET " + strings.Join(sets, ", ") + " WHERE mark_id = $? AND mark_status_id = $? AND deleted_at IS NULL"
+	args = append(args, markId, models.UnconfirmedStatus)
+	query = bindPlaceholders(query)
+
+	res, err := repo.Conn.ExecContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if rows == 0 {
+		return storage.ErrNotFound
+	}
+
+	return nil
+}
+
+func (repo *MarksRepository) DeleteMark(ctx context.Context, markId int) error {
+	const op = "storage.postgres.DeleteMark"
+
+	res, err := repo.Conn.ExecContext(ctx, "UPDATE marks SET deleted_at = NOW() WHERE mark_id = $1 AND deleted_at IS NULL", markId)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if rows == 0 {
+		return storage.ErrNotFound
+	}
+
+	return nil
+}
+
+// MergeMarks merges the marks into the mark in one transaction. The merges are recorded in the status history
+// of the mark and the merged marks are soft deleted, keeping the redirect to the mark.
+// The checks of the merged marks on their current status, if it is the status of the mark, are moved to
+// the current status of the mark, except for the users who checked the mark. The other checks are kept
+// on the merge items of the history.
+func (repo *MarksRepository) MergeMarks(ctx context.Context, markId int, mergedMarkIds []int) error {
+	const op = "storage.postgres.MergeMarks"
+
+	tx, err := repo.Conn.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback()
+
+	var historyItem models.MarkStatusHistoryItem
+
+	query := `
+		SELECT 
+			h.* 
+		FROM 
+			mark_status_history AS h
+		JOIN 
+			marks AS m ON m.mark_id = h.mark_id
+		WHERE 
+			h.mark_id = $1 AND h.merged_mark_id IS NULL AND m.deleted_at IS NULL
+		ORDER BY 
+			h.changed_at DESC 
+		LIMIT 1
+		FOR UPDATE OF m
+		`
+
+	if err := tx.GetContext(ctx, &historyItem, query, markId); err != nil {
+		switch err {
+		case sql.ErrNoRows:
+			return storage.ErrNotFound
+		default:
+			return fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	res, err := tx.ExecContext(ctx, `
+		UPDATE marks SET merged_into_mark_id = $1, deleted_at = NOW() 
+		WHERE mark_id = ANY($2) AND mark_id <> $1 AND deleted_at IS NULL`,
+		markId, pq.Array(mergedMarkIds),
+	)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if affected != int64(len(mergedMarkIds)) {
+		return storage.ErrNotFound
+	}
+
+	// marks merged earlier into the merged marks now redirect to the mark
+	if _, err := tx.ExecContext(ctx, `
+		UPDATE marks SET merged_into_mark_id = $1 WHERE merged_into_mark_id = ANY($2)`,
+		markId, pq.Array(mergedMarkIds),
+	); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if _, err := tx.ExecContext(ctx, `
+		INSERT INTO mark_status_history (mark_id, old_mark_status_id, new_mark_status_id, prev_id, merged_mark_id)
+		SELECT $1, $2, $2, $3, merged_mark_id FROM unnest($4::integer[]) AS merged_mark_id`,
+		markId, historyItem.NewMarkStatusID, historyItem.ID, pq.Array(mergedMarkIds),
+	); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	// the checks on the current status of the merged marks vote on the mark, one check per user
+	// who has not checked the mark yet, the checks made on the same status only
+	if _, err := tx.ExecContext(ctx, `
+		WITH current_items AS (
+			SELECT DISTINCT ON (mark_id) 
+				id 
+			FROM 
+				mark_status_history 
+			WHERE 
+				mark_id = ANY($4) AND merged_mark_id IS NULL 
+			ORDER BY 
+				mark_id, changed_at DESC
+		), moved AS (
+			SELECT DISTINCT ON (c.user_id) 
+				c.check_id 
+			FROM 
+				checks AS c 
+			WHERE 
+				c.mark_status_history_id IN (SELECT id FROM current_items) AND c.mark_status_id = $2 
+				AND NOT EXISTS (SELECT 1 FROM checks AS mc WHERE mc.mark_id = $1 AND mc.user_id = c.user_id) 
+			ORDER BY 
+				c.user_id, c.created_at
+		)
+		UPDATE checks SET mark_id = $1, mark_status_history_id = $3 WHERE check_id IN (SELECT check_id FROM moved)`,
+		markId, historyItem.NewMarkStatusID, historyItem.ID, pq.Array(mergedMarkIds),
+	); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	// the other checks are kept on the merge items of their marks and don't vote
+	if _, err := tx.ExecContext(ctx, `
+		UPDATE checks AS c SET mark_id = $1, mark_status_history_id = h.id 
+		FROM mark_status_history AS h 
+		WHERE c.mark_id = ANY($2) AND h.mark_id = $1 AND h.merged_mark_id = c.mark_id`,
+		markId, pq.Array(mergedMarkIds),
+	); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	// the photo files are copied to the keys of the mark, marks/{markId}/{checkId}/...
+	if _, err := tx.ExecContext(ctx, `
+		UPDATE photos SET mark_id = $1, key = regexp_replace(key, '^marks/[0-9]+/', 'marks/' || $1::integer || '/') 
+		WHERE mark_id = ANY($2)`,
+		markId, pq.Array(mergedMarkIds),
+	); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
 func (repo *MarksRepository) GetMarkTypes(ctx context.Context) ([]models.MarkType, error) {
 	const op = "storage.postgres.GetMarkTypes"
 
@@ -164,6 +523,63 @@ func (repo *MarksRepository) UpdateMarkStatus(ctx context.Context, markId int, m
 	return nil
 }
 
+// ResolveMarkStatus moves the mark from the old status to the terminal one and adds the check outcomes
+// to the users ratings in one transaction. It returns storage.ErrNotFound if the mark isn't in the old status.
+func (repo *MarksRepository) ResolveMarkStatus(ctx context.Context, markId int, oldStatusId, newStatusId models.MarkStatusType, outcomes []models.UserCheckOutcome) error {
+	const op = "storage.postgres.ResolveMarkStatus"
+
+	tx, err := repo.Conn.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback()
+
+	res, err := tx.ExecContext(ctx, "UPDATE marks SET mark_status_id = $1 WHERE mark_id = $2 AND mark_status_id = $3", newStatusId, markId, oldStatusId)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if affected == 0 {
+		return storage.ErrNotFound
+	}
+
+	userIds := make([]int, len(outcomes))
+	resolved := make([]int, len(outcomes))
+	agreed := make([]int, len(outcomes))
+	for i, outcome := range outcomes {
+		userIds[i] = outcome.UserID
+		resolved[i] = outcome.Resolved
+		agreed[i] = outcome.Agreed
+	}
+
+	// the rating is the share of the agreed checks in percent, smoothed towards 50 for the users with few checks
+	query := `
+		UPDATE 
+			users AS u
+		SET
+			resolved_checks = u.resolved_checks + o.resolved,
+			agreed_checks = u.agreed_checks + o.agreed,
+			rating = ROUND(100.0 * (u.agreed_checks + o.agreed + 1) / (u.resolved_checks + o.resolved + 2))
+		FROM
+			unnest($1::int[], $2::int[], $3::int[]) AS o(user_id, resolved, agreed)
+		WHERE
+			u.user_id = o.user_id
+		`
+
+	if _, err := tx.ExecContext(ctx, query, pq.Array(userIds), pq.Array(resolved), pq.Array(agreed)); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
 func (repo *MarksRepository) GetMarkStatusHistoryByMarkId(ctx context.Context, markId int) ([]models.MarkStatusHistoryItem, error) {
 	const op = "storage.postgres.GetMarkStatusHistoryByMarkId"
 
@@ -198,7 +614,7 @@ func (r *MarksRepository) GetLastMarkStatusHistoryItem(ctx context.Context, mark
 		FROM 
 			mark_status_history 
 		WHERE 
-			mark_id = $1 
+			mark_id = $1 AND merged_mark_id IS NULL
 		ORDER BY 
 			changed_at DESC 
 		LIMIT 1
@@ -0,0 +1,196 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/PritOriginal/problem-map-server/internal/models"
+	"github.com/PritOriginal/problem-map-server/internal/storage"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+type ModerationRepository struct {
+	Conn *sqlx.DB
+}
+
+func NewModeration(conn *sqlx.DB) *ModerationRepository {
+	return &ModerationRepository{Conn: conn}
+}
+
+// GetModerationQueue returns a page of the marks with split votes on their current status, stalled voting
+// or duplicate photos in the checks of their current status ordered by priority.
+// The population density is taken from the smallest admin boundary of the mark with the population tag.
+func (r *ModerationRepository) GetModerationQueue(ctx context.Context, filters models.GetModerationQueueFilters) ([]models.ModerationQueueItem, error) {
+	const op = "storage.postgres.GetModerationQueue"
+
+	items := []models.ModerationQueueItem{}
+
+	priority := filters.Priority
+	markTypeIds := make([]int, 0, len(priority.MarkTypePriorities))
+	markTypePriorities := make([]float64, 0, len(priority.MarkTypePriorities))
+	for markTypeId, markTypePriority := range priority.MarkTypePriorities {
+		markTypeIds = append(markTypeIds, markTypeId)
+		markTypePriorities = append(markTypePriorities, markTypePriority)
+	}
+
+	query := `
+		WITH last_history AS (
+			SELECT DISTINCT ON (mark_id)
+				mark_id, id, changed_at
+			FROM
+				mark_status_history
+			WHERE
+				merged_mark_id IS NULL
+			ORDER BY
+				mark_id, changed_at DESC
+		),
+		candidates AS (
+			SELECT
+				m.mark_id, m.description, ST_AsEWKB(m.geom) AS geom, m.type_mark_id, m.mark_status_id, m.user_id, m.created_at, m.updated_at,
+				lh.changed_at AS status_changed_at,
+				GREATEST(lh.changed_at, MAX(c.created_at)) AS last_activity_at,
+				COUNT(c.check_id) FILTER (WHERE c.result) AS votes_for,
+				COUNT(c.check_id) FILTER (WHERE NOT c.result) AS votes_against,
+				COALESCE(d.density, 0) AS population_density,
+				dp.duplicate_photos,
+				mc.user_id AS claimed_by,
+				mc.expires_at AS claim_expires_at
+			FROM
+				marks AS m
+			JOIN
+				last_history AS lh ON lh.mark_id = m.mark_id
+			LEFT JOIN
+				checks AS c ON c.mark_status_history_id = lh.id
+			LEFT JOIN LATERAL (
+				SELECT
+					(b.tags->>'population')::float8 / NULLIF(ST_Area(b.geom::geography) / 1000000, 0) AS density
+				FROM
+					admin_boundaries AS b
+				WHERE
+					ST_Contains(b.geom, m.geom) AND b.tags->>'population' ~ '^[0-9]+$'
+				ORDER BY
+					b.admin_level DESC
+				LIMIT 1
+			) AS d ON TRUE
+			LEFT JOIN LATERAL (
+				SELECT
+					EXISTS (
+						SELECT 1
+						FROM
+							checks AS dc
+						JOIN
+							photos_metadata AS pm ON pm.check_id = dc.check_id
+						WHERE
+							dc.mark_status_history_id = lh.id AND pm.duplicate
+					) AS duplicate_photos
+			) AS dp ON TRUE
+			LEFT JOIN
+				moderation_claims AS mc ON mc.mark_id = m.mark_id AND mc.expires_at > NOW()
+			WHERE
+				m.deleted_at IS NULL AND m.mark_status_id = ANY($?) AND (mc.user_id IS NULL OR mc.user_id = $?)
+			GROUP BY
+				m.mark_id, lh.changed_at, d.density, dp.duplicate_photos, mc.user_id, mc.expires_at
+			HAVING
+				(COUNT(c.check_id) FILTER (WHERE c.result) > 0 AND COUNT(c.check_id) FILTER (WHERE NOT c.result) > 0)
+				OR GREATEST(lh.changed_at, MAX(c.created_at)) < $?
+				OR dp.duplicate_photos
+			ORDER BY
+				lh.changed_at, m.mark_id
+			LIMIT $?
+		)
+		SELECT * FROM (
+			SELECT
+				q.*,
+				(
+					GREATEST(LEAST(EXTRACT(EPOCH FROM $?::timestamptz - q.status_changed_at) / $?, 1), 0)
+					+ CASE
+						WHEN q.votes_for > 0 AND q.votes_against > 0
+						THEN 1 - ABS(q.votes_for - q.votes_against)::float8 / (q.votes_for + q.votes_against)
+						ELSE 0
+					END
+					+ COALESCE(tp.priority, 0)
+					+ LEAST(q.population_density / $?, 1)
+					+ CASE WHEN q.duplicate_photos THEN 1 ELSE 0 END
+				)::float8 AS priority
+			FROM
+				candidates AS q
+			LEFT JOIN
+				unnest($?::int[], $?::float8[]) AS tp(type_mark_id, priority) ON tp.type_mark_id = q.type_mark_id
+		) AS queue WHERE 1=1`
+	args := []any{
+		pq.Array(filters.MarkStatusIds), filters.ModeratorId, filters.StalledBefore, filters.MaxCandidates,
+		filters.Pagination.Snapshot, priority.MaxAge.Seconds(), priority.MaxPopulationDensity,
+		pq.Array(markTypeIds), pq.Array(markTypePriorities),
+	}
+
+	keyset := newKeyset(filters.Pagination, models.ModerationQueueSorting, map[models.SortField]string{
+		models.SortByPriority: "priority",
+	}, "mark_id")
+	if condition, conditionArgs := keyset.condition(); condition != "" {
+		query += " AND " + condition
+		args = append(args, conditionArgs...)
+	}
+	orderBy, orderByArgs := keyset.orderBy()
+	query += orderBy
+	args = append(args, orderByArgs...)
+	query = bindPlaceholders(query)
+
+	if err := r.Conn.SelectContext(ctx, &items, query, args...); err != nil {
+		return items, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return items, nil
+}
+
+// ClaimMark claims the mark for the moderator or extends the moderator's claim.
+// It returns storage.ErrExists while the mark is claimed by another moderator.
+func (r *ModerationRepository) ClaimMark(ctx context.Context, markId, userId int, ttl time.Duration) (models.ModerationClaim, error) {
+	const op = "storage.postgres.ClaimMark"
+
+	var claim models.ModerationClaim
+
+	query := `
+		INSERT INTO moderation_claims (mark_id, user_id, claimed_at, expires_at)
+		VALUES ($1, $2, NOW(), NOW() + make_interval(secs => $3))
+		ON CONFLICT (mark_id) DO UPDATE SET
+			user_id = EXCLUDED.user_id, claimed_at = EXCLUDED.claimed_at, expires_at = EXCLUDED.expires_at
+		WHERE
+			moderation_claims.expires_at <= NOW() OR moderation_claims.user_id = EXCLUDED.user_id
+		RETURNING
+			mark_id, user_id, claimed_at, expires_at
+		`
+
+	if err := r.Conn.GetContext(ctx, &claim, query, markId, userId, ttl.Seconds()); err != nil {
+		switch err {
+		case sql.ErrNoRows:
+			return claim, storage.ErrExists
+		default:
+			return claim, fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	return claim, nil
+}
+
+// ReleaseMark releases the moderator's active claim of the mark
+func (r *ModerationRepository) ReleaseMark(ctx context.Context, markId, userId int) error {
+	const op = "storage.postgres.ReleaseMark"
+
+	res, err := r.Conn.ExecContext(ctx, "DELETE FROM moderation_claims WHERE mark_id = $1 AND user_id = $2 AND expires_at > NOW()", markId, userId)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if affected == 0 {
+		return storage.ErrNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,77 @@
+package postgres
+
+import (
+	"fmt"
+
+	"github.com/PritOriginal/problem-map-server/internal/models"
+)
+
+// keyset builds the clauses of a keyset paginated query. Items are sorted by the sort column
+// with the id column as a tiebreaker, the cursor holds both values of the last item of the previous page.
+type keyset struct {
+	p        models.Pagination
+	column   string
+	idColumn string
+}
+
+// newKeyset maps the sort field of the pagination to the column, an empty sort falls back to the sorting defaults
+func newKeyset(p models.Pagination, sorting models.Sorting, columns map[models.SortField]string, idColumn string) keyset {
+	if p.SortBy == "" {
+		p.SortBy = sorting.Fields[0]
+	}
+	if p.Order == "" {
+		p.Order = sorting.DefaultOrder
+	}
+
+	column, ok := columns[p.SortBy]
+	if !ok {
+		column = idColumn
+	}
+
+	return keyset{p: p, column: column, idColumn: idColumn}
+}
+
+// condition returns the condition selecting the items after the cursor, it is empty on the first page
+func (k keyset) condition() (string, []any) {
+	if k.p.Cursor == nil {
+		return "", nil
+	}
+
+	op := ">"
+	if k.p.Order == models.SortDesc {
+		op = "<"
+	}
+
+	if k.column == k.idColumn {
+		return fmt.Sprintf("%s %s $?", k.idColumn, op), []any{k.p.Cursor.ID}
+	}
+	return fmt.Sprintf("(%s, %s) %s ($?::%s, $?)", k.column, k.idColumn, op, k.cast()),
+		[]any{k.p.Cursor.Value, k.p.Cursor.ID}
+}
+
+// orderBy returns the ORDER BY and LIMIT clauses
+func (k keyset) orderBy() (string, []any) {
+	dir := "ASC"
+	if k.p.Order == models.SortDesc {
+		dir = "DESC"
+	}
+
+	clause := fmt.Sprintf(" ORDER BY %s %s", k.idColumn, dir)
+	if k.column != k.idColumn {
+		clause = fmt.Sprintf(" ORDER BY %s %s, %s %s", k.column, dir, k.idColumn, dir)
+	}
+
+	if k.p.Limit > 0 {
+		return clause + " LIMIT $?", []any{k.p.Limit}
+	}
+	return clause, nil
+}
+
+func (k keyset) cast() string {
+	switch k.p.SortBy {
+	case models.SortByDistance, models.SortByRank, models.SortByPriority:
+		return "float8"
+	default:
+		return "timestamp"
+	}
+}
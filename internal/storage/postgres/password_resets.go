@@ -0,0 +1,86 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/PritOriginal/problem-map-server/internal/storage"
+	"github.com/jmoiron/sqlx"
+)
+
+// PasswordResetsRepository stores the hashes of the password reset tokens, the tokens themselves are never stored
+type PasswordResetsRepository struct {
+	Conn *sqlx.DB
+}
+
+func NewPasswordResets(conn *sqlx.DB) *PasswordResetsRepository {
+	return &PasswordResetsRepository{Conn: conn}
+}
+
+// AddPasswordReset stores the reset token of the user valid for ttl, the previous tokens of the user
+// and the expired tokens are deleted
+func (r *PasswordResetsRepository) AddPasswordReset(ctx context.Context, userId int, tokenHash string, ttl time.Duration) error {
+	const op = "storage.postgres.AddPasswordReset"
+
+	tx, err := r.Conn.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, "DELETE FROM password_resets WHERE user_id = $1 OR expires_at <= NOW()", userId); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	query := `
+		INSERT INTO password_resets (token_hash, user_id, created_at, expires_at)
+		VALUES ($1, $2, NOW(), NOW() + make_interval(secs => $3))
+		`
+
+	if _, err := tx.ExecContext(ctx, query, tokenHash, userId, ttl.Seconds()); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// ResetPassword uses up the reset token and sets the password hash of its user in one statement, so the token
+// can't be used twice. It returns the user id or storage.ErrNotFound for the unknown, used and expired tokens.
+func (r *PasswordResetsRepository) ResetPassword(ctx context.Context, tokenHash, passwordHash string) (int, error) {
+	const op = "storage.postgres.ResetPassword"
+
+	var userId int
+
+	query := `
+		WITH reset AS (
+			DELETE FROM password_resets WHERE token_hash = $1 AND expires_at > NOW() RETURNING user_id
+		)
+		UPDATE 
+			users 
+		SET 
+			password_hash = $2 
+		FROM 
+			reset 
+		WHERE 
+			users.user_id = reset.user_id
+		RETURNING 
+			users.user_id
+		`
+
+	if err := r.Conn.GetContext(ctx, &userId, query, tokenHash, passwordHash); err != nil {
+		switch err {
+		case sql.ErrNoRows:
+			return 0, storage.ErrNotFound
+		default:
+			return 0, fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	return userId, nil
+}
@@ -2,6 +2,7 @@ package postgres
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/PritOriginal/problem-map-server/internal/config"
 	"github.com/jmoiron/sqlx"
@@ -33,3 +34,11 @@ func New(cfg config.DatabaseConfig) (*Postgres, error) {
 func (s *Postgres) Stop() error {
 	return s.DB.Close()
 }
+
+// bindPlaceholders replaces "$?" placeholders in the query with sequential positional parameters
+func bindPlaceholders(query string) string {
+	for i := 1; strings.Contains(query, "$?"); i++ {
+		query = strings.Replace(query, "$?", fmt.Sprintf("$%d", i), 1)
+	}
+	return query
+}
@@ -18,13 +18,25 @@ func NewTasks(conn *sqlx.DB) *TasksRepository {
 	return &TasksRepository{Conn: conn}
 }
 
-func (r *TasksRepository) GetTasks(ctx context.Context) ([]models.Task, error) {
+func (r *TasksRepository) GetTasks(ctx context.Context, pagination models.Pagination) ([]models.Task, error) {
 	const op = "storage.postgres.GetTasks"
 
 	tasks := make([]models.Task, 0)
 
-	query := "SELECT * FROM tasks"
-	if err := r.Conn.SelectContext(ctx, &tasks, query); err != nil {
+	query := "SELECT * FROM tasks WHERE 1=1"
+	var args []any
+
+	keyset := newKeyset(pagination, models.TasksSorting, nil, "task_id")
+	if condition, conditionArgs := keyset.condition(); condition != "" {
+		query += " AND " + condition
+		args = append(args, conditionArgs...)
+	}
+	orderBy, orderByArgs := keyset.orderBy()
+	query += orderBy
+	args = append(args, orderByArgs...)
+	query = bindPlaceholders(query)
+
+	if err := r.Conn.SelectContext(ctx, &tasks, query, args...); err != nil {
 		return tasks, fmt.Errorf("%s: %w", op, err)
 	}
 
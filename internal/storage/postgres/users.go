@@ -25,7 +25,7 @@ func (r *UsersRepository) GetUserById(ctx context.Context, id int) (models.User,
 
 	query := `
 			SELECT 
-				user_id, name, login, password_hash, ST_AsEWKB(home_point) as home_point, rating 
+				user_id, name, login, password_hash, ST_AsEWKB(home_point) as home_point, rating, user_role_id 
 			FROM 
 				users 
 			WHERE 
@@ -51,7 +51,7 @@ func (r *UsersRepository) GetUserByLogin(ctx context.Context, username string) (
 
 	query := `
 			SELECT
-				user_id, name, login, password_hash, ST_AsEWKB(home_point) as home_point, rating 
+				user_id, name, login, password_hash, ST_AsEWKB(home_point) as home_point, rating, user_role_id 
 			FROM 
 				users 
 			WHERE 
@@ -70,19 +70,32 @@ func (r *UsersRepository) GetUserByLogin(ctx context.Context, username string) (
 
 }
 
-func (r *UsersRepository) GetUsers(ctx context.Context) ([]models.User, error) {
+func (r *UsersRepository) GetUsers(ctx context.Context, pagination models.Pagination) ([]models.User, error) {
 	const op = "storage.postgres.GetUsers"
 
 	users := make([]models.User, 0)
 
 	query := `
 			SELECT
-				user_id, name, login, ST_AsEWKB(home_point) as home_point, rating
+				user_id, name, login, ST_AsEWKB(home_point) as home_point, rating, user_role_id
 			FROM 
 				users
+			WHERE
+				1=1
 			`
+	var args []any
 
-	if err := r.Conn.SelectContext(ctx, &users, query); err != nil {
+	keyset := newKeyset(pagination, models.UsersSorting, nil, "user_id")
+	if condition, conditionArgs := keyset.condition(); condition != "" {
+		query += " AND " + condition
+		args = append(args, conditionArgs...)
+	}
+	orderBy, orderByArgs := keyset.orderBy()
+	query += orderBy
+	args = append(args, orderByArgs...)
+	query = bindPlaceholders(query)
+
+	if err := r.Conn.SelectContext(ctx, &users, query, args...); err != nil {
 		return users, fmt.Errorf("%s: %w", op, err)
 	}
 
@@ -113,3 +126,22 @@ func (r *UsersRepository) AddUser(ctx context.Context, user models.User) (int64,
 
 	return id, nil
 }
+
+func (r *UsersRepository) UpdatePassword(ctx context.Context, userId int, passwordHash string) error {
+	const op = "storage.postgres.UpdatePassword"
+
+	res, err := r.Conn.ExecContext(ctx, "UPDATE users SET password_hash = $1 WHERE user_id = $2", passwordHash, userId)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if affected == 0 {
+		return storage.ErrNotFound
+	}
+
+	return nil
+}
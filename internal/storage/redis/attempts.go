@@ -0,0 +1,104 @@
+package redis
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/PritOriginal/problem-map-server/internal/models"
+	"github.com/redis/go-redis/v9"
+)
+
+// AttemptsRepository counts the attempts in the keys attempts:{key} and keeps the lockouts
+// of the keys in the keys lockouts:{key} expiring with the lockout
+type AttemptsRepository struct {
+	Client *redis.Client
+}
+
+func NewAttempts(r *Redis) *AttemptsRepository {
+	return &AttemptsRepository{Client: r.Client}
+}
+
+// addAttemptScript counts the attempt by all keys unless one of them is locked out. KEYS are the pairs
+// of the counter and the lockout of every limit, ARGV are the window, the base and the max delays in ms
+// followed by the free attempts of every limit. It returns the time left of the longest lockout in ms, 0 when counted.
+var addAttemptScript = redis.NewScript(`
+local retry = 0
+for i = 2, #KEYS, 2 do
+	retry = math.max(retry, redis.call("PTTL", KEYS[i]))
+end
+if retry > 0 then
+	return retry
+end
+for i = 1, #KEYS, 2 do
+	local attempts = redis.call("INCR", KEYS[i])
+	redis.call("PEXPIRE", KEYS[i], ARGV[1])
+	local over = attempts - tonumber(ARGV[3 + (i + 1) / 2])
+	if over > 0 then
+		local delay = math.floor(math.min(tonumber(ARGV[2]) * 2 ^ (over - 1), tonumber(ARGV[3])))
+		if delay > 0 then
+			redis.call("SET", KEYS[i + 1], 1, "PX", delay)
+		end
+	end
+end
+return 0
+`)
+
+// forgetAttemptScript uncounts the attempt of the key, the counter is removed when it's empty
+var forgetAttemptScript = redis.NewScript(`
+if redis.call("DECR", KEYS[1]) <= 0 then
+	redis.call("DEL", KEYS[1])
+end
+return 1
+`)
+
+func attemptsKey(key string) string {
+	return "attempts:" + key
+}
+
+func lockoutKey(key string) string {
+	return "lockouts:" + key
+}
+
+// AddAttempt counts the attempt by the keys of the limits before its outcome is known, so the concurrent attempts
+// can't bypass the limits. Every attempt over the free ones of the key locks the key out for the back-off.
+// While any of the keys is locked out the attempt isn't counted and the time left of the lockout is returned.
+func (r *AttemptsRepository) AddAttempt(ctx context.Context, limits []models.AttemptsLimit, backoff models.Backoff) (time.Duration, error) {
+	const op = "storage.redis.AddAttempt"
+
+	keys := make([]string, 0, 2*len(limits))
+	args := []any{backoff.Window.Milliseconds(), backoff.BaseDelay.Milliseconds(), backoff.MaxDelay.Milliseconds()}
+	for _, limit := range limits {
+		keys = append(keys, attemptsKey(limit.Key), lockoutKey(limit.Key))
+		args = append(args, limit.FreeAttempts)
+	}
+
+	retry, err := addAttemptScript.Run(ctx, r.Client, keys, args...).Int64()
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return time.Duration(retry) * time.Millisecond, nil
+}
+
+// ForgetAttempt uncounts the successful attempt of the key, the lockout of the key stays
+func (r *AttemptsRepository) ForgetAttempt(ctx context.Context, key string) error {
+	const op = "storage.redis.ForgetAttempt"
+
+	if err := forgetAttemptScript.Run(ctx, r.Client, []string{attemptsKey(key)}).Err(); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// ResetAttempts removes the attempts and the lockout of the key
+func (r *AttemptsRepository) ResetAttempts(ctx context.Context, key string) error {
+	const op = "storage.redis.ResetAttempts"
+
+	if err := r.Client.Del(ctx, attemptsKey(key), lockoutKey(key)).Err(); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,215 @@
+package redis
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/PritOriginal/problem-map-server/internal/models"
+	"github.com/PritOriginal/problem-map-server/internal/storage"
+	"github.com/redis/go-redis/v9"
+)
+
+// SessionsRepository keeps the sessions in the hashes sessions:{id} and the ids of the user sessions
+// in the set users:{userId}:sessions. The sessions expire with their last refresh token, the set expires
+// with the last issued refresh token of the user, as all of them have the same TTL.
+type SessionsRepository struct {
+	Client *redis.Client
+}
+
+func NewSessions(r *Redis) *SessionsRepository {
+	return &SessionsRepository{Client: r.Client}
+}
+
+// rotateSessionScript replaces the token id and the client of the session when the token id matches the expected one.
+// It returns 0 when there is no session and -1 when the token id doesn't match.
+var rotateSessionScript = redis.NewScript(`
+local current = redis.call("HGET", KEYS[1], "token_id")
+if not current then
+	return 0
+end
+if current ~= ARGV[1] then
+	return -1
+end
+redis.call("HSET", KEYS[1], "token_id", ARGV[2], "user_agent", ARGV[3], "ip", ARGV[4], "last_used_at", ARGV[5])
+redis.call("PEXPIRE", KEYS[1], ARGV[6])
+redis.call("PEXPIRE", KEYS[2], ARGV[6])
+return 1
+`)
+
+// deleteSessionScript deletes the session only when it is in the set of the user sessions.
+// It returns 0 when the user has no such session.
+var deleteSessionScript = redis.NewScript(`
+if redis.call("SREM", KEYS[1], ARGV[1]) == 0 then
+	return 0
+end
+redis.call("DEL", KEYS[2])
+return 1
+`)
+
+type session struct {
+	UserID     int    `redis:"user_id"`
+	TokenID    string `redis:"token_id"`
+	UserAgent  string `redis:"user_agent"`
+	IP         string `redis:"ip"`
+	CreatedAt  int64  `redis:"created_at"`
+	LastUsedAt int64  `redis:"last_used_at"`
+}
+
+func sessionKey(id string) string {
+	return "sessions:" + id
+}
+
+func userSessionsKey(userId int) string {
+	return fmt.Sprintf("users:%d:sessions", userId)
+}
+
+// AddSession stores the session expiring after the ttl
+func (r *SessionsRepository) AddSession(ctx context.Context, s models.Session, ttl time.Duration) error {
+	const op = "storage.redis.AddSession"
+
+	_, err := r.Client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.HSet(ctx, sessionKey(s.ID), session{
+			UserID:     s.UserID,
+			TokenID:    s.TokenID,
+			UserAgent:  s.UserAgent,
+			IP:         s.IP,
+			CreatedAt:  s.CreatedAt.Unix(),
+			LastUsedAt: s.LastUsedAt.Unix(),
+		})
+		pipe.PExpire(ctx, sessionKey(s.ID), ttl)
+		pipe.SAdd(ctx, userSessionsKey(s.UserID), s.ID)
+		pipe.PExpire(ctx, userSessionsKey(s.UserID), ttl)
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// RotateSession replaces the refresh token id, the client and the last use time of the user session
+// by the ones of s and extends the session for the ttl.
+// It returns storage.ErrNotFound when there is no session and storage.ErrConflict when the session has another token id.
+func (r *SessionsRepository) RotateSession(ctx context.Context, s models.Session, tokenId string, ttl time.Duration) error {
+	const op = "storage.redis.RotateSession"
+
+	res, err := rotateSessionScript.Run(ctx, r.Client,
+		[]string{sessionKey(s.ID), userSessionsKey(s.UserID)},
+		tokenId, s.TokenID, s.UserAgent, s.IP, s.LastUsedAt.Unix(), ttl.Milliseconds(),
+	).Int()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	switch res {
+	case 0:
+		return storage.ErrNotFound
+	case -1:
+		return storage.ErrConflict
+	}
+
+	return nil
+}
+
+// GetUserSessions returns the active sessions of the user, the ids of the expired sessions are removed from the set
+func (r *SessionsRepository) GetUserSessions(ctx context.Context, userId int) ([]models.Session, error) {
+	const op = "storage.redis.GetUserSessions"
+
+	sessions := []models.Session{}
+
+	ids, err := r.Client.SMembers(ctx, userSessionsKey(userId)).Result()
+	if err != nil {
+		return sessions, fmt.Errorf("%s: %w", op, err)
+	}
+	if len(ids) == 0 {
+		return sessions, nil
+	}
+
+	values := make([]*redis.MapStringStringCmd, len(ids))
+	ttls := make([]*redis.DurationCmd, len(ids))
+	_, err = r.Client.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		for i, id := range ids {
+			values[i] = pipe.HGetAll(ctx, sessionKey(id))
+			ttls[i] = pipe.PTTL(ctx, sessionKey(id))
+		}
+		return nil
+	})
+	if err != nil {
+		return sessions, fmt.Errorf("%s: %w", op, err)
+	}
+
+	now := time.Now()
+	var expired []any
+	for i, id := range ids {
+		if len(values[i].Val()) == 0 {
+			expired = append(expired, id)
+			continue
+		}
+
+		var s session
+		if err := values[i].Scan(&s); err != nil {
+			return sessions, fmt.Errorf("%s: %w", op, err)
+		}
+		sessions = append(sessions, models.Session{
+			ID:      id,
+			UserID:  s.UserID,
+			TokenID: s.TokenID,
+			SessionClient: models.SessionClient{
+				UserAgent: s.UserAgent,
+				IP:        s.IP,
+			},
+			CreatedAt:  time.Unix(s.CreatedAt, 0),
+			LastUsedAt: time.Unix(s.LastUsedAt, 0),
+			ExpiresAt:  now.Add(ttls[i].Val()),
+		})
+	}
+
+	if len(expired) > 0 {
+		if err := r.Client.SRem(ctx, userSessionsKey(userId), expired...).Err(); err != nil {
+			return sessions, fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	return sessions, nil
+}
+
+// DeleteSession deletes the user session, it returns storage.ErrNotFound when the user has no such session
+func (r *SessionsRepository) DeleteSession(ctx context.Context, userId int, id string) error {
+	const op = "storage.redis.DeleteSession"
+
+	res, err := deleteSessionScript.Run(ctx, r.Client,
+		[]string{userSessionsKey(userId), sessionKey(id)},
+		id,
+	).Int()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if res == 0 {
+		return storage.ErrNotFound
+	}
+
+	return nil
+}
+
+// DeleteUserSessions deletes all sessions of the user
+func (r *SessionsRepository) DeleteUserSessions(ctx context.Context, userId int) error {
+	const op = "storage.redis.DeleteUserSessions"
+
+	ids, err := r.Client.SMembers(ctx, userSessionsKey(userId)).Result()
+	if err != nil && !errors.Is(err, redis.Nil) {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	keys := []string{userSessionsKey(userId)}
+	for _, id := range ids {
+		keys = append(keys, sessionKey(id))
+	}
+	if err := r.Client.Del(ctx, keys...).Err(); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
@@ -1,41 +1,51 @@
 package s3
 
 import (
+	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"io"
-	"strconv"
 	"strings"
 
+	"github.com/PritOriginal/problem-map-server/internal/config"
+	"github.com/PritOriginal/problem-map-server/internal/models"
+	"github.com/PritOriginal/problem-map-server/internal/storage"
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
 )
 
 type PhotosRepo struct {
-	S3 *S3
+	S3  *S3
+	cfg config.AwsConfig
 }
 
-func NewPhotos(S3 *S3) *PhotosRepo {
-	return &PhotosRepo{S3: S3}
+func NewPhotos(S3 *S3, cfg config.AwsConfig) *PhotosRepo {
+	return &PhotosRepo{S3: S3, cfg: cfg}
 }
 
-func (repo *PhotosRepo) AddPhotos(ctx context.Context, markId, checkId int, photos []io.Reader) error {
+// AddPhotos stores the variants of the photos numbered from first and returns their records
+func (repo *PhotosRepo) AddPhotos(ctx context.Context, markId, checkId, first int, photos []io.Reader) ([]models.PhotoRecord, error) {
 	const op = "storage.s3.AddPhotos"
 
-	buckets, err := repo.S3.GetBuckets(ctx)
-	if err != nil {
-		return fmt.Errorf("%s: %w", op, err)
-	}
-
+	var records []models.PhotoRecord
 	for i, photo := range photos {
-		objectKey := fmt.Sprintf("marks/%v/%v/%v.jpg", markId, checkId, i+1)
-		err := repo.AddPhoto(ctx, *buckets[0].Name, objectKey, photo)
+		files, err := storage.EncodePhotoVariants(photo)
 		if err != nil {
-			return fmt.Errorf("%s: %w", op, err)
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+
+		for _, file := range files {
+			record := file.Record(markId, checkId, first+i)
+			if err := repo.AddPhoto(ctx, repo.S3.Bucket, record.Key, bytes.NewReader(file.Data)); err != nil {
+				return nil, fmt.Errorf("%s: %w", op, err)
+			}
+			records = append(records, record)
 		}
 	}
 
-	return nil
+	return records, nil
 }
 
 func (repo *PhotosRepo) AddPhoto(ctx context.Context, bucketName string, objectKey string, photo io.Reader) error {
@@ -53,87 +63,148 @@ func (repo *PhotosRepo) AddPhoto(ctx context.Context, bucketName string, objectK
 	return nil
 }
 
-func (repo *PhotosRepo) GetPhotos(ctx context.Context) (map[int]map[int][]string, error) {
-	const op = "storage.s3.GetPhotos"
+// URL returns the URL of the photo with the key, the URL of the photo in the private bucket is presigned
+func (repo *PhotosRepo) URL(ctx context.Context, key string) (string, error) {
+	const op = "storage.s3.URL"
 
-	photos, err := repo.getPhotos(ctx, &s3.ListObjectsV2Input{
-		Prefix: aws.String("marks/"),
-	})
+	if !repo.cfg.Private {
+		return aws.ToString(repo.S3.Client.Options().BaseEndpoint) + "/" + repo.S3.Bucket + "/" + key, nil
+	}
+
+	req, err := repo.S3.Presign.PresignGetObject(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(repo.S3.Bucket),
+		Key:    aws.String(key),
+	}, s3.WithPresignExpires(repo.cfg.URLExpires))
 	if err != nil {
-		return photos, fmt.Errorf("%s: %w", op, err)
+		return "", fmt.Errorf("%s: %w", op, err)
 	}
 
-	return photos, nil
+	return req.URL, nil
 }
 
-func (repo *PhotosRepo) GetPhotosByMarkId(ctx context.Context, markId int) (map[int]map[int][]string, error) {
-	const op = "storage.s3.GetPhotosByMarkId"
+// ListPhotos returns the keys of the photos with the prefix
+func (repo *PhotosRepo) ListPhotos(ctx context.Context, prefix string) ([]string, error) {
+	const op = "storage.s3.ListPhotos"
 
-	photos, err := repo.getPhotos(ctx, &s3.ListObjectsV2Input{
-		Prefix: aws.String(fmt.Sprintf("marks/%v", markId)),
+	var keys []string
+	err := repo.forEachObject(ctx, prefix, func(objectKey string) error {
+		keys = append(keys, objectKey)
+		return nil
 	})
 	if err != nil {
-		return photos, fmt.Errorf("%s: %w", op, err)
+		return nil, fmt.Errorf("%s: %w", op, err)
 	}
 
-	return photos, nil
+	return keys, nil
 }
 
-func (repo *PhotosRepo) GetPhotosByCheckId(ctx context.Context, markId, checkId int) ([]string, error) {
-	const op = "storage.s3.GetPhotosByMarkId"
+// GetPhoto returns the content of the photo with the key
+func (repo *PhotosRepo) GetPhoto(ctx context.Context, key string) (io.ReadCloser, error) {
+	const op = "storage.s3.GetPhoto"
 
-	photos, err := repo.getPhotos(ctx, &s3.ListObjectsV2Input{
-		Prefix: aws.String(fmt.Sprintf("marks/%v/%v", markId, checkId)),
+	output, err := repo.S3.Client.GetObject(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(repo.S3.Bucket),
+		Key:    aws.String(key),
 	})
 	if err != nil {
+		var noSuchKey *types.NoSuchKey
+		if errors.As(err, &noSuchKey) {
+			return nil, storage.ErrNotFound
+		}
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
 
-	return photos[markId][checkId], nil
+	return output.Body, nil
 }
 
-func (repo *PhotosRepo) getPhotos(ctx context.Context, params *s3.ListObjectsV2Input) (map[int]map[int][]string, error) {
-	const op = "storage.s3.getPhotos"
+// CopyPhotos copies the photos of the mark to the other mark, remapping the keys
+// marks/{fromMarkId}/{checkId}/n.jpg to marks/{toMarkId}/{checkId}/n.jpg.
+// It returns the keys of the copies, also the ones made before an error.
+func (repo *PhotosRepo) CopyPhotos(ctx context.Context, fromMarkId, toMarkId int) ([]string, error) {
+	const op = "storage.s3.CopyPhotos"
+
+	fromPrefix := fmt.Sprintf("marks/%v/", fromMarkId)
+	toPrefix := fmt.Sprintf("marks/%v/", toMarkId)
+
+	var copied []string
+	err := repo.forEachObject(ctx, fromPrefix, func(objectKey string) error {
+		copyKey := toPrefix + strings.TrimPrefix(objectKey, fromPrefix)
+		_, err := repo.S3.Client.CopyObject(ctx, &s3.CopyObjectInput{
+			Bucket:     aws.String(repo.S3.Bucket),
+			CopySource: aws.String(repo.S3.Bucket + "/" + objectKey),
+			Key:        aws.String(copyKey),
+		})
+		if err != nil {
+			return err
+		}
+		copied = append(copied, copyKey)
+		return nil
+	})
+	if err != nil {
+		return copied, fmt.Errorf("%s: %w", op, err)
+	}
 
-	photos := make(map[int]map[int][]string)
-	endpoint := *repo.S3.Client.Options().BaseEndpoint
+	return copied, nil
+}
 
-	buckets, err := repo.S3.GetBuckets(ctx)
-	if err != nil {
-		return photos, fmt.Errorf("%s: %w", op, err)
+// DeletePhotos deletes the photos with the keys
+func (repo *PhotosRepo) DeletePhotos(ctx context.Context, keys []string) error {
+	const op = "storage.s3.DeletePhotos"
+
+	for _, key := range keys {
+		_, err := repo.S3.Client.DeleteObject(ctx, &s3.DeleteObjectInput{
+			Bucket: aws.String(repo.S3.Bucket),
+			Key:    aws.String(key),
+		})
+		if err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
 	}
 
-	for _, bucket := range buckets {
-		params.Bucket = aws.String(*bucket.Name)
+	return nil
+}
 
-		paginator := s3.NewListObjectsV2Paginator(repo.S3.Client, params)
-		for paginator.HasMorePages() {
-			output, err := paginator.NextPage(ctx)
-			if err != nil {
-				return photos, fmt.Errorf("%s: %w", op, err)
-			}
-			for _, object := range output.Contents {
-				keyParts := strings.Split(*object.Key, "/")
+func (repo *PhotosRepo) DeletePhotosByMarkId(ctx context.Context, markId int) error {
+	const op = "storage.s3.DeletePhotosByMarkId"
 
-				markId, err := strconv.Atoi(keyParts[1])
-				if err != nil {
-					return photos, err
-				}
+	err := repo.forEachObject(ctx, fmt.Sprintf("marks/%v/", markId), func(objectKey string) error {
+		_, err := repo.S3.Client.DeleteObject(ctx, &s3.DeleteObjectInput{
+			Bucket: aws.String(repo.S3.Bucket),
+			Key:    aws.String(objectKey),
+		})
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
 
-				reviewId, err := strconv.Atoi(keyParts[2])
-				if err != nil {
-					return photos, err
-				}
+	return nil
+}
 
-				if photos[markId] == nil {
-					photos[markId] = make(map[int][]string)
-				}
+// forEachObject calls fn for every object with the prefix in the bucket.
+// The keys are listed before fn is called, so fn may add or delete objects.
+func (repo *PhotosRepo) forEachObject(ctx context.Context, prefix string, fn func(objectKey string) error) error {
+	var keys []string
 
-				src := endpoint + "/" + *bucket.Name + "/" + *object.Key
-				photos[markId][reviewId] = append(photos[markId][reviewId], src)
-			}
+	paginator := s3.NewListObjectsV2Paginator(repo.S3.Client, &s3.ListObjectsV2Input{
+		Bucket: aws.String(repo.S3.Bucket),
+		Prefix: aws.String(prefix),
+	})
+	for paginator.HasMorePages() {
+		output, err := paginator.NextPage(ctx)
+		if err != nil {
+			return err
+		}
+		for _, object := range output.Contents {
+			keys = append(keys, *object.Key)
 		}
 	}
 
-	return photos, nil
+	for _, key := range keys {
+		if err := fn(key); err != nil {
+			return err
+		}
+	}
+
+	return nil
 }
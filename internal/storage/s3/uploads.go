@@ -0,0 +1,79 @@
+package s3
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/PritOriginal/problem-map-server/internal/config"
+	"github.com/PritOriginal/problem-map-server/internal/models"
+	"github.com/PritOriginal/problem-map-server/internal/storage"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+)
+
+// UploadsRepo issues the presigned URLs the photos are uploaded by directly to the bucket.
+// The uploads which are not attached to a check are expected to be removed by the bucket lifecycle rule.
+type UploadsRepo struct {
+	S3  *S3
+	cfg config.AwsConfig
+}
+
+func NewUploads(S3 *S3, cfg config.AwsConfig) *UploadsRepo {
+	return &UploadsRepo{S3: S3, cfg: cfg}
+}
+
+// PresignUpload returns the presigned PUT URL of the object with the key
+func (repo *UploadsRepo) PresignUpload(ctx context.Context, key string) (models.PhotoUpload, error) {
+	const op = "storage.s3.PresignUpload"
+
+	req, err := repo.S3.Presign.PresignPutObject(ctx, &s3.PutObjectInput{
+		Bucket: aws.String(repo.S3.Bucket),
+		Key:    aws.String(key),
+	}, s3.WithPresignExpires(repo.cfg.UploadExpires))
+	if err != nil {
+		return models.PhotoUpload{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return models.PhotoUpload{
+		Key:       key,
+		URL:       req.URL,
+		ExpiresAt: time.Now().Add(repo.cfg.UploadExpires),
+	}, nil
+}
+
+// GetUpload returns the uploaded object, it returns storage.ErrNotFound when nothing was uploaded with the key
+func (repo *UploadsRepo) GetUpload(ctx context.Context, key string) (io.ReadCloser, error) {
+	const op = "storage.s3.GetUpload"
+
+	output, err := repo.S3.Client.GetObject(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(repo.S3.Bucket),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		var noSuchKey *types.NoSuchKey
+		if errors.As(err, &noSuchKey) {
+			return nil, storage.ErrNotFound
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return output.Body, nil
+}
+
+func (repo *UploadsRepo) DeleteUpload(ctx context.Context, key string) error {
+	const op = "storage.s3.DeleteUpload"
+
+	_, err := repo.S3.Client.DeleteObject(ctx, &s3.DeleteObjectInput{
+		Bucket: aws.String(repo.S3.Bucket),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
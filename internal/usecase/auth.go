@@ -2,10 +2,15 @@ package usecase
 
 import (
 	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"log/slog"
 	"strconv"
+	"sync"
+	"time"
 
 	"github.com/PritOriginal/problem-map-server/internal/config"
 	"github.com/PritOriginal/problem-map-server/internal/models"
@@ -15,18 +20,84 @@ import (
 	"github.com/PritOriginal/problem-map-server/pkg/token"
 )
 
+type SessionsRepository interface {
+	AddSession(ctx context.Context, session models.Session, ttl time.Duration) error
+	RotateSession(ctx context.Context, session models.Session, tokenId string, ttl time.Duration) error
+	GetUserSessions(ctx context.Context, userId int) ([]models.Session, error)
+	DeleteSession(ctx context.Context, userId int, id string) error
+	DeleteUserSessions(ctx context.Context, userId int) error
+}
+
+type PasswordResetsRepository interface {
+	AddPasswordReset(ctx context.Context, userId int, tokenHash string, ttl time.Duration) error
+	ResetPassword(ctx context.Context, tokenHash, passwordHash string) (int, error)
+}
+
+type AttemptsRepository interface {
+	AddAttempt(ctx context.Context, limits []models.AttemptsLimit, backoff models.Backoff) (time.Duration, error)
+	ForgetAttempt(ctx context.Context, key string) error
+	ResetAttempts(ctx context.Context, key string) error
+}
+
+// Notifier delivers the notifications to the users
+type Notifier interface {
+	SendPasswordReset(ctx context.Context, user models.User, token string, expiresAt time.Time) error
+}
+
 type Auth struct {
-	log     *slog.Logger
-	repos   AuthRepositories
-	authCfg config.AuthConfing
+	log        *slog.Logger
+	repos      AuthRepositories
+	authCfg    config.AuthConfing
+	accessKeys *token.KeySet
+	notifier   Notifier
+	// resets are the password resets being sent in the background
+	resets sync.WaitGroup
 }
 
 type AuthRepositories struct {
-	Users UsersRepository
+	Users          UsersRepository
+	Sessions       SessionsRepository
+	PasswordResets PasswordResetsRepository
+	Attempts       AttemptsRepository
+}
+
+func NewAuth(log *slog.Logger, authCfg config.AuthConfing, accessKeys *token.KeySet, notifier Notifier, repos AuthRepositories) *Auth {
+	return &Auth{log: log, repos: repos, authCfg: authCfg, accessKeys: accessKeys, notifier: notifier}
+}
+
+// LoadAccessKeys loads the keys of the access tokens. The tokens are signed by the HS256 secret
+// without the signing key, otherwise the secret only verifies the tokens issued before.
+func LoadAccessKeys(authCfg config.AuthConfing) (*token.KeySet, error) {
+	const op = "usecase.LoadAccessKeys"
+
+	access := authCfg.JWT.Access
+	if access.SigningKey == "" {
+		return token.NewHMACKeySet(access.Key), nil
+	}
+
+	var keys []token.Key
+	if access.Key != "" {
+		keys = append(keys, token.HMACKey(access.Key))
+	}
+	for _, keyCfg := range access.Keys {
+		key, err := token.LoadKey(keyCfg.ID, keyCfg.Algorithm, keyCfg.PrivateKeyFile, keyCfg.PublicKeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		keys = append(keys, key)
+	}
+
+	accessKeys, err := token.NewKeySet(access.SigningKey, keys...)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return accessKeys, nil
 }
 
-func NewAuth(log *slog.Logger, authCfg config.AuthConfing, repos AuthRepositories) *Auth {
-	return &Auth{log: log, repos: repos, authCfg: authCfg}
+// JWKS returns the public keys of the access tokens
+func (uc *Auth) JWKS() token.JWKS {
+	return uc.accessKeys.JWKS()
 }
 
 func (uc *Auth) SignUp(ctx context.Context, username, login, password string) (int64, error) {
@@ -62,11 +133,30 @@ func (uc *Auth) SignUp(ctx context.Context, username, login, password string) (i
 	return id, nil
 }
 
-func (uc *Auth) SignIn(ctx context.Context, login, password string) (string, string, error) {
+// SignIn starts a new session of the user on the client. The failed sign-ins are counted by the login
+// and by the client IP, too many of them lock the sign-in out with LockedOutError.
+func (uc *Auth) SignIn(ctx context.Context, login, password string, client models.SessionClient) (string, string, error) {
 	const op = "usecase.Users.SignIn"
 
+	loginKey, ipKey := "signin:login:"+login, "signin:ip:"+client.IP
+	limits := []models.AttemptsLimit{{Key: loginKey, FreeAttempts: uc.authCfg.SignIn.LoginAttempts}}
+	if client.IP != "" {
+		limits = append(limits, models.AttemptsLimit{Key: ipKey, FreeAttempts: uc.authCfg.SignIn.IPAttempts})
+	}
+	retryAfter, err := uc.repos.Attempts.AddAttempt(ctx, limits, models.Backoff(uc.authCfg.SignIn.Backoff))
+	if err != nil {
+		return "", "", fmt.Errorf("%s: %w", op, err)
+	}
+	if retryAfter > 0 {
+		return "", "", fmt.Errorf("%s: %w", op, &LockedOutError{RetryAfter: retryAfter})
+	}
+
 	user, err := uc.repos.Users.GetUserByLogin(ctx, login)
 	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			// the missing user takes as long as the wrong password
+			passwordUtils.CheckDummyHash(password)
+		}
 		return "", "", fmt.Errorf("%s: %w", op, err)
 	}
 
@@ -74,7 +164,30 @@ func (uc *Auth) SignIn(ctx context.Context, login, password string) (string, str
 		return "", "", fmt.Errorf("%s: %w", op, storage.ErrNotFound)
 	}
 
-	accessToken, refreshToken, err := uc.generateTokens(user.Id)
+	// the attempts were counted as failed ones beforehand
+	if err := uc.repos.Attempts.ResetAttempts(ctx, loginKey); err != nil {
+		return "", "", fmt.Errorf("%s: %w", op, err)
+	}
+	if client.IP != "" {
+		if err := uc.repos.Attempts.ForgetAttempt(ctx, ipKey); err != nil {
+			return "", "", fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	now := time.Now()
+	session := models.Session{
+		ID:            rand.Text(),
+		UserID:        user.Id,
+		TokenID:       rand.Text(),
+		SessionClient: client,
+		CreatedAt:     now,
+		LastUsedAt:    now,
+	}
+	if err := uc.repos.Sessions.AddSession(ctx, session, uc.authCfg.JWT.Refresh.ExpiredIn); err != nil {
+		return "", "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	accessToken, refreshToken, err := uc.generateTokens(user, session)
 	if err != nil {
 		return "", "", fmt.Errorf("%s: %w", op, err)
 	}
@@ -82,20 +195,41 @@ func (uc *Auth) SignIn(ctx context.Context, login, password string) (string, str
 	return accessToken, refreshToken, nil
 }
 
-func (uc *Auth) RefreshTokens(ctx context.Context, refreshToken string) (string, string, error) {
+// RefreshTokens rotates the refresh token of the session and records the client the session is used from.
+// Every refresh token can be used only once, using a rotated refresh token again means it was stolen,
+// so the session is revoked.
+func (uc *Auth) RefreshTokens(ctx context.Context, refreshToken string, client models.SessionClient) (string, string, error) {
 	const op = "usecase.Users.RefreshTokens"
 
-	sub, err := token.ValidateToken(refreshToken, uc.authCfg.JWT.Refresh.Key)
+	claims, err := uc.parseRefreshToken(refreshToken)
 	if err != nil {
-		return "", "", fmt.Errorf("%s: %w", op, ErrUnauthorized)
+		return "", "", fmt.Errorf("%s: %w", op, err)
 	}
 
-	userId, err := strconv.Atoi(fmt.Sprint(sub))
+	session := models.Session{
+		ID:            claims.sessionId,
+		UserID:        claims.userId,
+		TokenID:       rand.Text(),
+		SessionClient: client,
+		LastUsedAt:    time.Now(),
+	}
+	err = uc.repos.Sessions.RotateSession(ctx, session, claims.tokenId, uc.authCfg.JWT.Refresh.ExpiredIn)
 	if err != nil {
-		return "", "", fmt.Errorf("%s: %w", op, ErrUnauthorized)
+		switch {
+		case errors.Is(err, storage.ErrNotFound):
+			return "", "", fmt.Errorf("%s: %w: the session is revoked", op, ErrUnauthorized)
+		case errors.Is(err, storage.ErrConflict):
+			uc.log.Warn("refresh token reuse, the session is revoked", slog.Int("user_id", claims.userId), slog.String("session_id", claims.sessionId))
+			if err := uc.repos.Sessions.DeleteSession(ctx, claims.userId, claims.sessionId); err != nil && !errors.Is(err, storage.ErrNotFound) {
+				return "", "", fmt.Errorf("%s: %w", op, err)
+			}
+			return "", "", fmt.Errorf("%s: %w: the refresh token is reused", op, ErrUnauthorized)
+		default:
+			return "", "", fmt.Errorf("%s: %w", op, err)
+		}
 	}
 
-	user, err := uc.repos.Users.GetUserById(ctx, userId)
+	user, err := uc.repos.Users.GetUserById(ctx, claims.userId)
 	if err != nil {
 		if errors.Is(err, storage.ErrNotFound) {
 			return "", "", fmt.Errorf("%s: %w", op, ErrUnauthorized)
@@ -104,7 +238,7 @@ func (uc *Auth) RefreshTokens(ctx context.Context, refreshToken string) (string,
 		}
 	}
 
-	accessToken, refreshToken, err := uc.generateTokens(user.Id)
+	accessToken, refreshToken, err := uc.generateTokens(user, session)
 	if err != nil {
 		return "", "", fmt.Errorf("%s: %w", op, err)
 	}
@@ -112,15 +246,212 @@ func (uc *Auth) RefreshTokens(ctx context.Context, refreshToken string) (string,
 	return accessToken, refreshToken, nil
 }
 
-func (uc *Auth) generateTokens(userId int) (string, string, error) {
+// Logout revokes the session of the refresh token and returns the user of the session.
+// The access tokens issued for the session stay valid until they expire.
+func (uc *Auth) Logout(ctx context.Context, refreshToken string) (int, error) {
+	const op = "usecase.Users.Logout"
+
+	claims, err := uc.parseRefreshToken(refreshToken)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	err = uc.repos.Sessions.DeleteSession(ctx, claims.userId, claims.sessionId)
+	if err != nil && !errors.Is(err, storage.ErrNotFound) {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return claims.userId, nil
+}
+
+// LogoutAll revokes all sessions of the user
+func (uc *Auth) LogoutAll(ctx context.Context, userId int) error {
+	const op = "usecase.Users.LogoutAll"
+
+	if err := uc.repos.Sessions.DeleteUserSessions(ctx, userId); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// ChangePassword sets the new password of the user after checking the old one, the other sessions of the user are revoked.
+// It returns ErrForbidden when the old password is wrong.
+func (uc *Auth) ChangePassword(ctx context.Context, userId int, sessionId, oldPassword, newPassword string) error {
+	const op = "usecase.Users.ChangePassword"
+
+	user, err := uc.repos.Users.GetUserById(ctx, userId)
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			return fmt.Errorf("%s: %w", op, ErrUnauthorized)
+		}
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if !passwordUtils.CheckPasswordHash(oldPassword, user.PasswordHash) {
+		return fmt.Errorf("%s: %w: the old password is wrong", op, ErrForbidden)
+	}
+
+	passwordHash, err := passwordUtils.HashPassword(newPassword)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if err := uc.repos.Users.UpdatePassword(ctx, userId, passwordHash); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	sessions, err := uc.repos.Sessions.GetUserSessions(ctx, userId)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	for _, session := range sessions {
+		if session.ID == sessionId {
+			continue
+		}
+		if err := uc.repos.Sessions.DeleteSession(ctx, userId, session.ID); err != nil && !errors.Is(err, storage.ErrNotFound) {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	return nil
+}
+
+// RequestPasswordReset sends the password reset token to the user with the login in the background,
+// the token replaces the previous ones of the user. The unknown login is not an error and takes as long
+// as the known one, so the logins can't be enumerated. The requests are counted by the login and by the
+// client IP, too many of them lock the requests out with LockedOutError.
+func (uc *Auth) RequestPasswordReset(ctx context.Context, login, ip string) error {
+	const op = "usecase.Users.RequestPasswordReset"
+
+	resetCfg := uc.authCfg.PasswordReset
+	limits := []models.AttemptsLimit{{Key: "password_reset:login:" + login, FreeAttempts: resetCfg.LoginAttempts}}
+	if ip != "" {
+		limits = append(limits, models.AttemptsLimit{Key: "password_reset:ip:" + ip, FreeAttempts: resetCfg.IPAttempts})
+	}
+	retryAfter, err := uc.repos.Attempts.AddAttempt(ctx, limits, models.Backoff(resetCfg.Backoff))
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if retryAfter > 0 {
+		return fmt.Errorf("%s: %w", op, &LockedOutError{RetryAfter: retryAfter})
+	}
+
+	user, err := uc.repos.Users.GetUserByLogin(ctx, login)
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			uc.log.Debug("password reset of unknown user", slog.String("login", login))
+			return nil
+		}
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	// the token is stored and sent after the response, so the known login takes as long as the unknown one
+	sendCtx := context.WithoutCancel(ctx)
+	uc.resets.Go(func() {
+		if err := uc.sendPasswordReset(sendCtx, user); err != nil {
+			uc.log.Error("failed send password reset", slog.Int("user_id", user.Id), logger.Err(err))
+		}
+	})
+
+	return nil
+}
+
+func (uc *Auth) sendPasswordReset(ctx context.Context, user models.User) error {
+	const op = "usecase.Users.sendPasswordReset"
+
+	resetToken := rand.Text()
+	ttl := uc.authCfg.PasswordReset.ExpiredIn
+	if err := uc.repos.PasswordResets.AddPasswordReset(ctx, user.Id, hashResetToken(resetToken), ttl); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := uc.notifier.SendPasswordReset(ctx, user, resetToken, time.Now().Add(ttl)); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// Wait waits for the password resets being sent in the background
+func (uc *Auth) Wait() {
+	uc.resets.Wait()
+}
+
+// ResetPassword sets the new password of the user of the reset token and revokes all sessions of the user.
+// The token can be used only once, it returns ErrInvalidInput for the unknown, used and expired tokens.
+func (uc *Auth) ResetPassword(ctx context.Context, resetToken, newPassword string) (int, error) {
+	const op = "usecase.Users.ResetPassword"
+
+	passwordHash, err := passwordUtils.HashPassword(newPassword)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	userId, err := uc.repos.PasswordResets.ResetPassword(ctx, hashResetToken(resetToken), passwordHash)
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			return 0, fmt.Errorf("%s: %w: the reset token is invalid or expired", op, ErrInvalidInput)
+		}
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := uc.repos.Sessions.DeleteUserSessions(ctx, userId); err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return userId, nil
+}
+
+// hashResetToken returns the hash the reset token is stored by, the random tokens don't need a salt
+func hashResetToken(resetToken string) string {
+	sum := sha256.Sum256([]byte(resetToken))
+	return hex.EncodeToString(sum[:])
+}
+
+type refreshClaims struct {
+	userId    int
+	sessionId string
+	tokenId   string
+}
+
+// parseRefreshToken validates the refresh token, it returns ErrUnauthorized for the invalid tokens
+// and the tokens issued without a session
+func (uc *Auth) parseRefreshToken(refreshToken string) (refreshClaims, error) {
+	claims, err := token.ParseClaims(refreshToken, uc.authCfg.JWT.Refresh.Key)
+	if err != nil {
+		return refreshClaims{}, ErrUnauthorized
+	}
+
+	userId, err := strconv.Atoi(fmt.Sprint(claims["sub"]))
+	if err != nil {
+		return refreshClaims{}, ErrUnauthorized
+	}
+	sessionId, _ := claims[token.SessionClaim].(string)
+	tokenId, _ := claims[token.IDClaim].(string)
+	if sessionId == "" || tokenId == "" {
+		return refreshClaims{}, ErrUnauthorized
+	}
+
+	return refreshClaims{userId: userId, sessionId: sessionId, tokenId: tokenId}, nil
+}
+
+// generateTokens creates the access token with the user role and the session id and the refresh token of the session.
+// The role is not kept in the refresh token, so refreshing picks up role changes.
+func (uc *Auth) generateTokens(user models.User, session models.Session) (string, string, error) {
 	const op = "usecase.Users.generateTokens"
 
-	accessToken, err := token.CreateToken(uc.authCfg.JWT.Access.ExpiredIn, userId, uc.authCfg.JWT.Access.Key)
+	accessToken, err := uc.accessKeys.CreateToken(uc.authCfg.JWT.Access.ExpiredIn, user.Id, map[string]any{
+		token.RoleClaim:    user.RoleID.String(),
+		token.SessionClaim: session.ID,
+	})
 	if err != nil {
 		return "", "", fmt.Errorf("%s: %w", op, err)
 	}
 
-	refreshToken, err := token.CreateToken(uc.authCfg.JWT.Refresh.ExpiredIn, userId, uc.authCfg.JWT.Refresh.Key)
+	refreshToken, err := token.CreateTokenWithClaims(uc.authCfg.JWT.Refresh.ExpiredIn, user.Id, uc.authCfg.JWT.Refresh.Key, map[string]any{
+		token.SessionClaim: session.ID,
+		token.IDClaim:      session.TokenID,
+	})
 	if err != nil {
 		return "", "", fmt.Errorf("%s: %w", op, err)
 	}
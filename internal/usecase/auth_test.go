@@ -5,6 +5,7 @@ import (
 	"errors"
 	"log/slog"
 	"testing"
+	"time"
 
 	"github.com/PritOriginal/problem-map-server/internal/config"
 	"github.com/PritOriginal/problem-map-server/internal/models"
@@ -25,19 +26,30 @@ type method[T any] struct {
 
 type AuthSuite struct {
 	suite.Suite
-	uc        *usecase.Auth
-	log       *slog.Logger
-	usersRepo *usecase.MockUsersRepository
-	authCfg   config.AuthConfing
+	uc           *usecase.Auth
+	log          *slog.Logger
+	usersRepo    *usecase.MockUsersRepository
+	sessionsRepo *usecase.MockSessionsRepository
+	resetsRepo   *usecase.MockPasswordResetsRepository
+	notifier     *usecase.MockNotifier
+	attemptsRepo *usecase.MockAttemptsRepository
+	authCfg      config.AuthConfing
 }
 
 func (suite *AuthSuite) SetupSuite() {
 	suite.log = slogdiscard.NewDiscardLogger()
 	suite.usersRepo = usecase.NewMockUsersRepository(suite.T())
+	suite.sessionsRepo = usecase.NewMockSessionsRepository(suite.T())
+	suite.resetsRepo = usecase.NewMockPasswordResetsRepository(suite.T())
+	suite.notifier = usecase.NewMockNotifier(suite.T())
+	suite.attemptsRepo = usecase.NewMockAttemptsRepository(suite.T())
 	cfg := config.MustLoadPath("../../configs/config-tests.yaml")
 	suite.authCfg = cfg.Auth
-	suite.uc = usecase.NewAuth(suite.log, cfg.Auth, usecase.AuthRepositories{
-		Users: suite.usersRepo,
+	suite.uc = usecase.NewAuth(suite.log, cfg.Auth, token.NewHMACKeySet(cfg.Auth.JWT.Access.Key), suite.notifier, usecase.AuthRepositories{
+		Users:          suite.usersRepo,
+		Sessions:       suite.sessionsRepo,
+		PasswordResets: suite.resetsRepo,
+		Attempts:       suite.attemptsRepo,
 	})
 }
 
@@ -109,97 +121,478 @@ func (suite *AuthSuite) TestSignUp() {
 }
 
 func (suite *AuthSuite) TestSignIn() {
+	client := models.SessionClient{UserAgent: "agent", IP: "127.0.0.1"}
 	password := "password"
 	passwordHash, err := passwordUtils.HashPassword(password)
 	suite.NoError(err)
 
 	tests := []struct {
 		name           string
+		password       string
+		addAttempt     method[time.Duration]
 		getUserByLogin method[models.User]
+		wantErr        error
 	}{
 		{
-			name: "Ok",
+			name:     "Ok",
+			password: password,
 			getUserByLogin: method[models.User]{
 				data: models.User{
 					PasswordHash: passwordHash,
+					RoleID:       models.ModeratorRole,
 				},
 				err: nil,
 			},
 		},
 		{
-			name: "Err",
+			name:     "ErrWrongPassword",
+			password: "wrong password",
+			getUserByLogin: method[models.User]{
+				data: models.User{PasswordHash: passwordHash},
+			},
+			wantErr: storage.ErrNotFound,
+		},
+		{
+			name:     "ErrUnknownLogin",
+			password: password,
+			getUserByLogin: method[models.User]{
+				err: storage.ErrNotFound,
+			},
+			wantErr: storage.ErrNotFound,
+		},
+		{
+			name:     "ErrLocked",
+			password: password,
+			addAttempt: method[time.Duration]{
+				data: 2 * time.Second,
+			},
+			wantErr: usecase.ErrTooManyRequests,
+		},
+		{
+			name:     "Err",
+			password: password,
 			getUserByLogin: method[models.User]{
 				err: errors.New(""),
 			},
+			wantErr: errors.New(""),
 		},
 	}
 
 	for _, tt := range tests {
 		suite.Run(tt.name, func() {
 			func() {
-				suite.usersRepo.On("GetUserByLogin", mock.Anything, mock.AnythingOfType("string")).Once().
+				suite.attemptsRepo.On("AddAttempt", mock.Anything, []models.AttemptsLimit{
+					{Key: "signin:login:login", FreeAttempts: suite.authCfg.SignIn.LoginAttempts},
+					{Key: "signin:ip:127.0.0.1", FreeAttempts: suite.authCfg.SignIn.IPAttempts},
+				}, models.Backoff(suite.authCfg.SignIn.Backoff)).Once().
+					Return(tt.addAttempt.data, tt.addAttempt.err)
+				if tt.addAttempt.data > 0 {
+					return
+				}
+
+				suite.usersRepo.On("GetUserByLogin", mock.Anything, "login").Once().
 					Return(tt.getUserByLogin.data, tt.getUserByLogin.err)
-				if tt.getUserByLogin.err != nil {
+				if tt.wantErr != nil {
 					return
 				}
+
+				suite.attemptsRepo.On("ResetAttempts", mock.Anything, "signin:login:login").Once().
+					Return(nil)
+				suite.attemptsRepo.On("ForgetAttempt", mock.Anything, "signin:ip:127.0.0.1").Once().
+					Return(nil)
+				suite.sessionsRepo.On("AddSession", mock.Anything, mock.MatchedBy(func(session models.Session) bool {
+					return session.ID != "" && session.TokenID != "" && session.SessionClient == client
+				}), suite.authCfg.JWT.Refresh.ExpiredIn).Once().
+					Return(nil)
 			}()
 
-			_, _, gotErr := suite.uc.SignIn(context.Background(), "login", "password")
+			accessToken, refreshToken, gotErr := suite.uc.SignIn(context.Background(), "login", tt.password, client)
 
-			if tt.getUserByLogin.err == nil {
+			switch {
+			case tt.wantErr == nil:
 				suite.NoError(gotErr)
-			} else {
+
+				claims, err := token.ParseClaims(accessToken, suite.authCfg.JWT.Access.Key)
+				suite.NoError(err)
+				suite.Equal(tt.getUserByLogin.data.RoleID.String(), claims[token.RoleClaim])
+				suite.NotEmpty(claims[token.SessionClaim])
+
+				claims, err = token.ParseClaims(refreshToken, suite.authCfg.JWT.Refresh.Key)
+				suite.NoError(err)
+				suite.NotEmpty(claims[token.SessionClaim])
+				suite.NotEmpty(claims[token.IDClaim])
+			case tt.addAttempt.data > 0:
+				var lockedErr *usecase.LockedOutError
+				suite.ErrorAs(gotErr, &lockedErr)
+				suite.Equal(tt.addAttempt.data, lockedErr.RetryAfter)
+				suite.ErrorIs(gotErr, tt.wantErr)
+			case errors.Is(tt.wantErr, storage.ErrNotFound):
+				suite.ErrorIs(gotErr, tt.wantErr)
+			default:
 				suite.NotNil(gotErr)
 			}
+			suite.attemptsRepo.AssertExpectations(suite.T())
 			suite.usersRepo.AssertExpectations(suite.T())
+			suite.sessionsRepo.AssertExpectations(suite.T())
 		})
 	}
 }
 
+// refreshToken creates the refresh token of the session s1 with the token id t1
+func (suite *AuthSuite) refreshToken(userId int) string {
+	refreshToken, err := token.CreateTokenWithClaims(suite.authCfg.JWT.Refresh.ExpiredIn, userId, suite.authCfg.JWT.Refresh.Key, map[string]any{
+		token.SessionClaim: "s1",
+		token.IDClaim:      "t1",
+	})
+	suite.Require().NoError(err)
+
+	return refreshToken
+}
+
 func (suite *AuthSuite) TestRefreshTokens() {
 	userId := 1
-	refreshToken, err := token.CreateToken(suite.authCfg.JWT.Refresh.ExpiredIn, userId, suite.authCfg.JWT.Refresh.Key)
+	legacyRefreshToken, err := token.CreateToken(suite.authCfg.JWT.Refresh.ExpiredIn, userId, suite.authCfg.JWT.Refresh.Key)
 	suite.NoError(err)
 
 	tests := []struct {
-		name        string
-		getUserById method[models.User]
+		name          string
+		refreshToken  string
+		errRotate     error
+		wantDelete    bool
+		getUserById   *method[models.User]
+		wantErr       error
+		wantAnyErr    bool
+		wantNoSession bool
 	}{
 		{
-			name: "Ok",
-			getUserById: method[models.User]{
+			name:         "Ok",
+			refreshToken: suite.refreshToken(userId),
+			getUserById: &method[models.User]{
 				data: models.User{
 					Id: userId,
 				},
-				err: nil,
 			},
 		},
 		{
-			name: "Err",
-			getUserById: method[models.User]{
+			name:          "ErrLegacyToken",
+			refreshToken:  legacyRefreshToken,
+			wantNoSession: true,
+			wantErr:       usecase.ErrUnauthorized,
+		},
+		{
+			name:         "ErrRevokedSession",
+			refreshToken: suite.refreshToken(userId),
+			errRotate:    storage.ErrNotFound,
+			wantErr:      usecase.ErrUnauthorized,
+		},
+		{
+			name:         "ErrReusedToken",
+			refreshToken: suite.refreshToken(userId),
+			errRotate:    storage.ErrConflict,
+			wantDelete:   true,
+			wantErr:      usecase.ErrUnauthorized,
+		},
+		{
+			name:         "ErrGetUserById",
+			refreshToken: suite.refreshToken(userId),
+			getUserById: &method[models.User]{
 				err: errors.New(""),
 			},
+			wantAnyErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		suite.Run(tt.name, func() {
+			if !tt.wantNoSession {
+				suite.sessionsRepo.On("RotateSession", mock.Anything, mock.MatchedBy(func(session models.Session) bool {
+					return session.ID == "s1" && session.UserID == userId && session.TokenID != "t1" && session.UserAgent == "agent"
+				}), "t1", suite.authCfg.JWT.Refresh.ExpiredIn).Once().
+					Return(tt.errRotate)
+			}
+			if tt.wantDelete {
+				suite.sessionsRepo.On("DeleteSession", mock.Anything, userId, "s1").Once().
+					Return(nil)
+			}
+			if tt.getUserById != nil {
+				suite.usersRepo.On("GetUserById", mock.Anything, userId).Once().
+					Return(tt.getUserById.data, tt.getUserById.err)
+			}
+
+			_, refreshToken, gotErr := suite.uc.RefreshTokens(context.Background(), tt.refreshToken, models.SessionClient{UserAgent: "agent", IP: "127.0.0.1"})
+
+			switch {
+			case tt.wantErr != nil:
+				suite.ErrorIs(gotErr, tt.wantErr)
+			case tt.wantAnyErr:
+				suite.NotNil(gotErr)
+			default:
+				suite.NoError(gotErr)
+
+				claims, err := token.ParseClaims(refreshToken, suite.authCfg.JWT.Refresh.Key)
+				suite.NoError(err)
+				suite.Equal("s1", claims[token.SessionClaim])
+				suite.NotEqual("t1", claims[token.IDClaim])
+			}
+			suite.usersRepo.AssertExpectations(suite.T())
+			suite.sessionsRepo.AssertExpectations(suite.T())
+		})
+	}
+}
+
+func (suite *AuthSuite) TestLogout() {
+	tests := []struct {
+		name             string
+		refreshToken     string
+		errDeleteSession error
+		wantErr          error
+	}{
+		{
+			name:         "Ok",
+			refreshToken: suite.refreshToken(1),
+		},
+		{
+			name:             "OkRevokedSession",
+			refreshToken:     suite.refreshToken(1),
+			errDeleteSession: storage.ErrNotFound,
+		},
+		{
+			name:         "ErrInvalidToken",
+			refreshToken: "a.b.c",
+			wantErr:      usecase.ErrUnauthorized,
+		},
+	}
+
+	for _, tt := range tests {
+		suite.Run(tt.name, func() {
+			if tt.wantErr == nil {
+				suite.sessionsRepo.On("DeleteSession", mock.Anything, 1, "s1").Once().
+					Return(tt.errDeleteSession)
+			}
+
+			userId, gotErr := suite.uc.Logout(context.Background(), tt.refreshToken)
+
+			if tt.wantErr == nil {
+				suite.NoError(gotErr)
+				suite.Equal(1, userId)
+			} else {
+				suite.ErrorIs(gotErr, tt.wantErr)
+			}
+			suite.sessionsRepo.AssertExpectations(suite.T())
+		})
+	}
+}
+
+func (suite *AuthSuite) TestLogoutAll() {
+	suite.sessionsRepo.On("DeleteUserSessions", mock.Anything, 1).Once().
+		Return(nil)
+
+	suite.NoError(suite.uc.LogoutAll(context.Background(), 1))
+	suite.sessionsRepo.AssertExpectations(suite.T())
+}
+
+func (suite *AuthSuite) TestChangePassword() {
+	oldPassword := "password"
+	passwordHash, err := passwordUtils.HashPassword(oldPassword)
+	suite.NoError(err)
+
+	tests := []struct {
+		name           string
+		oldPassword    string
+		getUserById    method[models.User]
+		updatePassword error
+		wantErr        error
+	}{
+		{
+			name:        "Ok",
+			oldPassword: oldPassword,
+			getUserById: method[models.User]{data: models.User{Id: 1, PasswordHash: passwordHash}},
+		},
+		{
+			name:        "ErrWrongPassword",
+			oldPassword: "wrong password",
+			getUserById: method[models.User]{data: models.User{Id: 1, PasswordHash: passwordHash}},
+			wantErr:     usecase.ErrForbidden,
+		},
+		{
+			name:        "ErrNotFoundUser",
+			oldPassword: oldPassword,
+			getUserById: method[models.User]{err: storage.ErrNotFound},
+			wantErr:     usecase.ErrUnauthorized,
+		},
+		{
+			name:           "ErrUpdatePassword",
+			oldPassword:    oldPassword,
+			getUserById:    method[models.User]{data: models.User{Id: 1, PasswordHash: passwordHash}},
+			updatePassword: errors.New(""),
 		},
 	}
 
 	for _, tt := range tests {
 		suite.Run(tt.name, func() {
 			func() {
-				suite.usersRepo.On("GetUserById", mock.Anything, mock.AnythingOfType("int")).Once().
+				suite.usersRepo.On("GetUserById", mock.Anything, 1).Once().
 					Return(tt.getUserById.data, tt.getUserById.err)
-				if tt.getUserById.err != nil {
+				if tt.wantErr != nil {
+					return
+				}
+
+				suite.usersRepo.On("UpdatePassword", mock.Anything, 1, mock.MatchedBy(func(hash string) bool {
+					return passwordUtils.CheckPasswordHash("new password", hash)
+				})).Once().
+					Return(tt.updatePassword)
+				if tt.updatePassword != nil {
+					return
+				}
+
+				suite.sessionsRepo.On("GetUserSessions", mock.Anything, 1).Once().
+					Return([]models.Session{{ID: "s1"}, {ID: "s2"}}, nil)
+				suite.sessionsRepo.On("DeleteSession", mock.Anything, 1, "s2").Once().
+					Return(nil)
+			}()
+
+			gotErr := suite.uc.ChangePassword(context.Background(), 1, "s1", tt.oldPassword, "new password")
+
+			switch {
+			case tt.wantErr != nil:
+				suite.ErrorIs(gotErr, tt.wantErr)
+			case tt.updatePassword != nil:
+				suite.NotNil(gotErr)
+			default:
+				suite.NoError(gotErr)
+			}
+			suite.usersRepo.AssertExpectations(suite.T())
+			suite.sessionsRepo.AssertExpectations(suite.T())
+		})
+	}
+}
+
+func (suite *AuthSuite) TestRequestPasswordReset() {
+	resetCfg := suite.authCfg.PasswordReset
+
+	tests := []struct {
+		name              string
+		addAttempt        method[time.Duration]
+		getUserByLogin    method[models.User]
+		sendPasswordReset error
+		wantErr           error
+	}{
+		{
+			name:           "Ok",
+			getUserByLogin: method[models.User]{data: models.User{Id: 1, Login: "login"}},
+		},
+		{
+			name:           "OkUnknownLogin",
+			getUserByLogin: method[models.User]{err: storage.ErrNotFound},
+		},
+		{
+			// the reset is sent in the background, its error is only logged
+			name:              "OkErrSendPasswordReset",
+			getUserByLogin:    method[models.User]{data: models.User{Id: 1, Login: "login"}},
+			sendPasswordReset: errors.New(""),
+		},
+		{
+			name:       "ErrLockedOut",
+			addAttempt: method[time.Duration]{data: time.Minute},
+			wantErr:    usecase.ErrTooManyRequests,
+		},
+		{
+			name:       "ErrAddAttempt",
+			addAttempt: method[time.Duration]{err: errors.New("")},
+			wantErr:    errors.New(""),
+		},
+	}
+
+	for _, tt := range tests {
+		suite.Run(tt.name, func() {
+			func() {
+				suite.attemptsRepo.On("AddAttempt", mock.Anything, []models.AttemptsLimit{
+					{Key: "password_reset:login:login", FreeAttempts: resetCfg.LoginAttempts},
+					{Key: "password_reset:ip:127.0.0.1", FreeAttempts: resetCfg.IPAttempts},
+				}, models.Backoff(resetCfg.Backoff)).Once().
+					Return(tt.addAttempt.data, tt.addAttempt.err)
+				if tt.addAttempt.data > 0 || tt.addAttempt.err != nil {
 					return
 				}
+
+				suite.usersRepo.On("GetUserByLogin", mock.Anything, "login").Once().
+					Return(tt.getUserByLogin.data, tt.getUserByLogin.err)
+				if tt.getUserByLogin.err != nil {
+					return
+				}
+
+				var tokenHash string
+				suite.resetsRepo.On("AddPasswordReset", mock.Anything, 1, mock.AnythingOfType("string"), suite.authCfg.PasswordReset.ExpiredIn).Once().
+					Run(func(args mock.Arguments) { tokenHash = args.String(2) }).
+					Return(nil)
+				suite.notifier.On("SendPasswordReset", mock.Anything, tt.getUserByLogin.data, mock.MatchedBy(func(resetToken string) bool {
+					// the token is sent, only its hash is stored
+					return resetToken != "" && resetToken != tokenHash && len(tokenHash) == 64
+				}), mock.AnythingOfType("time.Time")).Once().
+					Return(tt.sendPasswordReset)
 			}()
 
-			_, _, gotErr := suite.uc.RefreshTokens(context.Background(), refreshToken)
+			gotErr := suite.uc.RequestPasswordReset(context.Background(), "login", "127.0.0.1")
+			suite.uc.Wait()
 
-			if tt.getUserById.err == nil {
+			if tt.wantErr == nil {
 				suite.NoError(gotErr)
 			} else {
 				suite.NotNil(gotErr)
 			}
+			if errors.Is(tt.wantErr, usecase.ErrTooManyRequests) {
+				var lockedErr *usecase.LockedOutError
+				suite.Require().ErrorAs(gotErr, &lockedErr)
+				suite.Equal(tt.addAttempt.data, lockedErr.RetryAfter)
+			}
+			suite.attemptsRepo.AssertExpectations(suite.T())
 			suite.usersRepo.AssertExpectations(suite.T())
+			suite.resetsRepo.AssertExpectations(suite.T())
+			suite.notifier.AssertExpectations(suite.T())
+		})
+	}
+}
+
+func (suite *AuthSuite) TestResetPassword() {
+	tests := []struct {
+		name          string
+		resetPassword method[int]
+		wantErr       error
+	}{
+		{
+			name:          "Ok",
+			resetPassword: method[int]{data: 1},
+		},
+		{
+			name:          "ErrInvalidToken",
+			resetPassword: method[int]{err: storage.ErrNotFound},
+			wantErr:       usecase.ErrInvalidInput,
+		},
+	}
+
+	for _, tt := range tests {
+		suite.Run(tt.name, func() {
+			suite.resetsRepo.On("ResetPassword", mock.Anything, mock.MatchedBy(func(tokenHash string) bool {
+				return len(tokenHash) == 64 && tokenHash != "token"
+			}), mock.MatchedBy(func(hash string) bool {
+				return passwordUtils.CheckPasswordHash("new password", hash)
+			})).Once().
+				Return(tt.resetPassword.data, tt.resetPassword.err)
+			if tt.wantErr == nil {
+				suite.sessionsRepo.On("DeleteUserSessions", mock.Anything, 1).Once().
+					Return(nil)
+			}
+
+			userId, gotErr := suite.uc.ResetPassword(context.Background(), "token", "new password")
+
+			if tt.wantErr == nil {
+				suite.NoError(gotErr)
+				suite.Equal(1, userId)
+			} else {
+				suite.ErrorIs(gotErr, tt.wantErr)
+			}
+			suite.resetsRepo.AssertExpectations(suite.T())
+			suite.sessionsRepo.AssertExpectations(suite.T())
 		})
 	}
 }
@@ -2,19 +2,31 @@ package usecase
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"log/slog"
+	"slices"
+	"time"
 
+	"github.com/PritOriginal/problem-map-server/internal/config"
 	"github.com/PritOriginal/problem-map-server/internal/models"
 	"github.com/PritOriginal/problem-map-server/internal/storage"
+	"github.com/PritOriginal/problem-map-server/pkg/logger"
+	"github.com/guregu/null/v6"
 )
 
 type ChecksRepository interface {
 	AddCheck(ctx context.Context, check models.Check) (int64, error)
+	AddPhotosMetadata(ctx context.Context, checkId, first int, metadata []models.PhotoMetadata) error
+	AddPhotoRecords(ctx context.Context, records []models.PhotoRecord) error
+	AddMissingPhotoRecords(ctx context.Context, records []models.PhotoRecord) (int64, error)
+	GetPhotoRecordsByCheckIds(ctx context.Context, checkIds []int) ([]models.PhotoRecord, error)
+	GetPhotoMetadata(ctx context.Context, checkId, number int) (models.PhotoMetadata, error)
+	GetSimilarPhotos(ctx context.Context, filters models.GetSimilarPhotosFilters) ([]models.SimilarPhoto, error)
 	GetCheckById(ctx context.Context, id int) (models.Check, error)
-	GetChecksByMarkId(ctx context.Context, markId int) ([]models.Check, error)
-	GetChecksByUserId(ctx context.Context, userId int) ([]models.Check, error)
+	GetChecksByMarkId(ctx context.Context, markId int, pagination models.Pagination) ([]models.Check, error)
+	GetChecksByUserId(ctx context.Context, userId int, pagination models.Pagination) ([]models.Check, error)
 	GetChecksByMarkHistoryId(ctx context.Context, markHistoryId int) ([]models.Check, error)
 	GetUserMarkCheck(ctx context.Context, userId int, markStatusHistoryId int) (models.Check, error)
 }
@@ -31,19 +43,21 @@ type ChecksRepositories struct {
 
 type Checks struct {
 	log               *slog.Logger
+	checksCfg         config.ChecksConfig
 	repos             ChecksRepositories
 	markStatusUpdater MarkStatusUpdater
 }
 
-func NewChecks(log *slog.Logger, markStatusUpdater MarkStatusUpdater, repos ChecksRepositories) *Checks {
+func NewChecks(log *slog.Logger, checksCfg config.ChecksConfig, markStatusUpdater MarkStatusUpdater, repos ChecksRepositories) *Checks {
 	return &Checks{
 		log:               log,
+		checksCfg:         checksCfg,
 		repos:             repos,
 		markStatusUpdater: markStatusUpdater,
 	}
 }
 
-func (uc *Checks) AddCheck(ctx context.Context, check models.Check, photos []io.Reader) (int64, error) {
+func (uc *Checks) AddCheck(ctx context.Context, check models.Check, photos []models.Photo) (int64, error) {
 	const op = "usecase.Checks.AddCheck"
 
 	historyItem, err := uc.repos.Marks.GetLastMarkStatusHistoryItem(ctx, check.MarkID)
@@ -67,12 +81,26 @@ func (uc *Checks) AddCheck(ctx context.Context, check models.Check, photos []io.
 		return 0, ErrConflict
 	}
 
+	mark, err := uc.repos.Marks.GetMarkById(ctx, check.MarkID)
+	if err != nil {
+		switch err {
+		case storage.ErrNotFound:
+			return 0, ErrNotFound
+		default:
+			return 0, fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	if err := uc.verifyLocation(&check, mark); err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
 	id, err := uc.repos.Checks.AddCheck(ctx, check)
 	if err != nil {
 		return 0, fmt.Errorf("%s: %w", op, err)
 	}
 
-	if err := uc.repos.Photos.AddPhotos(ctx, check.MarkID, int(id), photos); err != nil {
+	if err := addPhotos(ctx, uc.repos.Checks, uc.repos.Photos, check.MarkID, int(id), 1, photos); err != nil {
 		return 0, fmt.Errorf("%s: %w", op, err)
 	}
 
@@ -83,6 +111,106 @@ func (uc *Checks) AddCheck(ctx context.Context, check models.Check, photos []io.
 	return id, nil
 }
 
+// verifyLocation sets the distance from the user to the mark and flags the check made too far from the mark.
+// The checks without the location are accepted unless the location is required, then they are treated
+// as the ones too far. In the reject proximity mode such checks are rejected.
+func (uc *Checks) verifyLocation(check *models.Check, mark models.Mark) error {
+	maxDistance := uc.checksCfg.MaxDistance(mark.MarkTypeID)
+
+	if check.Location == nil {
+		if !uc.checksCfg.Proximity.RequireLocation {
+			return nil
+		}
+		if uc.checksCfg.Proximity.Mode == config.RejectProximity {
+			return fmt.Errorf("%w: the location is required", ErrInvalidInput)
+		}
+		check.Flagged = true
+		return nil
+	}
+
+	distance := mark.Geom.Distance(check.Location)
+	check.Distance = null.FloatFrom(distance)
+
+	if distance > maxDistance {
+		if uc.checksCfg.Proximity.Mode == config.RejectProximity {
+			return &CheckTooFarError{Distance: distance, MaxDistance: maxDistance}
+		}
+		check.Flagged = true
+	}
+
+	return nil
+}
+
+// duplicatePhotoDistance is the max Hamming distance between the perceptual hashes of the near-identical photos
+const duplicatePhotoDistance = 5
+
+// addPhotos stores the photos of the check numbered from first and their metadata.
+// The photos near-identical to a photo of another check are marked as duplicates for the moderators.
+func addPhotos(ctx context.Context, checks ChecksRepository, photos PhotosRepository, markId, checkId, first int, items []models.Photo) error {
+	data := make([]io.Reader, len(items))
+	metadata := make([]models.PhotoMetadata, len(items))
+	for i, item := range items {
+		data[i] = item.Data
+		metadata[i] = item.Metadata
+
+		if !item.Metadata.PHash.Valid {
+			continue
+		}
+		similar, err := checks.GetSimilarPhotos(ctx, models.GetSimilarPhotosFilters{
+			PHash:          item.Metadata.PHash.Int64,
+			MaxDistance:    duplicatePhotoDistance,
+			ExcludeCheckId: checkId,
+			Limit:          1,
+		})
+		if err != nil {
+			return err
+		}
+		metadata[i].Duplicate = len(similar) > 0
+	}
+
+	records, err := photos.AddPhotos(ctx, markId, checkId, first, data)
+	if err != nil {
+		return err
+	}
+
+	if err := checks.AddPhotoRecords(ctx, records); err != nil {
+		return err
+	}
+
+	return checks.AddPhotosMetadata(ctx, checkId, first, metadata)
+}
+
+// setPhotos sets the photo URLs of the checks from the records of their photos
+func setPhotos(ctx context.Context, checks ChecksRepository, photos PhotosRepository, items []models.Check) error {
+	ids := make([]int, len(items))
+	for i := range items {
+		ids[i] = items[i].ID
+	}
+
+	records, err := checks.GetPhotoRecordsByCheckIds(ctx, ids)
+	if err != nil {
+		return err
+	}
+
+	urls := make(map[string]string, len(records))
+	for _, record := range records {
+		if urls[record.Key], err = photos.URL(ctx, record.Key); err != nil {
+			return err
+		}
+	}
+
+	photosMap := storage.GroupPhotoURLs(records, urls)
+	for i := range items {
+		if urls, ok := photosMap[items[i].ID]; ok {
+			items[i].Photos = urls
+		} else {
+			items[i].Photos = []models.PhotoURLs{}
+		}
+	}
+
+	return nil
+}
+
 func (uc *Checks) checkPossibilityAddCheck(ctx context.Context, userId int, historyId int) (bool, error) {
 	const op = "usecase.Checks.checkPossibilityAddCheck"
 
@@ -107,113 +235,146 @@ func (uc *Checks) GetCheckById(ctx context.Context, id int) (models.Check, error
 		return check, fmt.Errorf("%s: %w", op, err)
 	}
 
-	check.Photos, err = uc.repos.Photos.GetPhotosByCheckId(ctx, check.MarkID, check.ID)
-	if err != nil {
+	checks := []models.Check{check}
+	if err := setPhotos(ctx, uc.repos.Checks, uc.repos.Photos, checks); err != nil {
 		return check, fmt.Errorf("%s: %w", op, err)
 	}
+	check = checks[0]
 
 	return check, nil
 }
 
-func (uc *Checks) GetChecksByMarkId(ctx context.Context, markId int) ([]models.Check, error) {
+func (uc *Checks) GetChecksByMarkId(ctx context.Context, markId int, pagination models.Pagination) ([]models.Check, string, error) {
 	const op = "usecase.Checks.GetChecksByMarkId"
 
-	checks, err := uc.repos.Checks.GetChecksByMarkId(ctx, markId)
-	if err != nil {
-		return checks, fmt.Errorf("%s: %w", op, err)
-	}
-
-	photosMap, err := uc.repos.Photos.GetPhotosByMarkId(ctx, markId)
+	checks, nextCursor, err := fetchPage(pagination, func(pagination models.Pagination) ([]models.Check, error) {
+		return uc.repos.Checks.GetChecksByMarkId(ctx, markId, pagination)
+	})
 	if err != nil {
-		return checks, fmt.Errorf("%s: %w", op, err)
+		return checks, "", fmt.Errorf("%s: %w", op, err)
 	}
 
-	for i := range len(checks) {
-		checks[i].Photos = photosMap[markId][checks[i].ID]
+	if err := setPhotos(ctx, uc.repos.Checks, uc.repos.Photos, checks); err != nil {
+		return checks, "", fmt.Errorf("%s: %w", op, err)
 	}
 
-	return checks, nil
+	return checks, nextCursor, nil
 }
 
-func (uc *Checks) GetChecksByUserId(ctx context.Context, userId int) ([]models.Check, error) {
+func (uc *Checks) GetChecksByUserId(ctx context.Context, userId int, pagination models.Pagination) ([]models.Check, string, error) {
 	const op = "usecase.Checks.GetChecksByUserId"
 
-	checks, err := uc.repos.Checks.GetChecksByUserId(ctx, userId)
+	checks, nextCursor, err := fetchPage(pagination, func(pagination models.Pagination) ([]models.Check, error) {
+		return uc.repos.Checks.GetChecksByUserId(ctx, userId, pagination)
+	})
 	if err != nil {
-		return checks, fmt.Errorf("%s: %w", op, err)
+		return checks, "", fmt.Errorf("%s: %w", op, err)
 	}
 
-	for i := range len(checks) {
-		checks[i].Photos, err = uc.repos.Photos.GetPhotosByCheckId(ctx, checks[i].MarkID, checks[i].ID)
-		if err != nil {
-			return checks, fmt.Errorf("%s: %w", op, err)
-		}
+	if err := setPhotos(ctx, uc.repos.Checks, uc.repos.Photos, checks); err != nil {
+		return checks, "", fmt.Errorf("%s: %w", op, err)
 	}
 
-	return checks, nil
+	return checks, nextCursor, nil
 }
 
 type UpdaterRepositories struct {
 	Marks  MarksRepository
 	Checks ChecksRepository
+	Photos PhotosRepository
 }
 type Updater struct {
-	log   *slog.Logger
-	repos UpdaterRepositories
+	log          *slog.Logger
+	consensusCfg config.ConsensusConfig
+	repos        UpdaterRepositories
 }
 
-func NewUpdater(log *slog.Logger, repos UpdaterRepositories) *Updater {
+func NewUpdater(log *slog.Logger, consensusCfg config.ConsensusConfig, repos UpdaterRepositories) *Updater {
 	return &Updater{
-		log:   log,
-		repos: repos,
+		log:          log,
+		consensusCfg: consensusCfg,
+		repos:        repos,
 	}
 }
 
+// Update changes the mark status when the checks of the current status reach the consensus
 func (u *Updater) Update(ctx context.Context, markId int) error {
 	const op = "usecase.Updater.Update"
 
-	mark, err := u.repos.Marks.GetMarkById(ctx, markId)
+	explanation, err := u.Explain(ctx, markId)
 	if err != nil {
 		return fmt.Errorf("%s: %w", op, err)
 	}
 
+	u.log.Debug("consensus", slog.String("decision", string(explanation.Decision)), slog.Float64("score", explanation.Score))
+
+	if !explanation.NewMarkStatusID.Valid {
+		return nil
+	}
+
+	if err := u.setStatus(ctx, explanation.MarkID, explanation.MarkStatusID, explanation.NewMarkStatusID.V); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	u.log.Debug("change mark status", slog.Int("old", int(explanation.MarkStatusID)), slog.Int("new", int(explanation.NewMarkStatusID.V)))
+
+	return nil
+}
+
+// Explain evaluates the consensus rule of the mark type on the checks of the current mark status
+// without changing it. Only the unconfirmed and under review statuses are decided by checks.
+func (u *Updater) Explain(ctx context.Context, markId int) (models.ConsensusExplanation, error) {
+	const op = "usecase.Updater.Explain"
+
+	mark, err := u.repos.Marks.GetMarkById(ctx, markId)
+	if err != nil {
+		return models.ConsensusExplanation{}, fmt.Errorf("%s: %w", op, err)
+	}
+
 	historyItem, err := u.repos.Marks.GetLastMarkStatusHistoryItem(ctx, markId)
 	if err != nil {
-		return fmt.Errorf("%s: %w", op, err)
+		return models.ConsensusExplanation{}, fmt.Errorf("%s: %w", op, err)
 	}
 
-	if mark.MarkStatusID == models.UnconfirmedStatus || mark.MarkStatusID == models.UnderReviewStatus {
-		checks, err := u.repos.Checks.GetChecksByMarkHistoryId(ctx, historyItem.ID)
-		if err != nil {
-			return fmt.Errorf("%s: %w", op, err)
-		}
+	rule := u.consensusCfg.Rule(mark.MarkTypeID)
+	explanation := models.ConsensusExplanation{
+		MarkID:       mark.ID,
+		MarkTypeID:   mark.MarkTypeID,
+		MarkStatusID: mark.MarkStatusID,
+		Rule:         rule,
+		ConsensusResult: models.ConsensusResult{
+			Decision: models.NoDecision,
+			Reasons:  []string{"the mark status is not decided by checks"},
+		},
+	}
 
-		score := 0
-		for _, check := range checks {
-			if check.Result {
-				score++
-			} else {
-				score--
-			}
-		}
+	if mark.MarkStatusID != models.UnconfirmedStatus && mark.MarkStatusID != models.UnderReviewStatus {
+		return explanation, nil
+	}
+
+	checks, err := u.repos.Checks.GetChecksByMarkHistoryId(ctx, historyItem.ID)
+	if err != nil {
+		return explanation, fmt.Errorf("%s: %w", op, err)
+	}
 
-		u.log.Debug("score", slog.Int("val", score))
-
-		if score >= 3 {
-			newMarkStatusId, err := u.confirm(ctx, mark)
-			if err != nil {
-				return fmt.Errorf("%s: %w", op, err)
-			}
-			u.log.Debug("change mark status", slog.Int("old", int(mark.MarkStatusID)), slog.Int("new", int(newMarkStatusId)))
-		} else if score <= -3 {
-			newMarkStatusId, err := u.reject(ctx, mark)
-			if err != nil {
-				return fmt.Errorf("%s: %w", op, err)
-			}
-			u.log.Debug("change mark status", slog.Int("old", int(mark.MarkStatusID)), slog.Int("new", int(newMarkStatusId)))
+	var policy ConsensusPolicy = NewRulePolicy(rule)
+	explanation.ConsensusResult = policy.Evaluate(checks, time.Now())
+
+	switch explanation.Decision {
+	case models.ConfirmDecision:
+		newStatus, err := confirmedStatus(mark.MarkStatusID)
+		if err != nil {
+			return explanation, fmt.Errorf("%s: %w", op, err)
 		}
+		explanation.NewMarkStatusID = null.ValueFrom(newStatus)
+	case models.RejectDecision:
+		newStatus, err := rejectedStatus(mark.MarkStatusID)
+		if err != nil {
+			return explanation, fmt.Errorf("%s: %w", op, err)
+		}
+		explanation.NewMarkStatusID = null.ValueFrom(newStatus)
 	}
-	return nil
+
+	return explanation, nil
 }
 
 func (u *Updater) Confirm(ctx context.Context, markId int) (models.MarkStatusType, error) {
@@ -224,63 +385,228 @@ func (u *Updater) Confirm(ctx context.Context, markId int) (models.MarkStatusTyp
 		return 0, fmt.Errorf("%s: %w", op, err)
 	}
 
-	return u.confirm(ctx, mark)
-}
+	newStatus, err := confirmedStatus(mark.MarkStatusID)
+	if err != nil {
+		return 0, err
+	}
 
-func (u *Updater) confirm(ctx context.Context, mark models.Mark) (models.MarkStatusType, error) {
-	const op = "usecase.Map.confirm"
+	if err := u.setStatus(ctx, mark.ID, mark.MarkStatusID, newStatus); err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
 
-	var newStatus models.MarkStatusType
+	return newStatus, nil
+}
 
-	switch mark.MarkStatusID {
+// confirmedStatus returns the status the mark moves to when its current status is confirmed
+func confirmedStatus(status models.MarkStatusType) (models.MarkStatusType, error) {
+	switch status {
 	case models.UnconfirmedStatus:
-		newStatus = models.ConfirmedStatus
+		return models.ConfirmedStatus, nil
 	case models.ConfirmedStatus, models.RediscoveredStatus:
-		newStatus = models.UnderReviewStatus
+		return models.UnderReviewStatus, nil
 	case models.UnderReviewStatus:
-		newStatus = models.ClosedStatus
+		return models.ClosedStatus, nil
 	default:
 		return 0, ErrConflict
 	}
+}
+
+func (u *Updater) Reject(ctx context.Context, markId int) (models.MarkStatusType, error) {
+	const op = "usecase.Map.Reject"
+
+	mark, err := u.repos.Marks.GetMarkById(ctx, markId)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
 
-	if err := u.repos.Marks.UpdateMarkStatus(ctx, mark.ID, newStatus); err != nil {
+	newStatus, err := rejectedStatus(mark.MarkStatusID)
+	if err != nil {
+		return 0, err
+	}
+
+	if err := u.setStatus(ctx, mark.ID, mark.MarkStatusID, newStatus); err != nil {
 		return 0, fmt.Errorf("%s: %w", op, err)
 	}
 
 	return newStatus, nil
-
 }
 
-func (u *Updater) Reject(ctx context.Context, markId int) (models.MarkStatusType, error) {
-	const op = "usecase.Map.Reject"
+// setStatus changes the mark status. Reaching a terminal status also updates the ratings of the users
+// by how their checks agreed with the decisions taken on the mark.
+func (u *Updater) setStatus(ctx context.Context, markId int, oldStatus, newStatus models.MarkStatusType) error {
+	const op = "usecase.Updater.setStatus"
 
-	mark, err := u.repos.Marks.GetMarkById(ctx, markId)
+	if !newStatus.IsTerminal() {
+		if err := u.repos.Marks.UpdateMarkStatus(ctx, markId, newStatus); err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+		return nil
+	}
+
+	historyItems, err := u.repos.Marks.GetMarkStatusHistoryByMarkId(ctx, markId)
 	if err != nil {
-		return 0, fmt.Errorf("%s: %w", op, err)
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	checks, err := u.repos.Checks.GetChecksByMarkId(ctx, markId, models.Pagination{})
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	outcomes := checkOutcomes(historyItems, checks, newStatus)
+	if err := u.repos.Marks.ResolveMarkStatus(ctx, markId, oldStatus, newStatus, outcomes); err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			// the status has been changed concurrently
+			return fmt.Errorf("%s: %w", op, ErrConflict)
+		}
+		return fmt.Errorf("%s: %w", op, err)
 	}
 
-	return u.reject(ctx, mark)
+	return nil
 }
 
-func (u *Updater) reject(ctx context.Context, mark models.Mark) (models.MarkStatusType, error) {
-	const op = "usecase.Map.reject"
+// checkOutcomes counts the checks of every user and the ones agreed with the decision taken on the checked status.
+// The history items are ordered by the change time, the last one is followed by the new status.
+func checkOutcomes(historyItems []models.MarkStatusHistoryItem, checks []models.Check, newStatus models.MarkStatusType) []models.UserCheckOutcome {
+	type transition struct {
+		from, to models.MarkStatusType
+	}
+
+	transitions := make(map[int]transition, len(historyItems))
+	var prev *models.MarkStatusHistoryItem
+	for i := range historyItems {
+		// the merge items don't change the status
+		if historyItems[i].MergedMarkId.Valid {
+			continue
+		}
+		if prev != nil {
+			transitions[prev.ID] = transition{from: prev.NewMarkStatusID, to: historyItems[i].NewMarkStatusID}
+		}
+		prev = &historyItems[i]
+	}
+	if prev != nil {
+		transitions[prev.ID] = transition{from: prev.NewMarkStatusID, to: newStatus}
+	}
+
+	outcomes := map[int]*models.UserCheckOutcome{}
+	for _, check := range checks {
+		t, ok := transitions[check.MarkStatusHistoryItemId]
+		if !ok {
+			continue
+		}
+
+		var confirmed bool
+		if status, err := confirmedStatus(t.from); err == nil && status == t.to {
+			confirmed = true
+		} else if status, err := rejectedStatus(t.from); err != nil || status != t.to {
+			// the status wasn't decided by the voting
+			continue
+		}
+
+		outcome, ok := outcomes[check.UserID]
+		if !ok {
+			outcome = &models.UserCheckOutcome{UserID: check.UserID}
+			outcomes[check.UserID] = outcome
+		}
+		outcome.Resolved++
+		if check.Result == confirmed {
+			outcome.Agreed++
+		}
+	}
+
+	result := make([]models.UserCheckOutcome, 0, len(outcomes))
+	for _, outcome := range outcomes {
+		result = append(result, *outcome)
+	}
+	slices.SortFunc(result, func(a, b models.UserCheckOutcome) int {
+		return a.UserID - b.UserID
+	})
 
-	var newStatus models.MarkStatusType
+	return result
+}
 
-	switch mark.MarkStatusID {
+// rejectedStatus returns the status the mark moves to when its current status is rejected
+func rejectedStatus(status models.MarkStatusType) (models.MarkStatusType, error) {
+	switch status {
 	case models.UnconfirmedStatus, models.ConfirmedStatus:
-		newStatus = models.RefutedStatus
+		return models.RefutedStatus, nil
 	case models.RediscoveredStatus:
-		newStatus = models.ClosedStatus
+		return models.ClosedStatus, nil
 	case models.UnderReviewStatus:
-		newStatus = models.RediscoveredStatus
+		return models.RediscoveredStatus, nil
 	default:
 		return 0, ErrConflict
 	}
+}
 
-	if err := u.repos.Marks.UpdateMarkStatus(ctx, mark.ID, newStatus); err != nil {
-		return 0, fmt.Errorf("%s: %w", op, err)
+// deletePhotoCopies deletes the photos copied for a failed merge, also when the request is canceled
+func (u *Updater) deletePhotoCopies(ctx context.Context, keys []string) {
+	if len(keys) == 0 {
+		return
 	}
+	if err := u.repos.Photos.DeletePhotos(context.WithoutCancel(ctx), keys); err != nil {
+		u.log.Error("failed delete photo copies of failed merge", slog.Int("count", len(keys)), logger.Err(err))
+	}
+}
 
-	return newStatus, nil
+// MaxMergedMarks is the max number of marks merged at once
+const MaxMergedMarks = 50
+
+// Merge merges the duplicate marks into the canonical mark. The checks and photos of the merged marks
+// are moved to the canonical mark and the merged marks resolve to it afterwards.
+// Only the checks on the current status of the merged marks vote on the canonical mark, one per user.
+// Only open marks can be merged.
+func (u *Updater) Merge(ctx context.Context, markId int, mergedMarkIds []int) error {
+	const op = "usecase.Updater.Merge"
+
+	if len(mergedMarkIds) == 0 || len(mergedMarkIds) > MaxMergedMarks {
+		return fmt.Errorf("%s: %w", op, ErrInvalidInput)
+	}
+	seen := make(map[int]bool, len(mergedMarkIds))
+	for _, id := range mergedMarkIds {
+		if id == markId || seen[id] {
+			return fmt.Errorf("%s: %w", op, ErrInvalidInput)
+		}
+		seen[id] = true
+	}
+
+	for _, id := range append([]int{markId}, mergedMarkIds...) {
+		mark, err := u.repos.Marks.GetMarkById(ctx, id)
+		if err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+		if !slices.Contains(openMarkStatuses, int(mark.MarkStatusID)) {
+			return fmt.Errorf("%s: %w", op, ErrConflict)
+		}
+	}
+
+	// the photos are copied before and deleted after the merge, so a failed merge leaves them in place
+	// and only the copies are deleted
+	var copied []string
+	for _, id := range mergedMarkIds {
+		keys, err := u.repos.Photos.CopyPhotos(ctx, id, markId)
+		copied = append(copied, keys...)
+		if err != nil {
+			u.deletePhotoCopies(ctx, copied)
+			return fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	if err := u.repos.Marks.MergeMarks(ctx, markId, mergedMarkIds); err != nil {
+		u.deletePhotoCopies(ctx, copied)
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	for _, id := range mergedMarkIds {
+		if err := u.repos.Photos.DeletePhotosByMarkId(ctx, id); err != nil {
+			u.log.Error("failed delete photos of merged mark", slog.Int("mark_id", id), logger.Err(err))
+		}
+	}
+
+	// the moved checks may already be enough to change the status
+	if err := u.Update(ctx, markId); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
 }
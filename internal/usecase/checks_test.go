@@ -3,22 +3,25 @@ package usecase_test
 import (
 	"context"
 	"errors"
-	"io"
 	"log/slog"
 	"testing"
 
+	"github.com/PritOriginal/problem-map-server/internal/config"
 	"github.com/PritOriginal/problem-map-server/internal/models"
 	"github.com/PritOriginal/problem-map-server/internal/storage"
 	"github.com/PritOriginal/problem-map-server/internal/usecase"
 	"github.com/PritOriginal/problem-map-server/pkg/logger/slogdiscard"
+	"github.com/guregu/null/v6"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
+	"github.com/twpayne/go-geom"
 )
 
 type ChecksSuite struct {
 	suite.Suite
 	uc         *usecase.Checks
 	log        *slog.Logger
+	checksCfg  config.ChecksConfig
 	updater    *usecase.MockMarkStatusUpdater
 	marksRepo  *usecase.MockMarksRepository
 	checksRepo *usecase.MockChecksRepository
@@ -31,7 +34,8 @@ func (suite *ChecksSuite) SetupSuite() {
 	suite.marksRepo = usecase.NewMockMarksRepository(suite.T())
 	suite.checksRepo = usecase.NewMockChecksRepository(suite.T())
 	suite.photosRepo = usecase.NewMockPhotosRepository(suite.T())
-	suite.uc = usecase.NewChecks(suite.log, suite.updater, usecase.ChecksRepositories{
+	suite.checksCfg = config.MustLoadPath("../../configs/config-tests.yaml").Checks
+	suite.uc = usecase.NewChecks(suite.log, suite.checksCfg, suite.updater, usecase.ChecksRepositories{
 		Marks:  suite.marksRepo,
 		Checks: suite.checksRepo,
 		Photos: suite.photosRepo,
@@ -47,8 +51,11 @@ func (suite *ChecksSuite) TestAddCheck() {
 		name                         string
 		getLastMarkStatusHistoryItem method[models.MarkStatusHistoryItem]
 		getUserMarkCheck             method[models.Check]
+		getMarkById                  method[models.Mark]
 		addCheck                     method[int64]
-		addPhotos                    method[any]
+		addPhotos                    method[[]models.PhotoRecord]
+		addPhotoRecords              method[any]
+		addPhotosMetadata            method[any]
 		update                       method[any]
 	}{
 		{
@@ -62,11 +69,21 @@ func (suite *ChecksSuite) TestAddCheck() {
 			getUserMarkCheck: method[models.Check]{
 				err: storage.ErrNotFound,
 			},
+			getMarkById: method[models.Mark]{
+				data: models.Mark{Geom: models.NewPoint(geom.Coord{0, 0})},
+				err:  nil,
+			},
 			addCheck: method[int64]{
 				data: int64(1),
 				err:  nil,
 			},
-			addPhotos: method[any]{
+			addPhotos: method[[]models.PhotoRecord]{
+				err: nil,
+			},
+			addPhotoRecords: method[any]{
+				err: nil,
+			},
+			addPhotosMetadata: method[any]{
 				err: nil,
 			},
 			update: method[any]{
@@ -140,6 +157,21 @@ func (suite *ChecksSuite) TestAddCheck() {
 				err: nil,
 			},
 		},
+		{
+			name: "ErrGetMarkById",
+			getLastMarkStatusHistoryItem: method[models.MarkStatusHistoryItem]{
+				data: models.MarkStatusHistoryItem{
+					NewMarkStatusID: models.UnconfirmedStatus,
+				},
+				err: nil,
+			},
+			getUserMarkCheck: method[models.Check]{
+				err: storage.ErrNotFound,
+			},
+			getMarkById: method[models.Mark]{
+				err: errors.New(""),
+			},
+		},
 		{
 			name: "ErrAddCheck",
 			getLastMarkStatusHistoryItem: method[models.MarkStatusHistoryItem]{
@@ -151,6 +183,10 @@ func (suite *ChecksSuite) TestAddCheck() {
 			getUserMarkCheck: method[models.Check]{
 				err: storage.ErrNotFound,
 			},
+			getMarkById: method[models.Mark]{
+				data: models.Mark{Geom: models.NewPoint(geom.Coord{0, 0})},
+				err:  nil,
+			},
 			addCheck: method[int64]{
 				data: int64(0),
 				err:  errors.New(""),
@@ -167,11 +203,70 @@ func (suite *ChecksSuite) TestAddCheck() {
 			getUserMarkCheck: method[models.Check]{
 				err: storage.ErrNotFound,
 			},
+			getMarkById: method[models.Mark]{
+				data: models.Mark{Geom: models.NewPoint(geom.Coord{0, 0})},
+				err:  nil,
+			},
+			addCheck: method[int64]{
+				data: int64(1),
+				err:  nil,
+			},
+			addPhotos: method[[]models.PhotoRecord]{
+				err: errors.New(""),
+			},
+		},
+		{
+			name: "ErrAddPhotoRecords",
+			getLastMarkStatusHistoryItem: method[models.MarkStatusHistoryItem]{
+				data: models.MarkStatusHistoryItem{
+					NewMarkStatusID: models.UnconfirmedStatus,
+				},
+				err: nil,
+			},
+			getUserMarkCheck: method[models.Check]{
+				err: storage.ErrNotFound,
+			},
+			getMarkById: method[models.Mark]{
+				data: models.Mark{Geom: models.NewPoint(geom.Coord{0, 0})},
+				err:  nil,
+			},
+			addCheck: method[int64]{
+				data: int64(1),
+				err:  nil,
+			},
+			addPhotos: method[[]models.PhotoRecord]{
+				err: nil,
+			},
+			addPhotoRecords: method[any]{
+				err: errors.New(""),
+			},
+		},
+		{
+			name: "ErrAddPhotosMetadata",
+			getLastMarkStatusHistoryItem: method[models.MarkStatusHistoryItem]{
+				data: models.MarkStatusHistoryItem{
+					NewMarkStatusID: models.UnconfirmedStatus,
+				},
+				err: nil,
+			},
+			getUserMarkCheck: method[models.Check]{
+				err: storage.ErrNotFound,
+			},
+			getMarkById: method[models.Mark]{
+				data: models.Mark{Geom: models.NewPoint(geom.Coord{0, 0})},
+				err:  nil,
+			},
 			addCheck: method[int64]{
 				data: int64(1),
 				err:  nil,
 			},
-			addPhotos: method[any]{
+			addPhotos: method[[]models.PhotoRecord]{
+				err: nil,
+			},
+			addPhotoRecords: method[any]{
+				err: nil,
+			},
+			addPhotosMetadata: method[any]{
 				err: errors.New(""),
 			},
 		},
@@ -186,11 +281,21 @@ func (suite *ChecksSuite) TestAddCheck() {
 			getUserMarkCheck: method[models.Check]{
 				err: storage.ErrNotFound,
 			},
+			getMarkById: method[models.Mark]{
+				data: models.Mark{Geom: models.NewPoint(geom.Coord{0, 0})},
+				err:  nil,
+			},
 			addCheck: method[int64]{
 				data: int64(1),
 				err:  nil,
 			},
-			addPhotos: method[any]{
+			addPhotos: method[[]models.PhotoRecord]{
+				err: nil,
+			},
+			addPhotoRecords: method[any]{
+				err: nil,
+			},
+			addPhotosMetadata: method[any]{
 				err: nil,
 			},
 			update: method[any]{
@@ -213,18 +318,36 @@ func (suite *ChecksSuite) TestAddCheck() {
 					return
 				}
 
+				suite.marksRepo.On("GetMarkById", mock.Anything, mock.AnythingOfType("int")).Once().
+					Return(tt.getMarkById.data, tt.getMarkById.err)
+				if tt.getMarkById.err != nil {
+					return
+				}
+
 				suite.checksRepo.On("AddCheck", mock.Anything, mock.Anything).Once().
 					Return(tt.addCheck.data, tt.addCheck.err)
 				if tt.addCheck.err != nil {
 					return
 				}
 
-				suite.photosRepo.On("AddPhotos", mock.Anything, mock.AnythingOfType("int"), mock.AnythingOfType("int"), mock.Anything).Once().
-					Return(tt.addPhotos.err)
+				suite.photosRepo.On("AddPhotos", mock.Anything, mock.AnythingOfType("int"), mock.AnythingOfType("int"), 1, mock.Anything).Once().
+					Return(tt.addPhotos.data, tt.addPhotos.err)
 				if tt.addPhotos.err != nil {
 					return
 				}
 
+				suite.checksRepo.On("AddPhotoRecords", mock.Anything, mock.Anything).Once().
+					Return(tt.addPhotoRecords.err)
+				if tt.addPhotoRecords.err != nil {
+					return
+				}
+
+				suite.checksRepo.On("AddPhotosMetadata", mock.Anything, mock.AnythingOfType("int"), 1, mock.Anything).Once().
+					Return(tt.addPhotosMetadata.err)
+				if tt.addPhotosMetadata.err != nil {
+					return
+				}
+
 				suite.updater.On("Update", mock.Anything, mock.AnythingOfType("int")).Once().
 					Return(tt.update.err)
 				if tt.update.err != nil {
@@ -232,17 +355,148 @@ func (suite *ChecksSuite) TestAddCheck() {
 				}
 			}()
 
-			_, gotErr := suite.uc.AddCheck(context.Background(), models.Check{}, []io.Reader{})
+			_, gotErr := suite.uc.AddCheck(context.Background(), models.Check{}, []models.Photo{})
 
 			if tt.getLastMarkStatusHistoryItem.err == nil &&
 				tt.getUserMarkCheck.err == storage.ErrNotFound &&
+				tt.getMarkById.err == nil &&
 				tt.addCheck.err == nil &&
 				tt.addPhotos.err == nil &&
+				tt.addPhotoRecords.err == nil &&
+				tt.addPhotosMetadata.err == nil &&
 				tt.update.err == nil {
 				suite.NoError(gotErr)
 			} else {
 				suite.NotNil(gotErr)
 			}
+			suite.marksRepo.AssertExpectations(suite.T())
+			suite.checksRepo.AssertExpectations(suite.T())
+			suite.photosRepo.AssertExpectations(suite.T())
+			suite.updater.AssertExpectations(suite.T())
+		})
+	}
+}
+
+func (suite *ChecksSuite) TestAddCheckLocation() {
+	mark := models.Mark{
+		ID:         1,
+		Geom:       models.NewPoint(geom.Coord{37.6173, 55.7558}),
+		MarkTypeID: 2,
+	}
+	near := models.NewPoint(geom.Coord{37.6183, 55.7558})
+	far := models.NewPoint(geom.Coord{37.7173, 55.7558})
+
+	rejectCfg := suite.checksCfg
+	rejectCfg.Proximity.Mode = config.RejectProximity
+	requireCfg := suite.checksCfg
+	requireCfg.Proximity.RequireLocation = true
+	requireRejectCfg := rejectCfg
+	requireRejectCfg.Proximity.RequireLocation = true
+
+	tests := []struct {
+		name        string
+		checksCfg   config.ChecksConfig
+		location    *models.Point
+		wantFlagged bool
+		wantErr     error
+	}{
+		{
+			name:        "OkNear",
+			checksCfg:   suite.checksCfg,
+			location:    near,
+			wantFlagged: false,
+		},
+		{
+			name:        "OkFlaggedFar",
+			checksCfg:   suite.checksCfg,
+			location:    far,
+			wantFlagged: true,
+		},
+		{
+			name:        "OkWithoutLocation",
+			checksCfg:   suite.checksCfg,
+			location:    nil,
+			wantFlagged: false,
+		},
+		{
+			name:        "OkFlaggedWithoutRequiredLocation",
+			checksCfg:   requireCfg,
+			location:    nil,
+			wantFlagged: true,
+		},
+		{
+			name:      "OkRejectNear",
+			checksCfg: rejectCfg,
+			location:  near,
+		},
+		{
+			name:      "ErrRejectFar",
+			checksCfg: rejectCfg,
+			location:  far,
+			wantErr:   &usecase.CheckTooFarError{},
+		},
+		{
+			name:      "OkRejectWithoutLocation",
+			checksCfg: rejectCfg,
+			location:  nil,
+		},
+		{
+			name:      "ErrRejectWithoutRequiredLocation",
+			checksCfg: requireRejectCfg,
+			location:  nil,
+			wantErr:   usecase.ErrInvalidInput,
+		},
+	}
+	for _, tt := range tests {
+		suite.Run(tt.name, func() {
+			uc := usecase.NewChecks(suite.log, tt.checksCfg, suite.updater, usecase.ChecksRepositories{
+				Marks:  suite.marksRepo,
+				Checks: suite.checksRepo,
+				Photos: suite.photosRepo,
+			})
+
+			suite.marksRepo.On("GetLastMarkStatusHistoryItem", mock.Anything, mark.ID).Once().
+				Return(models.MarkStatusHistoryItem{ID: 1, NewMarkStatusID: models.UnconfirmedStatus}, nil)
+			suite.checksRepo.On("GetUserMarkCheck", mock.Anything, 1, 1).Once().
+				Return(models.Check{}, storage.ErrNotFound)
+			suite.marksRepo.On("GetMarkById", mock.Anything, mark.ID).Once().
+				Return(mark, nil)
+
+			var gotCheck models.Check
+			if tt.wantErr == nil {
+				suite.checksRepo.On("AddCheck", mock.Anything, mock.AnythingOfType("models.Check")).Once().
+					Run(func(args mock.Arguments) {
+						gotCheck = args.Get(1).(models.Check)
+					}).
+					Return(int64(1), nil)
+				suite.photosRepo.On("AddPhotos", mock.Anything, mark.ID, 1, 1, mock.Anything).Once().
+					Return([]models.PhotoRecord{}, nil)
+				suite.checksRepo.On("AddPhotoRecords", mock.Anything, mock.Anything).Once().
+					Return(nil)
+				suite.checksRepo.On("AddPhotosMetadata", mock.Anything, 1, 1, mock.Anything).Once().
+					Return(nil)
+				suite.updater.On("Update", mock.Anything, mark.ID).Once().
+					Return(nil)
+			}
+
+			_, gotErr := uc.AddCheck(context.Background(), models.Check{
+				UserID:   1,
+				MarkID:   mark.ID,
+				Location: tt.location,
+			}, []models.Photo{})
+
+			switch want := tt.wantErr.(type) {
+			case nil:
+				suite.NoError(gotErr)
+				suite.Equal(tt.wantFlagged, gotCheck.Flagged)
+				suite.Equal(tt.location != nil, gotCheck.Distance.Valid)
+			case *usecase.CheckTooFarError:
+				suite.ErrorAs(gotErr, &want)
+				suite.Equal(suite.checksCfg.MaxDistance(mark.MarkTypeID), want.MaxDistance)
+			default:
+				suite.ErrorIs(gotErr, want)
+			}
+			suite.marksRepo.AssertExpectations(suite.T())
 			suite.checksRepo.AssertExpectations(suite.T())
 			suite.photosRepo.AssertExpectations(suite.T())
 			suite.updater.AssertExpectations(suite.T())
@@ -252,26 +506,26 @@ func (suite *ChecksSuite) TestAddCheck() {
 
 func (suite *ChecksSuite) TestGetCheckById() {
 	tests := []struct {
-		name               string
-		getCheckById       method[models.Check]
-		getPhotosByCheckId method[[]string]
+		name                      string
+		getCheckById              method[models.Check]
+		getPhotoRecordsByCheckIds method[[]models.PhotoRecord]
 	}{
 		{
-			name:               "Ok",
-			getCheckById:       method[models.Check]{},
-			getPhotosByCheckId: method[[]string]{},
+			name:                      "Ok",
+			getCheckById:              method[models.Check]{},
+			getPhotoRecordsByCheckIds: method[[]models.PhotoRecord]{},
 		},
 		{
 			name: "ErrGetCheckById",
 			getCheckById: method[models.Check]{
 				err: errors.New(""),
 			},
-			getPhotosByCheckId: method[[]string]{},
+			getPhotoRecordsByCheckIds: method[[]models.PhotoRecord]{},
 		},
 		{
-			name:         "ErrGetPhotosByCheckId",
+			name:         "ErrGetPhotoRecordsByCheckIds",
 			getCheckById: method[models.Check]{},
-			getPhotosByCheckId: method[[]string]{
+			getPhotoRecordsByCheckIds: method[[]models.PhotoRecord]{
 				err: errors.New(""),
 			},
 		},
@@ -285,16 +539,16 @@ func (suite *ChecksSuite) TestGetCheckById() {
 					return
 				}
 
-				suite.photosRepo.On("GetPhotosByCheckId", mock.Anything, mock.AnythingOfType("int"), mock.AnythingOfType("int")).Once().
-					Return(tt.getPhotosByCheckId.data, tt.getPhotosByCheckId.err)
-				if tt.getPhotosByCheckId.err != nil {
+				suite.checksRepo.On("GetPhotoRecordsByCheckIds", mock.Anything, mock.Anything).Once().
+					Return(tt.getPhotoRecordsByCheckIds.data, tt.getPhotoRecordsByCheckIds.err)
+				if tt.getPhotoRecordsByCheckIds.err != nil {
 					return
 				}
 			}()
 
 			_, gotErr := suite.uc.GetCheckById(context.Background(), 1)
 
-			if tt.getCheckById.err == nil && tt.getPhotosByCheckId.err == nil {
+			if tt.getCheckById.err == nil && tt.getPhotoRecordsByCheckIds.err == nil {
 				suite.NoError(gotErr)
 			} else {
 				suite.NotNil(gotErr)
@@ -307,9 +561,9 @@ func (suite *ChecksSuite) TestGetCheckById() {
 
 func (suite *ChecksSuite) TestGetChecksByMarkId() {
 	tests := []struct {
-		name              string
-		getChecksByMarkId method[[]models.Check]
-		getPhotosByMarkId method[map[int]map[int][]string]
+		name                      string
+		getChecksByMarkId         method[[]models.Check]
+		getPhotoRecordsByCheckIds method[[]models.PhotoRecord]
 	}{
 		{
 			name: "Ok",
@@ -317,8 +571,8 @@ func (suite *ChecksSuite) TestGetChecksByMarkId() {
 				data: []models.Check{{}, {}},
 				err:  nil,
 			},
-			getPhotosByMarkId: method[map[int]map[int][]string]{
-				data: map[int]map[int][]string{},
+			getPhotoRecordsByCheckIds: method[[]models.PhotoRecord]{
+				data: []models.PhotoRecord{},
 				err:  nil,
 			},
 		},
@@ -328,18 +582,18 @@ func (suite *ChecksSuite) TestGetChecksByMarkId() {
 				data: nil,
 				err:  errors.New(""),
 			},
-			getPhotosByMarkId: method[map[int]map[int][]string]{
+			getPhotoRecordsByCheckIds: method[[]models.PhotoRecord]{
 				data: nil,
 				err:  nil,
 			},
 		},
 		{
-			name: "ErrGetPhotosByMarkId",
+			name: "ErrGetPhotoRecordsByCheckIds",
 			getChecksByMarkId: method[[]models.Check]{
 				data: []models.Check{{}, {}},
 				err:  nil,
 			},
-			getPhotosByMarkId: method[map[int]map[int][]string]{
+			getPhotoRecordsByCheckIds: method[[]models.PhotoRecord]{
 				data: nil,
 				err:  errors.New(""),
 			},
@@ -349,22 +603,22 @@ func (suite *ChecksSuite) TestGetChecksByMarkId() {
 	for _, tt := range tests {
 		suite.Run(tt.name, func() {
 			func() {
-				suite.checksRepo.On("GetChecksByMarkId", mock.Anything, mock.AnythingOfType("int")).Once().
+				suite.checksRepo.On("GetChecksByMarkId", mock.Anything, mock.AnythingOfType("int"), mock.Anything).Once().
 					Return(tt.getChecksByMarkId.data, tt.getChecksByMarkId.err)
 				if tt.getChecksByMarkId.err != nil {
 					return
 				}
 
-				suite.photosRepo.On("GetPhotosByMarkId", mock.Anything, mock.AnythingOfType("int")).Once().
-					Return(tt.getPhotosByMarkId.data, tt.getPhotosByMarkId.err)
-				if tt.getPhotosByMarkId.err != nil {
+				suite.checksRepo.On("GetPhotoRecordsByCheckIds", mock.Anything, mock.Anything).Once().
+					Return(tt.getPhotoRecordsByCheckIds.data, tt.getPhotoRecordsByCheckIds.err)
+				if tt.getPhotoRecordsByCheckIds.err != nil {
 					return
 				}
 			}()
 
-			_, gotErr := suite.uc.GetChecksByMarkId(context.Background(), 1)
+			_, _, gotErr := suite.uc.GetChecksByMarkId(context.Background(), 1, models.Pagination{})
 
-			if tt.getChecksByMarkId.err == nil && tt.getPhotosByMarkId.err == nil {
+			if tt.getChecksByMarkId.err == nil && tt.getPhotoRecordsByCheckIds.err == nil {
 				suite.NoError(gotErr)
 			} else {
 				suite.NotNil(gotErr)
@@ -377,9 +631,9 @@ func (suite *ChecksSuite) TestGetChecksByMarkId() {
 
 func (suite *ChecksSuite) TestGetChecksByUserId() {
 	tests := []struct {
-		name               string
-		getChecksByUserId  method[[]models.Check]
-		getPhotosByCheckId method[[]string]
+		name                      string
+		getChecksByUserId         method[[]models.Check]
+		getPhotoRecordsByCheckIds method[[]models.PhotoRecord]
 	}{
 		{
 			name: "Ok",
@@ -387,8 +641,8 @@ func (suite *ChecksSuite) TestGetChecksByUserId() {
 				data: []models.Check{{}},
 				err:  nil,
 			},
-			getPhotosByCheckId: method[[]string]{
-				data: []string{},
+			getPhotoRecordsByCheckIds: method[[]models.PhotoRecord]{
+				data: []models.PhotoRecord{},
 				err:  nil,
 			},
 		},
@@ -398,18 +652,18 @@ func (suite *ChecksSuite) TestGetChecksByUserId() {
 				data: nil,
 				err:  errors.New(""),
 			},
-			getPhotosByCheckId: method[[]string]{
+			getPhotoRecordsByCheckIds: method[[]models.PhotoRecord]{
 				data: nil,
 				err:  nil,
 			},
 		},
 		{
-			name: "ErrGetPhotosByCheckId",
+			name: "ErrGetPhotoRecordsByCheckIds",
 			getChecksByUserId: method[[]models.Check]{
 				data: []models.Check{{}},
 				err:  nil,
 			},
-			getPhotosByCheckId: method[[]string]{
+			getPhotoRecordsByCheckIds: method[[]models.PhotoRecord]{
 				data: nil,
 				err:  errors.New(""),
 			},
@@ -419,22 +673,22 @@ func (suite *ChecksSuite) TestGetChecksByUserId() {
 	for _, tt := range tests {
 		suite.Run(tt.name, func() {
 			func() {
-				suite.checksRepo.On("GetChecksByUserId", mock.Anything, mock.AnythingOfType("int")).Once().
+				suite.checksRepo.On("GetChecksByUserId", mock.Anything, mock.AnythingOfType("int"), mock.Anything).Once().
 					Return(tt.getChecksByUserId.data, tt.getChecksByUserId.err)
 				if tt.getChecksByUserId.err != nil {
 					return
 				}
 
-				suite.photosRepo.On("GetPhotosByCheckId", mock.Anything, mock.AnythingOfType("int"), mock.AnythingOfType("int")).Once().
-					Return(tt.getPhotosByCheckId.data, tt.getPhotosByCheckId.err)
-				if tt.getPhotosByCheckId.err != nil {
+				suite.checksRepo.On("GetPhotoRecordsByCheckIds", mock.Anything, mock.Anything).Once().
+					Return(tt.getPhotoRecordsByCheckIds.data, tt.getPhotoRecordsByCheckIds.err)
+				if tt.getPhotoRecordsByCheckIds.err != nil {
 					return
 				}
 			}()
 
-			_, gotErr := suite.uc.GetChecksByUserId(context.Background(), 1)
+			_, _, gotErr := suite.uc.GetChecksByUserId(context.Background(), 1, models.Pagination{})
 
-			if tt.getChecksByUserId.err == nil && tt.getPhotosByCheckId.err == nil {
+			if tt.getChecksByUserId.err == nil && tt.getPhotoRecordsByCheckIds.err == nil {
 				suite.NoError(gotErr)
 			} else {
 				suite.NotNil(gotErr)
@@ -451,15 +705,18 @@ type MarkStatusUpdaterSuite struct {
 	log        *slog.Logger
 	marksRepo  *usecase.MockMarksRepository
 	checksRepo *usecase.MockChecksRepository
+	photosRepo *usecase.MockPhotosRepository
 }
 
 func (suite *MarkStatusUpdaterSuite) SetupSuite() {
 	suite.log = slogdiscard.NewDiscardLogger()
 	suite.marksRepo = usecase.NewMockMarksRepository(suite.T())
 	suite.checksRepo = usecase.NewMockChecksRepository(suite.T())
-	suite.u = usecase.NewUpdater(suite.log, usecase.UpdaterRepositories{
+	suite.photosRepo = usecase.NewMockPhotosRepository(suite.T())
+	suite.u = usecase.NewUpdater(suite.log, config.MustLoadPath("../../configs/config-tests.yaml").Consensus, usecase.UpdaterRepositories{
 		Marks:  suite.marksRepo,
 		Checks: suite.checksRepo,
+		Photos: suite.photosRepo,
 	})
 }
 
@@ -467,6 +724,23 @@ func TestMarkStatusUpdater(t *testing.T) {
 	suite.Run(t, new(MarkStatusUpdaterSuite))
 }
 
+// expectStatusChange expects the change of the mark status,
+// the terminal statuses are resolved together with the users ratings
+func (suite *MarkStatusUpdaterSuite) expectStatusChange(newStatus models.MarkStatusType, err error) {
+	if !newStatus.IsTerminal() {
+		suite.marksRepo.On("UpdateMarkStatus", mock.Anything, mock.AnythingOfType("int"), newStatus).Once().
+			Return(err)
+		return
+	}
+
+	suite.marksRepo.On("GetMarkStatusHistoryByMarkId", mock.Anything, mock.AnythingOfType("int")).Once().
+		Return([]models.MarkStatusHistoryItem{}, nil)
+	suite.checksRepo.On("GetChecksByMarkId", mock.Anything, mock.AnythingOfType("int"), mock.Anything).Once().
+		Return([]models.Check{}, nil)
+	suite.marksRepo.On("ResolveMarkStatus", mock.Anything, mock.AnythingOfType("int"), mock.Anything, newStatus, mock.Anything).Once().
+		Return(err)
+}
+
 func (suite *MarkStatusUpdaterSuite) TestUpdateMarkStatus() {
 	tests := []struct {
 		name                         string
@@ -474,6 +748,7 @@ func (suite *MarkStatusUpdaterSuite) TestUpdateMarkStatus() {
 		getLastMarkStatusHistoryItem method[models.MarkStatusHistoryItem]
 		getChecksByMarkHistoryId     method[[]models.Check]
 		wantUpdated                  bool
+		newStatus                    models.MarkStatusType
 		updateMarkStatus             method[any]
 	}{
 		{
@@ -529,6 +804,7 @@ func (suite *MarkStatusUpdaterSuite) TestUpdateMarkStatus() {
 				err: nil,
 			},
 			wantUpdated: true,
+			newStatus:   models.ConfirmedStatus,
 			updateMarkStatus: method[any]{
 				err: nil,
 			},
@@ -561,6 +837,7 @@ func (suite *MarkStatusUpdaterSuite) TestUpdateMarkStatus() {
 				err: nil,
 			},
 			wantUpdated: true,
+			newStatus:   models.ConfirmedStatus,
 			updateMarkStatus: method[any]{
 				err: errors.New(""),
 			},
@@ -593,6 +870,7 @@ func (suite *MarkStatusUpdaterSuite) TestUpdateMarkStatus() {
 				err: nil,
 			},
 			wantUpdated: true,
+			newStatus:   models.RefutedStatus,
 			updateMarkStatus: method[any]{
 				err: nil,
 			},
@@ -625,6 +903,7 @@ func (suite *MarkStatusUpdaterSuite) TestUpdateMarkStatus() {
 				err: nil,
 			},
 			wantUpdated: true,
+			newStatus:   models.RefutedStatus,
 			updateMarkStatus: method[any]{
 				err: errors.New(""),
 			},
@@ -696,11 +975,7 @@ func (suite *MarkStatusUpdaterSuite) TestUpdateMarkStatus() {
 					}
 
 					if tt.wantUpdated {
-						suite.marksRepo.On("UpdateMarkStatus", mock.Anything, mock.AnythingOfType("int"), mock.Anything).Once().
-							Return(tt.updateMarkStatus.err)
-						if tt.updateMarkStatus.err != nil {
-							return
-						}
+						suite.expectStatusChange(tt.newStatus, tt.updateMarkStatus.err)
 					}
 				}
 			}()
@@ -788,6 +1063,17 @@ func (suite *MarkStatusUpdaterSuite) TestConfirm() {
 			updateMarkStatus: method[any]{
 				err: errors.New(""),
 			},
+			want: models.ConfirmedStatus,
+		},
+		{
+			name: "Err-ResolveMarkStatus",
+			getMarkById: method[models.Mark]{
+				data: models.Mark{MarkStatusID: models.UnderReviewStatus},
+			},
+			updateMarkStatus: method[any]{
+				err: errors.New(""),
+			},
+			want: models.ClosedStatus,
 		},
 	}
 
@@ -800,11 +1086,7 @@ func (suite *MarkStatusUpdaterSuite) TestConfirm() {
 					return
 				}
 
-				suite.marksRepo.On("UpdateMarkStatus", mock.Anything, mock.AnythingOfType("int"), mock.AnythingOfType("models.MarkStatusType")).Once().
-					Return(tt.updateMarkStatus.err)
-				if tt.updateMarkStatus.err != nil {
-					return
-				}
+				suite.expectStatusChange(tt.want, tt.updateMarkStatus.err)
 			}()
 
 			got, gotErr := suite.u.Confirm(context.Background(), 1)
@@ -887,6 +1169,7 @@ func (suite *MarkStatusUpdaterSuite) TestReject() {
 			updateMarkStatus: method[any]{
 				err: errors.New(""),
 			},
+			want: models.RefutedStatus,
 		},
 	}
 
@@ -899,11 +1182,7 @@ func (suite *MarkStatusUpdaterSuite) TestReject() {
 					return
 				}
 
-				suite.marksRepo.On("UpdateMarkStatus", mock.Anything, mock.AnythingOfType("int"), mock.AnythingOfType("models.MarkStatusType")).Once().
-					Return(tt.updateMarkStatus.err)
-				if tt.updateMarkStatus.err != nil {
-					return
-				}
+				suite.expectStatusChange(tt.want, tt.updateMarkStatus.err)
 			}()
 
 			got, gotErr := suite.u.Reject(context.Background(), 1)
@@ -918,3 +1197,268 @@ func (suite *MarkStatusUpdaterSuite) TestReject() {
 		})
 	}
 }
+
+func (suite *MarkStatusUpdaterSuite) TestRejectUpdatesRatings() {
+	historyItems := []models.MarkStatusHistoryItem{
+		{ID: 1, NewMarkStatusID: models.UnconfirmedStatus},
+		{ID: 2, NewMarkStatusID: models.ConfirmedStatus},
+		{ID: 3, NewMarkStatusID: models.ConfirmedStatus, MergedMarkId: null.IntFrom(5)},
+		{ID: 4, NewMarkStatusID: models.UnderReviewStatus},
+		{ID: 5, NewMarkStatusID: models.RediscoveredStatus},
+	}
+	checks := []models.Check{
+		// the unconfirmed status was confirmed
+		{UserID: 1, MarkStatusHistoryItemId: 1, Result: true},
+		{UserID: 2, MarkStatusHistoryItemId: 1, Result: false},
+		// the under review status was rejected
+		{UserID: 1, MarkStatusHistoryItemId: 4, Result: false},
+		{UserID: 3, MarkStatusHistoryItemId: 4, Result: true},
+		// the rediscovered status is rejected now
+		{UserID: 2, MarkStatusHistoryItemId: 5, Result: false},
+	}
+
+	suite.marksRepo.On("GetMarkById", mock.Anything, 1).Once().
+		Return(models.Mark{ID: 1, MarkStatusID: models.RediscoveredStatus}, nil)
+	suite.marksRepo.On("GetMarkStatusHistoryByMarkId", mock.Anything, 1).Once().
+		Return(historyItems, nil)
+	suite.checksRepo.On("GetChecksByMarkId", mock.Anything, 1, mock.Anything).Once().
+		Return(checks, nil)
+	suite.marksRepo.On("ResolveMarkStatus", mock.Anything, 1, models.RediscoveredStatus, models.ClosedStatus, []models.UserCheckOutcome{
+		{UserID: 1, Resolved: 2, Agreed: 2},
+		{UserID: 2, Resolved: 2, Agreed: 1},
+		{UserID: 3, Resolved: 1, Agreed: 0},
+	}).Once().
+		Return(nil)
+
+	got, err := suite.u.Reject(context.Background(), 1)
+
+	suite.NoError(err)
+	suite.Equal(models.ClosedStatus, got)
+	suite.marksRepo.AssertExpectations(suite.T())
+	suite.checksRepo.AssertExpectations(suite.T())
+}
+
+func (suite *MarkStatusUpdaterSuite) TestExplain() {
+	confirmingChecks := []models.Check{
+		{UserID: 1, Result: true},
+		{UserID: 2, Result: true},
+		{UserID: 3, Result: true},
+	}
+
+	tests := []struct {
+		name                     string
+		mark                     models.Mark
+		getChecksByMarkHistoryId *method[[]models.Check]
+		wantDecision             models.ConsensusDecision
+		wantNewStatus            models.MarkStatusType
+	}{
+		{
+			name: "Ok-Confirm",
+			mark: models.Mark{ID: 1, MarkStatusID: models.UnderReviewStatus},
+			getChecksByMarkHistoryId: &method[[]models.Check]{
+				data: confirmingChecks,
+			},
+			wantDecision:  models.ConfirmDecision,
+			wantNewStatus: models.ClosedStatus,
+		},
+		{
+			name: "Ok-MarkTypeRule",
+			mark: models.Mark{ID: 1, MarkTypeID: 1, MarkStatusID: models.UnconfirmedStatus},
+			getChecksByMarkHistoryId: &method[[]models.Check]{
+				data: confirmingChecks,
+			},
+			wantDecision: models.NoDecision,
+		},
+		{
+			name:         "Ok-NotVotedStatus",
+			mark:         models.Mark{ID: 1, MarkStatusID: models.ClosedStatus},
+			wantDecision: models.NoDecision,
+		},
+		{
+			name: "Err-GetChecksByMarkHistoryId",
+			mark: models.Mark{ID: 1, MarkStatusID: models.UnconfirmedStatus},
+			getChecksByMarkHistoryId: &method[[]models.Check]{
+				err: errors.New(""),
+			},
+		},
+	}
+	for _, tt := range tests {
+		suite.Run(tt.name, func() {
+			suite.marksRepo.On("GetMarkById", mock.Anything, 1).Once().
+				Return(tt.mark, nil)
+			suite.marksRepo.On("GetLastMarkStatusHistoryItem", mock.Anything, 1).Once().
+				Return(models.MarkStatusHistoryItem{ID: 1}, nil)
+			if tt.getChecksByMarkHistoryId != nil {
+				suite.checksRepo.On("GetChecksByMarkHistoryId", mock.Anything, 1).Once().
+					Return(tt.getChecksByMarkHistoryId.data, tt.getChecksByMarkHistoryId.err)
+			}
+
+			explanation, gotErr := suite.u.Explain(context.Background(), 1)
+
+			if tt.getChecksByMarkHistoryId != nil && tt.getChecksByMarkHistoryId.err != nil {
+				suite.NotNil(gotErr)
+			} else {
+				suite.NoError(gotErr)
+				suite.Equal(tt.wantDecision, explanation.Decision)
+				suite.Equal(tt.wantNewStatus, explanation.NewMarkStatusID.V)
+				suite.NotEmpty(explanation.Reasons)
+			}
+			suite.marksRepo.AssertExpectations(suite.T())
+			suite.checksRepo.AssertExpectations(suite.T())
+		})
+	}
+}
+
+func (suite *MarkStatusUpdaterSuite) TestMerge() {
+	openMark := method[models.Mark]{data: models.Mark{MarkStatusID: models.UnconfirmedStatus}}
+	copiedKeys := []string{"marks/1/3/0.jpg"}
+
+	tests := []struct {
+		name                 string
+		mergedMarkIds        []int
+		getMarkById          method[models.Mark]
+		getMergedMarkById    method[models.Mark]
+		copyPhotos           method[[]string]
+		mergeMarks           method[any]
+		deletePhotos         method[any]
+		deletePhotosByMarkId method[any]
+		err                  error
+	}{
+		{
+			name:              "Ok",
+			mergedMarkIds:     []int{2},
+			getMarkById:       openMark,
+			getMergedMarkById: openMark,
+			copyPhotos:        method[[]string]{data: copiedKeys},
+		},
+		{
+			name:                 "Ok-ErrDeletePhotos",
+			mergedMarkIds:        []int{2},
+			getMarkById:          openMark,
+			getMergedMarkById:    openMark,
+			deletePhotosByMarkId: method[any]{err: errors.New("")},
+		},
+		{
+			name:          "Err-NoMergedMarks",
+			mergedMarkIds: []int{},
+			err:           usecase.ErrInvalidInput,
+		},
+		{
+			name:          "Err-MergeIntoItself",
+			mergedMarkIds: []int{1},
+			err:           usecase.ErrInvalidInput,
+		},
+		{
+			name:          "Err-DuplicateMergedMarks",
+			mergedMarkIds: []int{2, 2},
+			err:           usecase.ErrInvalidInput,
+		},
+		{
+			name:          "Err-NotFound",
+			mergedMarkIds: []int{2},
+			getMarkById:   openMark,
+			getMergedMarkById: method[models.Mark]{
+				err: storage.ErrNotFound,
+			},
+			err: storage.ErrNotFound,
+		},
+		{
+			name:          "Err-ClosedMark",
+			mergedMarkIds: []int{2},
+			getMarkById: method[models.Mark]{
+				data: models.Mark{MarkStatusID: models.ClosedStatus},
+			},
+			err: usecase.ErrConflict,
+		},
+		{
+			name:              "Err-CopyPhotos",
+			mergedMarkIds:     []int{2},
+			getMarkById:       openMark,
+			getMergedMarkById: openMark,
+			copyPhotos:        method[[]string]{err: errors.New("")},
+		},
+		{
+			name:              "Err-CopyPhotosPartly",
+			mergedMarkIds:     []int{2},
+			getMarkById:       openMark,
+			getMergedMarkById: openMark,
+			copyPhotos:        method[[]string]{data: copiedKeys, err: errors.New("")},
+		},
+		{
+			name:              "Err-MergeMarks",
+			mergedMarkIds:     []int{2},
+			getMarkById:       openMark,
+			getMergedMarkById: openMark,
+			copyPhotos:        method[[]string]{data: copiedKeys},
+			mergeMarks:        method[any]{err: errors.New("")},
+		},
+		{
+			name:              "Err-MergeMarks-ErrDeletePhotos",
+			mergedMarkIds:     []int{2},
+			getMarkById:       openMark,
+			getMergedMarkById: openMark,
+			copyPhotos:        method[[]string]{data: copiedKeys},
+			mergeMarks:        method[any]{err: errors.New("")},
+			deletePhotos:      method[any]{err: errors.New("")},
+		},
+	}
+	for _, tt := range tests {
+		suite.Run(tt.name, func() {
+			func() {
+				if errors.Is(tt.err, usecase.ErrInvalidInput) {
+					return
+				}
+
+				suite.marksRepo.On("GetMarkById", mock.Anything, 1).Once().
+					Return(tt.getMarkById.data, tt.getMarkById.err)
+				if tt.getMarkById.data.MarkStatusID != models.UnconfirmedStatus {
+					return
+				}
+
+				suite.marksRepo.On("GetMarkById", mock.Anything, 2).Once().
+					Return(tt.getMergedMarkById.data, tt.getMergedMarkById.err)
+				if tt.getMergedMarkById.err != nil {
+					return
+				}
+
+				suite.photosRepo.On("CopyPhotos", mock.Anything, 2, 1).Once().
+					Return(tt.copyPhotos.data, tt.copyPhotos.err)
+				if tt.copyPhotos.err == nil {
+					suite.marksRepo.On("MergeMarks", mock.Anything, 1, tt.mergedMarkIds).Once().
+						Return(tt.mergeMarks.err)
+				}
+				if tt.copyPhotos.err != nil || tt.mergeMarks.err != nil {
+					if len(tt.copyPhotos.data) > 0 {
+						suite.photosRepo.On("DeletePhotos", mock.Anything, tt.copyPhotos.data).Once().
+							Return(tt.deletePhotos.err)
+					}
+					return
+				}
+
+				suite.photosRepo.On("DeletePhotosByMarkId", mock.Anything, 2).Once().
+					Return(tt.deletePhotosByMarkId.err)
+
+				suite.marksRepo.On("GetMarkById", mock.Anything, 1).Once().
+					Return(tt.getMarkById.data, nil)
+				suite.marksRepo.On("GetLastMarkStatusHistoryItem", mock.Anything, 1).Once().
+					Return(models.MarkStatusHistoryItem{ID: 1}, nil)
+				suite.checksRepo.On("GetChecksByMarkHistoryId", mock.Anything, 1).Once().
+					Return([]models.Check{{Result: true}}, nil)
+			}()
+
+			gotErr := suite.u.Merge(context.Background(), 1, tt.mergedMarkIds)
+
+			switch {
+			case tt.err != nil:
+				suite.ErrorIs(gotErr, tt.err)
+			case tt.copyPhotos.err != nil || tt.mergeMarks.err != nil:
+				suite.NotNil(gotErr)
+			default:
+				suite.NoError(gotErr)
+			}
+			suite.marksRepo.AssertExpectations(suite.T())
+			suite.checksRepo.AssertExpectations(suite.T())
+			suite.photosRepo.AssertExpectations(suite.T())
+		})
+	}
+}
@@ -0,0 +1,94 @@
+package usecase
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/PritOriginal/problem-map-server/internal/models"
+)
+
+// ConsensusPolicy decides whether the checks of the current mark status are enough to change it
+type ConsensusPolicy interface {
+	Evaluate(checks []models.Check, now time.Time) models.ConsensusResult
+}
+
+// RulePolicy is the consensus policy configured by a rule
+type RulePolicy struct {
+	Rule models.ConsensusRule
+}
+
+func NewRulePolicy(rule models.ConsensusRule) *RulePolicy {
+	return &RulePolicy{Rule: rule}
+}
+
+// Evaluate weighs the checks and applies the rule limits one by one, the first unmet limit is the reason of no decision
+func (p *RulePolicy) Evaluate(checks []models.Check, now time.Time) models.ConsensusResult {
+	result := models.ConsensusResult{
+		Decision: models.NoDecision,
+		Votes:    len(checks),
+		Reasons:  []string{},
+	}
+
+	users := make(map[int]bool, len(checks))
+	for _, check := range checks {
+		users[check.UserID] = true
+
+		weight := p.weight(check, now)
+		if check.Result {
+			result.WeightFor += weight
+		} else {
+			result.WeightAgainst += weight
+		}
+	}
+	result.DistinctUsers = len(users)
+	result.Score = result.WeightFor - result.WeightAgainst
+	if total := result.WeightFor + result.WeightAgainst; total > 0 {
+		result.Ratio = math.Max(result.WeightFor, result.WeightAgainst) / total
+	}
+
+	if result.Votes < p.Rule.MinVotes {
+		result.Reasons = append(result.Reasons, fmt.Sprintf("not enough votes: %d of %d", result.Votes, p.Rule.MinVotes))
+		return result
+	}
+	if result.DistinctUsers < p.Rule.MinDistinctUsers {
+		result.Reasons = append(result.Reasons, fmt.Sprintf("not enough distinct users: %d of %d", result.DistinctUsers, p.Rule.MinDistinctUsers))
+		return result
+	}
+	if math.Abs(result.Score) < p.Rule.Threshold || result.Score == 0 {
+		result.Reasons = append(result.Reasons, fmt.Sprintf("score %.2f doesn't reach the threshold ±%.2f", result.Score, p.Rule.Threshold))
+		return result
+	}
+	if result.Ratio < p.Rule.Ratio {
+		result.Reasons = append(result.Reasons, fmt.Sprintf("ratio %.2f is below %.2f", result.Ratio, p.Rule.Ratio))
+		return result
+	}
+
+	if result.Score > 0 {
+		result.Decision = models.ConfirmDecision
+		result.Reasons = append(result.Reasons, fmt.Sprintf("score %.2f reached the threshold %.2f", result.Score, p.Rule.Threshold))
+	} else {
+		result.Decision = models.RejectDecision
+		result.Reasons = append(result.Reasons, fmt.Sprintf("score %.2f reached the threshold -%.2f", result.Score, p.Rule.Threshold))
+	}
+
+	return result
+}
+
+// weight returns the weight of the check, it is scaled by the rating of the user and the flag
+// and halves every half life of the rule
+func (p *RulePolicy) weight(check models.Check, now time.Time) float64 {
+	weight := 1.0
+	if p.Rule.ReputationWeighted {
+		weight = models.RatingWeight(check.UserRating)
+	}
+	if check.Flagged {
+		weight *= p.Rule.FlaggedWeight
+	}
+
+	if age := now.Sub(check.CreatedAt); p.Rule.HalfLife > 0 && age > 0 {
+		weight *= math.Pow(0.5, float64(age)/float64(p.Rule.HalfLife))
+	}
+
+	return weight
+}
@@ -0,0 +1,140 @@
+package usecase_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/PritOriginal/problem-map-server/internal/models"
+	"github.com/PritOriginal/problem-map-server/internal/usecase"
+)
+
+func TestRulePolicy_Evaluate(t *testing.T) {
+	now := time.Now()
+
+	votes := func(userIds []int, result bool, age time.Duration) []models.Check {
+		checks := make([]models.Check, len(userIds))
+		for i, userId := range userIds {
+			checks[i] = models.Check{UserID: userId, Result: result, CreatedAt: now.Add(-age)}
+		}
+		return checks
+	}
+
+	tests := []struct {
+		name      string
+		rule      models.ConsensusRule
+		checks    []models.Check
+		want      models.ConsensusDecision
+		wantScore float64
+	}{
+		{
+			name:      "Confirm",
+			rule:      models.ConsensusRule{Threshold: 3},
+			checks:    votes([]int{1, 2, 3}, true, 0),
+			want:      models.ConfirmDecision,
+			wantScore: 3,
+		},
+		{
+			name:      "Reject",
+			rule:      models.ConsensusRule{Threshold: 3},
+			checks:    append(votes([]int{1, 2, 3, 4}, false, 0), votes([]int{5}, true, 0)...),
+			want:      models.RejectDecision,
+			wantScore: -3,
+		},
+		{
+			name:      "NoThreshold",
+			rule:      models.ConsensusRule{Threshold: 3},
+			checks:    append(votes([]int{1, 2, 3}, true, 0), votes([]int{4}, false, 0)...),
+			want:      models.NoDecision,
+			wantScore: 2,
+		},
+		{
+			name:      "NoVotes",
+			rule:      models.ConsensusRule{Threshold: 3},
+			checks:    nil,
+			want:      models.NoDecision,
+			wantScore: 0,
+		},
+		{
+			name:      "MinVotes",
+			rule:      models.ConsensusRule{Threshold: 3, MinVotes: 4},
+			checks:    votes([]int{1, 2, 3}, true, 0),
+			want:      models.NoDecision,
+			wantScore: 3,
+		},
+		{
+			name:      "MinDistinctUsers",
+			rule:      models.ConsensusRule{Threshold: 3, MinDistinctUsers: 3},
+			checks:    votes([]int{1, 1, 2}, true, 0),
+			want:      models.NoDecision,
+			wantScore: 3,
+		},
+		{
+			name:      "Ratio",
+			rule:      models.ConsensusRule{Threshold: 3, Ratio: 0.8},
+			checks:    append(votes([]int{1, 2, 3, 4, 5, 6}, true, 0), votes([]int{7, 8, 9}, false, 0)...),
+			want:      models.NoDecision,
+			wantScore: 3,
+		},
+		{
+			name:      "TimeDecay",
+			rule:      models.ConsensusRule{Threshold: 3, HalfLife: time.Hour},
+			checks:    append(votes([]int{1, 2}, true, 0), votes([]int{3, 4}, true, time.Hour)...),
+			want:      models.ConfirmDecision,
+			wantScore: 3,
+		},
+		{
+			name:      "TimeDecayNoThreshold",
+			rule:      models.ConsensusRule{Threshold: 3, HalfLife: time.Hour},
+			checks:    votes([]int{1, 2, 3, 4}, true, 2*time.Hour),
+			want:      models.NoDecision,
+			wantScore: 1,
+		},
+		{
+			name: "ReputationWeighted",
+			rule: models.ConsensusRule{Threshold: 3, ReputationWeighted: true},
+			checks: []models.Check{
+				{UserID: 1, Result: true, UserRating: 100, CreatedAt: now},
+				{UserID: 2, Result: true, UserRating: 50, CreatedAt: now},
+			},
+			want:      models.ConfirmDecision,
+			wantScore: 3,
+		},
+		{
+			name: "ReputationWeightedNoThreshold",
+			rule: models.ConsensusRule{Threshold: 3, ReputationWeighted: true},
+			checks: []models.Check{
+				{UserID: 1, Result: true, UserRating: 25, CreatedAt: now},
+				{UserID: 2, Result: true, UserRating: 25, CreatedAt: now},
+				{UserID: 3, Result: true, UserRating: 25, CreatedAt: now},
+			},
+			want:      models.NoDecision,
+			wantScore: 1.5,
+		},
+		{
+			name: "FlaggedNoThreshold",
+			rule: models.ConsensusRule{Threshold: 3, FlaggedWeight: 0.5},
+			checks: []models.Check{
+				{UserID: 1, Result: true, CreatedAt: now},
+				{UserID: 2, Result: true, CreatedAt: now},
+				{UserID: 3, Result: true, Flagged: true, CreatedAt: now},
+			},
+			want:      models.NoDecision,
+			wantScore: 2.5,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := usecase.NewRulePolicy(tt.rule).Evaluate(tt.checks, now)
+
+			if got.Decision != tt.want {
+				t.Errorf("Evaluate() decision = %v, want %v, reasons %v", got.Decision, tt.want, got.Reasons)
+			}
+			if diff := got.Score - tt.wantScore; diff > 1e-9 || diff < -1e-9 {
+				t.Errorf("Evaluate() score = %v, want %v", got.Score, tt.wantScore)
+			}
+			if len(got.Reasons) == 0 {
+				t.Errorf("Evaluate() has no reasons")
+			}
+		})
+	}
+}
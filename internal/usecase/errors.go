@@ -1,9 +1,60 @@
 package usecase
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/PritOriginal/problem-map-server/internal/models"
+)
 
 var (
 	ErrNotFound     = errors.New("Not found")
 	ErrConflict     = errors.New("Conflict")
 	ErrUnauthorized = errors.New("Unauthorized")
+	ErrForbidden    = errors.New("Forbidden")
+	ErrInvalidInput = errors.New("Invalid input")
+	// ErrTooManyRequests means the action is locked out after too many attempts
+	ErrTooManyRequests = errors.New("Too many requests")
 )
+
+// DuplicateMarksError is returned when open marks of the same type were found near the new mark
+type DuplicateMarksError struct {
+	Marks []models.NearbyMark
+}
+
+func (e *DuplicateMarksError) Error() string {
+	return fmt.Sprintf("found %d possible duplicate marks", len(e.Marks))
+}
+
+func (e *DuplicateMarksError) Unwrap() error {
+	return ErrConflict
+}
+
+// CheckTooFarError is returned when the user checks the mark too far from it
+type CheckTooFarError struct {
+	Distance    float64
+	MaxDistance float64
+}
+
+func (e *CheckTooFarError) Error() string {
+	return fmt.Sprintf("the check is %.0f m from the mark, the max distance is %.0f m", e.Distance, e.MaxDistance)
+}
+
+func (e *CheckTooFarError) Unwrap() error {
+	return ErrInvalidInput
+}
+
+// LockedOutError is returned when the sign-in or the password reset request is locked out
+// after too many attempts by the login or from the IP
+type LockedOutError struct {
+	RetryAfter time.Duration
+}
+
+func (e *LockedOutError) Error() string {
+	return fmt.Sprintf("locked out for %v", e.RetryAfter)
+}
+
+func (e *LockedOutError) Unwrap() error {
+	return ErrTooManyRequests
+}
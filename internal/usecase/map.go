@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"math"
 
 	"github.com/PritOriginal/problem-map-server/internal/models"
 )
@@ -11,6 +12,9 @@ import (
 type MapRepository interface {
 	GetAdminBoundaries(ctx context.Context, filters models.GetAdminBoundaryFilters) ([]models.AdminBoundary, error)
 	GetAdminBoundariesMarksCount(ctx context.Context, filters models.GetAdminBoundaryMarksCountFilters) ([]models.AdminBoundaryMarksCount, error)
+	GetMarkClusters(ctx context.Context, filters models.GetMarkClustersFilters) ([]models.MarkCluster, error)
+	GetMarksTile(ctx context.Context, tile models.Tile) ([]byte, error)
+	GetAdminBoundariesTile(ctx context.Context, tile models.Tile) ([]byte, error)
 	GetRegions(ctx context.Context) ([]models.Region, error)
 	GetCities(ctx context.Context) ([]models.City, error)
 	GetDistricts(ctx context.Context) ([]models.District, error)
@@ -22,7 +26,8 @@ type Map struct {
 }
 
 type MapRepositories struct {
-	Map MapRepository
+	Map   MapRepository
+	Marks MarksRepository
 }
 
 func NewMap(log *slog.Logger, repos MapRepositories) *Map {
@@ -49,6 +54,67 @@ func (uc *Map) GetAdminBoundariesMarksCount(ctx context.Context, filters models.
 	return boundariesCount, nil
 }
 
+const (
+	// ClusterMaxZoom is the zoom level starting from which individual marks are returned instead of clusters
+	ClusterMaxZoom = 16
+	// clusterCellsPerTile is the number of grid cells along one side of a map tile
+	clusterCellsPerTile = 4
+)
+
+// GetMarkClusters groups marks inside the bbox into grid cells whose size depends on the zoom.
+// Once the zoom reaches ClusterMaxZoom, marks are returned individually instead of clusters,
+// at most models.MaxPageLimit of them, truncated reports that the bbox has more marks.
+func (uc *Map) GetMarkClusters(ctx context.Context, filters models.GetMarkClustersFilters) ([]models.MarkCluster, []models.Mark, bool, error) {
+	const op = "usecase.Map.GetMarkClusters"
+
+	if filters.Zoom >= ClusterMaxZoom {
+		marks, nextCursor, err := fetchPage(models.Pagination{Limit: models.MaxPageLimit}, func(pagination models.Pagination) ([]models.Mark, error) {
+			return uc.repos.Marks.GetMarks(ctx, models.GetMarksFilters{
+				MarkTypeIds: filters.MarkTypeIds,
+				BBox:        &filters.BBox,
+				Pagination:  pagination,
+			})
+		})
+		if err != nil {
+			return nil, nil, false, fmt.Errorf("%s: %w", op, err)
+		}
+		return []models.MarkCluster{}, marks, nextCursor != "", nil
+	}
+
+	filters.GridSize = clusterGridSize(filters.Zoom)
+
+	clusters, err := uc.repos.Map.GetMarkClusters(ctx, filters)
+	if err != nil {
+		return nil, nil, false, fmt.Errorf("%s: %w", op, err)
+	}
+	return clusters, []models.Mark{}, false, nil
+}
+
+// clusterGridSize returns the grid cell size in degrees for the zoom level
+func clusterGridSize(zoom int) float64 {
+	return 360 / math.Exp2(float64(zoom)) / clusterCellsPerTile
+}
+
+// GetTile returns the layer tile encoded as Mapbox Vector Tile
+func (uc *Map) GetTile(ctx context.Context, layer models.TileLayer, tile models.Tile) ([]byte, error) {
+	const op = "usecase.Map.GetTile"
+
+	var mvt []byte
+	var err error
+	switch layer {
+	case models.TileLayerMarks:
+		mvt, err = uc.repos.Map.GetMarksTile(ctx, tile)
+	case models.TileLayerAdminBoundaries:
+		mvt, err = uc.repos.Map.GetAdminBoundariesTile(ctx, tile)
+	default:
+		return nil, fmt.Errorf("%s: %w", op, ErrNotFound)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return mvt, nil
+}
+
 func (uc *Map) GetRegions(ctx context.Context) ([]models.Region, error) {
 	const op = "usecase.Map.GetRegions"
 
@@ -15,16 +15,19 @@ import (
 
 type MapSuite struct {
 	suite.Suite
-	uc      *usecase.Map
-	log     *slog.Logger
-	mapRepo *usecase.MockMapRepository
+	uc        *usecase.Map
+	log       *slog.Logger
+	mapRepo   *usecase.MockMapRepository
+	marksRepo *usecase.MockMarksRepository
 }
 
 func (suite *MapSuite) SetupSuite() {
 	suite.log = slogdiscard.NewDiscardLogger()
 	suite.mapRepo = usecase.NewMockMapRepository(suite.T())
+	suite.marksRepo = usecase.NewMockMarksRepository(suite.T())
 	suite.uc = usecase.NewMap(suite.log, usecase.MapRepositories{
-		Map: suite.mapRepo,
+		Map:   suite.mapRepo,
+		Marks: suite.marksRepo,
 	})
 }
 
@@ -118,6 +121,158 @@ func (suite *MapSuite) TestGetAdminBoundariesMarksCount() {
 	}
 }
 
+func (suite *MapSuite) TestGetMarkClusters() {
+	tests := []struct {
+		name            string
+		zoom            int
+		getMarkClusters method[[]models.MarkCluster]
+		getMarks        method[[]models.Mark]
+		wantTruncated   bool
+	}{
+		{
+			name: "OkClusters",
+			zoom: 12,
+			getMarkClusters: method[[]models.MarkCluster]{
+				data: []models.MarkCluster{},
+				err:  nil,
+			},
+		},
+		{
+			name: "ErrClusters",
+			zoom: 12,
+			getMarkClusters: method[[]models.MarkCluster]{
+				data: nil,
+				err:  errors.New(""),
+			},
+		},
+		{
+			name: "OkMarks",
+			zoom: usecase.ClusterMaxZoom,
+			getMarks: method[[]models.Mark]{
+				data: []models.Mark{},
+				err:  nil,
+			},
+		},
+		{
+			name: "OkMarksTruncated",
+			zoom: usecase.ClusterMaxZoom,
+			getMarks: method[[]models.Mark]{
+				data: make([]models.Mark, models.MaxPageLimit+1),
+				err:  nil,
+			},
+			wantTruncated: true,
+		},
+		{
+			name: "ErrMarks",
+			zoom: usecase.ClusterMaxZoom,
+			getMarks: method[[]models.Mark]{
+				data: nil,
+				err:  errors.New(""),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		suite.Run(tt.name, func() {
+			wantErr := tt.getMarkClusters.err
+			if tt.zoom >= usecase.ClusterMaxZoom {
+				wantErr = tt.getMarks.err
+				suite.marksRepo.On("GetMarks", mock.Anything, mock.MatchedBy(func(filters models.GetMarksFilters) bool {
+					return filters.BBox != nil && filters.Pagination.Limit == models.MaxPageLimit+1
+				})).Once().
+					Return(tt.getMarks.data, tt.getMarks.err)
+			} else {
+				suite.mapRepo.On("GetMarkClusters", mock.Anything, mock.MatchedBy(func(filters models.GetMarkClustersFilters) bool {
+					return filters.GridSize > 0
+				})).Once().
+					Return(tt.getMarkClusters.data, tt.getMarkClusters.err)
+			}
+
+			clusters, marks, truncated, gotErr := suite.uc.GetMarkClusters(context.Background(), models.GetMarkClustersFilters{
+				BBox: models.BBox{MinLon: 41.3, MinLat: 52.6, MaxLon: 41.6, MaxLat: 52.8},
+				Zoom: tt.zoom,
+			})
+
+			if wantErr == nil {
+				suite.NoError(gotErr)
+				suite.NotNil(clusters)
+				suite.NotNil(marks)
+				suite.LessOrEqual(len(marks), models.MaxPageLimit)
+				suite.Equal(tt.wantTruncated, truncated)
+			} else {
+				suite.NotNil(gotErr)
+			}
+			suite.mapRepo.AssertExpectations(suite.T())
+			suite.marksRepo.AssertExpectations(suite.T())
+		})
+	}
+}
+
+func (suite *MapSuite) TestGetTile() {
+	tests := []struct {
+		name    string
+		layer   models.TileLayer
+		method  string
+		getTile method[[]byte]
+		wantErr error
+	}{
+		{
+			name:   "OkMarks",
+			layer:  models.TileLayerMarks,
+			method: "GetMarksTile",
+			getTile: method[[]byte]{
+				data: []byte{},
+				err:  nil,
+			},
+		},
+		{
+			name:   "OkAdminBoundaries",
+			layer:  models.TileLayerAdminBoundaries,
+			method: "GetAdminBoundariesTile",
+			getTile: method[[]byte]{
+				data: []byte{},
+				err:  nil,
+			},
+		},
+		{
+			name:   "ErrMarks",
+			layer:  models.TileLayerMarks,
+			method: "GetMarksTile",
+			getTile: method[[]byte]{
+				data: nil,
+				err:  errors.New(""),
+			},
+			wantErr: errors.New(""),
+		},
+		{
+			name:    "ErrUnknownLayer",
+			layer:   models.TileLayer("roads"),
+			wantErr: usecase.ErrNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		suite.Run(tt.name, func() {
+			if tt.method != "" {
+				suite.mapRepo.On(tt.method, mock.Anything, models.Tile{Z: 12, X: 2519, Y: 1343}).Once().
+					Return(tt.getTile.data, tt.getTile.err)
+			}
+
+			_, gotErr := suite.uc.GetTile(context.Background(), tt.layer, models.Tile{Z: 12, X: 2519, Y: 1343})
+
+			if tt.wantErr == nil {
+				suite.NoError(gotErr)
+			} else {
+				suite.NotNil(gotErr)
+			}
+			if errors.Is(tt.wantErr, usecase.ErrNotFound) {
+				suite.ErrorIs(gotErr, usecase.ErrNotFound)
+			}
+			suite.mapRepo.AssertExpectations(suite.T())
+		})
+	}
+}
+
 func (suite *MapSuite) TestGetRegions() {
 	tests := []struct {
 		name       string
@@ -2,35 +2,51 @@ package usecase
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"log/slog"
+	"time"
 
+	"github.com/PritOriginal/problem-map-server/internal/config"
 	"github.com/PritOriginal/problem-map-server/internal/models"
+	"github.com/PritOriginal/problem-map-server/internal/storage"
 )
 
 type MarksRepository interface {
 	GetMarks(ctx context.Context, filters models.GetMarksFilters) ([]models.Mark, error)
+	GetNearbyMarks(ctx context.Context, filters models.GetNearbyMarksFilters) ([]models.NearbyMark, error)
+	SearchMarks(ctx context.Context, filters models.SearchMarksFilters) ([]models.FoundMark, error)
 	GetMarkById(ctx context.Context, id int) (models.Mark, error)
-	GetMarksByUserId(ctx context.Context, userId int) ([]models.Mark, error)
+	GetMarksByUserId(ctx context.Context, userId int, pagination models.Pagination) ([]models.Mark, error)
 	AddMark(ctx context.Context, mark models.Mark) (int64, error)
+	UpdateMark(ctx context.Context, markId int, update models.MarkUpdate) error
+	DeleteMark(ctx context.Context, markId int) error
 	GetMarkTypes(ctx context.Context) ([]models.MarkType, error)
 	GetMarkStatuses(ctx context.Context) ([]models.MarkStatus, error)
 	UpdateMarkStatus(ctx context.Context, markId int, markStatusId models.MarkStatusType) error
+	ResolveMarkStatus(ctx context.Context, markId int, oldStatusId, newStatusId models.MarkStatusType, outcomes []models.UserCheckOutcome) error
 	GetMarkStatusHistoryByMarkId(ctx context.Context, markId int) ([]models.MarkStatusHistoryItem, error)
 	GetLastMarkStatusHistoryItem(ctx context.Context, markId int) (models.MarkStatusHistoryItem, error)
+	GetMergedMarkId(ctx context.Context, id int) (int, error)
+	MergeMarks(ctx context.Context, markId int, mergedMarkIds []int) error
 }
 
+// PhotosRepository stores the photo files, the records of the stored files are kept by ChecksRepository
 type PhotosRepository interface {
-	AddPhotos(ctx context.Context, markId, checkId int, photos []io.Reader) error
-	GetPhotos(ctx context.Context) (map[int]map[int][]string, error)
-	GetPhotosByMarkId(ctx context.Context, markId int) (map[int]map[int][]string, error)
-	GetPhotosByCheckId(ctx context.Context, markId, checkId int) ([]string, error)
+	AddPhotos(ctx context.Context, markId, checkId, first int, photos []io.Reader) ([]models.PhotoRecord, error)
+	URL(ctx context.Context, key string) (string, error)
+	ListPhotos(ctx context.Context, prefix string) ([]string, error)
+	GetPhoto(ctx context.Context, key string) (io.ReadCloser, error)
+	CopyPhotos(ctx context.Context, fromMarkId, toMarkId int) ([]string, error)
+	DeletePhotos(ctx context.Context, keys []string) error
+	DeletePhotosByMarkId(ctx context.Context, markId int) error
 }
 
 type Marks struct {
-	log   *slog.Logger
-	repos MarksRepositories
+	log      *slog.Logger
+	marksCfg config.MarksConfig
+	repos    MarksRepositories
 }
 
 type MarksRepositories struct {
@@ -39,46 +55,136 @@ type MarksRepositories struct {
 	Photos PhotosRepository
 }
 
-func NewMarks(log *slog.Logger, repos MarksRepositories) *Marks {
+func NewMarks(log *slog.Logger, marksCfg config.MarksConfig, repos MarksRepositories) *Marks {
 	return &Marks{
-		log:   log,
-		repos: repos,
+		log:      log,
+		marksCfg: marksCfg,
+		repos:    repos,
 	}
 }
 
-func (uc *Marks) GetMarks(ctx context.Context, filters models.GetMarksFilters) ([]models.Mark, error) {
+// GetMarks returns a page of marks matching the filters and the cursor to the next page.
+// The page size is capped by models.MaxPageLimit.
+func (uc *Marks) GetMarks(ctx context.Context, filters models.GetMarksFilters) ([]models.Mark, string, error) {
 	const op = "usecase.Map.GetMarks"
 
-	marks, err := uc.repos.Marks.GetMarks(ctx, filters)
+	marks, nextCursor, err := fetchPage(filters.Pagination, func(pagination models.Pagination) ([]models.Mark, error) {
+		filters.Pagination = pagination
+		return uc.repos.Marks.GetMarks(ctx, filters)
+	})
 	if err != nil {
-		return marks, fmt.Errorf("%s: %w", op, err)
+		return marks, "", fmt.Errorf("%s: %w", op, err)
 	}
-	return marks, nil
+
+	return marks, nextCursor, nil
+}
+
+// GetNearbyMarks returns a page of marks around the point ordered by distance and the cursor to the next page
+func (uc *Marks) GetNearbyMarks(ctx context.Context, filters models.GetNearbyMarksFilters) ([]models.NearbyMark, string, error) {
+	const op = "usecase.Map.GetNearbyMarks"
+
+	marks, nextCursor, err := fetchPage(filters.Pagination, func(pagination models.Pagination) ([]models.NearbyMark, error) {
+		filters.Pagination = pagination
+		return uc.repos.Marks.GetNearbyMarks(ctx, filters)
+	})
+	if err != nil {
+		return marks, "", fmt.Errorf("%s: %w", op, err)
+	}
+	return marks, nextCursor, nil
+}
+
+// SearchMarks returns a page of marks whose description or check comments match the query,
+// ordered by the search rank, and the cursor to the next page
+func (uc *Marks) SearchMarks(ctx context.Context, filters models.SearchMarksFilters) ([]models.FoundMark, string, error) {
+	const op = "usecase.Map.SearchMarks"
+
+	marks, nextCursor, err := fetchPage(filters.Pagination, func(pagination models.Pagination) ([]models.FoundMark, error) {
+		filters.Pagination = pagination
+		return uc.repos.Marks.SearchMarks(ctx, filters)
+	})
+	if err != nil {
+		return marks, "", fmt.Errorf("%s: %w", op, err)
+	}
+	return marks, nextCursor, nil
 }
 
+// GetMarkById returns the mark, the id of a merged mark resolves to the mark it was merged into
 func (uc *Marks) GetMarkById(ctx context.Context, id int) (models.Mark, error) {
 	const op = "usecase.Map.GetMarkById"
 
 	mark, err := uc.repos.Marks.GetMarkById(ctx, id)
+	if errors.Is(err, storage.ErrNotFound) {
+		// the mark may have been merged into another one
+		var mergedIntoId int
+		if mergedIntoId, err = uc.repos.Marks.GetMergedMarkId(ctx, id); err == nil {
+			mark, err = uc.repos.Marks.GetMarkById(ctx, mergedIntoId)
+		}
+	}
 	if err != nil {
 		return mark, fmt.Errorf("%s: %w", op, err)
 	}
 	return mark, nil
 }
 
-func (uc *Marks) GetMarksByUserId(ctx context.Context, userId int) ([]models.Mark, error) {
+func (uc *Marks) GetMarksByUserId(ctx context.Context, userId int, pagination models.Pagination) ([]models.Mark, string, error) {
 	const op = "usecase.Map.GetMarksByUserId"
 
-	marks, err := uc.repos.Marks.GetMarksByUserId(ctx, userId)
+	marks, nextCursor, err := fetchPage(pagination, func(pagination models.Pagination) ([]models.Mark, error) {
+		return uc.repos.Marks.GetMarksByUserId(ctx, userId, pagination)
+	})
+	if err != nil {
+		return marks, "", fmt.Errorf("%s: %w", op, err)
+	}
+	return marks, nextCursor, nil
+}
+
+// openMarkStatuses are the statuses of the marks that can be duplicated by a new mark
+var openMarkStatuses = []int{
+	int(models.UnconfirmedStatus),
+	int(models.ConfirmedStatus),
+	int(models.UnderReviewStatus),
+	int(models.RediscoveredStatus),
+}
+
+// MaxDuplicateMarks is the max number of possible duplicates returned by FindDuplicateMarks
+const MaxDuplicateMarks = 10
+
+// FindDuplicateMarks returns the closest open marks of the same type created within the configured
+// time window and radius around the mark
+func (uc *Marks) FindDuplicateMarks(ctx context.Context, mark models.Mark) ([]models.NearbyMark, error) {
+	const op = "usecase.Map.FindDuplicateMarks"
+
+	duplicatesCfg := uc.marksCfg.Duplicates
+	marks, err := uc.repos.Marks.GetNearbyMarks(ctx, models.GetNearbyMarksFilters{
+		Longitude:     mark.Geom.Ewkb.Coords().X(),
+		Latitude:      mark.Geom.Ewkb.Coords().Y(),
+		Radius:        duplicatesCfg.Radius,
+		MarkTypeIds:   []int{mark.MarkTypeID},
+		MarkStatusIds: openMarkStatuses,
+		CreatedAfter:  time.Now().Add(-duplicatesCfg.Window),
+		Pagination:    models.Pagination{Limit: MaxDuplicateMarks},
+	})
 	if err != nil {
 		return marks, fmt.Errorf("%s: %w", op, err)
 	}
 	return marks, nil
 }
 
-func (uc *Marks) AddMark(ctx context.Context, mark models.Mark, photos []io.Reader) (int64, error) {
+// AddMark adds the mark with the author's check. Unless force is set, the mark is rejected
+// with DuplicateMarksError when FindDuplicateMarks finds possible duplicates.
+func (uc *Marks) AddMark(ctx context.Context, mark models.Mark, photos []models.Photo, force bool) (int64, error) {
 	const op = "usecase.Map.AddMark"
 
+	if !force {
+		duplicates, err := uc.FindDuplicateMarks(ctx, mark)
+		if err != nil {
+			return 0, fmt.Errorf("%s: %w", op, err)
+		}
+		if len(duplicates) > 0 {
+			return 0, fmt.Errorf("%s: %w", op, &DuplicateMarksError{Marks: duplicates})
+		}
+	}
+
 	markId, err := uc.repos.Marks.AddMark(ctx, mark)
 	if err != nil {
 		return 0, fmt.Errorf("%s: %w", op, err)
@@ -103,13 +209,63 @@ func (uc *Marks) AddMark(ctx context.Context, mark models.Mark, photos []io.Read
 		return 0, fmt.Errorf("%s: %w", op, err)
 	}
 
-	if err := uc.repos.Photos.AddPhotos(ctx, int(markId), int(checkId), photos); err != nil {
+	if err := addPhotos(ctx, uc.repos.Checks, uc.repos.Photos, int(markId), int(checkId), 1, photos); err != nil {
 		return 0, fmt.Errorf("%s: %w", op, err)
 	}
 
 	return markId, nil
 }
 
+// MaxMarkGeomCorrection is the max distance in metres the author can move the mark by
+const MaxMarkGeomCorrection = 50
+
+// UpdateMark applies the author's edit to the mark. Marks can be edited only while they are unconfirmed,
+// the geometry can only be corrected within MaxMarkGeomCorrection.
+func (uc *Marks) UpdateMark(ctx context.Context, userId, markId int, update models.MarkUpdate) error {
+	const op = "usecase.Map.UpdateMark"
+
+	mark, err := uc.repos.Marks.GetMarkById(ctx, markId)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if mark.UserID != userId {
+		return fmt.Errorf("%s: %w", op, ErrForbidden)
+	}
+	if mark.MarkStatusID != models.UnconfirmedStatus {
+		return fmt.Errorf("%s: %w", op, ErrConflict)
+	}
+	if update.Geom != nil && mark.Geom.Distance(update.Geom) > MaxMarkGeomCorrection {
+		return fmt.Errorf("%s: %w", op, ErrInvalidInput)
+	}
+
+	if err := uc.repos.Marks.UpdateMark(ctx, markId, update); err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			// the mark has been confirmed, rejected or deleted concurrently
+			return fmt.Errorf("%s: %w", op, ErrConflict)
+		}
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	return nil
+}
+
+// DeleteMark soft deletes the mark, only the author can delete it
+func (uc *Marks) DeleteMark(ctx context.Context, userId, markId int) error {
+	const op = "usecase.Map.DeleteMark"
+
+	mark, err := uc.repos.Marks.GetMarkById(ctx, markId)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if mark.UserID != userId {
+		return fmt.Errorf("%s: %w", op, ErrForbidden)
+	}
+
+	if err := uc.repos.Marks.DeleteMark(ctx, markId); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	return nil
+}
+
 func (uc *Marks) GetMarkTypes(ctx context.Context) ([]models.MarkType, error) {
 	const op = "usecase.Map.GetMarkTypes"
 
@@ -141,24 +297,15 @@ func (uc *Marks) GetMarkStatusHistoryByMarkId(ctx context.Context, markId int, w
 	}
 
 	if withChecks {
-		checks, err := uc.repos.Checks.GetChecksByMarkId(ctx, markId)
+		checks, err := uc.repos.Checks.GetChecksByMarkId(ctx, markId, models.Pagination{})
 		if err != nil {
 			return nil, fmt.Errorf("%s: %w", op, err)
 		}
 
-		photosMap, err := uc.repos.Photos.GetPhotosByMarkId(ctx, markId)
-		if err != nil {
+		if err := setPhotos(ctx, uc.repos.Checks, uc.repos.Photos, checks); err != nil {
 			return nil, fmt.Errorf("%s: %w", op, err)
 		}
 
-		for i := range len(checks) {
-			if photos, ok := photosMap[markId][checks[i].ID]; ok {
-				checks[i].Photos = photos
-			} else {
-				checks[i].Photos = []string{}
-			}
-		}
-
 		historyItems = uc.addChecksToHistoryItems(historyItems, checks)
 	}
 
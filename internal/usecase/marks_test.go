@@ -3,21 +3,24 @@ package usecase_test
 import (
 	"context"
 	"errors"
-	"io"
 	"log/slog"
 	"testing"
 
+	"github.com/PritOriginal/problem-map-server/internal/config"
 	"github.com/PritOriginal/problem-map-server/internal/models"
+	"github.com/PritOriginal/problem-map-server/internal/storage"
 	"github.com/PritOriginal/problem-map-server/internal/usecase"
 	"github.com/PritOriginal/problem-map-server/pkg/logger/slogdiscard"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
+	"github.com/twpayne/go-geom"
 )
 
 type MarksSuite struct {
 	suite.Suite
 	uc         *usecase.Marks
 	log        *slog.Logger
+	marksCfg   config.MarksConfig
 	marksRepo  *usecase.MockMarksRepository
 	checksRepo *usecase.MockChecksRepository
 	photosRepo *usecase.MockPhotosRepository
@@ -28,7 +31,8 @@ func (suite *MarksSuite) SetupSuite() {
 	suite.marksRepo = usecase.NewMockMarksRepository(suite.T())
 	suite.checksRepo = usecase.NewMockChecksRepository(suite.T())
 	suite.photosRepo = usecase.NewMockPhotosRepository(suite.T())
-	suite.uc = usecase.NewMarks(suite.log, usecase.MarksRepositories{
+	suite.marksCfg = config.MustLoadPath("../../configs/config-tests.yaml").Marks
+	suite.uc = usecase.NewMarks(suite.log, suite.marksCfg, usecase.MarksRepositories{
 		Marks:  suite.marksRepo,
 		Checks: suite.checksRepo,
 		Photos: suite.photosRepo,
@@ -41,18 +45,54 @@ func TestMarks(t *testing.T) {
 
 func (suite *MarksSuite) TestGetMarks() {
 	tests := []struct {
-		name     string
-		getMarks method[[]models.Mark]
+		name           string
+		limit          int
+		wantLimit      int
+		getMarks       method[[]models.Mark]
+		wantLen        int
+		wantNextCursor bool
 	}{
 		{
-			name: "Ok",
+			name:      "Ok",
+			wantLimit: models.MaxPageLimit + 1,
 			getMarks: method[[]models.Mark]{
 				data: []models.Mark{},
 				err:  nil,
 			},
 		},
 		{
-			name: "Err",
+			name:      "OkLimitAboveMax",
+			limit:     models.MaxPageLimit + 100,
+			wantLimit: models.MaxPageLimit + 1,
+			getMarks: method[[]models.Mark]{
+				data: []models.Mark{},
+				err:  nil,
+			},
+		},
+		{
+			name:      "OkLastPage",
+			limit:     2,
+			wantLimit: 3,
+			getMarks: method[[]models.Mark]{
+				data: make([]models.Mark, 2),
+				err:  nil,
+			},
+			wantLen: 2,
+		},
+		{
+			name:      "OkNextPage",
+			limit:     2,
+			wantLimit: 3,
+			getMarks: method[[]models.Mark]{
+				data: make([]models.Mark, 3),
+				err:  nil,
+			},
+			wantLen:        2,
+			wantNextCursor: true,
+		},
+		{
+			name:      "Err",
+			wantLimit: models.MaxPageLimit + 1,
 			getMarks: method[[]models.Mark]{
 				data: nil,
 				err:  errors.New(""),
@@ -63,17 +103,135 @@ func (suite *MarksSuite) TestGetMarks() {
 	for _, tt := range tests {
 		suite.Run(tt.name, func() {
 			func() {
-				suite.marksRepo.On("GetMarks", mock.Anything, mock.AnythingOfType("models.GetMarksFilters")).Once().
+				suite.marksRepo.On("GetMarks", mock.Anything, mock.MatchedBy(func(filters models.GetMarksFilters) bool {
+					return filters.Pagination.Limit == tt.wantLimit
+				})).Once().
 					Return(tt.getMarks.data, tt.getMarks.err)
 				if tt.getMarks.err != nil {
 					return
 				}
 			}()
 
-			_, gotErr := suite.uc.GetMarks(context.Background(), models.GetMarksFilters{})
+			marks, nextCursor, gotErr := suite.uc.GetMarks(context.Background(), models.GetMarksFilters{
+				Pagination: models.Pagination{Limit: tt.limit},
+			})
 
 			if tt.getMarks.err == nil {
 				suite.NoError(gotErr)
+				suite.Len(marks, tt.wantLen)
+				suite.Equal(tt.wantNextCursor, nextCursor != "")
+			} else {
+				suite.NotNil(gotErr)
+			}
+			suite.marksRepo.AssertExpectations(suite.T())
+		})
+	}
+}
+
+func (suite *MarksSuite) TestGetNearbyMarks() {
+	tests := []struct {
+		name           string
+		limit          int
+		wantLimit      int
+		getNearbyMarks method[[]models.NearbyMark]
+	}{
+		{
+			name:      "Ok",
+			limit:     20,
+			wantLimit: 21,
+			getNearbyMarks: method[[]models.NearbyMark]{
+				data: []models.NearbyMark{},
+				err:  nil,
+			},
+		},
+		{
+			name:      "OkWithoutLimit",
+			wantLimit: models.MaxPageLimit + 1,
+			getNearbyMarks: method[[]models.NearbyMark]{
+				data: []models.NearbyMark{},
+				err:  nil,
+			},
+		},
+		{
+			name:      "Err",
+			limit:     20,
+			wantLimit: 21,
+			getNearbyMarks: method[[]models.NearbyMark]{
+				data: nil,
+				err:  errors.New(""),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		suite.Run(tt.name, func() {
+			suite.marksRepo.On("GetNearbyMarks", mock.Anything, mock.MatchedBy(func(filters models.GetNearbyMarksFilters) bool {
+				return filters.Pagination.Limit == tt.wantLimit
+			})).Once().
+				Return(tt.getNearbyMarks.data, tt.getNearbyMarks.err)
+
+			_, _, gotErr := suite.uc.GetNearbyMarks(context.Background(), models.GetNearbyMarksFilters{
+				Longitude:  41.46,
+				Latitude:   52.71,
+				Radius:     500,
+				Pagination: models.Pagination{Limit: tt.limit},
+			})
+
+			if tt.getNearbyMarks.err == nil {
+				suite.NoError(gotErr)
+			} else {
+				suite.NotNil(gotErr)
+			}
+			suite.marksRepo.AssertExpectations(suite.T())
+		})
+	}
+}
+
+func (suite *MarksSuite) TestSearchMarks() {
+	tests := []struct {
+		name           string
+		searchMarks    method[[]models.FoundMark]
+		wantNextCursor bool
+	}{
+		{
+			name: "Ok",
+			searchMarks: method[[]models.FoundMark]{
+				data: make([]models.FoundMark, 2),
+				err:  nil,
+			},
+		},
+		{
+			name: "OkNextPage",
+			searchMarks: method[[]models.FoundMark]{
+				data: make([]models.FoundMark, 3),
+				err:  nil,
+			},
+			wantNextCursor: true,
+		},
+		{
+			name: "Err",
+			searchMarks: method[[]models.FoundMark]{
+				data: nil,
+				err:  errors.New(""),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		suite.Run(tt.name, func() {
+			suite.marksRepo.On("SearchMarks", mock.Anything, mock.MatchedBy(func(filters models.SearchMarksFilters) bool {
+				return filters.Query == "яма" && filters.Pagination.Limit == 3
+			})).Once().
+				Return(tt.searchMarks.data, tt.searchMarks.err)
+
+			_, nextCursor, gotErr := suite.uc.SearchMarks(context.Background(), models.SearchMarksFilters{
+				Query:      "яма",
+				Pagination: models.Pagination{Limit: 2},
+			})
+
+			if tt.searchMarks.err == nil {
+				suite.NoError(gotErr)
+				suite.Equal(tt.wantNextCursor, nextCursor != "")
 			} else {
 				suite.NotNil(gotErr)
 			}
@@ -125,6 +283,58 @@ func (suite *MarksSuite) TestGetMarkById() {
 	}
 }
 
+func (suite *MarksSuite) TestGetMarkByIdMerged() {
+	tests := []struct {
+		name              string
+		getMergedMarkId   method[int]
+		getMergedIntoMark method[models.Mark]
+	}{
+		{
+			name:              "Ok",
+			getMergedMarkId:   method[int]{data: 2},
+			getMergedIntoMark: method[models.Mark]{data: models.Mark{ID: 2}},
+		},
+		{
+			name:            "Err-NotMerged",
+			getMergedMarkId: method[int]{err: storage.ErrNotFound},
+		},
+		{
+			name:              "Err-GetMergedIntoMark",
+			getMergedMarkId:   method[int]{data: 2},
+			getMergedIntoMark: method[models.Mark]{err: errors.New("")},
+		},
+	}
+
+	for _, tt := range tests {
+		suite.Run(tt.name, func() {
+			func() {
+				suite.marksRepo.On("GetMarkById", mock.Anything, 1).Once().
+					Return(models.Mark{}, storage.ErrNotFound)
+				suite.marksRepo.On("GetMergedMarkId", mock.Anything, 1).Once().
+					Return(tt.getMergedMarkId.data, tt.getMergedMarkId.err)
+				if tt.getMergedMarkId.err != nil {
+					return
+				}
+				suite.marksRepo.On("GetMarkById", mock.Anything, tt.getMergedMarkId.data).Once().
+					Return(tt.getMergedIntoMark.data, tt.getMergedIntoMark.err)
+			}()
+
+			got, gotErr := suite.uc.GetMarkById(context.Background(), 1)
+
+			switch {
+			case tt.getMergedMarkId.err != nil:
+				suite.ErrorIs(gotErr, storage.ErrNotFound)
+			case tt.getMergedIntoMark.err != nil:
+				suite.NotNil(gotErr)
+			default:
+				suite.NoError(gotErr)
+				suite.Equal(tt.getMergedIntoMark.data, got)
+			}
+			suite.marksRepo.AssertExpectations(suite.T())
+		})
+	}
+}
+
 func (suite *MarksSuite) TestGetMarksByUserId() {
 	tests := []struct {
 		name             string
@@ -149,14 +359,14 @@ func (suite *MarksSuite) TestGetMarksByUserId() {
 	for _, tt := range tests {
 		suite.Run(tt.name, func() {
 			func() {
-				suite.marksRepo.On("GetMarksByUserId", mock.Anything, mock.AnythingOfType("int")).Once().
+				suite.marksRepo.On("GetMarksByUserId", mock.Anything, mock.AnythingOfType("int"), mock.Anything).Once().
 					Return(tt.getMarksByUserId.data, tt.getMarksByUserId.err)
 				if tt.getMarksByUserId.err != nil {
 					return
 				}
 			}()
 
-			_, gotErr := suite.uc.GetMarksByUserId(context.Background(), 1)
+			_, _, gotErr := suite.uc.GetMarksByUserId(context.Background(), 1, models.Pagination{})
 
 			if tt.getMarksByUserId.err == nil {
 				suite.NoError(gotErr)
@@ -168,13 +378,65 @@ func (suite *MarksSuite) TestGetMarksByUserId() {
 	}
 }
 
+func (suite *MarksSuite) TestFindDuplicateMarks() {
+	tests := []struct {
+		name           string
+		getNearbyMarks method[[]models.NearbyMark]
+	}{
+		{
+			name: "Ok",
+			getNearbyMarks: method[[]models.NearbyMark]{
+				data: []models.NearbyMark{{}},
+				err:  nil,
+			},
+		},
+		{
+			name: "Err",
+			getNearbyMarks: method[[]models.NearbyMark]{
+				data: nil,
+				err:  errors.New(""),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		suite.Run(tt.name, func() {
+			suite.marksRepo.On("GetNearbyMarks", mock.Anything, mock.MatchedBy(func(filters models.GetNearbyMarksFilters) bool {
+				return filters.Radius == suite.marksCfg.Duplicates.Radius &&
+					filters.Longitude == 41.463077 &&
+					len(filters.MarkTypeIds) == 1 && filters.MarkTypeIds[0] == 2 &&
+					!filters.CreatedAfter.IsZero() &&
+					filters.Pagination.Limit == usecase.MaxDuplicateMarks
+			})).Once().
+				Return(tt.getNearbyMarks.data, tt.getNearbyMarks.err)
+
+			duplicates, gotErr := suite.uc.FindDuplicateMarks(context.Background(), models.Mark{
+				Geom:       models.NewPoint(geom.Coord{41.463077, 52.718319}),
+				MarkTypeID: 2,
+			})
+
+			if tt.getNearbyMarks.err == nil {
+				suite.NoError(gotErr)
+				suite.Len(duplicates, len(tt.getNearbyMarks.data))
+			} else {
+				suite.NotNil(gotErr)
+			}
+			suite.marksRepo.AssertExpectations(suite.T())
+		})
+	}
+}
+
 func (suite *MarksSuite) TestAddMark() {
 	tests := []struct {
 		name                         string
+		force                        bool
+		getNearbyMarks               method[[]models.NearbyMark]
 		addMark                      method[int64]
 		getLastMarkStatusHistoryItem method[models.MarkStatusHistoryItem]
 		addCheck                     method[int64]
-		addPhotos                    method[any]
+		addPhotos                    method[[]models.PhotoRecord]
+		addPhotoRecords              method[any]
+		addPhotosMetadata            method[any]
 	}{
 		{
 			name: "Ok",
@@ -189,10 +451,54 @@ func (suite *MarksSuite) TestAddMark() {
 				data: int64(1),
 				err:  nil,
 			},
-			addPhotos: method[any]{
+			addPhotos: method[[]models.PhotoRecord]{
+				err: nil,
+			},
+			addPhotoRecords: method[any]{
+				err: nil,
+			},
+			addPhotosMetadata: method[any]{
+				err: nil,
+			},
+		},
+		{
+			name:  "OkForce",
+			force: true,
+			addMark: method[int64]{
+				data: int64(1),
+				err:  nil,
+			},
+			getLastMarkStatusHistoryItem: method[models.MarkStatusHistoryItem]{
+				err: nil,
+			},
+			addCheck: method[int64]{
+				data: int64(1),
+				err:  nil,
+			},
+			addPhotos: method[[]models.PhotoRecord]{
+				err: nil,
+			},
+			addPhotoRecords: method[any]{
+				err: nil,
+			},
+			addPhotosMetadata: method[any]{
 				err: nil,
 			},
 		},
+		{
+			name: "ErrGetNearbyMarks",
+			getNearbyMarks: method[[]models.NearbyMark]{
+				data: nil,
+				err:  errors.New(""),
+			},
+		},
+		{
+			name: "ErrDuplicates",
+			getNearbyMarks: method[[]models.NearbyMark]{
+				data: []models.NearbyMark{{}},
+				err:  nil,
+			},
+		},
 		{
 			name: "ErrAddMark",
 			addMark: method[int64]{
@@ -237,7 +543,50 @@ func (suite *MarksSuite) TestAddMark() {
 				data: int64(1),
 				err:  nil,
 			},
-			addPhotos: method[any]{
+			addPhotos: method[[]models.PhotoRecord]{
+				err: errors.New(""),
+			},
+		},
+		{
+			name: "ErrAddPhotoRecords",
+			addMark: method[int64]{
+				data: int64(1),
+				err:  nil,
+			},
+			getLastMarkStatusHistoryItem: method[models.MarkStatusHistoryItem]{
+				err: nil,
+			},
+			addCheck: method[int64]{
+				data: int64(1),
+				err:  nil,
+			},
+			addPhotos: method[[]models.PhotoRecord]{
+				err: nil,
+			},
+			addPhotoRecords: method[any]{
+				err: errors.New(""),
+			},
+		},
+		{
+			name: "ErrAddPhotosMetadata",
+			addMark: method[int64]{
+				data: int64(1),
+				err:  nil,
+			},
+			getLastMarkStatusHistoryItem: method[models.MarkStatusHistoryItem]{
+				err: nil,
+			},
+			addCheck: method[int64]{
+				data: int64(1),
+				err:  nil,
+			},
+			addPhotos: method[[]models.PhotoRecord]{
+				err: nil,
+			},
+			addPhotoRecords: method[any]{
+				err: nil,
+			},
+			addPhotosMetadata: method[any]{
 				err: errors.New(""),
 			},
 		},
@@ -246,6 +595,14 @@ func (suite *MarksSuite) TestAddMark() {
 	for _, tt := range tests {
 		suite.Run(tt.name, func() {
 			func() {
+				if !tt.force {
+					suite.marksRepo.On("GetNearbyMarks", mock.Anything, mock.AnythingOfType("models.GetNearbyMarksFilters")).Once().
+						Return(tt.getNearbyMarks.data, tt.getNearbyMarks.err)
+					if tt.getNearbyMarks.err != nil || len(tt.getNearbyMarks.data) > 0 {
+						return
+					}
+				}
+
 				suite.marksRepo.On("AddMark", mock.Anything, mock.Anything).Once().
 					Return(tt.addMark.data, tt.addMark.err)
 				if tt.addMark.err != nil {
@@ -264,19 +621,41 @@ func (suite *MarksSuite) TestAddMark() {
 					return
 				}
 
-				suite.photosRepo.On("AddPhotos", mock.Anything, mock.AnythingOfType("int"), mock.AnythingOfType("int"), mock.Anything).Once().
-					Return(tt.addPhotos.err)
+				suite.photosRepo.On("AddPhotos", mock.Anything, mock.AnythingOfType("int"), mock.AnythingOfType("int"), 1, mock.Anything).Once().
+					Return(tt.addPhotos.data, tt.addPhotos.err)
 				if tt.addPhotos.err != nil {
 					return
 				}
+
+				suite.checksRepo.On("AddPhotoRecords", mock.Anything, mock.Anything).Once().
+					Return(tt.addPhotoRecords.err)
+				if tt.addPhotoRecords.err != nil {
+					return
+				}
+
+				suite.checksRepo.On("AddPhotosMetadata", mock.Anything, mock.AnythingOfType("int"), 1, mock.Anything).Once().
+					Return(tt.addPhotosMetadata.err)
+				if tt.addPhotosMetadata.err != nil {
+					return
+				}
 			}()
 
-			_, gotErr := suite.uc.AddMark(context.Background(), models.Mark{}, []io.Reader{})
+			mark := models.Mark{
+				Geom:       models.NewPoint(geom.Coord{41.463077, 52.718319}),
+				MarkTypeID: 1,
+			}
+			_, gotErr := suite.uc.AddMark(context.Background(), mark, []models.Photo{}, tt.force)
 
-			if tt.addMark.err == nil &&
+			var duplicatesErr *usecase.DuplicateMarksError
+			suite.Equal(len(tt.getNearbyMarks.data) > 0, errors.As(gotErr, &duplicatesErr))
+			if tt.getNearbyMarks.err == nil &&
+				len(tt.getNearbyMarks.data) == 0 &&
+				tt.addMark.err == nil &&
 				tt.getLastMarkStatusHistoryItem.err == nil &&
 				tt.addCheck.err == nil &&
-				tt.addPhotos.err == nil {
+				tt.addPhotos.err == nil &&
+				tt.addPhotoRecords.err == nil &&
+				tt.addPhotosMetadata.err == nil {
 				suite.NoError(gotErr)
 			} else {
 				suite.NotNil(gotErr)
@@ -288,6 +667,198 @@ func (suite *MarksSuite) TestAddMark() {
 	}
 }
 
+func (suite *MarksSuite) TestUpdateMark() {
+	unconfirmedMark := models.Mark{
+		ID:           1,
+		Geom:         models.NewPoint(geom.Coord{41.463077, 52.718319}),
+		MarkStatusID: models.UnconfirmedStatus,
+		UserID:       1,
+	}
+	confirmedMark := unconfirmedMark
+	confirmedMark.MarkStatusID = models.ConfirmedStatus
+
+	description := "new description"
+
+	tests := []struct {
+		name        string
+		userId      int
+		update      models.MarkUpdate
+		getMarkById method[models.Mark]
+		updateMark  method[any]
+		wantErr     error
+	}{
+		{
+			name:   "Ok",
+			userId: 1,
+			update: models.MarkUpdate{Description: &description},
+			getMarkById: method[models.Mark]{
+				data: unconfirmedMark,
+			},
+		},
+		{
+			name:   "OkGeomCorrection",
+			userId: 1,
+			update: models.MarkUpdate{Geom: models.NewPoint(geom.Coord{41.463200, 52.718400})},
+			getMarkById: method[models.Mark]{
+				data: unconfirmedMark,
+			},
+		},
+		{
+			name:   "ErrGetMarkById",
+			userId: 1,
+			update: models.MarkUpdate{Description: &description},
+			getMarkById: method[models.Mark]{
+				err: errors.New(""),
+			},
+			wantErr: errors.New(""),
+		},
+		{
+			name:   "ErrNotAuthor",
+			userId: 2,
+			update: models.MarkUpdate{Description: &description},
+			getMarkById: method[models.Mark]{
+				data: unconfirmedMark,
+			},
+			wantErr: usecase.ErrForbidden,
+		},
+		{
+			name:   "ErrNotUnconfirmed",
+			userId: 1,
+			update: models.MarkUpdate{Description: &description},
+			getMarkById: method[models.Mark]{
+				data: confirmedMark,
+			},
+			wantErr: usecase.ErrConflict,
+		},
+		{
+			name:   "ErrGeomCorrectionTooLarge",
+			userId: 1,
+			update: models.MarkUpdate{Geom: models.NewPoint(geom.Coord{41.470000, 52.718319})},
+			getMarkById: method[models.Mark]{
+				data: unconfirmedMark,
+			},
+			wantErr: usecase.ErrInvalidInput,
+		},
+		{
+			name:   "ErrResolvedConcurrently",
+			userId: 1,
+			update: models.MarkUpdate{Description: &description},
+			getMarkById: method[models.Mark]{
+				data: unconfirmedMark,
+			},
+			updateMark: method[any]{
+				err: storage.ErrNotFound,
+			},
+			wantErr: usecase.ErrConflict,
+		},
+		{
+			name:   "ErrUpdateMark",
+			userId: 1,
+			update: models.MarkUpdate{Description: &description},
+			getMarkById: method[models.Mark]{
+				data: unconfirmedMark,
+			},
+			updateMark: method[any]{
+				err: errors.New(""),
+			},
+			wantErr: errors.New(""),
+		},
+	}
+
+	for _, tt := range tests {
+		suite.Run(tt.name, func() {
+			suite.marksRepo.On("GetMarkById", mock.Anything, 1).Once().
+				Return(tt.getMarkById.data, tt.getMarkById.err)
+			if tt.wantErr == nil || tt.updateMark.err != nil {
+				suite.marksRepo.On("UpdateMark", mock.Anything, 1, tt.update).Once().
+					Return(tt.updateMark.err)
+			}
+
+			gotErr := suite.uc.UpdateMark(context.Background(), tt.userId, 1, tt.update)
+
+			if tt.wantErr == nil {
+				suite.NoError(gotErr)
+			} else {
+				suite.NotNil(gotErr)
+			}
+			if errors.Is(tt.wantErr, usecase.ErrForbidden) ||
+				errors.Is(tt.wantErr, usecase.ErrConflict) ||
+				errors.Is(tt.wantErr, usecase.ErrInvalidInput) {
+				suite.ErrorIs(gotErr, tt.wantErr)
+			}
+			suite.marksRepo.AssertExpectations(suite.T())
+		})
+	}
+}
+
+func (suite *MarksSuite) TestDeleteMark() {
+	tests := []struct {
+		name        string
+		userId      int
+		getMarkById method[models.Mark]
+		deleteMark  method[any]
+		wantErr     error
+	}{
+		{
+			name:   "Ok",
+			userId: 1,
+			getMarkById: method[models.Mark]{
+				data: models.Mark{ID: 1, UserID: 1},
+			},
+		},
+		{
+			name:   "ErrGetMarkById",
+			userId: 1,
+			getMarkById: method[models.Mark]{
+				err: errors.New(""),
+			},
+			wantErr: errors.New(""),
+		},
+		{
+			name:   "ErrNotAuthor",
+			userId: 2,
+			getMarkById: method[models.Mark]{
+				data: models.Mark{ID: 1, UserID: 1},
+			},
+			wantErr: usecase.ErrForbidden,
+		},
+		{
+			name:   "ErrDeleteMark",
+			userId: 1,
+			getMarkById: method[models.Mark]{
+				data: models.Mark{ID: 1, UserID: 1},
+			},
+			deleteMark: method[any]{
+				err: errors.New(""),
+			},
+			wantErr: errors.New(""),
+		},
+	}
+
+	for _, tt := range tests {
+		suite.Run(tt.name, func() {
+			suite.marksRepo.On("GetMarkById", mock.Anything, 1).Once().
+				Return(tt.getMarkById.data, tt.getMarkById.err)
+			if tt.wantErr == nil || tt.deleteMark.err != nil {
+				suite.marksRepo.On("DeleteMark", mock.Anything, 1).Once().
+					Return(tt.deleteMark.err)
+			}
+
+			gotErr := suite.uc.DeleteMark(context.Background(), tt.userId, 1)
+
+			if tt.wantErr == nil {
+				suite.NoError(gotErr)
+			} else {
+				suite.NotNil(gotErr)
+			}
+			if errors.Is(tt.wantErr, usecase.ErrForbidden) {
+				suite.ErrorIs(gotErr, usecase.ErrForbidden)
+			}
+			suite.marksRepo.AssertExpectations(suite.T())
+		})
+	}
+}
+
 func (suite *MarksSuite) TestGetMarkTypes() {
 	tests := []struct {
 		name         string
@@ -380,7 +951,7 @@ func (suite *MarksSuite) TestGetMarkStatusHistoryByMarkId() {
 		getMarkStatusHistoryByMarkId method[[]models.MarkStatusHistoryItem]
 		withChecks                   bool
 		getChecksByMarkId            method[[]models.Check]
-		getPhotosByMarkId            method[map[int]map[int][]string]
+		getPhotoRecordsByCheckIds    method[[]models.PhotoRecord]
 	}{
 		{
 			name: "Ok",
@@ -406,11 +977,10 @@ func (suite *MarksSuite) TestGetMarkStatusHistoryByMarkId() {
 				},
 				err: nil,
 			},
-			getPhotosByMarkId: method[map[int]map[int][]string]{
-				data: map[int]map[int][]string{
-					1: {
-						1: []string{"1", "2"},
-					},
+			getPhotoRecordsByCheckIds: method[[]models.PhotoRecord]{
+				data: []models.PhotoRecord{
+					{Key: "marks/1/1/1.jpg", MarkID: 1, CheckID: 1, Number: 1, Variant: models.OriginalVariant},
+					{Key: "marks/1/1/2.jpg", MarkID: 1, CheckID: 1, Number: 2, Variant: models.OriginalVariant},
 				},
 				err: nil,
 			},
@@ -432,7 +1002,7 @@ func (suite *MarksSuite) TestGetMarkStatusHistoryByMarkId() {
 			},
 		},
 		{
-			name: "ErrGetPhotosByMarkId",
+			name: "ErrGetPhotoRecordsByCheckIds",
 			getMarkStatusHistoryByMarkId: method[[]models.MarkStatusHistoryItem]{
 				err: nil,
 			},
@@ -440,7 +1010,7 @@ func (suite *MarksSuite) TestGetMarkStatusHistoryByMarkId() {
 			getChecksByMarkId: method[[]models.Check]{
 				err: nil,
 			},
-			getPhotosByMarkId: method[map[int]map[int][]string]{
+			getPhotoRecordsByCheckIds: method[[]models.PhotoRecord]{
 				err: errors.New(""),
 			},
 		},
@@ -455,17 +1025,20 @@ func (suite *MarksSuite) TestGetMarkStatusHistoryByMarkId() {
 				}
 
 				if tt.withChecks {
-					suite.checksRepo.On("GetChecksByMarkId", mock.Anything, mock.AnythingOfType("int")).Once().
+					suite.checksRepo.On("GetChecksByMarkId", mock.Anything, mock.AnythingOfType("int"), mock.Anything).Once().
 						Return(tt.getChecksByMarkId.data, tt.getChecksByMarkId.err)
 					if tt.getChecksByMarkId.err != nil {
 						return
 					}
 
-					suite.photosRepo.On("GetPhotosByMarkId", mock.Anything, mock.AnythingOfType("int")).Once().
-						Return(tt.getPhotosByMarkId.data, tt.getPhotosByMarkId.err)
-					if tt.getPhotosByMarkId.err != nil {
+					suite.checksRepo.On("GetPhotoRecordsByCheckIds", mock.Anything, mock.Anything).Once().
+						Return(tt.getPhotoRecordsByCheckIds.data, tt.getPhotoRecordsByCheckIds.err)
+					if tt.getPhotoRecordsByCheckIds.err != nil {
 						return
 					}
+
+					suite.photosRepo.On("URL", mock.Anything, mock.AnythingOfType("string")).Times(len(tt.getPhotoRecordsByCheckIds.data)).
+						Return("", nil)
 				}
 			}()
 
@@ -473,7 +1046,7 @@ func (suite *MarksSuite) TestGetMarkStatusHistoryByMarkId() {
 
 			if tt.getMarkStatusHistoryByMarkId.err == nil &&
 				tt.getChecksByMarkId.err == nil &&
-				tt.getPhotosByMarkId.err == nil {
+				tt.getPhotoRecordsByCheckIds.err == nil {
 				suite.NoError(gotErr)
 			} else {
 				suite.NotNil(gotErr)
@@ -7,18 +7,19 @@ package usecase
 import (
 	"context"
 	"io"
+	"time"
 
 	"github.com/PritOriginal/problem-map-server/internal/models"
 	mock "github.com/stretchr/testify/mock"
 )
 
-// NewMockChecksRepository creates a new instance of MockChecksRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// NewMockSessionsRepository creates a new instance of MockSessionsRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
-func NewMockChecksRepository(t interface {
+func NewMockSessionsRepository(t interface {
 	mock.TestingT
 	Cleanup(func())
-}) *MockChecksRepository {
-	mock := &MockChecksRepository{}
+}) *MockSessionsRepository {
+	mock := &MockSessionsRepository{}
 	mock.Mock.Test(t)
 
 	t.Cleanup(func() { mock.AssertExpectations(t) })
@@ -26,192 +27,113 @@ func NewMockChecksRepository(t interface {
 	return mock
 }
 
-// MockChecksRepository is an autogenerated mock type for the ChecksRepository type
-type MockChecksRepository struct {
+// MockSessionsRepository is an autogenerated mock type for the SessionsRepository type
+type MockSessionsRepository struct {
 	mock.Mock
 }
 
-type MockChecksRepository_Expecter struct {
+type MockSessionsRepository_Expecter struct {
 	mock *mock.Mock
 }
 
-func (_m *MockChecksRepository) EXPECT() *MockChecksRepository_Expecter {
-	return &MockChecksRepository_Expecter{mock: &_m.Mock}
+func (_m *MockSessionsRepository) EXPECT() *MockSessionsRepository_Expecter {
+	return &MockSessionsRepository_Expecter{mock: &_m.Mock}
 }
 
-// AddCheck provides a mock function for the type MockChecksRepository
-func (_mock *MockChecksRepository) AddCheck(ctx context.Context, check models.Check) (int64, error) {
-	ret := _mock.Called(ctx, check)
+// AddSession provides a mock function for the type MockSessionsRepository
+func (_mock *MockSessionsRepository) AddSession(ctx context.Context, session models.Session, ttl time.Duration) error {
+	ret := _mock.Called(ctx, session, ttl)
 
 	if len(ret) == 0 {
-		panic("no return value specified for AddCheck")
+		panic("no return value specified for AddSession")
 	}
 
-	var r0 int64
-	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, models.Check) (int64, error)); ok {
-		return returnFunc(ctx, check)
-	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, models.Check) int64); ok {
-		r0 = returnFunc(ctx, check)
-	} else {
-		r0 = ret.Get(0).(int64)
-	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, models.Check) error); ok {
-		r1 = returnFunc(ctx, check)
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, models.Session, time.Duration) error); ok {
+		r0 = returnFunc(ctx, session, ttl)
 	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Error(0)
 	}
-	return r0, r1
+	return r0
 }
 
-// MockChecksRepository_AddCheck_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddCheck'
-type MockChecksRepository_AddCheck_Call struct {
+// MockSessionsRepository_AddSession_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddSession'
+type MockSessionsRepository_AddSession_Call struct {
 	*mock.Call
 }
 
-// AddCheck is a helper method to define mock.On call
+// AddSession is a helper method to define mock.On call
 //   - ctx context.Context
-//   - check models.Check
-func (_e *MockChecksRepository_Expecter) AddCheck(ctx interface{}, check interface{}) *MockChecksRepository_AddCheck_Call {
-	return &MockChecksRepository_AddCheck_Call{Call: _e.mock.On("AddCheck", ctx, check)}
+//   - session models.Session
+//   - ttl time.Duration
+func (_e *MockSessionsRepository_Expecter) AddSession(ctx interface{}, session interface{}, ttl interface{}) *MockSessionsRepository_AddSession_Call {
+	return &MockSessionsRepository_AddSession_Call{Call: _e.mock.On("AddSession", ctx, session, ttl)}
 }
 
-func (_c *MockChecksRepository_AddCheck_Call) Run(run func(ctx context.Context, check models.Check)) *MockChecksRepository_AddCheck_Call {
+func (_c *MockSessionsRepository_AddSession_Call) Run(run func(ctx context.Context, session models.Session, ttl time.Duration)) *MockSessionsRepository_AddSession_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 models.Check
+		var arg1 models.Session
 		if args[1] != nil {
-			arg1 = args[1].(models.Check)
-		}
-		run(
-			arg0,
-			arg1,
-		)
-	})
-	return _c
-}
-
-func (_c *MockChecksRepository_AddCheck_Call) Return(n int64, err error) *MockChecksRepository_AddCheck_Call {
-	_c.Call.Return(n, err)
-	return _c
-}
-
-func (_c *MockChecksRepository_AddCheck_Call) RunAndReturn(run func(ctx context.Context, check models.Check) (int64, error)) *MockChecksRepository_AddCheck_Call {
-	_c.Call.Return(run)
-	return _c
-}
-
-// GetCheckById provides a mock function for the type MockChecksRepository
-func (_mock *MockChecksRepository) GetCheckById(ctx context.Context, id int) (models.Check, error) {
-	ret := _mock.Called(ctx, id)
-
-	if len(ret) == 0 {
-		panic("no return value specified for GetCheckById")
-	}
-
-	var r0 models.Check
-	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, int) (models.Check, error)); ok {
-		return returnFunc(ctx, id)
-	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, int) models.Check); ok {
-		r0 = returnFunc(ctx, id)
-	} else {
-		r0 = ret.Get(0).(models.Check)
-	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, int) error); ok {
-		r1 = returnFunc(ctx, id)
-	} else {
-		r1 = ret.Error(1)
-	}
-	return r0, r1
-}
-
-// MockChecksRepository_GetCheckById_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetCheckById'
-type MockChecksRepository_GetCheckById_Call struct {
-	*mock.Call
-}
-
-// GetCheckById is a helper method to define mock.On call
-//   - ctx context.Context
-//   - id int
-func (_e *MockChecksRepository_Expecter) GetCheckById(ctx interface{}, id interface{}) *MockChecksRepository_GetCheckById_Call {
-	return &MockChecksRepository_GetCheckById_Call{Call: _e.mock.On("GetCheckById", ctx, id)}
-}
-
-func (_c *MockChecksRepository_GetCheckById_Call) Run(run func(ctx context.Context, id int)) *MockChecksRepository_GetCheckById_Call {
-	_c.Call.Run(func(args mock.Arguments) {
-		var arg0 context.Context
-		if args[0] != nil {
-			arg0 = args[0].(context.Context)
+			arg1 = args[1].(models.Session)
 		}
-		var arg1 int
-		if args[1] != nil {
-			arg1 = args[1].(int)
+		var arg2 time.Duration
+		if args[2] != nil {
+			arg2 = args[2].(time.Duration)
 		}
 		run(
 			arg0,
 			arg1,
+			arg2,
 		)
 	})
 	return _c
 }
 
-func (_c *MockChecksRepository_GetCheckById_Call) Return(check models.Check, err error) *MockChecksRepository_GetCheckById_Call {
-	_c.Call.Return(check, err)
+func (_c *MockSessionsRepository_AddSession_Call) Return(err error) *MockSessionsRepository_AddSession_Call {
+	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockChecksRepository_GetCheckById_Call) RunAndReturn(run func(ctx context.Context, id int) (models.Check, error)) *MockChecksRepository_GetCheckById_Call {
+func (_c *MockSessionsRepository_AddSession_Call) RunAndReturn(run func(ctx context.Context, session models.Session, ttl time.Duration) error) *MockSessionsRepository_AddSession_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetChecksByMarkHistoryId provides a mock function for the type MockChecksRepository
-func (_mock *MockChecksRepository) GetChecksByMarkHistoryId(ctx context.Context, markHistoryId int) ([]models.Check, error) {
-	ret := _mock.Called(ctx, markHistoryId)
+// DeleteSession provides a mock function for the type MockSessionsRepository
+func (_mock *MockSessionsRepository) DeleteSession(ctx context.Context, userId int, id string) error {
+	ret := _mock.Called(ctx, userId, id)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetChecksByMarkHistoryId")
+		panic("no return value specified for DeleteSession")
 	}
 
-	var r0 []models.Check
-	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, int) ([]models.Check, error)); ok {
-		return returnFunc(ctx, markHistoryId)
-	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, int) []models.Check); ok {
-		r0 = returnFunc(ctx, markHistoryId)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]models.Check)
-		}
-	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, int) error); ok {
-		r1 = returnFunc(ctx, markHistoryId)
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int, string) error); ok {
+		r0 = returnFunc(ctx, userId, id)
 	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Error(0)
 	}
-	return r0, r1
+	return r0
 }
 
-// MockChecksRepository_GetChecksByMarkHistoryId_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetChecksByMarkHistoryId'
-type MockChecksRepository_GetChecksByMarkHistoryId_Call struct {
+// MockSessionsRepository_DeleteSession_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteSession'
+type MockSessionsRepository_DeleteSession_Call struct {
 	*mock.Call
 }
 
-// GetChecksByMarkHistoryId is a helper method to define mock.On call
+// DeleteSession is a helper method to define mock.On call
 //   - ctx context.Context
-//   - markHistoryId int
-func (_e *MockChecksRepository_Expecter) GetChecksByMarkHistoryId(ctx interface{}, markHistoryId interface{}) *MockChecksRepository_GetChecksByMarkHistoryId_Call {
-	return &MockChecksRepository_GetChecksByMarkHistoryId_Call{Call: _e.mock.On("GetChecksByMarkHistoryId", ctx, markHistoryId)}
+//   - userId int
+//   - id string
+func (_e *MockSessionsRepository_Expecter) DeleteSession(ctx interface{}, userId interface{}, id interface{}) *MockSessionsRepository_DeleteSession_Call {
+	return &MockSessionsRepository_DeleteSession_Call{Call: _e.mock.On("DeleteSession", ctx, userId, id)}
 }
 
-func (_c *MockChecksRepository_GetChecksByMarkHistoryId_Call) Run(run func(ctx context.Context, markHistoryId int)) *MockChecksRepository_GetChecksByMarkHistoryId_Call {
+func (_c *MockSessionsRepository_DeleteSession_Call) Run(run func(ctx context.Context, userId int, id string)) *MockSessionsRepository_DeleteSession_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -221,65 +143,59 @@ func (_c *MockChecksRepository_GetChecksByMarkHistoryId_Call) Run(run func(ctx c
 		if args[1] != nil {
 			arg1 = args[1].(int)
 		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
 		run(
 			arg0,
 			arg1,
+			arg2,
 		)
 	})
 	return _c
 }
 
-func (_c *MockChecksRepository_GetChecksByMarkHistoryId_Call) Return(checks []models.Check, err error) *MockChecksRepository_GetChecksByMarkHistoryId_Call {
-	_c.Call.Return(checks, err)
+func (_c *MockSessionsRepository_DeleteSession_Call) Return(err error) *MockSessionsRepository_DeleteSession_Call {
+	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockChecksRepository_GetChecksByMarkHistoryId_Call) RunAndReturn(run func(ctx context.Context, markHistoryId int) ([]models.Check, error)) *MockChecksRepository_GetChecksByMarkHistoryId_Call {
+func (_c *MockSessionsRepository_DeleteSession_Call) RunAndReturn(run func(ctx context.Context, userId int, id string) error) *MockSessionsRepository_DeleteSession_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetChecksByMarkId provides a mock function for the type MockChecksRepository
-func (_mock *MockChecksRepository) GetChecksByMarkId(ctx context.Context, markId int) ([]models.Check, error) {
-	ret := _mock.Called(ctx, markId)
+// DeleteUserSessions provides a mock function for the type MockSessionsRepository
+func (_mock *MockSessionsRepository) DeleteUserSessions(ctx context.Context, userId int) error {
+	ret := _mock.Called(ctx, userId)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetChecksByMarkId")
+		panic("no return value specified for DeleteUserSessions")
 	}
 
-	var r0 []models.Check
-	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, int) ([]models.Check, error)); ok {
-		return returnFunc(ctx, markId)
-	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, int) []models.Check); ok {
-		r0 = returnFunc(ctx, markId)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]models.Check)
-		}
-	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, int) error); ok {
-		r1 = returnFunc(ctx, markId)
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int) error); ok {
+		r0 = returnFunc(ctx, userId)
 	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Error(0)
 	}
-	return r0, r1
+	return r0
 }
 
-// MockChecksRepository_GetChecksByMarkId_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetChecksByMarkId'
-type MockChecksRepository_GetChecksByMarkId_Call struct {
+// MockSessionsRepository_DeleteUserSessions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteUserSessions'
+type MockSessionsRepository_DeleteUserSessions_Call struct {
 	*mock.Call
 }
 
-// GetChecksByMarkId is a helper method to define mock.On call
+// DeleteUserSessions is a helper method to define mock.On call
 //   - ctx context.Context
-//   - markId int
-func (_e *MockChecksRepository_Expecter) GetChecksByMarkId(ctx interface{}, markId interface{}) *MockChecksRepository_GetChecksByMarkId_Call {
-	return &MockChecksRepository_GetChecksByMarkId_Call{Call: _e.mock.On("GetChecksByMarkId", ctx, markId)}
+//   - userId int
+func (_e *MockSessionsRepository_Expecter) DeleteUserSessions(ctx interface{}, userId interface{}) *MockSessionsRepository_DeleteUserSessions_Call {
+	return &MockSessionsRepository_DeleteUserSessions_Call{Call: _e.mock.On("DeleteUserSessions", ctx, userId)}
 }
 
-func (_c *MockChecksRepository_GetChecksByMarkId_Call) Run(run func(ctx context.Context, markId int)) *MockChecksRepository_GetChecksByMarkId_Call {
+func (_c *MockSessionsRepository_DeleteUserSessions_Call) Run(run func(ctx context.Context, userId int)) *MockSessionsRepository_DeleteUserSessions_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -297,34 +213,34 @@ func (_c *MockChecksRepository_GetChecksByMarkId_Call) Run(run func(ctx context.
 	return _c
 }
 
-func (_c *MockChecksRepository_GetChecksByMarkId_Call) Return(checks []models.Check, err error) *MockChecksRepository_GetChecksByMarkId_Call {
-	_c.Call.Return(checks, err)
+func (_c *MockSessionsRepository_DeleteUserSessions_Call) Return(err error) *MockSessionsRepository_DeleteUserSessions_Call {
+	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockChecksRepository_GetChecksByMarkId_Call) RunAndReturn(run func(ctx context.Context, markId int) ([]models.Check, error)) *MockChecksRepository_GetChecksByMarkId_Call {
+func (_c *MockSessionsRepository_DeleteUserSessions_Call) RunAndReturn(run func(ctx context.Context, userId int) error) *MockSessionsRepository_DeleteUserSessions_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetChecksByUserId provides a mock function for the type MockChecksRepository
-func (_mock *MockChecksRepository) GetChecksByUserId(ctx context.Context, userId int) ([]models.Check, error) {
+// GetUserSessions provides a mock function for the type MockSessionsRepository
+func (_mock *MockSessionsRepository) GetUserSessions(ctx context.Context, userId int) ([]models.Session, error) {
 	ret := _mock.Called(ctx, userId)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetChecksByUserId")
+		panic("no return value specified for GetUserSessions")
 	}
 
-	var r0 []models.Check
+	var r0 []models.Session
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, int) ([]models.Check, error)); ok {
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int) ([]models.Session, error)); ok {
 		return returnFunc(ctx, userId)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, int) []models.Check); ok {
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int) []models.Session); ok {
 		r0 = returnFunc(ctx, userId)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]models.Check)
+			r0 = ret.Get(0).([]models.Session)
 		}
 	}
 	if returnFunc, ok := ret.Get(1).(func(context.Context, int) error); ok {
@@ -335,19 +251,19 @@ func (_mock *MockChecksRepository) GetChecksByUserId(ctx context.Context, userId
 	return r0, r1
 }
 
-// MockChecksRepository_GetChecksByUserId_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetChecksByUserId'
-type MockChecksRepository_GetChecksByUserId_Call struct {
+// MockSessionsRepository_GetUserSessions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUserSessions'
+type MockSessionsRepository_GetUserSessions_Call struct {
 	*mock.Call
 }
 
-// GetChecksByUserId is a helper method to define mock.On call
+// GetUserSessions is a helper method to define mock.On call
 //   - ctx context.Context
 //   - userId int
-func (_e *MockChecksRepository_Expecter) GetChecksByUserId(ctx interface{}, userId interface{}) *MockChecksRepository_GetChecksByUserId_Call {
-	return &MockChecksRepository_GetChecksByUserId_Call{Call: _e.mock.On("GetChecksByUserId", ctx, userId)}
+func (_e *MockSessionsRepository_Expecter) GetUserSessions(ctx interface{}, userId interface{}) *MockSessionsRepository_GetUserSessions_Call {
+	return &MockSessionsRepository_GetUserSessions_Call{Call: _e.mock.On("GetUserSessions", ctx, userId)}
 }
 
-func (_c *MockChecksRepository_GetChecksByUserId_Call) Run(run func(ctx context.Context, userId int)) *MockChecksRepository_GetChecksByUserId_Call {
+func (_c *MockSessionsRepository_GetUserSessions_Call) Run(run func(ctx context.Context, userId int)) *MockSessionsRepository_GetUserSessions_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -365,95 +281,92 @@ func (_c *MockChecksRepository_GetChecksByUserId_Call) Run(run func(ctx context.
 	return _c
 }
 
-func (_c *MockChecksRepository_GetChecksByUserId_Call) Return(checks []models.Check, err error) *MockChecksRepository_GetChecksByUserId_Call {
-	_c.Call.Return(checks, err)
+func (_c *MockSessionsRepository_GetUserSessions_Call) Return(sessions []models.Session, err error) *MockSessionsRepository_GetUserSessions_Call {
+	_c.Call.Return(sessions, err)
 	return _c
 }
 
-func (_c *MockChecksRepository_GetChecksByUserId_Call) RunAndReturn(run func(ctx context.Context, userId int) ([]models.Check, error)) *MockChecksRepository_GetChecksByUserId_Call {
+func (_c *MockSessionsRepository_GetUserSessions_Call) RunAndReturn(run func(ctx context.Context, userId int) ([]models.Session, error)) *MockSessionsRepository_GetUserSessions_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetUserMarkCheck provides a mock function for the type MockChecksRepository
-func (_mock *MockChecksRepository) GetUserMarkCheck(ctx context.Context, userId int, markStatusHistoryId int) (models.Check, error) {
-	ret := _mock.Called(ctx, userId, markStatusHistoryId)
+// RotateSession provides a mock function for the type MockSessionsRepository
+func (_mock *MockSessionsRepository) RotateSession(ctx context.Context, session models.Session, tokenId string, ttl time.Duration) error {
+	ret := _mock.Called(ctx, session, tokenId, ttl)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetUserMarkCheck")
+		panic("no return value specified for RotateSession")
 	}
 
-	var r0 models.Check
-	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, int, int) (models.Check, error)); ok {
-		return returnFunc(ctx, userId, markStatusHistoryId)
-	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, int, int) models.Check); ok {
-		r0 = returnFunc(ctx, userId, markStatusHistoryId)
-	} else {
-		r0 = ret.Get(0).(models.Check)
-	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, int, int) error); ok {
-		r1 = returnFunc(ctx, userId, markStatusHistoryId)
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, models.Session, string, time.Duration) error); ok {
+		r0 = returnFunc(ctx, session, tokenId, ttl)
 	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Error(0)
 	}
-	return r0, r1
+	return r0
 }
 
-// MockChecksRepository_GetUserMarkCheck_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUserMarkCheck'
-type MockChecksRepository_GetUserMarkCheck_Call struct {
+// MockSessionsRepository_RotateSession_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RotateSession'
+type MockSessionsRepository_RotateSession_Call struct {
 	*mock.Call
 }
 
-// GetUserMarkCheck is a helper method to define mock.On call
+// RotateSession is a helper method to define mock.On call
 //   - ctx context.Context
-//   - userId int
-//   - markStatusHistoryId int
-func (_e *MockChecksRepository_Expecter) GetUserMarkCheck(ctx interface{}, userId interface{}, markStatusHistoryId interface{}) *MockChecksRepository_GetUserMarkCheck_Call {
-	return &MockChecksRepository_GetUserMarkCheck_Call{Call: _e.mock.On("GetUserMarkCheck", ctx, userId, markStatusHistoryId)}
+//   - session models.Session
+//   - tokenId string
+//   - ttl time.Duration
+func (_e *MockSessionsRepository_Expecter) RotateSession(ctx interface{}, session interface{}, tokenId interface{}, ttl interface{}) *MockSessionsRepository_RotateSession_Call {
+	return &MockSessionsRepository_RotateSession_Call{Call: _e.mock.On("RotateSession", ctx, session, tokenId, ttl)}
 }
 
-func (_c *MockChecksRepository_GetUserMarkCheck_Call) Run(run func(ctx context.Context, userId int, markStatusHistoryId int)) *MockChecksRepository_GetUserMarkCheck_Call {
+func (_c *MockSessionsRepository_RotateSession_Call) Run(run func(ctx context.Context, session models.Session, tokenId string, ttl time.Duration)) *MockSessionsRepository_RotateSession_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 int
+		var arg1 models.Session
 		if args[1] != nil {
-			arg1 = args[1].(int)
+			arg1 = args[1].(models.Session)
 		}
-		var arg2 int
+		var arg2 string
 		if args[2] != nil {
-			arg2 = args[2].(int)
+			arg2 = args[2].(string)
+		}
+		var arg3 time.Duration
+		if args[3] != nil {
+			arg3 = args[3].(time.Duration)
 		}
 		run(
 			arg0,
 			arg1,
 			arg2,
+			arg3,
 		)
 	})
 	return _c
 }
 
-func (_c *MockChecksRepository_GetUserMarkCheck_Call) Return(check models.Check, err error) *MockChecksRepository_GetUserMarkCheck_Call {
-	_c.Call.Return(check, err)
+func (_c *MockSessionsRepository_RotateSession_Call) Return(err error) *MockSessionsRepository_RotateSession_Call {
+	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockChecksRepository_GetUserMarkCheck_Call) RunAndReturn(run func(ctx context.Context, userId int, markStatusHistoryId int) (models.Check, error)) *MockChecksRepository_GetUserMarkCheck_Call {
+func (_c *MockSessionsRepository_RotateSession_Call) RunAndReturn(run func(ctx context.Context, session models.Session, tokenId string, ttl time.Duration) error) *MockSessionsRepository_RotateSession_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// NewMockMarkStatusUpdater creates a new instance of MockMarkStatusUpdater. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// NewMockPasswordResetsRepository creates a new instance of MockPasswordResetsRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
-func NewMockMarkStatusUpdater(t interface {
+func NewMockPasswordResetsRepository(t interface {
 	mock.TestingT
 	Cleanup(func())
-}) *MockMarkStatusUpdater {
-	mock := &MockMarkStatusUpdater{}
+}) *MockPasswordResetsRepository {
+	mock := &MockPasswordResetsRepository{}
 	mock.Mock.Test(t)
 
 	t.Cleanup(func() { mock.AssertExpectations(t) })
@@ -461,49 +374,51 @@ func NewMockMarkStatusUpdater(t interface {
 	return mock
 }
 
-// MockMarkStatusUpdater is an autogenerated mock type for the MarkStatusUpdater type
-type MockMarkStatusUpdater struct {
+// MockPasswordResetsRepository is an autogenerated mock type for the PasswordResetsRepository type
+type MockPasswordResetsRepository struct {
 	mock.Mock
 }
 
-type MockMarkStatusUpdater_Expecter struct {
+type MockPasswordResetsRepository_Expecter struct {
 	mock *mock.Mock
 }
 
-func (_m *MockMarkStatusUpdater) EXPECT() *MockMarkStatusUpdater_Expecter {
-	return &MockMarkStatusUpdater_Expecter{mock: &_m.Mock}
+func (_m *MockPasswordResetsRepository) EXPECT() *MockPasswordResetsRepository_Expecter {
+	return &MockPasswordResetsRepository_Expecter{mock: &_m.Mock}
 }
 
-// Update provides a mock function for the type MockMarkStatusUpdater
-func (_mock *MockMarkStatusUpdater) Update(ctx context.Context, markId int) error {
-	ret := _mock.Called(ctx, markId)
+// AddPasswordReset provides a mock function for the type MockPasswordResetsRepository
+func (_mock *MockPasswordResetsRepository) AddPasswordReset(ctx context.Context, userId int, tokenHash string, ttl time.Duration) error {
+	ret := _mock.Called(ctx, userId, tokenHash, ttl)
 
 	if len(ret) == 0 {
-		panic("no return value specified for Update")
+		panic("no return value specified for AddPasswordReset")
 	}
 
 	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, int) error); ok {
-		r0 = returnFunc(ctx, markId)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int, string, time.Duration) error); ok {
+		r0 = returnFunc(ctx, userId, tokenHash, ttl)
 	} else {
 		r0 = ret.Error(0)
 	}
 	return r0
 }
 
-// MockMarkStatusUpdater_Update_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Update'
-type MockMarkStatusUpdater_Update_Call struct {
+// MockPasswordResetsRepository_AddPasswordReset_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddPasswordReset'
+type MockPasswordResetsRepository_AddPasswordReset_Call struct {
 	*mock.Call
 }
 
-// Update is a helper method to define mock.On call
+// AddPasswordReset is a helper method to define mock.On call
 //   - ctx context.Context
-//   - markId int
-func (_e *MockMarkStatusUpdater_Expecter) Update(ctx interface{}, markId interface{}) *MockMarkStatusUpdater_Update_Call {
-	return &MockMarkStatusUpdater_Update_Call{Call: _e.mock.On("Update", ctx, markId)}
+//   - userId int
+//   - tokenHash string
+//   - ttl time.Duration
+func (_e *MockPasswordResetsRepository_Expecter) AddPasswordReset(ctx interface{}, userId interface{}, tokenHash interface{}, ttl interface{}) *MockPasswordResetsRepository_AddPasswordReset_Call {
+	return &MockPasswordResetsRepository_AddPasswordReset_Call{Call: _e.mock.On("AddPasswordReset", ctx, userId, tokenHash, ttl)}
 }
 
-func (_c *MockMarkStatusUpdater_Update_Call) Run(run func(ctx context.Context, markId int)) *MockMarkStatusUpdater_Update_Call {
+func (_c *MockPasswordResetsRepository_AddPasswordReset_Call) Run(run func(ctx context.Context, userId int, tokenHash string, ttl time.Duration)) *MockPasswordResetsRepository_AddPasswordReset_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -513,7 +428,2316 @@ func (_c *MockMarkStatusUpdater_Update_Call) Run(run func(ctx context.Context, m
 		if args[1] != nil {
 			arg1 = args[1].(int)
 		}
-		run(
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 time.Duration
+		if args[3] != nil {
+			arg3 = args[3].(time.Duration)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockPasswordResetsRepository_AddPasswordReset_Call) Return(err error) *MockPasswordResetsRepository_AddPasswordReset_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockPasswordResetsRepository_AddPasswordReset_Call) RunAndReturn(run func(ctx context.Context, userId int, tokenHash string, ttl time.Duration) error) *MockPasswordResetsRepository_AddPasswordReset_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ResetPassword provides a mock function for the type MockPasswordResetsRepository
+func (_mock *MockPasswordResetsRepository) ResetPassword(ctx context.Context, tokenHash string, passwordHash string) (int, error) {
+	ret := _mock.Called(ctx, tokenHash, passwordHash)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ResetPassword")
+	}
+
+	var r0 int
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) (int, error)); ok {
+		return returnFunc(ctx, tokenHash, passwordHash)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) int); ok {
+		r0 = returnFunc(ctx, tokenHash, passwordHash)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = returnFunc(ctx, tokenHash, passwordHash)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockPasswordResetsRepository_ResetPassword_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ResetPassword'
+type MockPasswordResetsRepository_ResetPassword_Call struct {
+	*mock.Call
+}
+
+// ResetPassword is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tokenHash string
+//   - passwordHash string
+func (_e *MockPasswordResetsRepository_Expecter) ResetPassword(ctx interface{}, tokenHash interface{}, passwordHash interface{}) *MockPasswordResetsRepository_ResetPassword_Call {
+	return &MockPasswordResetsRepository_ResetPassword_Call{Call: _e.mock.On("ResetPassword", ctx, tokenHash, passwordHash)}
+}
+
+func (_c *MockPasswordResetsRepository_ResetPassword_Call) Run(run func(ctx context.Context, tokenHash string, passwordHash string)) *MockPasswordResetsRepository_ResetPassword_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockPasswordResetsRepository_ResetPassword_Call) Return(n int, err error) *MockPasswordResetsRepository_ResetPassword_Call {
+	_c.Call.Return(n, err)
+	return _c
+}
+
+func (_c *MockPasswordResetsRepository_ResetPassword_Call) RunAndReturn(run func(ctx context.Context, tokenHash string, passwordHash string) (int, error)) *MockPasswordResetsRepository_ResetPassword_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockAttemptsRepository creates a new instance of MockAttemptsRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockAttemptsRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockAttemptsRepository {
+	mock := &MockAttemptsRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockAttemptsRepository is an autogenerated mock type for the AttemptsRepository type
+type MockAttemptsRepository struct {
+	mock.Mock
+}
+
+type MockAttemptsRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockAttemptsRepository) EXPECT() *MockAttemptsRepository_Expecter {
+	return &MockAttemptsRepository_Expecter{mock: &_m.Mock}
+}
+
+// AddAttempt provides a mock function for the type MockAttemptsRepository
+func (_mock *MockAttemptsRepository) AddAttempt(ctx context.Context, limits []models.AttemptsLimit, backoff models.Backoff) (time.Duration, error) {
+	ret := _mock.Called(ctx, limits, backoff)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddAttempt")
+	}
+
+	var r0 time.Duration
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []models.AttemptsLimit, models.Backoff) (time.Duration, error)); ok {
+		return returnFunc(ctx, limits, backoff)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []models.AttemptsLimit, models.Backoff) time.Duration); ok {
+		r0 = returnFunc(ctx, limits, backoff)
+	} else {
+		r0 = ret.Get(0).(time.Duration)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, []models.AttemptsLimit, models.Backoff) error); ok {
+		r1 = returnFunc(ctx, limits, backoff)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockAttemptsRepository_AddAttempt_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddAttempt'
+type MockAttemptsRepository_AddAttempt_Call struct {
+	*mock.Call
+}
+
+// AddAttempt is a helper method to define mock.On call
+//   - ctx context.Context
+//   - limits []models.AttemptsLimit
+//   - backoff models.Backoff
+func (_e *MockAttemptsRepository_Expecter) AddAttempt(ctx interface{}, limits interface{}, backoff interface{}) *MockAttemptsRepository_AddAttempt_Call {
+	return &MockAttemptsRepository_AddAttempt_Call{Call: _e.mock.On("AddAttempt", ctx, limits, backoff)}
+}
+
+func (_c *MockAttemptsRepository_AddAttempt_Call) Run(run func(ctx context.Context, limits []models.AttemptsLimit, backoff models.Backoff)) *MockAttemptsRepository_AddAttempt_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 []models.AttemptsLimit
+		if args[1] != nil {
+			arg1 = args[1].([]models.AttemptsLimit)
+		}
+		var arg2 models.Backoff
+		if args[2] != nil {
+			arg2 = args[2].(models.Backoff)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAttemptsRepository_AddAttempt_Call) Return(duration time.Duration, err error) *MockAttemptsRepository_AddAttempt_Call {
+	_c.Call.Return(duration, err)
+	return _c
+}
+
+func (_c *MockAttemptsRepository_AddAttempt_Call) RunAndReturn(run func(ctx context.Context, limits []models.AttemptsLimit, backoff models.Backoff) (time.Duration, error)) *MockAttemptsRepository_AddAttempt_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ForgetAttempt provides a mock function for the type MockAttemptsRepository
+func (_mock *MockAttemptsRepository) ForgetAttempt(ctx context.Context, key string) error {
+	ret := _mock.Called(ctx, key)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ForgetAttempt")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = returnFunc(ctx, key)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockAttemptsRepository_ForgetAttempt_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ForgetAttempt'
+type MockAttemptsRepository_ForgetAttempt_Call struct {
+	*mock.Call
+}
+
+// ForgetAttempt is a helper method to define mock.On call
+//   - ctx context.Context
+//   - key string
+func (_e *MockAttemptsRepository_Expecter) ForgetAttempt(ctx interface{}, key interface{}) *MockAttemptsRepository_ForgetAttempt_Call {
+	return &MockAttemptsRepository_ForgetAttempt_Call{Call: _e.mock.On("ForgetAttempt", ctx, key)}
+}
+
+func (_c *MockAttemptsRepository_ForgetAttempt_Call) Run(run func(ctx context.Context, key string)) *MockAttemptsRepository_ForgetAttempt_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAttemptsRepository_ForgetAttempt_Call) Return(err error) *MockAttemptsRepository_ForgetAttempt_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockAttemptsRepository_ForgetAttempt_Call) RunAndReturn(run func(ctx context.Context, key string) error) *MockAttemptsRepository_ForgetAttempt_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ResetAttempts provides a mock function for the type MockAttemptsRepository
+func (_mock *MockAttemptsRepository) ResetAttempts(ctx context.Context, key string) error {
+	ret := _mock.Called(ctx, key)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ResetAttempts")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = returnFunc(ctx, key)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockAttemptsRepository_ResetAttempts_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ResetAttempts'
+type MockAttemptsRepository_ResetAttempts_Call struct {
+	*mock.Call
+}
+
+// ResetAttempts is a helper method to define mock.On call
+//   - ctx context.Context
+//   - key string
+func (_e *MockAttemptsRepository_Expecter) ResetAttempts(ctx interface{}, key interface{}) *MockAttemptsRepository_ResetAttempts_Call {
+	return &MockAttemptsRepository_ResetAttempts_Call{Call: _e.mock.On("ResetAttempts", ctx, key)}
+}
+
+func (_c *MockAttemptsRepository_ResetAttempts_Call) Run(run func(ctx context.Context, key string)) *MockAttemptsRepository_ResetAttempts_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAttemptsRepository_ResetAttempts_Call) Return(err error) *MockAttemptsRepository_ResetAttempts_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockAttemptsRepository_ResetAttempts_Call) RunAndReturn(run func(ctx context.Context, key string) error) *MockAttemptsRepository_ResetAttempts_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockNotifier creates a new instance of MockNotifier. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockNotifier(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockNotifier {
+	mock := &MockNotifier{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockNotifier is an autogenerated mock type for the Notifier type
+type MockNotifier struct {
+	mock.Mock
+}
+
+type MockNotifier_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockNotifier) EXPECT() *MockNotifier_Expecter {
+	return &MockNotifier_Expecter{mock: &_m.Mock}
+}
+
+// SendPasswordReset provides a mock function for the type MockNotifier
+func (_mock *MockNotifier) SendPasswordReset(ctx context.Context, user models.User, token string, expiresAt time.Time) error {
+	ret := _mock.Called(ctx, user, token, expiresAt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SendPasswordReset")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, models.User, string, time.Time) error); ok {
+		r0 = returnFunc(ctx, user, token, expiresAt)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockNotifier_SendPasswordReset_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SendPasswordReset'
+type MockNotifier_SendPasswordReset_Call struct {
+	*mock.Call
+}
+
+// SendPasswordReset is a helper method to define mock.On call
+//   - ctx context.Context
+//   - user models.User
+//   - token string
+//   - expiresAt time.Time
+func (_e *MockNotifier_Expecter) SendPasswordReset(ctx interface{}, user interface{}, token interface{}, expiresAt interface{}) *MockNotifier_SendPasswordReset_Call {
+	return &MockNotifier_SendPasswordReset_Call{Call: _e.mock.On("SendPasswordReset", ctx, user, token, expiresAt)}
+}
+
+func (_c *MockNotifier_SendPasswordReset_Call) Run(run func(ctx context.Context, user models.User, token string, expiresAt time.Time)) *MockNotifier_SendPasswordReset_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 models.User
+		if args[1] != nil {
+			arg1 = args[1].(models.User)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 time.Time
+		if args[3] != nil {
+			arg3 = args[3].(time.Time)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockNotifier_SendPasswordReset_Call) Return(err error) *MockNotifier_SendPasswordReset_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockNotifier_SendPasswordReset_Call) RunAndReturn(run func(ctx context.Context, user models.User, token string, expiresAt time.Time) error) *MockNotifier_SendPasswordReset_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockChecksRepository creates a new instance of MockChecksRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockChecksRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockChecksRepository {
+	mock := &MockChecksRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockChecksRepository is an autogenerated mock type for the ChecksRepository type
+type MockChecksRepository struct {
+	mock.Mock
+}
+
+type MockChecksRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockChecksRepository) EXPECT() *MockChecksRepository_Expecter {
+	return &MockChecksRepository_Expecter{mock: &_m.Mock}
+}
+
+// AddCheck provides a mock function for the type MockChecksRepository
+func (_mock *MockChecksRepository) AddCheck(ctx context.Context, check models.Check) (int64, error) {
+	ret := _mock.Called(ctx, check)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddCheck")
+	}
+
+	var r0 int64
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, models.Check) (int64, error)); ok {
+		return returnFunc(ctx, check)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, models.Check) int64); ok {
+		r0 = returnFunc(ctx, check)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, models.Check) error); ok {
+		r1 = returnFunc(ctx, check)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockChecksRepository_AddCheck_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddCheck'
+type MockChecksRepository_AddCheck_Call struct {
+	*mock.Call
+}
+
+// AddCheck is a helper method to define mock.On call
+//   - ctx context.Context
+//   - check models.Check
+func (_e *MockChecksRepository_Expecter) AddCheck(ctx interface{}, check interface{}) *MockChecksRepository_AddCheck_Call {
+	return &MockChecksRepository_AddCheck_Call{Call: _e.mock.On("AddCheck", ctx, check)}
+}
+
+func (_c *MockChecksRepository_AddCheck_Call) Run(run func(ctx context.Context, check models.Check)) *MockChecksRepository_AddCheck_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 models.Check
+		if args[1] != nil {
+			arg1 = args[1].(models.Check)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockChecksRepository_AddCheck_Call) Return(n int64, err error) *MockChecksRepository_AddCheck_Call {
+	_c.Call.Return(n, err)
+	return _c
+}
+
+func (_c *MockChecksRepository_AddCheck_Call) RunAndReturn(run func(ctx context.Context, check models.Check) (int64, error)) *MockChecksRepository_AddCheck_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// AddMissingPhotoRecords provides a mock function for the type MockChecksRepository
+func (_mock *MockChecksRepository) AddMissingPhotoRecords(ctx context.Context, records []models.PhotoRecord) (int64, error) {
+	ret := _mock.Called(ctx, records)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddMissingPhotoRecords")
+	}
+
+	var r0 int64
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []models.PhotoRecord) (int64, error)); ok {
+		return returnFunc(ctx, records)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []models.PhotoRecord) int64); ok {
+		r0 = returnFunc(ctx, records)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, []models.PhotoRecord) error); ok {
+		r1 = returnFunc(ctx, records)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockChecksRepository_AddMissingPhotoRecords_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddMissingPhotoRecords'
+type MockChecksRepository_AddMissingPhotoRecords_Call struct {
+	*mock.Call
+}
+
+// AddMissingPhotoRecords is a helper method to define mock.On call
+//   - ctx context.Context
+//   - records []models.PhotoRecord
+func (_e *MockChecksRepository_Expecter) AddMissingPhotoRecords(ctx interface{}, records interface{}) *MockChecksRepository_AddMissingPhotoRecords_Call {
+	return &MockChecksRepository_AddMissingPhotoRecords_Call{Call: _e.mock.On("AddMissingPhotoRecords", ctx, records)}
+}
+
+func (_c *MockChecksRepository_AddMissingPhotoRecords_Call) Run(run func(ctx context.Context, records []models.PhotoRecord)) *MockChecksRepository_AddMissingPhotoRecords_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 []models.PhotoRecord
+		if args[1] != nil {
+			arg1 = args[1].([]models.PhotoRecord)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockChecksRepository_AddMissingPhotoRecords_Call) Return(n int64, err error) *MockChecksRepository_AddMissingPhotoRecords_Call {
+	_c.Call.Return(n, err)
+	return _c
+}
+
+func (_c *MockChecksRepository_AddMissingPhotoRecords_Call) RunAndReturn(run func(ctx context.Context, records []models.PhotoRecord) (int64, error)) *MockChecksRepository_AddMissingPhotoRecords_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// AddPhotoRecords provides a mock function for the type MockChecksRepository
+func (_mock *MockChecksRepository) AddPhotoRecords(ctx context.Context, records []models.PhotoRecord) error {
+	ret := _mock.Called(ctx, records)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddPhotoRecords")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []models.PhotoRecord) error); ok {
+		r0 = returnFunc(ctx, records)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockChecksRepository_AddPhotoRecords_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddPhotoRecords'
+type MockChecksRepository_AddPhotoRecords_Call struct {
+	*mock.Call
+}
+
+// AddPhotoRecords is a helper method to define mock.On call
+//   - ctx context.Context
+//   - records []models.PhotoRecord
+func (_e *MockChecksRepository_Expecter) AddPhotoRecords(ctx interface{}, records interface{}) *MockChecksRepository_AddPhotoRecords_Call {
+	return &MockChecksRepository_AddPhotoRecords_Call{Call: _e.mock.On("AddPhotoRecords", ctx, records)}
+}
+
+func (_c *MockChecksRepository_AddPhotoRecords_Call) Run(run func(ctx context.Context, records []models.PhotoRecord)) *MockChecksRepository_AddPhotoRecords_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 []models.PhotoRecord
+		if args[1] != nil {
+			arg1 = args[1].([]models.PhotoRecord)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockChecksRepository_AddPhotoRecords_Call) Return(err error) *MockChecksRepository_AddPhotoRecords_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockChecksRepository_AddPhotoRecords_Call) RunAndReturn(run func(ctx context.Context, records []models.PhotoRecord) error) *MockChecksRepository_AddPhotoRecords_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// AddPhotosMetadata provides a mock function for the type MockChecksRepository
+func (_mock *MockChecksRepository) AddPhotosMetadata(ctx context.Context, checkId int, first int, metadata []models.PhotoMetadata) error {
+	ret := _mock.Called(ctx, checkId, first, metadata)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddPhotosMetadata")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int, int, []models.PhotoMetadata) error); ok {
+		r0 = returnFunc(ctx, checkId, first, metadata)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockChecksRepository_AddPhotosMetadata_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddPhotosMetadata'
+type MockChecksRepository_AddPhotosMetadata_Call struct {
+	*mock.Call
+}
+
+// AddPhotosMetadata is a helper method to define mock.On call
+//   - ctx context.Context
+//   - checkId int
+//   - first int
+//   - metadata []models.PhotoMetadata
+func (_e *MockChecksRepository_Expecter) AddPhotosMetadata(ctx interface{}, checkId interface{}, first interface{}, metadata interface{}) *MockChecksRepository_AddPhotosMetadata_Call {
+	return &MockChecksRepository_AddPhotosMetadata_Call{Call: _e.mock.On("AddPhotosMetadata", ctx, checkId, first, metadata)}
+}
+
+func (_c *MockChecksRepository_AddPhotosMetadata_Call) Run(run func(ctx context.Context, checkId int, first int, metadata []models.PhotoMetadata)) *MockChecksRepository_AddPhotosMetadata_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int
+		if args[1] != nil {
+			arg1 = args[1].(int)
+		}
+		var arg2 int
+		if args[2] != nil {
+			arg2 = args[2].(int)
+		}
+		var arg3 []models.PhotoMetadata
+		if args[3] != nil {
+			arg3 = args[3].([]models.PhotoMetadata)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockChecksRepository_AddPhotosMetadata_Call) Return(err error) *MockChecksRepository_AddPhotosMetadata_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockChecksRepository_AddPhotosMetadata_Call) RunAndReturn(run func(ctx context.Context, checkId int, first int, metadata []models.PhotoMetadata) error) *MockChecksRepository_AddPhotosMetadata_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetCheckById provides a mock function for the type MockChecksRepository
+func (_mock *MockChecksRepository) GetCheckById(ctx context.Context, id int) (models.Check, error) {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetCheckById")
+	}
+
+	var r0 models.Check
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int) (models.Check, error)); ok {
+		return returnFunc(ctx, id)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int) models.Check); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		r0 = ret.Get(0).(models.Check)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = returnFunc(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockChecksRepository_GetCheckById_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetCheckById'
+type MockChecksRepository_GetCheckById_Call struct {
+	*mock.Call
+}
+
+// GetCheckById is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int
+func (_e *MockChecksRepository_Expecter) GetCheckById(ctx interface{}, id interface{}) *MockChecksRepository_GetCheckById_Call {
+	return &MockChecksRepository_GetCheckById_Call{Call: _e.mock.On("GetCheckById", ctx, id)}
+}
+
+func (_c *MockChecksRepository_GetCheckById_Call) Run(run func(ctx context.Context, id int)) *MockChecksRepository_GetCheckById_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int
+		if args[1] != nil {
+			arg1 = args[1].(int)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockChecksRepository_GetCheckById_Call) Return(check models.Check, err error) *MockChecksRepository_GetCheckById_Call {
+	_c.Call.Return(check, err)
+	return _c
+}
+
+func (_c *MockChecksRepository_GetCheckById_Call) RunAndReturn(run func(ctx context.Context, id int) (models.Check, error)) *MockChecksRepository_GetCheckById_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetChecksByMarkHistoryId provides a mock function for the type MockChecksRepository
+func (_mock *MockChecksRepository) GetChecksByMarkHistoryId(ctx context.Context, markHistoryId int) ([]models.Check, error) {
+	ret := _mock.Called(ctx, markHistoryId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetChecksByMarkHistoryId")
+	}
+
+	var r0 []models.Check
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int) ([]models.Check, error)); ok {
+		return returnFunc(ctx, markHistoryId)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int) []models.Check); ok {
+		r0 = returnFunc(ctx, markHistoryId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.Check)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = returnFunc(ctx, markHistoryId)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockChecksRepository_GetChecksByMarkHistoryId_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetChecksByMarkHistoryId'
+type MockChecksRepository_GetChecksByMarkHistoryId_Call struct {
+	*mock.Call
+}
+
+// GetChecksByMarkHistoryId is a helper method to define mock.On call
+//   - ctx context.Context
+//   - markHistoryId int
+func (_e *MockChecksRepository_Expecter) GetChecksByMarkHistoryId(ctx interface{}, markHistoryId interface{}) *MockChecksRepository_GetChecksByMarkHistoryId_Call {
+	return &MockChecksRepository_GetChecksByMarkHistoryId_Call{Call: _e.mock.On("GetChecksByMarkHistoryId", ctx, markHistoryId)}
+}
+
+func (_c *MockChecksRepository_GetChecksByMarkHistoryId_Call) Run(run func(ctx context.Context, markHistoryId int)) *MockChecksRepository_GetChecksByMarkHistoryId_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int
+		if args[1] != nil {
+			arg1 = args[1].(int)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockChecksRepository_GetChecksByMarkHistoryId_Call) Return(checks []models.Check, err error) *MockChecksRepository_GetChecksByMarkHistoryId_Call {
+	_c.Call.Return(checks, err)
+	return _c
+}
+
+func (_c *MockChecksRepository_GetChecksByMarkHistoryId_Call) RunAndReturn(run func(ctx context.Context, markHistoryId int) ([]models.Check, error)) *MockChecksRepository_GetChecksByMarkHistoryId_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetChecksByMarkId provides a mock function for the type MockChecksRepository
+func (_mock *MockChecksRepository) GetChecksByMarkId(ctx context.Context, markId int, pagination models.Pagination) ([]models.Check, error) {
+	ret := _mock.Called(ctx, markId, pagination)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetChecksByMarkId")
+	}
+
+	var r0 []models.Check
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int, models.Pagination) ([]models.Check, error)); ok {
+		return returnFunc(ctx, markId, pagination)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int, models.Pagination) []models.Check); ok {
+		r0 = returnFunc(ctx, markId, pagination)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.Check)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int, models.Pagination) error); ok {
+		r1 = returnFunc(ctx, markId, pagination)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockChecksRepository_GetChecksByMarkId_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetChecksByMarkId'
+type MockChecksRepository_GetChecksByMarkId_Call struct {
+	*mock.Call
+}
+
+// GetChecksByMarkId is a helper method to define mock.On call
+//   - ctx context.Context
+//   - markId int
+//   - pagination models.Pagination
+func (_e *MockChecksRepository_Expecter) GetChecksByMarkId(ctx interface{}, markId interface{}, pagination interface{}) *MockChecksRepository_GetChecksByMarkId_Call {
+	return &MockChecksRepository_GetChecksByMarkId_Call{Call: _e.mock.On("GetChecksByMarkId", ctx, markId, pagination)}
+}
+
+func (_c *MockChecksRepository_GetChecksByMarkId_Call) Run(run func(ctx context.Context, markId int, pagination models.Pagination)) *MockChecksRepository_GetChecksByMarkId_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int
+		if args[1] != nil {
+			arg1 = args[1].(int)
+		}
+		var arg2 models.Pagination
+		if args[2] != nil {
+			arg2 = args[2].(models.Pagination)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockChecksRepository_GetChecksByMarkId_Call) Return(checks []models.Check, err error) *MockChecksRepository_GetChecksByMarkId_Call {
+	_c.Call.Return(checks, err)
+	return _c
+}
+
+func (_c *MockChecksRepository_GetChecksByMarkId_Call) RunAndReturn(run func(ctx context.Context, markId int, pagination models.Pagination) ([]models.Check, error)) *MockChecksRepository_GetChecksByMarkId_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetChecksByUserId provides a mock function for the type MockChecksRepository
+func (_mock *MockChecksRepository) GetChecksByUserId(ctx context.Context, userId int, pagination models.Pagination) ([]models.Check, error) {
+	ret := _mock.Called(ctx, userId, pagination)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetChecksByUserId")
+	}
+
+	var r0 []models.Check
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int, models.Pagination) ([]models.Check, error)); ok {
+		return returnFunc(ctx, userId, pagination)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int, models.Pagination) []models.Check); ok {
+		r0 = returnFunc(ctx, userId, pagination)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.Check)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int, models.Pagination) error); ok {
+		r1 = returnFunc(ctx, userId, pagination)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockChecksRepository_GetChecksByUserId_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetChecksByUserId'
+type MockChecksRepository_GetChecksByUserId_Call struct {
+	*mock.Call
+}
+
+// GetChecksByUserId is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userId int
+//   - pagination models.Pagination
+func (_e *MockChecksRepository_Expecter) GetChecksByUserId(ctx interface{}, userId interface{}, pagination interface{}) *MockChecksRepository_GetChecksByUserId_Call {
+	return &MockChecksRepository_GetChecksByUserId_Call{Call: _e.mock.On("GetChecksByUserId", ctx, userId, pagination)}
+}
+
+func (_c *MockChecksRepository_GetChecksByUserId_Call) Run(run func(ctx context.Context, userId int, pagination models.Pagination)) *MockChecksRepository_GetChecksByUserId_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int
+		if args[1] != nil {
+			arg1 = args[1].(int)
+		}
+		var arg2 models.Pagination
+		if args[2] != nil {
+			arg2 = args[2].(models.Pagination)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockChecksRepository_GetChecksByUserId_Call) Return(checks []models.Check, err error) *MockChecksRepository_GetChecksByUserId_Call {
+	_c.Call.Return(checks, err)
+	return _c
+}
+
+func (_c *MockChecksRepository_GetChecksByUserId_Call) RunAndReturn(run func(ctx context.Context, userId int, pagination models.Pagination) ([]models.Check, error)) *MockChecksRepository_GetChecksByUserId_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetPhotoMetadata provides a mock function for the type MockChecksRepository
+func (_mock *MockChecksRepository) GetPhotoMetadata(ctx context.Context, checkId int, number int) (models.PhotoMetadata, error) {
+	ret := _mock.Called(ctx, checkId, number)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPhotoMetadata")
+	}
+
+	var r0 models.PhotoMetadata
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int, int) (models.PhotoMetadata, error)); ok {
+		return returnFunc(ctx, checkId, number)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int, int) models.PhotoMetadata); ok {
+		r0 = returnFunc(ctx, checkId, number)
+	} else {
+		r0 = ret.Get(0).(models.PhotoMetadata)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int, int) error); ok {
+		r1 = returnFunc(ctx, checkId, number)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockChecksRepository_GetPhotoMetadata_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPhotoMetadata'
+type MockChecksRepository_GetPhotoMetadata_Call struct {
+	*mock.Call
+}
+
+// GetPhotoMetadata is a helper method to define mock.On call
+//   - ctx context.Context
+//   - checkId int
+//   - number int
+func (_e *MockChecksRepository_Expecter) GetPhotoMetadata(ctx interface{}, checkId interface{}, number interface{}) *MockChecksRepository_GetPhotoMetadata_Call {
+	return &MockChecksRepository_GetPhotoMetadata_Call{Call: _e.mock.On("GetPhotoMetadata", ctx, checkId, number)}
+}
+
+func (_c *MockChecksRepository_GetPhotoMetadata_Call) Run(run func(ctx context.Context, checkId int, number int)) *MockChecksRepository_GetPhotoMetadata_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int
+		if args[1] != nil {
+			arg1 = args[1].(int)
+		}
+		var arg2 int
+		if args[2] != nil {
+			arg2 = args[2].(int)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockChecksRepository_GetPhotoMetadata_Call) Return(photoMetadata models.PhotoMetadata, err error) *MockChecksRepository_GetPhotoMetadata_Call {
+	_c.Call.Return(photoMetadata, err)
+	return _c
+}
+
+func (_c *MockChecksRepository_GetPhotoMetadata_Call) RunAndReturn(run func(ctx context.Context, checkId int, number int) (models.PhotoMetadata, error)) *MockChecksRepository_GetPhotoMetadata_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetPhotoRecordsByCheckIds provides a mock function for the type MockChecksRepository
+func (_mock *MockChecksRepository) GetPhotoRecordsByCheckIds(ctx context.Context, checkIds []int) ([]models.PhotoRecord, error) {
+	ret := _mock.Called(ctx, checkIds)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPhotoRecordsByCheckIds")
+	}
+
+	var r0 []models.PhotoRecord
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []int) ([]models.PhotoRecord, error)); ok {
+		return returnFunc(ctx, checkIds)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []int) []models.PhotoRecord); ok {
+		r0 = returnFunc(ctx, checkIds)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.PhotoRecord)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, []int) error); ok {
+		r1 = returnFunc(ctx, checkIds)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockChecksRepository_GetPhotoRecordsByCheckIds_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPhotoRecordsByCheckIds'
+type MockChecksRepository_GetPhotoRecordsByCheckIds_Call struct {
+	*mock.Call
+}
+
+// GetPhotoRecordsByCheckIds is a helper method to define mock.On call
+//   - ctx context.Context
+//   - checkIds []int
+func (_e *MockChecksRepository_Expecter) GetPhotoRecordsByCheckIds(ctx interface{}, checkIds interface{}) *MockChecksRepository_GetPhotoRecordsByCheckIds_Call {
+	return &MockChecksRepository_GetPhotoRecordsByCheckIds_Call{Call: _e.mock.On("GetPhotoRecordsByCheckIds", ctx, checkIds)}
+}
+
+func (_c *MockChecksRepository_GetPhotoRecordsByCheckIds_Call) Run(run func(ctx context.Context, checkIds []int)) *MockChecksRepository_GetPhotoRecordsByCheckIds_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 []int
+		if args[1] != nil {
+			arg1 = args[1].([]int)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockChecksRepository_GetPhotoRecordsByCheckIds_Call) Return(photoRecords []models.PhotoRecord, err error) *MockChecksRepository_GetPhotoRecordsByCheckIds_Call {
+	_c.Call.Return(photoRecords, err)
+	return _c
+}
+
+func (_c *MockChecksRepository_GetPhotoRecordsByCheckIds_Call) RunAndReturn(run func(ctx context.Context, checkIds []int) ([]models.PhotoRecord, error)) *MockChecksRepository_GetPhotoRecordsByCheckIds_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetSimilarPhotos provides a mock function for the type MockChecksRepository
+func (_mock *MockChecksRepository) GetSimilarPhotos(ctx context.Context, filters models.GetSimilarPhotosFilters) ([]models.SimilarPhoto, error) {
+	ret := _mock.Called(ctx, filters)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetSimilarPhotos")
+	}
+
+	var r0 []models.SimilarPhoto
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, models.GetSimilarPhotosFilters) ([]models.SimilarPhoto, error)); ok {
+		return returnFunc(ctx, filters)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, models.GetSimilarPhotosFilters) []models.SimilarPhoto); ok {
+		r0 = returnFunc(ctx, filters)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.SimilarPhoto)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, models.GetSimilarPhotosFilters) error); ok {
+		r1 = returnFunc(ctx, filters)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockChecksRepository_GetSimilarPhotos_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetSimilarPhotos'
+type MockChecksRepository_GetSimilarPhotos_Call struct {
+	*mock.Call
+}
+
+// GetSimilarPhotos is a helper method to define mock.On call
+//   - ctx context.Context
+//   - filters models.GetSimilarPhotosFilters
+func (_e *MockChecksRepository_Expecter) GetSimilarPhotos(ctx interface{}, filters interface{}) *MockChecksRepository_GetSimilarPhotos_Call {
+	return &MockChecksRepository_GetSimilarPhotos_Call{Call: _e.mock.On("GetSimilarPhotos", ctx, filters)}
+}
+
+func (_c *MockChecksRepository_GetSimilarPhotos_Call) Run(run func(ctx context.Context, filters models.GetSimilarPhotosFilters)) *MockChecksRepository_GetSimilarPhotos_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 models.GetSimilarPhotosFilters
+		if args[1] != nil {
+			arg1 = args[1].(models.GetSimilarPhotosFilters)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockChecksRepository_GetSimilarPhotos_Call) Return(similarPhotos []models.SimilarPhoto, err error) *MockChecksRepository_GetSimilarPhotos_Call {
+	_c.Call.Return(similarPhotos, err)
+	return _c
+}
+
+func (_c *MockChecksRepository_GetSimilarPhotos_Call) RunAndReturn(run func(ctx context.Context, filters models.GetSimilarPhotosFilters) ([]models.SimilarPhoto, error)) *MockChecksRepository_GetSimilarPhotos_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetUserMarkCheck provides a mock function for the type MockChecksRepository
+func (_mock *MockChecksRepository) GetUserMarkCheck(ctx context.Context, userId int, markStatusHistoryId int) (models.Check, error) {
+	ret := _mock.Called(ctx, userId, markStatusHistoryId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUserMarkCheck")
+	}
+
+	var r0 models.Check
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int, int) (models.Check, error)); ok {
+		return returnFunc(ctx, userId, markStatusHistoryId)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int, int) models.Check); ok {
+		r0 = returnFunc(ctx, userId, markStatusHistoryId)
+	} else {
+		r0 = ret.Get(0).(models.Check)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int, int) error); ok {
+		r1 = returnFunc(ctx, userId, markStatusHistoryId)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockChecksRepository_GetUserMarkCheck_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUserMarkCheck'
+type MockChecksRepository_GetUserMarkCheck_Call struct {
+	*mock.Call
+}
+
+// GetUserMarkCheck is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userId int
+//   - markStatusHistoryId int
+func (_e *MockChecksRepository_Expecter) GetUserMarkCheck(ctx interface{}, userId interface{}, markStatusHistoryId interface{}) *MockChecksRepository_GetUserMarkCheck_Call {
+	return &MockChecksRepository_GetUserMarkCheck_Call{Call: _e.mock.On("GetUserMarkCheck", ctx, userId, markStatusHistoryId)}
+}
+
+func (_c *MockChecksRepository_GetUserMarkCheck_Call) Run(run func(ctx context.Context, userId int, markStatusHistoryId int)) *MockChecksRepository_GetUserMarkCheck_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int
+		if args[1] != nil {
+			arg1 = args[1].(int)
+		}
+		var arg2 int
+		if args[2] != nil {
+			arg2 = args[2].(int)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockChecksRepository_GetUserMarkCheck_Call) Return(check models.Check, err error) *MockChecksRepository_GetUserMarkCheck_Call {
+	_c.Call.Return(check, err)
+	return _c
+}
+
+func (_c *MockChecksRepository_GetUserMarkCheck_Call) RunAndReturn(run func(ctx context.Context, userId int, markStatusHistoryId int) (models.Check, error)) *MockChecksRepository_GetUserMarkCheck_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockMarkStatusUpdater creates a new instance of MockMarkStatusUpdater. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockMarkStatusUpdater(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockMarkStatusUpdater {
+	mock := &MockMarkStatusUpdater{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockMarkStatusUpdater is an autogenerated mock type for the MarkStatusUpdater type
+type MockMarkStatusUpdater struct {
+	mock.Mock
+}
+
+type MockMarkStatusUpdater_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockMarkStatusUpdater) EXPECT() *MockMarkStatusUpdater_Expecter {
+	return &MockMarkStatusUpdater_Expecter{mock: &_m.Mock}
+}
+
+// Update provides a mock function for the type MockMarkStatusUpdater
+func (_mock *MockMarkStatusUpdater) Update(ctx context.Context, markId int) error {
+	ret := _mock.Called(ctx, markId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Update")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int) error); ok {
+		r0 = returnFunc(ctx, markId)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockMarkStatusUpdater_Update_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Update'
+type MockMarkStatusUpdater_Update_Call struct {
+	*mock.Call
+}
+
+// Update is a helper method to define mock.On call
+//   - ctx context.Context
+//   - markId int
+func (_e *MockMarkStatusUpdater_Expecter) Update(ctx interface{}, markId interface{}) *MockMarkStatusUpdater_Update_Call {
+	return &MockMarkStatusUpdater_Update_Call{Call: _e.mock.On("Update", ctx, markId)}
+}
+
+func (_c *MockMarkStatusUpdater_Update_Call) Run(run func(ctx context.Context, markId int)) *MockMarkStatusUpdater_Update_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int
+		if args[1] != nil {
+			arg1 = args[1].(int)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockMarkStatusUpdater_Update_Call) Return(err error) *MockMarkStatusUpdater_Update_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockMarkStatusUpdater_Update_Call) RunAndReturn(run func(ctx context.Context, markId int) error) *MockMarkStatusUpdater_Update_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockConsensusPolicy creates a new instance of MockConsensusPolicy. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockConsensusPolicy(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockConsensusPolicy {
+	mock := &MockConsensusPolicy{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockConsensusPolicy is an autogenerated mock type for the ConsensusPolicy type
+type MockConsensusPolicy struct {
+	mock.Mock
+}
+
+type MockConsensusPolicy_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockConsensusPolicy) EXPECT() *MockConsensusPolicy_Expecter {
+	return &MockConsensusPolicy_Expecter{mock: &_m.Mock}
+}
+
+// Evaluate provides a mock function for the type MockConsensusPolicy
+func (_mock *MockConsensusPolicy) Evaluate(checks []models.Check, now time.Time) models.ConsensusResult {
+	ret := _mock.Called(checks, now)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Evaluate")
+	}
+
+	var r0 models.ConsensusResult
+	if returnFunc, ok := ret.Get(0).(func([]models.Check, time.Time) models.ConsensusResult); ok {
+		r0 = returnFunc(checks, now)
+	} else {
+		r0 = ret.Get(0).(models.ConsensusResult)
+	}
+	return r0
+}
+
+// MockConsensusPolicy_Evaluate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Evaluate'
+type MockConsensusPolicy_Evaluate_Call struct {
+	*mock.Call
+}
+
+// Evaluate is a helper method to define mock.On call
+//   - checks []models.Check
+//   - now time.Time
+func (_e *MockConsensusPolicy_Expecter) Evaluate(checks interface{}, now interface{}) *MockConsensusPolicy_Evaluate_Call {
+	return &MockConsensusPolicy_Evaluate_Call{Call: _e.mock.On("Evaluate", checks, now)}
+}
+
+func (_c *MockConsensusPolicy_Evaluate_Call) Run(run func(checks []models.Check, now time.Time)) *MockConsensusPolicy_Evaluate_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 []models.Check
+		if args[0] != nil {
+			arg0 = args[0].([]models.Check)
+		}
+		var arg1 time.Time
+		if args[1] != nil {
+			arg1 = args[1].(time.Time)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockConsensusPolicy_Evaluate_Call) Return(consensusResult models.ConsensusResult) *MockConsensusPolicy_Evaluate_Call {
+	_c.Call.Return(consensusResult)
+	return _c
+}
+
+func (_c *MockConsensusPolicy_Evaluate_Call) RunAndReturn(run func(checks []models.Check, now time.Time) models.ConsensusResult) *MockConsensusPolicy_Evaluate_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockMapRepository creates a new instance of MockMapRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockMapRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockMapRepository {
+	mock := &MockMapRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockMapRepository is an autogenerated mock type for the MapRepository type
+type MockMapRepository struct {
+	mock.Mock
+}
+
+type MockMapRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockMapRepository) EXPECT() *MockMapRepository_Expecter {
+	return &MockMapRepository_Expecter{mock: &_m.Mock}
+}
+
+// GetAdminBoundaries provides a mock function for the type MockMapRepository
+func (_mock *MockMapRepository) GetAdminBoundaries(ctx context.Context, filters models.GetAdminBoundaryFilters) ([]models.AdminBoundary, error) {
+	ret := _mock.Called(ctx, filters)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAdminBoundaries")
+	}
+
+	var r0 []models.AdminBoundary
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, models.GetAdminBoundaryFilters) ([]models.AdminBoundary, error)); ok {
+		return returnFunc(ctx, filters)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, models.GetAdminBoundaryFilters) []models.AdminBoundary); ok {
+		r0 = returnFunc(ctx, filters)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.AdminBoundary)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, models.GetAdminBoundaryFilters) error); ok {
+		r1 = returnFunc(ctx, filters)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockMapRepository_GetAdminBoundaries_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAdminBoundaries'
+type MockMapRepository_GetAdminBoundaries_Call struct {
+	*mock.Call
+}
+
+// GetAdminBoundaries is a helper method to define mock.On call
+//   - ctx context.Context
+//   - filters models.GetAdminBoundaryFilters
+func (_e *MockMapRepository_Expecter) GetAdminBoundaries(ctx interface{}, filters interface{}) *MockMapRepository_GetAdminBoundaries_Call {
+	return &MockMapRepository_GetAdminBoundaries_Call{Call: _e.mock.On("GetAdminBoundaries", ctx, filters)}
+}
+
+func (_c *MockMapRepository_GetAdminBoundaries_Call) Run(run func(ctx context.Context, filters models.GetAdminBoundaryFilters)) *MockMapRepository_GetAdminBoundaries_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 models.GetAdminBoundaryFilters
+		if args[1] != nil {
+			arg1 = args[1].(models.GetAdminBoundaryFilters)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockMapRepository_GetAdminBoundaries_Call) Return(adminBoundarys []models.AdminBoundary, err error) *MockMapRepository_GetAdminBoundaries_Call {
+	_c.Call.Return(adminBoundarys, err)
+	return _c
+}
+
+func (_c *MockMapRepository_GetAdminBoundaries_Call) RunAndReturn(run func(ctx context.Context, filters models.GetAdminBoundaryFilters) ([]models.AdminBoundary, error)) *MockMapRepository_GetAdminBoundaries_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetAdminBoundariesMarksCount provides a mock function for the type MockMapRepository
+func (_mock *MockMapRepository) GetAdminBoundariesMarksCount(ctx context.Context, filters models.GetAdminBoundaryMarksCountFilters) ([]models.AdminBoundaryMarksCount, error) {
+	ret := _mock.Called(ctx, filters)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAdminBoundariesMarksCount")
+	}
+
+	var r0 []models.AdminBoundaryMarksCount
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, models.GetAdminBoundaryMarksCountFilters) ([]models.AdminBoundaryMarksCount, error)); ok {
+		return returnFunc(ctx, filters)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, models.GetAdminBoundaryMarksCountFilters) []models.AdminBoundaryMarksCount); ok {
+		r0 = returnFunc(ctx, filters)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.AdminBoundaryMarksCount)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, models.GetAdminBoundaryMarksCountFilters) error); ok {
+		r1 = returnFunc(ctx, filters)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockMapRepository_GetAdminBoundariesMarksCount_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAdminBoundariesMarksCount'
+type MockMapRepository_GetAdminBoundariesMarksCount_Call struct {
+	*mock.Call
+}
+
+// GetAdminBoundariesMarksCount is a helper method to define mock.On call
+//   - ctx context.Context
+//   - filters models.GetAdminBoundaryMarksCountFilters
+func (_e *MockMapRepository_Expecter) GetAdminBoundariesMarksCount(ctx interface{}, filters interface{}) *MockMapRepository_GetAdminBoundariesMarksCount_Call {
+	return &MockMapRepository_GetAdminBoundariesMarksCount_Call{Call: _e.mock.On("GetAdminBoundariesMarksCount", ctx, filters)}
+}
+
+func (_c *MockMapRepository_GetAdminBoundariesMarksCount_Call) Run(run func(ctx context.Context, filters models.GetAdminBoundaryMarksCountFilters)) *MockMapRepository_GetAdminBoundariesMarksCount_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 models.GetAdminBoundaryMarksCountFilters
+		if args[1] != nil {
+			arg1 = args[1].(models.GetAdminBoundaryMarksCountFilters)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockMapRepository_GetAdminBoundariesMarksCount_Call) Return(adminBoundaryMarksCounts []models.AdminBoundaryMarksCount, err error) *MockMapRepository_GetAdminBoundariesMarksCount_Call {
+	_c.Call.Return(adminBoundaryMarksCounts, err)
+	return _c
+}
+
+func (_c *MockMapRepository_GetAdminBoundariesMarksCount_Call) RunAndReturn(run func(ctx context.Context, filters models.GetAdminBoundaryMarksCountFilters) ([]models.AdminBoundaryMarksCount, error)) *MockMapRepository_GetAdminBoundariesMarksCount_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetAdminBoundariesTile provides a mock function for the type MockMapRepository
+func (_mock *MockMapRepository) GetAdminBoundariesTile(ctx context.Context, tile models.Tile) ([]byte, error) {
+	ret := _mock.Called(ctx, tile)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAdminBoundariesTile")
+	}
+
+	var r0 []byte
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, models.Tile) ([]byte, error)); ok {
+		return returnFunc(ctx, tile)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, models.Tile) []byte); ok {
+		r0 = returnFunc(ctx, tile)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]byte)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, models.Tile) error); ok {
+		r1 = returnFunc(ctx, tile)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockMapRepository_GetAdminBoundariesTile_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAdminBoundariesTile'
+type MockMapRepository_GetAdminBoundariesTile_Call struct {
+	*mock.Call
+}
+
+// GetAdminBoundariesTile is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tile models.Tile
+func (_e *MockMapRepository_Expecter) GetAdminBoundariesTile(ctx interface{}, tile interface{}) *MockMapRepository_GetAdminBoundariesTile_Call {
+	return &MockMapRepository_GetAdminBoundariesTile_Call{Call: _e.mock.On("GetAdminBoundariesTile", ctx, tile)}
+}
+
+func (_c *MockMapRepository_GetAdminBoundariesTile_Call) Run(run func(ctx context.Context, tile models.Tile)) *MockMapRepository_GetAdminBoundariesTile_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 models.Tile
+		if args[1] != nil {
+			arg1 = args[1].(models.Tile)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockMapRepository_GetAdminBoundariesTile_Call) Return(bytes []byte, err error) *MockMapRepository_GetAdminBoundariesTile_Call {
+	_c.Call.Return(bytes, err)
+	return _c
+}
+
+func (_c *MockMapRepository_GetAdminBoundariesTile_Call) RunAndReturn(run func(ctx context.Context, tile models.Tile) ([]byte, error)) *MockMapRepository_GetAdminBoundariesTile_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetCities provides a mock function for the type MockMapRepository
+func (_mock *MockMapRepository) GetCities(ctx context.Context) ([]models.City, error) {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetCities")
+	}
+
+	var r0 []models.City
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) ([]models.City, error)); ok {
+		return returnFunc(ctx)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context) []models.City); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.City)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = returnFunc(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockMapRepository_GetCities_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetCities'
+type MockMapRepository_GetCities_Call struct {
+	*mock.Call
+}
+
+// GetCities is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockMapRepository_Expecter) GetCities(ctx interface{}) *MockMapRepository_GetCities_Call {
+	return &MockMapRepository_GetCities_Call{Call: _e.mock.On("GetCities", ctx)}
+}
+
+func (_c *MockMapRepository_GetCities_Call) Run(run func(ctx context.Context)) *MockMapRepository_GetCities_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockMapRepository_GetCities_Call) Return(citys []models.City, err error) *MockMapRepository_GetCities_Call {
+	_c.Call.Return(citys, err)
+	return _c
+}
+
+func (_c *MockMapRepository_GetCities_Call) RunAndReturn(run func(ctx context.Context) ([]models.City, error)) *MockMapRepository_GetCities_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetDistricts provides a mock function for the type MockMapRepository
+func (_mock *MockMapRepository) GetDistricts(ctx context.Context) ([]models.District, error) {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetDistricts")
+	}
+
+	var r0 []models.District
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) ([]models.District, error)); ok {
+		return returnFunc(ctx)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context) []models.District); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.District)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = returnFunc(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockMapRepository_GetDistricts_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetDistricts'
+type MockMapRepository_GetDistricts_Call struct {
+	*mock.Call
+}
+
+// GetDistricts is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockMapRepository_Expecter) GetDistricts(ctx interface{}) *MockMapRepository_GetDistricts_Call {
+	return &MockMapRepository_GetDistricts_Call{Call: _e.mock.On("GetDistricts", ctx)}
+}
+
+func (_c *MockMapRepository_GetDistricts_Call) Run(run func(ctx context.Context)) *MockMapRepository_GetDistricts_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockMapRepository_GetDistricts_Call) Return(districts []models.District, err error) *MockMapRepository_GetDistricts_Call {
+	_c.Call.Return(districts, err)
+	return _c
+}
+
+func (_c *MockMapRepository_GetDistricts_Call) RunAndReturn(run func(ctx context.Context) ([]models.District, error)) *MockMapRepository_GetDistricts_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetMarkClusters provides a mock function for the type MockMapRepository
+func (_mock *MockMapRepository) GetMarkClusters(ctx context.Context, filters models.GetMarkClustersFilters) ([]models.MarkCluster, error) {
+	ret := _mock.Called(ctx, filters)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetMarkClusters")
+	}
+
+	var r0 []models.MarkCluster
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, models.GetMarkClustersFilters) ([]models.MarkCluster, error)); ok {
+		return returnFunc(ctx, filters)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, models.GetMarkClustersFilters) []models.MarkCluster); ok {
+		r0 = returnFunc(ctx, filters)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.MarkCluster)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, models.GetMarkClustersFilters) error); ok {
+		r1 = returnFunc(ctx, filters)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockMapRepository_GetMarkClusters_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetMarkClusters'
+type MockMapRepository_GetMarkClusters_Call struct {
+	*mock.Call
+}
+
+// GetMarkClusters is a helper method to define mock.On call
+//   - ctx context.Context
+//   - filters models.GetMarkClustersFilters
+func (_e *MockMapRepository_Expecter) GetMarkClusters(ctx interface{}, filters interface{}) *MockMapRepository_GetMarkClusters_Call {
+	return &MockMapRepository_GetMarkClusters_Call{Call: _e.mock.On("GetMarkClusters", ctx, filters)}
+}
+
+func (_c *MockMapRepository_GetMarkClusters_Call) Run(run func(ctx context.Context, filters models.GetMarkClustersFilters)) *MockMapRepository_GetMarkClusters_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 models.GetMarkClustersFilters
+		if args[1] != nil {
+			arg1 = args[1].(models.GetMarkClustersFilters)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockMapRepository_GetMarkClusters_Call) Return(markClusters []models.MarkCluster, err error) *MockMapRepository_GetMarkClusters_Call {
+	_c.Call.Return(markClusters, err)
+	return _c
+}
+
+func (_c *MockMapRepository_GetMarkClusters_Call) RunAndReturn(run func(ctx context.Context, filters models.GetMarkClustersFilters) ([]models.MarkCluster, error)) *MockMapRepository_GetMarkClusters_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetMarksTile provides a mock function for the type MockMapRepository
+func (_mock *MockMapRepository) GetMarksTile(ctx context.Context, tile models.Tile) ([]byte, error) {
+	ret := _mock.Called(ctx, tile)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetMarksTile")
+	}
+
+	var r0 []byte
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, models.Tile) ([]byte, error)); ok {
+		return returnFunc(ctx, tile)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, models.Tile) []byte); ok {
+		r0 = returnFunc(ctx, tile)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]byte)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, models.Tile) error); ok {
+		r1 = returnFunc(ctx, tile)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockMapRepository_GetMarksTile_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetMarksTile'
+type MockMapRepository_GetMarksTile_Call struct {
+	*mock.Call
+}
+
+// GetMarksTile is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tile models.Tile
+func (_e *MockMapRepository_Expecter) GetMarksTile(ctx interface{}, tile interface{}) *MockMapRepository_GetMarksTile_Call {
+	return &MockMapRepository_GetMarksTile_Call{Call: _e.mock.On("GetMarksTile", ctx, tile)}
+}
+
+func (_c *MockMapRepository_GetMarksTile_Call) Run(run func(ctx context.Context, tile models.Tile)) *MockMapRepository_GetMarksTile_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 models.Tile
+		if args[1] != nil {
+			arg1 = args[1].(models.Tile)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockMapRepository_GetMarksTile_Call) Return(bytes []byte, err error) *MockMapRepository_GetMarksTile_Call {
+	_c.Call.Return(bytes, err)
+	return _c
+}
+
+func (_c *MockMapRepository_GetMarksTile_Call) RunAndReturn(run func(ctx context.Context, tile models.Tile) ([]byte, error)) *MockMapRepository_GetMarksTile_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetRegions provides a mock function for the type MockMapRepository
+func (_mock *MockMapRepository) GetRegions(ctx context.Context) ([]models.Region, error) {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetRegions")
+	}
+
+	var r0 []models.Region
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) ([]models.Region, error)); ok {
+		return returnFunc(ctx)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context) []models.Region); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.Region)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = returnFunc(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockMapRepository_GetRegions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetRegions'
+type MockMapRepository_GetRegions_Call struct {
+	*mock.Call
+}
+
+// GetRegions is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockMapRepository_Expecter) GetRegions(ctx interface{}) *MockMapRepository_GetRegions_Call {
+	return &MockMapRepository_GetRegions_Call{Call: _e.mock.On("GetRegions", ctx)}
+}
+
+func (_c *MockMapRepository_GetRegions_Call) Run(run func(ctx context.Context)) *MockMapRepository_GetRegions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockMapRepository_GetRegions_Call) Return(regions []models.Region, err error) *MockMapRepository_GetRegions_Call {
+	_c.Call.Return(regions, err)
+	return _c
+}
+
+func (_c *MockMapRepository_GetRegions_Call) RunAndReturn(run func(ctx context.Context) ([]models.Region, error)) *MockMapRepository_GetRegions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockMarksRepository creates a new instance of MockMarksRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockMarksRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockMarksRepository {
+	mock := &MockMarksRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockMarksRepository is an autogenerated mock type for the MarksRepository type
+type MockMarksRepository struct {
+	mock.Mock
+}
+
+type MockMarksRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockMarksRepository) EXPECT() *MockMarksRepository_Expecter {
+	return &MockMarksRepository_Expecter{mock: &_m.Mock}
+}
+
+// AddMark provides a mock function for the type MockMarksRepository
+func (_mock *MockMarksRepository) AddMark(ctx context.Context, mark models.Mark) (int64, error) {
+	ret := _mock.Called(ctx, mark)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddMark")
+	}
+
+	var r0 int64
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, models.Mark) (int64, error)); ok {
+		return returnFunc(ctx, mark)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, models.Mark) int64); ok {
+		r0 = returnFunc(ctx, mark)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, models.Mark) error); ok {
+		r1 = returnFunc(ctx, mark)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockMarksRepository_AddMark_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddMark'
+type MockMarksRepository_AddMark_Call struct {
+	*mock.Call
+}
+
+// AddMark is a helper method to define mock.On call
+//   - ctx context.Context
+//   - mark models.Mark
+func (_e *MockMarksRepository_Expecter) AddMark(ctx interface{}, mark interface{}) *MockMarksRepository_AddMark_Call {
+	return &MockMarksRepository_AddMark_Call{Call: _e.mock.On("AddMark", ctx, mark)}
+}
+
+func (_c *MockMarksRepository_AddMark_Call) Run(run func(ctx context.Context, mark models.Mark)) *MockMarksRepository_AddMark_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 models.Mark
+		if args[1] != nil {
+			arg1 = args[1].(models.Mark)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockMarksRepository_AddMark_Call) Return(n int64, err error) *MockMarksRepository_AddMark_Call {
+	_c.Call.Return(n, err)
+	return _c
+}
+
+func (_c *MockMarksRepository_AddMark_Call) RunAndReturn(run func(ctx context.Context, mark models.Mark) (int64, error)) *MockMarksRepository_AddMark_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteMark provides a mock function for the type MockMarksRepository
+func (_mock *MockMarksRepository) DeleteMark(ctx context.Context, markId int) error {
+	ret := _mock.Called(ctx, markId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteMark")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int) error); ok {
+		r0 = returnFunc(ctx, markId)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockMarksRepository_DeleteMark_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteMark'
+type MockMarksRepository_DeleteMark_Call struct {
+	*mock.Call
+}
+
+// DeleteMark is a helper method to define mock.On call
+//   - ctx context.Context
+//   - markId int
+func (_e *MockMarksRepository_Expecter) DeleteMark(ctx interface{}, markId interface{}) *MockMarksRepository_DeleteMark_Call {
+	return &MockMarksRepository_DeleteMark_Call{Call: _e.mock.On("DeleteMark", ctx, markId)}
+}
+
+func (_c *MockMarksRepository_DeleteMark_Call) Run(run func(ctx context.Context, markId int)) *MockMarksRepository_DeleteMark_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int
+		if args[1] != nil {
+			arg1 = args[1].(int)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockMarksRepository_DeleteMark_Call) Return(err error) *MockMarksRepository_DeleteMark_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockMarksRepository_DeleteMark_Call) RunAndReturn(run func(ctx context.Context, markId int) error) *MockMarksRepository_DeleteMark_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetLastMarkStatusHistoryItem provides a mock function for the type MockMarksRepository
+func (_mock *MockMarksRepository) GetLastMarkStatusHistoryItem(ctx context.Context, markId int) (models.MarkStatusHistoryItem, error) {
+	ret := _mock.Called(ctx, markId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetLastMarkStatusHistoryItem")
+	}
+
+	var r0 models.MarkStatusHistoryItem
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int) (models.MarkStatusHistoryItem, error)); ok {
+		return returnFunc(ctx, markId)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int) models.MarkStatusHistoryItem); ok {
+		r0 = returnFunc(ctx, markId)
+	} else {
+		r0 = ret.Get(0).(models.MarkStatusHistoryItem)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = returnFunc(ctx, markId)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockMarksRepository_GetLastMarkStatusHistoryItem_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetLastMarkStatusHistoryItem'
+type MockMarksRepository_GetLastMarkStatusHistoryItem_Call struct {
+	*mock.Call
+}
+
+// GetLastMarkStatusHistoryItem is a helper method to define mock.On call
+//   - ctx context.Context
+//   - markId int
+func (_e *MockMarksRepository_Expecter) GetLastMarkStatusHistoryItem(ctx interface{}, markId interface{}) *MockMarksRepository_GetLastMarkStatusHistoryItem_Call {
+	return &MockMarksRepository_GetLastMarkStatusHistoryItem_Call{Call: _e.mock.On("GetLastMarkStatusHistoryItem", ctx, markId)}
+}
+
+func (_c *MockMarksRepository_GetLastMarkStatusHistoryItem_Call) Run(run func(ctx context.Context, markId int)) *MockMarksRepository_GetLastMarkStatusHistoryItem_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int
+		if args[1] != nil {
+			arg1 = args[1].(int)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockMarksRepository_GetLastMarkStatusHistoryItem_Call) Return(markStatusHistoryItem models.MarkStatusHistoryItem, err error) *MockMarksRepository_GetLastMarkStatusHistoryItem_Call {
+	_c.Call.Return(markStatusHistoryItem, err)
+	return _c
+}
+
+func (_c *MockMarksRepository_GetLastMarkStatusHistoryItem_Call) RunAndReturn(run func(ctx context.Context, markId int) (models.MarkStatusHistoryItem, error)) *MockMarksRepository_GetLastMarkStatusHistoryItem_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetMarkById provides a mock function for the type MockMarksRepository
+func (_mock *MockMarksRepository) GetMarkById(ctx context.Context, id int) (models.Mark, error) {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetMarkById")
+	}
+
+	var r0 models.Mark
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int) (models.Mark, error)); ok {
+		return returnFunc(ctx, id)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int) models.Mark); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		r0 = ret.Get(0).(models.Mark)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = returnFunc(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockMarksRepository_GetMarkById_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetMarkById'
+type MockMarksRepository_GetMarkById_Call struct {
+	*mock.Call
+}
+
+// GetMarkById is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int
+func (_e *MockMarksRepository_Expecter) GetMarkById(ctx interface{}, id interface{}) *MockMarksRepository_GetMarkById_Call {
+	return &MockMarksRepository_GetMarkById_Call{Call: _e.mock.On("GetMarkById", ctx, id)}
+}
+
+func (_c *MockMarksRepository_GetMarkById_Call) Run(run func(ctx context.Context, id int)) *MockMarksRepository_GetMarkById_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int
+		if args[1] != nil {
+			arg1 = args[1].(int)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockMarksRepository_GetMarkById_Call) Return(mark models.Mark, err error) *MockMarksRepository_GetMarkById_Call {
+	_c.Call.Return(mark, err)
+	return _c
+}
+
+func (_c *MockMarksRepository_GetMarkById_Call) RunAndReturn(run func(ctx context.Context, id int) (models.Mark, error)) *MockMarksRepository_GetMarkById_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetMarkStatusHistoryByMarkId provides a mock function for the type MockMarksRepository
+func (_mock *MockMarksRepository) GetMarkStatusHistoryByMarkId(ctx context.Context, markId int) ([]models.MarkStatusHistoryItem, error) {
+	ret := _mock.Called(ctx, markId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetMarkStatusHistoryByMarkId")
+	}
+
+	var r0 []models.MarkStatusHistoryItem
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int) ([]models.MarkStatusHistoryItem, error)); ok {
+		return returnFunc(ctx, markId)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int) []models.MarkStatusHistoryItem); ok {
+		r0 = returnFunc(ctx, markId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.MarkStatusHistoryItem)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = returnFunc(ctx, markId)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockMarksRepository_GetMarkStatusHistoryByMarkId_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetMarkStatusHistoryByMarkId'
+type MockMarksRepository_GetMarkStatusHistoryByMarkId_Call struct {
+	*mock.Call
+}
+
+// GetMarkStatusHistoryByMarkId is a helper method to define mock.On call
+//   - ctx context.Context
+//   - markId int
+func (_e *MockMarksRepository_Expecter) GetMarkStatusHistoryByMarkId(ctx interface{}, markId interface{}) *MockMarksRepository_GetMarkStatusHistoryByMarkId_Call {
+	return &MockMarksRepository_GetMarkStatusHistoryByMarkId_Call{Call: _e.mock.On("GetMarkStatusHistoryByMarkId", ctx, markId)}
+}
+
+func (_c *MockMarksRepository_GetMarkStatusHistoryByMarkId_Call) Run(run func(ctx context.Context, markId int)) *MockMarksRepository_GetMarkStatusHistoryByMarkId_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int
+		if args[1] != nil {
+			arg1 = args[1].(int)
+		}
+		run(
 			arg0,
 			arg1,
 		)
@@ -521,64 +2745,161 @@ func (_c *MockMarkStatusUpdater_Update_Call) Run(run func(ctx context.Context, m
 	return _c
 }
 
-func (_c *MockMarkStatusUpdater_Update_Call) Return(err error) *MockMarkStatusUpdater_Update_Call {
-	_c.Call.Return(err)
+func (_c *MockMarksRepository_GetMarkStatusHistoryByMarkId_Call) Return(markStatusHistoryItems []models.MarkStatusHistoryItem, err error) *MockMarksRepository_GetMarkStatusHistoryByMarkId_Call {
+	_c.Call.Return(markStatusHistoryItems, err)
+	return _c
+}
+
+func (_c *MockMarksRepository_GetMarkStatusHistoryByMarkId_Call) RunAndReturn(run func(ctx context.Context, markId int) ([]models.MarkStatusHistoryItem, error)) *MockMarksRepository_GetMarkStatusHistoryByMarkId_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetMarkStatuses provides a mock function for the type MockMarksRepository
+func (_mock *MockMarksRepository) GetMarkStatuses(ctx context.Context) ([]models.MarkStatus, error) {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetMarkStatuses")
+	}
+
+	var r0 []models.MarkStatus
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) ([]models.MarkStatus, error)); ok {
+		return returnFunc(ctx)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context) []models.MarkStatus); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.MarkStatus)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = returnFunc(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockMarksRepository_GetMarkStatuses_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetMarkStatuses'
+type MockMarksRepository_GetMarkStatuses_Call struct {
+	*mock.Call
+}
+
+// GetMarkStatuses is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockMarksRepository_Expecter) GetMarkStatuses(ctx interface{}) *MockMarksRepository_GetMarkStatuses_Call {
+	return &MockMarksRepository_GetMarkStatuses_Call{Call: _e.mock.On("GetMarkStatuses", ctx)}
+}
+
+func (_c *MockMarksRepository_GetMarkStatuses_Call) Run(run func(ctx context.Context)) *MockMarksRepository_GetMarkStatuses_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockMarksRepository_GetMarkStatuses_Call) Return(markStatuss []models.MarkStatus, err error) *MockMarksRepository_GetMarkStatuses_Call {
+	_c.Call.Return(markStatuss, err)
 	return _c
 }
 
-func (_c *MockMarkStatusUpdater_Update_Call) RunAndReturn(run func(ctx context.Context, markId int) error) *MockMarkStatusUpdater_Update_Call {
+func (_c *MockMarksRepository_GetMarkStatuses_Call) RunAndReturn(run func(ctx context.Context) ([]models.MarkStatus, error)) *MockMarksRepository_GetMarkStatuses_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// NewMockMapRepository creates a new instance of MockMapRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
-// The first argument is typically a *testing.T value.
-func NewMockMapRepository(t interface {
-	mock.TestingT
-	Cleanup(func())
-}) *MockMapRepository {
-	mock := &MockMapRepository{}
-	mock.Mock.Test(t)
+// GetMarkTypes provides a mock function for the type MockMarksRepository
+func (_mock *MockMarksRepository) GetMarkTypes(ctx context.Context) ([]models.MarkType, error) {
+	ret := _mock.Called(ctx)
 
-	t.Cleanup(func() { mock.AssertExpectations(t) })
+	if len(ret) == 0 {
+		panic("no return value specified for GetMarkTypes")
+	}
 
-	return mock
+	var r0 []models.MarkType
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) ([]models.MarkType, error)); ok {
+		return returnFunc(ctx)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context) []models.MarkType); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.MarkType)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = returnFunc(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
 }
 
-// MockMapRepository is an autogenerated mock type for the MapRepository type
-type MockMapRepository struct {
-	mock.Mock
+// MockMarksRepository_GetMarkTypes_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetMarkTypes'
+type MockMarksRepository_GetMarkTypes_Call struct {
+	*mock.Call
 }
 
-type MockMapRepository_Expecter struct {
-	mock *mock.Mock
+// GetMarkTypes is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockMarksRepository_Expecter) GetMarkTypes(ctx interface{}) *MockMarksRepository_GetMarkTypes_Call {
+	return &MockMarksRepository_GetMarkTypes_Call{Call: _e.mock.On("GetMarkTypes", ctx)}
 }
 
-func (_m *MockMapRepository) EXPECT() *MockMapRepository_Expecter {
-	return &MockMapRepository_Expecter{mock: &_m.Mock}
+func (_c *MockMarksRepository_GetMarkTypes_Call) Run(run func(ctx context.Context)) *MockMarksRepository_GetMarkTypes_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
 }
 
-// GetAdminBoundaries provides a mock function for the type MockMapRepository
-func (_mock *MockMapRepository) GetAdminBoundaries(ctx context.Context, filters models.GetAdminBoundaryFilters) ([]models.AdminBoundary, error) {
+func (_c *MockMarksRepository_GetMarkTypes_Call) Return(markTypes []models.MarkType, err error) *MockMarksRepository_GetMarkTypes_Call {
+	_c.Call.Return(markTypes, err)
+	return _c
+}
+
+func (_c *MockMarksRepository_GetMarkTypes_Call) RunAndReturn(run func(ctx context.Context) ([]models.MarkType, error)) *MockMarksRepository_GetMarkTypes_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetMarks provides a mock function for the type MockMarksRepository
+func (_mock *MockMarksRepository) GetMarks(ctx context.Context, filters models.GetMarksFilters) ([]models.Mark, error) {
 	ret := _mock.Called(ctx, filters)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetAdminBoundaries")
+		panic("no return value specified for GetMarks")
 	}
 
-	var r0 []models.AdminBoundary
+	var r0 []models.Mark
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, models.GetAdminBoundaryFilters) ([]models.AdminBoundary, error)); ok {
+	if returnFunc, ok := ret.Get(0).(func(context.Context, models.GetMarksFilters) ([]models.Mark, error)); ok {
 		return returnFunc(ctx, filters)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, models.GetAdminBoundaryFilters) []models.AdminBoundary); ok {
+	if returnFunc, ok := ret.Get(0).(func(context.Context, models.GetMarksFilters) []models.Mark); ok {
 		r0 = returnFunc(ctx, filters)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]models.AdminBoundary)
+			r0 = ret.Get(0).([]models.Mark)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, models.GetAdminBoundaryFilters) error); ok {
+	if returnFunc, ok := ret.Get(1).(func(context.Context, models.GetMarksFilters) error); ok {
 		r1 = returnFunc(ctx, filters)
 	} else {
 		r1 = ret.Error(1)
@@ -586,27 +2907,27 @@ func (_mock *MockMapRepository) GetAdminBoundaries(ctx context.Context, filters
 	return r0, r1
 }
 
-// MockMapRepository_GetAdminBoundaries_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAdminBoundaries'
-type MockMapRepository_GetAdminBoundaries_Call struct {
+// MockMarksRepository_GetMarks_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetMarks'
+type MockMarksRepository_GetMarks_Call struct {
 	*mock.Call
 }
 
-// GetAdminBoundaries is a helper method to define mock.On call
+// GetMarks is a helper method to define mock.On call
 //   - ctx context.Context
-//   - filters models.GetAdminBoundaryFilters
-func (_e *MockMapRepository_Expecter) GetAdminBoundaries(ctx interface{}, filters interface{}) *MockMapRepository_GetAdminBoundaries_Call {
-	return &MockMapRepository_GetAdminBoundaries_Call{Call: _e.mock.On("GetAdminBoundaries", ctx, filters)}
+//   - filters models.GetMarksFilters
+func (_e *MockMarksRepository_Expecter) GetMarks(ctx interface{}, filters interface{}) *MockMarksRepository_GetMarks_Call {
+	return &MockMarksRepository_GetMarks_Call{Call: _e.mock.On("GetMarks", ctx, filters)}
 }
 
-func (_c *MockMapRepository_GetAdminBoundaries_Call) Run(run func(ctx context.Context, filters models.GetAdminBoundaryFilters)) *MockMapRepository_GetAdminBoundaries_Call {
+func (_c *MockMarksRepository_GetMarks_Call) Run(run func(ctx context.Context, filters models.GetMarksFilters)) *MockMarksRepository_GetMarks_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 models.GetAdminBoundaryFilters
+		var arg1 models.GetMarksFilters
 		if args[1] != nil {
-			arg1 = args[1].(models.GetAdminBoundaryFilters)
+			arg1 = args[1].(models.GetMarksFilters)
 		}
 		run(
 			arg0,
@@ -616,344 +2937,411 @@ func (_c *MockMapRepository_GetAdminBoundaries_Call) Run(run func(ctx context.Co
 	return _c
 }
 
-func (_c *MockMapRepository_GetAdminBoundaries_Call) Return(adminBoundarys []models.AdminBoundary, err error) *MockMapRepository_GetAdminBoundaries_Call {
-	_c.Call.Return(adminBoundarys, err)
+func (_c *MockMarksRepository_GetMarks_Call) Return(marks []models.Mark, err error) *MockMarksRepository_GetMarks_Call {
+	_c.Call.Return(marks, err)
 	return _c
 }
 
-func (_c *MockMapRepository_GetAdminBoundaries_Call) RunAndReturn(run func(ctx context.Context, filters models.GetAdminBoundaryFilters) ([]models.AdminBoundary, error)) *MockMapRepository_GetAdminBoundaries_Call {
+func (_c *MockMarksRepository_GetMarks_Call) RunAndReturn(run func(ctx context.Context, filters models.GetMarksFilters) ([]models.Mark, error)) *MockMarksRepository_GetMarks_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetAdminBoundariesMarksCount provides a mock function for the type MockMapRepository
-func (_mock *MockMapRepository) GetAdminBoundariesMarksCount(ctx context.Context, filters models.GetAdminBoundaryMarksCountFilters) ([]models.AdminBoundaryMarksCount, error) {
-	ret := _mock.Called(ctx, filters)
+// GetMarksByUserId provides a mock function for the type MockMarksRepository
+func (_mock *MockMarksRepository) GetMarksByUserId(ctx context.Context, userId int, pagination models.Pagination) ([]models.Mark, error) {
+	ret := _mock.Called(ctx, userId, pagination)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetAdminBoundariesMarksCount")
+		panic("no return value specified for GetMarksByUserId")
 	}
 
-	var r0 []models.AdminBoundaryMarksCount
+	var r0 []models.Mark
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, models.GetAdminBoundaryMarksCountFilters) ([]models.AdminBoundaryMarksCount, error)); ok {
-		return returnFunc(ctx, filters)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int, models.Pagination) ([]models.Mark, error)); ok {
+		return returnFunc(ctx, userId, pagination)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, models.GetAdminBoundaryMarksCountFilters) []models.AdminBoundaryMarksCount); ok {
-		r0 = returnFunc(ctx, filters)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int, models.Pagination) []models.Mark); ok {
+		r0 = returnFunc(ctx, userId, pagination)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]models.AdminBoundaryMarksCount)
+			r0 = ret.Get(0).([]models.Mark)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, models.GetAdminBoundaryMarksCountFilters) error); ok {
-		r1 = returnFunc(ctx, filters)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int, models.Pagination) error); ok {
+		r1 = returnFunc(ctx, userId, pagination)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockMapRepository_GetAdminBoundariesMarksCount_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAdminBoundariesMarksCount'
-type MockMapRepository_GetAdminBoundariesMarksCount_Call struct {
+// MockMarksRepository_GetMarksByUserId_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetMarksByUserId'
+type MockMarksRepository_GetMarksByUserId_Call struct {
 	*mock.Call
 }
 
-// GetAdminBoundariesMarksCount is a helper method to define mock.On call
+// GetMarksByUserId is a helper method to define mock.On call
 //   - ctx context.Context
-//   - filters models.GetAdminBoundaryMarksCountFilters
-func (_e *MockMapRepository_Expecter) GetAdminBoundariesMarksCount(ctx interface{}, filters interface{}) *MockMapRepository_GetAdminBoundariesMarksCount_Call {
-	return &MockMapRepository_GetAdminBoundariesMarksCount_Call{Call: _e.mock.On("GetAdminBoundariesMarksCount", ctx, filters)}
+//   - userId int
+//   - pagination models.Pagination
+func (_e *MockMarksRepository_Expecter) GetMarksByUserId(ctx interface{}, userId interface{}, pagination interface{}) *MockMarksRepository_GetMarksByUserId_Call {
+	return &MockMarksRepository_GetMarksByUserId_Call{Call: _e.mock.On("GetMarksByUserId", ctx, userId, pagination)}
 }
 
-func (_c *MockMapRepository_GetAdminBoundariesMarksCount_Call) Run(run func(ctx context.Context, filters models.GetAdminBoundaryMarksCountFilters)) *MockMapRepository_GetAdminBoundariesMarksCount_Call {
+func (_c *MockMarksRepository_GetMarksByUserId_Call) Run(run func(ctx context.Context, userId int, pagination models.Pagination)) *MockMarksRepository_GetMarksByUserId_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 models.GetAdminBoundaryMarksCountFilters
+		var arg1 int
 		if args[1] != nil {
-			arg1 = args[1].(models.GetAdminBoundaryMarksCountFilters)
+			arg1 = args[1].(int)
+		}
+		var arg2 models.Pagination
+		if args[2] != nil {
+			arg2 = args[2].(models.Pagination)
 		}
 		run(
 			arg0,
 			arg1,
+			arg2,
 		)
 	})
 	return _c
 }
 
-func (_c *MockMapRepository_GetAdminBoundariesMarksCount_Call) Return(adminBoundaryMarksCounts []models.AdminBoundaryMarksCount, err error) *MockMapRepository_GetAdminBoundariesMarksCount_Call {
-	_c.Call.Return(adminBoundaryMarksCounts, err)
+func (_c *MockMarksRepository_GetMarksByUserId_Call) Return(marks []models.Mark, err error) *MockMarksRepository_GetMarksByUserId_Call {
+	_c.Call.Return(marks, err)
 	return _c
 }
 
-func (_c *MockMapRepository_GetAdminBoundariesMarksCount_Call) RunAndReturn(run func(ctx context.Context, filters models.GetAdminBoundaryMarksCountFilters) ([]models.AdminBoundaryMarksCount, error)) *MockMapRepository_GetAdminBoundariesMarksCount_Call {
+func (_c *MockMarksRepository_GetMarksByUserId_Call) RunAndReturn(run func(ctx context.Context, userId int, pagination models.Pagination) ([]models.Mark, error)) *MockMarksRepository_GetMarksByUserId_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetCities provides a mock function for the type MockMapRepository
-func (_mock *MockMapRepository) GetCities(ctx context.Context) ([]models.City, error) {
-	ret := _mock.Called(ctx)
+// GetMergedMarkId provides a mock function for the type MockMarksRepository
+func (_mock *MockMarksRepository) GetMergedMarkId(ctx context.Context, id int) (int, error) {
+	ret := _mock.Called(ctx, id)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetCities")
+		panic("no return value specified for GetMergedMarkId")
 	}
 
-	var r0 []models.City
+	var r0 int
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context) ([]models.City, error)); ok {
-		return returnFunc(ctx)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int) (int, error)); ok {
+		return returnFunc(ctx, id)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context) []models.City); ok {
-		r0 = returnFunc(ctx)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int) int); ok {
+		r0 = returnFunc(ctx, id)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]models.City)
-		}
+		r0 = ret.Get(0).(int)
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
-		r1 = returnFunc(ctx)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = returnFunc(ctx, id)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockMapRepository_GetCities_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetCities'
-type MockMapRepository_GetCities_Call struct {
+// MockMarksRepository_GetMergedMarkId_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetMergedMarkId'
+type MockMarksRepository_GetMergedMarkId_Call struct {
 	*mock.Call
 }
 
-// GetCities is a helper method to define mock.On call
+// GetMergedMarkId is a helper method to define mock.On call
 //   - ctx context.Context
-func (_e *MockMapRepository_Expecter) GetCities(ctx interface{}) *MockMapRepository_GetCities_Call {
-	return &MockMapRepository_GetCities_Call{Call: _e.mock.On("GetCities", ctx)}
+//   - id int
+func (_e *MockMarksRepository_Expecter) GetMergedMarkId(ctx interface{}, id interface{}) *MockMarksRepository_GetMergedMarkId_Call {
+	return &MockMarksRepository_GetMergedMarkId_Call{Call: _e.mock.On("GetMergedMarkId", ctx, id)}
 }
 
-func (_c *MockMapRepository_GetCities_Call) Run(run func(ctx context.Context)) *MockMapRepository_GetCities_Call {
+func (_c *MockMarksRepository_GetMergedMarkId_Call) Run(run func(ctx context.Context, id int)) *MockMarksRepository_GetMergedMarkId_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
+		var arg1 int
+		if args[1] != nil {
+			arg1 = args[1].(int)
+		}
 		run(
 			arg0,
+			arg1,
 		)
 	})
 	return _c
 }
 
-func (_c *MockMapRepository_GetCities_Call) Return(citys []models.City, err error) *MockMapRepository_GetCities_Call {
-	_c.Call.Return(citys, err)
+func (_c *MockMarksRepository_GetMergedMarkId_Call) Return(n int, err error) *MockMarksRepository_GetMergedMarkId_Call {
+	_c.Call.Return(n, err)
 	return _c
 }
 
-func (_c *MockMapRepository_GetCities_Call) RunAndReturn(run func(ctx context.Context) ([]models.City, error)) *MockMapRepository_GetCities_Call {
+func (_c *MockMarksRepository_GetMergedMarkId_Call) RunAndReturn(run func(ctx context.Context, id int) (int, error)) *MockMarksRepository_GetMergedMarkId_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetDistricts provides a mock function for the type MockMapRepository
-func (_mock *MockMapRepository) GetDistricts(ctx context.Context) ([]models.District, error) {
-	ret := _mock.Called(ctx)
+// GetNearbyMarks provides a mock function for the type MockMarksRepository
+func (_mock *MockMarksRepository) GetNearbyMarks(ctx context.Context, filters models.GetNearbyMarksFilters) ([]models.NearbyMark, error) {
+	ret := _mock.Called(ctx, filters)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetDistricts")
+		panic("no return value specified for GetNearbyMarks")
 	}
 
-	var r0 []models.District
+	var r0 []models.NearbyMark
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context) ([]models.District, error)); ok {
-		return returnFunc(ctx)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, models.GetNearbyMarksFilters) ([]models.NearbyMark, error)); ok {
+		return returnFunc(ctx, filters)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context) []models.District); ok {
-		r0 = returnFunc(ctx)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, models.GetNearbyMarksFilters) []models.NearbyMark); ok {
+		r0 = returnFunc(ctx, filters)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]models.District)
+			r0 = ret.Get(0).([]models.NearbyMark)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
-		r1 = returnFunc(ctx)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, models.GetNearbyMarksFilters) error); ok {
+		r1 = returnFunc(ctx, filters)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockMapRepository_GetDistricts_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetDistricts'
-type MockMapRepository_GetDistricts_Call struct {
+// MockMarksRepository_GetNearbyMarks_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetNearbyMarks'
+type MockMarksRepository_GetNearbyMarks_Call struct {
 	*mock.Call
 }
 
-// GetDistricts is a helper method to define mock.On call
+// GetNearbyMarks is a helper method to define mock.On call
 //   - ctx context.Context
-func (_e *MockMapRepository_Expecter) GetDistricts(ctx interface{}) *MockMapRepository_GetDistricts_Call {
-	return &MockMapRepository_GetDistricts_Call{Call: _e.mock.On("GetDistricts", ctx)}
+//   - filters models.GetNearbyMarksFilters
+func (_e *MockMarksRepository_Expecter) GetNearbyMarks(ctx interface{}, filters interface{}) *MockMarksRepository_GetNearbyMarks_Call {
+	return &MockMarksRepository_GetNearbyMarks_Call{Call: _e.mock.On("GetNearbyMarks", ctx, filters)}
 }
 
-func (_c *MockMapRepository_GetDistricts_Call) Run(run func(ctx context.Context)) *MockMapRepository_GetDistricts_Call {
+func (_c *MockMarksRepository_GetNearbyMarks_Call) Run(run func(ctx context.Context, filters models.GetNearbyMarksFilters)) *MockMarksRepository_GetNearbyMarks_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 models.GetNearbyMarksFilters
+		if args[1] != nil {
+			arg1 = args[1].(models.GetNearbyMarksFilters)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockMarksRepository_GetNearbyMarks_Call) Return(nearbyMarks []models.NearbyMark, err error) *MockMarksRepository_GetNearbyMarks_Call {
+	_c.Call.Return(nearbyMarks, err)
+	return _c
+}
+
+func (_c *MockMarksRepository_GetNearbyMarks_Call) RunAndReturn(run func(ctx context.Context, filters models.GetNearbyMarksFilters) ([]models.NearbyMark, error)) *MockMarksRepository_GetNearbyMarks_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// MergeMarks provides a mock function for the type MockMarksRepository
+func (_mock *MockMarksRepository) MergeMarks(ctx context.Context, markId int, mergedMarkIds []int) error {
+	ret := _mock.Called(ctx, markId, mergedMarkIds)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MergeMarks")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int, []int) error); ok {
+		r0 = returnFunc(ctx, markId, mergedMarkIds)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockMarksRepository_MergeMarks_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MergeMarks'
+type MockMarksRepository_MergeMarks_Call struct {
+	*mock.Call
+}
+
+// MergeMarks is a helper method to define mock.On call
+//   - ctx context.Context
+//   - markId int
+//   - mergedMarkIds []int
+func (_e *MockMarksRepository_Expecter) MergeMarks(ctx interface{}, markId interface{}, mergedMarkIds interface{}) *MockMarksRepository_MergeMarks_Call {
+	return &MockMarksRepository_MergeMarks_Call{Call: _e.mock.On("MergeMarks", ctx, markId, mergedMarkIds)}
+}
+
+func (_c *MockMarksRepository_MergeMarks_Call) Run(run func(ctx context.Context, markId int, mergedMarkIds []int)) *MockMarksRepository_MergeMarks_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
+		var arg1 int
+		if args[1] != nil {
+			arg1 = args[1].(int)
+		}
+		var arg2 []int
+		if args[2] != nil {
+			arg2 = args[2].([]int)
+		}
 		run(
 			arg0,
+			arg1,
+			arg2,
 		)
 	})
 	return _c
 }
 
-func (_c *MockMapRepository_GetDistricts_Call) Return(districts []models.District, err error) *MockMapRepository_GetDistricts_Call {
-	_c.Call.Return(districts, err)
+func (_c *MockMarksRepository_MergeMarks_Call) Return(err error) *MockMarksRepository_MergeMarks_Call {
+	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockMapRepository_GetDistricts_Call) RunAndReturn(run func(ctx context.Context) ([]models.District, error)) *MockMapRepository_GetDistricts_Call {
+func (_c *MockMarksRepository_MergeMarks_Call) RunAndReturn(run func(ctx context.Context, markId int, mergedMarkIds []int) error) *MockMarksRepository_MergeMarks_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetRegions provides a mock function for the type MockMapRepository
-func (_mock *MockMapRepository) GetRegions(ctx context.Context) ([]models.Region, error) {
-	ret := _mock.Called(ctx)
+// ResolveMarkStatus provides a mock function for the type MockMarksRepository
+func (_mock *MockMarksRepository) ResolveMarkStatus(ctx context.Context, markId int, oldStatusId models.MarkStatusType, newStatusId models.MarkStatusType, outcomes []models.UserCheckOutcome) error {
+	ret := _mock.Called(ctx, markId, oldStatusId, newStatusId, outcomes)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetRegions")
+		panic("no return value specified for ResolveMarkStatus")
 	}
 
-	var r0 []models.Region
-	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context) ([]models.Region, error)); ok {
-		return returnFunc(ctx)
-	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context) []models.Region); ok {
-		r0 = returnFunc(ctx)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]models.Region)
-		}
-	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
-		r1 = returnFunc(ctx)
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int, models.MarkStatusType, models.MarkStatusType, []models.UserCheckOutcome) error); ok {
+		r0 = returnFunc(ctx, markId, oldStatusId, newStatusId, outcomes)
 	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Error(0)
 	}
-	return r0, r1
+	return r0
 }
 
-// MockMapRepository_GetRegions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetRegions'
-type MockMapRepository_GetRegions_Call struct {
+// MockMarksRepository_ResolveMarkStatus_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ResolveMarkStatus'
+type MockMarksRepository_ResolveMarkStatus_Call struct {
 	*mock.Call
 }
 
-// GetRegions is a helper method to define mock.On call
+// ResolveMarkStatus is a helper method to define mock.On call
 //   - ctx context.Context
-func (_e *MockMapRepository_Expecter) GetRegions(ctx interface{}) *MockMapRepository_GetRegions_Call {
-	return &MockMapRepository_GetRegions_Call{Call: _e.mock.On("GetRegions", ctx)}
+//   - markId int
+//   - oldStatusId models.MarkStatusType
+//   - newStatusId models.MarkStatusType
+//   - outcomes []models.UserCheckOutcome
+func (_e *MockMarksRepository_Expecter) ResolveMarkStatus(ctx interface{}, markId interface{}, oldStatusId interface{}, newStatusId interface{}, outcomes interface{}) *MockMarksRepository_ResolveMarkStatus_Call {
+	return &MockMarksRepository_ResolveMarkStatus_Call{Call: _e.mock.On("ResolveMarkStatus", ctx, markId, oldStatusId, newStatusId, outcomes)}
 }
 
-func (_c *MockMapRepository_GetRegions_Call) Run(run func(ctx context.Context)) *MockMapRepository_GetRegions_Call {
+func (_c *MockMarksRepository_ResolveMarkStatus_Call) Run(run func(ctx context.Context, markId int, oldStatusId models.MarkStatusType, newStatusId models.MarkStatusType, outcomes []models.UserCheckOutcome)) *MockMarksRepository_ResolveMarkStatus_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
+		var arg1 int
+		if args[1] != nil {
+			arg1 = args[1].(int)
+		}
+		var arg2 models.MarkStatusType
+		if args[2] != nil {
+			arg2 = args[2].(models.MarkStatusType)
+		}
+		var arg3 models.MarkStatusType
+		if args[3] != nil {
+			arg3 = args[3].(models.MarkStatusType)
+		}
+		var arg4 []models.UserCheckOutcome
+		if args[4] != nil {
+			arg4 = args[4].([]models.UserCheckOutcome)
+		}
 		run(
 			arg0,
+			arg1,
+			arg2,
+			arg3,
+			arg4,
 		)
 	})
 	return _c
 }
 
-func (_c *MockMapRepository_GetRegions_Call) Return(regions []models.Region, err error) *MockMapRepository_GetRegions_Call {
-	_c.Call.Return(regions, err)
+func (_c *MockMarksRepository_ResolveMarkStatus_Call) Return(err error) *MockMarksRepository_ResolveMarkStatus_Call {
+	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockMapRepository_GetRegions_Call) RunAndReturn(run func(ctx context.Context) ([]models.Region, error)) *MockMapRepository_GetRegions_Call {
+func (_c *MockMarksRepository_ResolveMarkStatus_Call) RunAndReturn(run func(ctx context.Context, markId int, oldStatusId models.MarkStatusType, newStatusId models.MarkStatusType, outcomes []models.UserCheckOutcome) error) *MockMarksRepository_ResolveMarkStatus_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// NewMockMarksRepository creates a new instance of MockMarksRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
-// The first argument is typically a *testing.T value.
-func NewMockMarksRepository(t interface {
-	mock.TestingT
-	Cleanup(func())
-}) *MockMarksRepository {
-	mock := &MockMarksRepository{}
-	mock.Mock.Test(t)
-
-	t.Cleanup(func() { mock.AssertExpectations(t) })
-
-	return mock
-}
-
-// MockMarksRepository is an autogenerated mock type for the MarksRepository type
-type MockMarksRepository struct {
-	mock.Mock
-}
-
-type MockMarksRepository_Expecter struct {
-	mock *mock.Mock
-}
-
-func (_m *MockMarksRepository) EXPECT() *MockMarksRepository_Expecter {
-	return &MockMarksRepository_Expecter{mock: &_m.Mock}
-}
-
-// AddMark provides a mock function for the type MockMarksRepository
-func (_mock *MockMarksRepository) AddMark(ctx context.Context, mark models.Mark) (int64, error) {
-	ret := _mock.Called(ctx, mark)
+// SearchMarks provides a mock function for the type MockMarksRepository
+func (_mock *MockMarksRepository) SearchMarks(ctx context.Context, filters models.SearchMarksFilters) ([]models.FoundMark, error) {
+	ret := _mock.Called(ctx, filters)
 
 	if len(ret) == 0 {
-		panic("no return value specified for AddMark")
+		panic("no return value specified for SearchMarks")
 	}
 
-	var r0 int64
+	var r0 []models.FoundMark
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, models.Mark) (int64, error)); ok {
-		return returnFunc(ctx, mark)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, models.SearchMarksFilters) ([]models.FoundMark, error)); ok {
+		return returnFunc(ctx, filters)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, models.Mark) int64); ok {
-		r0 = returnFunc(ctx, mark)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, models.SearchMarksFilters) []models.FoundMark); ok {
+		r0 = returnFunc(ctx, filters)
 	} else {
-		r0 = ret.Get(0).(int64)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.FoundMark)
+		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, models.Mark) error); ok {
-		r1 = returnFunc(ctx, mark)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, models.SearchMarksFilters) error); ok {
+		r1 = returnFunc(ctx, filters)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockMarksRepository_AddMark_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddMark'
-type MockMarksRepository_AddMark_Call struct {
+// MockMarksRepository_SearchMarks_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SearchMarks'
+type MockMarksRepository_SearchMarks_Call struct {
 	*mock.Call
 }
 
-// AddMark is a helper method to define mock.On call
+// SearchMarks is a helper method to define mock.On call
 //   - ctx context.Context
-//   - mark models.Mark
-func (_e *MockMarksRepository_Expecter) AddMark(ctx interface{}, mark interface{}) *MockMarksRepository_AddMark_Call {
-	return &MockMarksRepository_AddMark_Call{Call: _e.mock.On("AddMark", ctx, mark)}
+//   - filters models.SearchMarksFilters
+func (_e *MockMarksRepository_Expecter) SearchMarks(ctx interface{}, filters interface{}) *MockMarksRepository_SearchMarks_Call {
+	return &MockMarksRepository_SearchMarks_Call{Call: _e.mock.On("SearchMarks", ctx, filters)}
 }
 
-func (_c *MockMarksRepository_AddMark_Call) Run(run func(ctx context.Context, mark models.Mark)) *MockMarksRepository_AddMark_Call {
+func (_c *MockMarksRepository_SearchMarks_Call) Run(run func(ctx context.Context, filters models.SearchMarksFilters)) *MockMarksRepository_SearchMarks_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 models.Mark
+		var arg1 models.SearchMarksFilters
 		if args[1] != nil {
-			arg1 = args[1].(models.Mark)
+			arg1 = args[1].(models.SearchMarksFilters)
 		}
 		run(
 			arg0,
@@ -963,55 +3351,47 @@ func (_c *MockMarksRepository_AddMark_Call) Run(run func(ctx context.Context, ma
 	return _c
 }
 
-func (_c *MockMarksRepository_AddMark_Call) Return(n int64, err error) *MockMarksRepository_AddMark_Call {
-	_c.Call.Return(n, err)
+func (_c *MockMarksRepository_SearchMarks_Call) Return(foundMarks []models.FoundMark, err error) *MockMarksRepository_SearchMarks_Call {
+	_c.Call.Return(foundMarks, err)
 	return _c
 }
 
-func (_c *MockMarksRepository_AddMark_Call) RunAndReturn(run func(ctx context.Context, mark models.Mark) (int64, error)) *MockMarksRepository_AddMark_Call {
+func (_c *MockMarksRepository_SearchMarks_Call) RunAndReturn(run func(ctx context.Context, filters models.SearchMarksFilters) ([]models.FoundMark, error)) *MockMarksRepository_SearchMarks_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetLastMarkStatusHistoryItem provides a mock function for the type MockMarksRepository
-func (_mock *MockMarksRepository) GetLastMarkStatusHistoryItem(ctx context.Context, markId int) (models.MarkStatusHistoryItem, error) {
-	ret := _mock.Called(ctx, markId)
+// UpdateMark provides a mock function for the type MockMarksRepository
+func (_mock *MockMarksRepository) UpdateMark(ctx context.Context, markId int, update models.MarkUpdate) error {
+	ret := _mock.Called(ctx, markId, update)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetLastMarkStatusHistoryItem")
+		panic("no return value specified for UpdateMark")
 	}
 
-	var r0 models.MarkStatusHistoryItem
-	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, int) (models.MarkStatusHistoryItem, error)); ok {
-		return returnFunc(ctx, markId)
-	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, int) models.MarkStatusHistoryItem); ok {
-		r0 = returnFunc(ctx, markId)
-	} else {
-		r0 = ret.Get(0).(models.MarkStatusHistoryItem)
-	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, int) error); ok {
-		r1 = returnFunc(ctx, markId)
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int, models.MarkUpdate) error); ok {
+		r0 = returnFunc(ctx, markId, update)
 	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Error(0)
 	}
-	return r0, r1
+	return r0
 }
 
-// MockMarksRepository_GetLastMarkStatusHistoryItem_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetLastMarkStatusHistoryItem'
-type MockMarksRepository_GetLastMarkStatusHistoryItem_Call struct {
+// MockMarksRepository_UpdateMark_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateMark'
+type MockMarksRepository_UpdateMark_Call struct {
 	*mock.Call
 }
 
-// GetLastMarkStatusHistoryItem is a helper method to define mock.On call
+// UpdateMark is a helper method to define mock.On call
 //   - ctx context.Context
 //   - markId int
-func (_e *MockMarksRepository_Expecter) GetLastMarkStatusHistoryItem(ctx interface{}, markId interface{}) *MockMarksRepository_GetLastMarkStatusHistoryItem_Call {
-	return &MockMarksRepository_GetLastMarkStatusHistoryItem_Call{Call: _e.mock.On("GetLastMarkStatusHistoryItem", ctx, markId)}
+//   - update models.MarkUpdate
+func (_e *MockMarksRepository_Expecter) UpdateMark(ctx interface{}, markId interface{}, update interface{}) *MockMarksRepository_UpdateMark_Call {
+	return &MockMarksRepository_UpdateMark_Call{Call: _e.mock.On("UpdateMark", ctx, markId, update)}
 }
 
-func (_c *MockMarksRepository_GetLastMarkStatusHistoryItem_Call) Run(run func(ctx context.Context, markId int)) *MockMarksRepository_GetLastMarkStatusHistoryItem_Call {
+func (_c *MockMarksRepository_UpdateMark_Call) Run(run func(ctx context.Context, markId int, update models.MarkUpdate)) *MockMarksRepository_UpdateMark_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -1021,64 +3401,60 @@ func (_c *MockMarksRepository_GetLastMarkStatusHistoryItem_Call) Run(run func(ct
 		if args[1] != nil {
 			arg1 = args[1].(int)
 		}
+		var arg2 models.MarkUpdate
+		if args[2] != nil {
+			arg2 = args[2].(models.MarkUpdate)
+		}
 		run(
 			arg0,
 			arg1,
+			arg2,
 		)
 	})
 	return _c
 }
 
-func (_c *MockMarksRepository_GetLastMarkStatusHistoryItem_Call) Return(markStatusHistoryItem models.MarkStatusHistoryItem, err error) *MockMarksRepository_GetLastMarkStatusHistoryItem_Call {
-	_c.Call.Return(markStatusHistoryItem, err)
+func (_c *MockMarksRepository_UpdateMark_Call) Return(err error) *MockMarksRepository_UpdateMark_Call {
+	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockMarksRepository_GetLastMarkStatusHistoryItem_Call) RunAndReturn(run func(ctx context.Context, markId int) (models.MarkStatusHistoryItem, error)) *MockMarksRepository_GetLastMarkStatusHistoryItem_Call {
+func (_c *MockMarksRepository_UpdateMark_Call) RunAndReturn(run func(ctx context.Context, markId int, update models.MarkUpdate) error) *MockMarksRepository_UpdateMark_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetLastMarkStatusHistoryItemWithStatus provides a mock function for the type MockMarksRepository
-func (_mock *MockMarksRepository) GetLastMarkStatusHistoryItemWithStatus(ctx context.Context, markId int, newMarkStatusId models.MarkStatusType) (models.MarkStatusHistoryItem, error) {
-	ret := _mock.Called(ctx, markId, newMarkStatusId)
+// UpdateMarkStatus provides a mock function for the type MockMarksRepository
+func (_mock *MockMarksRepository) UpdateMarkStatus(ctx context.Context, markId int, markStatusId models.MarkStatusType) error {
+	ret := _mock.Called(ctx, markId, markStatusId)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetLastMarkStatusHistoryItemWithStatus")
+		panic("no return value specified for UpdateMarkStatus")
 	}
 
-	var r0 models.MarkStatusHistoryItem
-	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, int, models.MarkStatusType) (models.MarkStatusHistoryItem, error)); ok {
-		return returnFunc(ctx, markId, newMarkStatusId)
-	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, int, models.MarkStatusType) models.MarkStatusHistoryItem); ok {
-		r0 = returnFunc(ctx, markId, newMarkStatusId)
-	} else {
-		r0 = ret.Get(0).(models.MarkStatusHistoryItem)
-	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, int, models.MarkStatusType) error); ok {
-		r1 = returnFunc(ctx, markId, newMarkStatusId)
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int, models.MarkStatusType) error); ok {
+		r0 = returnFunc(ctx, markId, markStatusId)
 	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Error(0)
 	}
-	return r0, r1
+	return r0
 }
 
-// MockMarksRepository_GetLastMarkStatusHistoryItemWithStatus_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetLastMarkStatusHistoryItemWithStatus'
-type MockMarksRepository_GetLastMarkStatusHistoryItemWithStatus_Call struct {
+// MockMarksRepository_UpdateMarkStatus_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateMarkStatus'
+type MockMarksRepository_UpdateMarkStatus_Call struct {
 	*mock.Call
 }
 
-// GetLastMarkStatusHistoryItemWithStatus is a helper method to define mock.On call
+// UpdateMarkStatus is a helper method to define mock.On call
 //   - ctx context.Context
 //   - markId int
-//   - newMarkStatusId models.MarkStatusType
-func (_e *MockMarksRepository_Expecter) GetLastMarkStatusHistoryItemWithStatus(ctx interface{}, markId interface{}, newMarkStatusId interface{}) *MockMarksRepository_GetLastMarkStatusHistoryItemWithStatus_Call {
-	return &MockMarksRepository_GetLastMarkStatusHistoryItemWithStatus_Call{Call: _e.mock.On("GetLastMarkStatusHistoryItemWithStatus", ctx, markId, newMarkStatusId)}
+//   - markStatusId models.MarkStatusType
+func (_e *MockMarksRepository_Expecter) UpdateMarkStatus(ctx interface{}, markId interface{}, markStatusId interface{}) *MockMarksRepository_UpdateMarkStatus_Call {
+	return &MockMarksRepository_UpdateMarkStatus_Call{Call: _e.mock.On("UpdateMarkStatus", ctx, markId, markStatusId)}
 }
 
-func (_c *MockMarksRepository_GetLastMarkStatusHistoryItemWithStatus_Call) Run(run func(ctx context.Context, markId int, newMarkStatusId models.MarkStatusType)) *MockMarksRepository_GetLastMarkStatusHistoryItemWithStatus_Call {
+func (_c *MockMarksRepository_UpdateMarkStatus_Call) Run(run func(ctx context.Context, markId int, markStatusId models.MarkStatusType)) *MockMarksRepository_UpdateMarkStatus_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -1101,55 +3477,87 @@ func (_c *MockMarksRepository_GetLastMarkStatusHistoryItemWithStatus_Call) Run(r
 	return _c
 }
 
-func (_c *MockMarksRepository_GetLastMarkStatusHistoryItemWithStatus_Call) Return(markStatusHistoryItem models.MarkStatusHistoryItem, err error) *MockMarksRepository_GetLastMarkStatusHistoryItemWithStatus_Call {
-	_c.Call.Return(markStatusHistoryItem, err)
+func (_c *MockMarksRepository_UpdateMarkStatus_Call) Return(err error) *MockMarksRepository_UpdateMarkStatus_Call {
+	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockMarksRepository_GetLastMarkStatusHistoryItemWithStatus_Call) RunAndReturn(run func(ctx context.Context, markId int, newMarkStatusId models.MarkStatusType) (models.MarkStatusHistoryItem, error)) *MockMarksRepository_GetLastMarkStatusHistoryItemWithStatus_Call {
+func (_c *MockMarksRepository_UpdateMarkStatus_Call) RunAndReturn(run func(ctx context.Context, markId int, markStatusId models.MarkStatusType) error) *MockMarksRepository_UpdateMarkStatus_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetMarkById provides a mock function for the type MockMarksRepository
-func (_mock *MockMarksRepository) GetMarkById(ctx context.Context, id int) (models.Mark, error) {
-	ret := _mock.Called(ctx, id)
+// NewMockPhotosRepository creates a new instance of MockPhotosRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockPhotosRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockPhotosRepository {
+	mock := &MockPhotosRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockPhotosRepository is an autogenerated mock type for the PhotosRepository type
+type MockPhotosRepository struct {
+	mock.Mock
+}
+
+type MockPhotosRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockPhotosRepository) EXPECT() *MockPhotosRepository_Expecter {
+	return &MockPhotosRepository_Expecter{mock: &_m.Mock}
+}
+
+// AddPhotos provides a mock function for the type MockPhotosRepository
+func (_mock *MockPhotosRepository) AddPhotos(ctx context.Context, markId int, checkId int, first int, photos []io.Reader) ([]models.PhotoRecord, error) {
+	ret := _mock.Called(ctx, markId, checkId, first, photos)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetMarkById")
+		panic("no return value specified for AddPhotos")
 	}
 
-	var r0 models.Mark
+	var r0 []models.PhotoRecord
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, int) (models.Mark, error)); ok {
-		return returnFunc(ctx, id)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int, int, int, []io.Reader) ([]models.PhotoRecord, error)); ok {
+		return returnFunc(ctx, markId, checkId, first, photos)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, int) models.Mark); ok {
-		r0 = returnFunc(ctx, id)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int, int, int, []io.Reader) []models.PhotoRecord); ok {
+		r0 = returnFunc(ctx, markId, checkId, first, photos)
 	} else {
-		r0 = ret.Get(0).(models.Mark)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.PhotoRecord)
+		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, int) error); ok {
-		r1 = returnFunc(ctx, id)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int, int, int, []io.Reader) error); ok {
+		r1 = returnFunc(ctx, markId, checkId, first, photos)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockMarksRepository_GetMarkById_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetMarkById'
-type MockMarksRepository_GetMarkById_Call struct {
+// MockPhotosRepository_AddPhotos_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddPhotos'
+type MockPhotosRepository_AddPhotos_Call struct {
 	*mock.Call
 }
 
-// GetMarkById is a helper method to define mock.On call
+// AddPhotos is a helper method to define mock.On call
 //   - ctx context.Context
-//   - id int
-func (_e *MockMarksRepository_Expecter) GetMarkById(ctx interface{}, id interface{}) *MockMarksRepository_GetMarkById_Call {
-	return &MockMarksRepository_GetMarkById_Call{Call: _e.mock.On("GetMarkById", ctx, id)}
+//   - markId int
+//   - checkId int
+//   - first int
+//   - photos []io.Reader
+func (_e *MockPhotosRepository_Expecter) AddPhotos(ctx interface{}, markId interface{}, checkId interface{}, first interface{}, photos interface{}) *MockPhotosRepository_AddPhotos_Call {
+	return &MockPhotosRepository_AddPhotos_Call{Call: _e.mock.On("AddPhotos", ctx, markId, checkId, first, photos)}
 }
 
-func (_c *MockMarksRepository_GetMarkById_Call) Run(run func(ctx context.Context, id int)) *MockMarksRepository_GetMarkById_Call {
+func (_c *MockPhotosRepository_AddPhotos_Call) Run(run func(ctx context.Context, markId int, checkId int, first int, photos []io.Reader)) *MockPhotosRepository_AddPhotos_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -1159,65 +3567,81 @@ func (_c *MockMarksRepository_GetMarkById_Call) Run(run func(ctx context.Context
 		if args[1] != nil {
 			arg1 = args[1].(int)
 		}
+		var arg2 int
+		if args[2] != nil {
+			arg2 = args[2].(int)
+		}
+		var arg3 int
+		if args[3] != nil {
+			arg3 = args[3].(int)
+		}
+		var arg4 []io.Reader
+		if args[4] != nil {
+			arg4 = args[4].([]io.Reader)
+		}
 		run(
 			arg0,
 			arg1,
+			arg2,
+			arg3,
+			arg4,
 		)
 	})
 	return _c
 }
 
-func (_c *MockMarksRepository_GetMarkById_Call) Return(mark models.Mark, err error) *MockMarksRepository_GetMarkById_Call {
-	_c.Call.Return(mark, err)
+func (_c *MockPhotosRepository_AddPhotos_Call) Return(photoRecords []models.PhotoRecord, err error) *MockPhotosRepository_AddPhotos_Call {
+	_c.Call.Return(photoRecords, err)
 	return _c
 }
 
-func (_c *MockMarksRepository_GetMarkById_Call) RunAndReturn(run func(ctx context.Context, id int) (models.Mark, error)) *MockMarksRepository_GetMarkById_Call {
+func (_c *MockPhotosRepository_AddPhotos_Call) RunAndReturn(run func(ctx context.Context, markId int, checkId int, first int, photos []io.Reader) ([]models.PhotoRecord, error)) *MockPhotosRepository_AddPhotos_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetMarkStatusHistoryByMarkId provides a mock function for the type MockMarksRepository
-func (_mock *MockMarksRepository) GetMarkStatusHistoryByMarkId(ctx context.Context, markId int) ([]models.MarkStatusHistoryItem, error) {
-	ret := _mock.Called(ctx, markId)
+// CopyPhotos provides a mock function for the type MockPhotosRepository
+func (_mock *MockPhotosRepository) CopyPhotos(ctx context.Context, fromMarkId int, toMarkId int) ([]string, error) {
+	ret := _mock.Called(ctx, fromMarkId, toMarkId)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetMarkStatusHistoryByMarkId")
+		panic("no return value specified for CopyPhotos")
 	}
 
-	var r0 []models.MarkStatusHistoryItem
+	var r0 []string
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, int) ([]models.MarkStatusHistoryItem, error)); ok {
-		return returnFunc(ctx, markId)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int, int) ([]string, error)); ok {
+		return returnFunc(ctx, fromMarkId, toMarkId)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, int) []models.MarkStatusHistoryItem); ok {
-		r0 = returnFunc(ctx, markId)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int, int) []string); ok {
+		r0 = returnFunc(ctx, fromMarkId, toMarkId)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]models.MarkStatusHistoryItem)
+			r0 = ret.Get(0).([]string)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, int) error); ok {
-		r1 = returnFunc(ctx, markId)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int, int) error); ok {
+		r1 = returnFunc(ctx, fromMarkId, toMarkId)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockMarksRepository_GetMarkStatusHistoryByMarkId_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetMarkStatusHistoryByMarkId'
-type MockMarksRepository_GetMarkStatusHistoryByMarkId_Call struct {
+// MockPhotosRepository_CopyPhotos_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CopyPhotos'
+type MockPhotosRepository_CopyPhotos_Call struct {
 	*mock.Call
 }
 
-// GetMarkStatusHistoryByMarkId is a helper method to define mock.On call
+// CopyPhotos is a helper method to define mock.On call
 //   - ctx context.Context
-//   - markId int
-func (_e *MockMarksRepository_Expecter) GetMarkStatusHistoryByMarkId(ctx interface{}, markId interface{}) *MockMarksRepository_GetMarkStatusHistoryByMarkId_Call {
-	return &MockMarksRepository_GetMarkStatusHistoryByMarkId_Call{Call: _e.mock.On("GetMarkStatusHistoryByMarkId", ctx, markId)}
+//   - fromMarkId int
+//   - toMarkId int
+func (_e *MockPhotosRepository_Expecter) CopyPhotos(ctx interface{}, fromMarkId interface{}, toMarkId interface{}) *MockPhotosRepository_CopyPhotos_Call {
+	return &MockPhotosRepository_CopyPhotos_Call{Call: _e.mock.On("CopyPhotos", ctx, fromMarkId, toMarkId)}
 }
 
-func (_c *MockMarksRepository_GetMarkStatusHistoryByMarkId_Call) Run(run func(ctx context.Context, markId int)) *MockMarksRepository_GetMarkStatusHistoryByMarkId_Call {
+func (_c *MockPhotosRepository_CopyPhotos_Call) Run(run func(ctx context.Context, fromMarkId int, toMarkId int)) *MockPhotosRepository_CopyPhotos_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -1227,197 +3651,192 @@ func (_c *MockMarksRepository_GetMarkStatusHistoryByMarkId_Call) Run(run func(ct
 		if args[1] != nil {
 			arg1 = args[1].(int)
 		}
+		var arg2 int
+		if args[2] != nil {
+			arg2 = args[2].(int)
+		}
 		run(
 			arg0,
 			arg1,
+			arg2,
 		)
 	})
 	return _c
 }
 
-func (_c *MockMarksRepository_GetMarkStatusHistoryByMarkId_Call) Return(markStatusHistoryItems []models.MarkStatusHistoryItem, err error) *MockMarksRepository_GetMarkStatusHistoryByMarkId_Call {
-	_c.Call.Return(markStatusHistoryItems, err)
+func (_c *MockPhotosRepository_CopyPhotos_Call) Return(strings []string, err error) *MockPhotosRepository_CopyPhotos_Call {
+	_c.Call.Return(strings, err)
 	return _c
 }
 
-func (_c *MockMarksRepository_GetMarkStatusHistoryByMarkId_Call) RunAndReturn(run func(ctx context.Context, markId int) ([]models.MarkStatusHistoryItem, error)) *MockMarksRepository_GetMarkStatusHistoryByMarkId_Call {
+func (_c *MockPhotosRepository_CopyPhotos_Call) RunAndReturn(run func(ctx context.Context, fromMarkId int, toMarkId int) ([]string, error)) *MockPhotosRepository_CopyPhotos_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetMarkStatuses provides a mock function for the type MockMarksRepository
-func (_mock *MockMarksRepository) GetMarkStatuses(ctx context.Context) ([]models.MarkStatus, error) {
-	ret := _mock.Called(ctx)
+// DeletePhotos provides a mock function for the type MockPhotosRepository
+func (_mock *MockPhotosRepository) DeletePhotos(ctx context.Context, keys []string) error {
+	ret := _mock.Called(ctx, keys)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetMarkStatuses")
+		panic("no return value specified for DeletePhotos")
 	}
 
-	var r0 []models.MarkStatus
-	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context) ([]models.MarkStatus, error)); ok {
-		return returnFunc(ctx)
-	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context) []models.MarkStatus); ok {
-		r0 = returnFunc(ctx)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]models.MarkStatus)
-		}
-	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
-		r1 = returnFunc(ctx)
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []string) error); ok {
+		r0 = returnFunc(ctx, keys)
 	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Error(0)
 	}
-	return r0, r1
+	return r0
 }
 
-// MockMarksRepository_GetMarkStatuses_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetMarkStatuses'
-type MockMarksRepository_GetMarkStatuses_Call struct {
+// MockPhotosRepository_DeletePhotos_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeletePhotos'
+type MockPhotosRepository_DeletePhotos_Call struct {
 	*mock.Call
 }
 
-// GetMarkStatuses is a helper method to define mock.On call
+// DeletePhotos is a helper method to define mock.On call
 //   - ctx context.Context
-func (_e *MockMarksRepository_Expecter) GetMarkStatuses(ctx interface{}) *MockMarksRepository_GetMarkStatuses_Call {
-	return &MockMarksRepository_GetMarkStatuses_Call{Call: _e.mock.On("GetMarkStatuses", ctx)}
+//   - keys []string
+func (_e *MockPhotosRepository_Expecter) DeletePhotos(ctx interface{}, keys interface{}) *MockPhotosRepository_DeletePhotos_Call {
+	return &MockPhotosRepository_DeletePhotos_Call{Call: _e.mock.On("DeletePhotos", ctx, keys)}
 }
 
-func (_c *MockMarksRepository_GetMarkStatuses_Call) Run(run func(ctx context.Context)) *MockMarksRepository_GetMarkStatuses_Call {
+func (_c *MockPhotosRepository_DeletePhotos_Call) Run(run func(ctx context.Context, keys []string)) *MockPhotosRepository_DeletePhotos_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
+		var arg1 []string
+		if args[1] != nil {
+			arg1 = args[1].([]string)
+		}
 		run(
 			arg0,
+			arg1,
 		)
 	})
 	return _c
 }
 
-func (_c *MockMarksRepository_GetMarkStatuses_Call) Return(markStatuss []models.MarkStatus, err error) *MockMarksRepository_GetMarkStatuses_Call {
-	_c.Call.Return(markStatuss, err)
+func (_c *MockPhotosRepository_DeletePhotos_Call) Return(err error) *MockPhotosRepository_DeletePhotos_Call {
+	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockMarksRepository_GetMarkStatuses_Call) RunAndReturn(run func(ctx context.Context) ([]models.MarkStatus, error)) *MockMarksRepository_GetMarkStatuses_Call {
+func (_c *MockPhotosRepository_DeletePhotos_Call) RunAndReturn(run func(ctx context.Context, keys []string) error) *MockPhotosRepository_DeletePhotos_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetMarkTypes provides a mock function for the type MockMarksRepository
-func (_mock *MockMarksRepository) GetMarkTypes(ctx context.Context) ([]models.MarkType, error) {
-	ret := _mock.Called(ctx)
+// DeletePhotosByMarkId provides a mock function for the type MockPhotosRepository
+func (_mock *MockPhotosRepository) DeletePhotosByMarkId(ctx context.Context, markId int) error {
+	ret := _mock.Called(ctx, markId)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetMarkTypes")
+		panic("no return value specified for DeletePhotosByMarkId")
 	}
 
-	var r0 []models.MarkType
-	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context) ([]models.MarkType, error)); ok {
-		return returnFunc(ctx)
-	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context) []models.MarkType); ok {
-		r0 = returnFunc(ctx)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]models.MarkType)
-		}
-	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
-		r1 = returnFunc(ctx)
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int) error); ok {
+		r0 = returnFunc(ctx, markId)
 	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Error(0)
 	}
-	return r0, r1
+	return r0
 }
 
-// MockMarksRepository_GetMarkTypes_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetMarkTypes'
-type MockMarksRepository_GetMarkTypes_Call struct {
+// MockPhotosRepository_DeletePhotosByMarkId_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeletePhotosByMarkId'
+type MockPhotosRepository_DeletePhotosByMarkId_Call struct {
 	*mock.Call
 }
 
-// GetMarkTypes is a helper method to define mock.On call
+// DeletePhotosByMarkId is a helper method to define mock.On call
 //   - ctx context.Context
-func (_e *MockMarksRepository_Expecter) GetMarkTypes(ctx interface{}) *MockMarksRepository_GetMarkTypes_Call {
-	return &MockMarksRepository_GetMarkTypes_Call{Call: _e.mock.On("GetMarkTypes", ctx)}
+//   - markId int
+func (_e *MockPhotosRepository_Expecter) DeletePhotosByMarkId(ctx interface{}, markId interface{}) *MockPhotosRepository_DeletePhotosByMarkId_Call {
+	return &MockPhotosRepository_DeletePhotosByMarkId_Call{Call: _e.mock.On("DeletePhotosByMarkId", ctx, markId)}
 }
 
-func (_c *MockMarksRepository_GetMarkTypes_Call) Run(run func(ctx context.Context)) *MockMarksRepository_GetMarkTypes_Call {
+func (_c *MockPhotosRepository_DeletePhotosByMarkId_Call) Run(run func(ctx context.Context, markId int)) *MockPhotosRepository_DeletePhotosByMarkId_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
+		var arg1 int
+		if args[1] != nil {
+			arg1 = args[1].(int)
+		}
 		run(
 			arg0,
+			arg1,
 		)
 	})
 	return _c
 }
 
-func (_c *MockMarksRepository_GetMarkTypes_Call) Return(markTypes []models.MarkType, err error) *MockMarksRepository_GetMarkTypes_Call {
-	_c.Call.Return(markTypes, err)
+func (_c *MockPhotosRepository_DeletePhotosByMarkId_Call) Return(err error) *MockPhotosRepository_DeletePhotosByMarkId_Call {
+	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockMarksRepository_GetMarkTypes_Call) RunAndReturn(run func(ctx context.Context) ([]models.MarkType, error)) *MockMarksRepository_GetMarkTypes_Call {
+func (_c *MockPhotosRepository_DeletePhotosByMarkId_Call) RunAndReturn(run func(ctx context.Context, markId int) error) *MockPhotosRepository_DeletePhotosByMarkId_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetMarks provides a mock function for the type MockMarksRepository
-func (_mock *MockMarksRepository) GetMarks(ctx context.Context, filters models.GetMarksFilters) ([]models.Mark, error) {
-	ret := _mock.Called(ctx, filters)
+// GetPhoto provides a mock function for the type MockPhotosRepository
+func (_mock *MockPhotosRepository) GetPhoto(ctx context.Context, key string) (io.ReadCloser, error) {
+	ret := _mock.Called(ctx, key)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetMarks")
+		panic("no return value specified for GetPhoto")
 	}
 
-	var r0 []models.Mark
+	var r0 io.ReadCloser
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, models.GetMarksFilters) ([]models.Mark, error)); ok {
-		return returnFunc(ctx, filters)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (io.ReadCloser, error)); ok {
+		return returnFunc(ctx, key)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, models.GetMarksFilters) []models.Mark); ok {
-		r0 = returnFunc(ctx, filters)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) io.ReadCloser); ok {
+		r0 = returnFunc(ctx, key)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]models.Mark)
+			r0 = ret.Get(0).(io.ReadCloser)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, models.GetMarksFilters) error); ok {
-		r1 = returnFunc(ctx, filters)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, key)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockMarksRepository_GetMarks_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetMarks'
-type MockMarksRepository_GetMarks_Call struct {
+// MockPhotosRepository_GetPhoto_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPhoto'
+type MockPhotosRepository_GetPhoto_Call struct {
 	*mock.Call
 }
 
-// GetMarks is a helper method to define mock.On call
+// GetPhoto is a helper method to define mock.On call
 //   - ctx context.Context
-//   - filters models.GetMarksFilters
-func (_e *MockMarksRepository_Expecter) GetMarks(ctx interface{}, filters interface{}) *MockMarksRepository_GetMarks_Call {
-	return &MockMarksRepository_GetMarks_Call{Call: _e.mock.On("GetMarks", ctx, filters)}
+//   - key string
+func (_e *MockPhotosRepository_Expecter) GetPhoto(ctx interface{}, key interface{}) *MockPhotosRepository_GetPhoto_Call {
+	return &MockPhotosRepository_GetPhoto_Call{Call: _e.mock.On("GetPhoto", ctx, key)}
 }
 
-func (_c *MockMarksRepository_GetMarks_Call) Run(run func(ctx context.Context, filters models.GetMarksFilters)) *MockMarksRepository_GetMarks_Call {
+func (_c *MockPhotosRepository_GetPhoto_Call) Run(run func(ctx context.Context, key string)) *MockPhotosRepository_GetPhoto_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 models.GetMarksFilters
+		var arg1 string
 		if args[1] != nil {
-			arg1 = args[1].(models.GetMarksFilters)
+			arg1 = args[1].(string)
 		}
 		run(
 			arg0,
@@ -1427,65 +3846,65 @@ func (_c *MockMarksRepository_GetMarks_Call) Run(run func(ctx context.Context, f
 	return _c
 }
 
-func (_c *MockMarksRepository_GetMarks_Call) Return(marks []models.Mark, err error) *MockMarksRepository_GetMarks_Call {
-	_c.Call.Return(marks, err)
+func (_c *MockPhotosRepository_GetPhoto_Call) Return(readCloser io.ReadCloser, err error) *MockPhotosRepository_GetPhoto_Call {
+	_c.Call.Return(readCloser, err)
 	return _c
 }
 
-func (_c *MockMarksRepository_GetMarks_Call) RunAndReturn(run func(ctx context.Context, filters models.GetMarksFilters) ([]models.Mark, error)) *MockMarksRepository_GetMarks_Call {
+func (_c *MockPhotosRepository_GetPhoto_Call) RunAndReturn(run func(ctx context.Context, key string) (io.ReadCloser, error)) *MockPhotosRepository_GetPhoto_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetMarksByUserId provides a mock function for the type MockMarksRepository
-func (_mock *MockMarksRepository) GetMarksByUserId(ctx context.Context, userId int) ([]models.Mark, error) {
-	ret := _mock.Called(ctx, userId)
+// ListPhotos provides a mock function for the type MockPhotosRepository
+func (_mock *MockPhotosRepository) ListPhotos(ctx context.Context, prefix string) ([]string, error) {
+	ret := _mock.Called(ctx, prefix)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetMarksByUserId")
+		panic("no return value specified for ListPhotos")
 	}
 
-	var r0 []models.Mark
+	var r0 []string
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, int) ([]models.Mark, error)); ok {
-		return returnFunc(ctx, userId)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) ([]string, error)); ok {
+		return returnFunc(ctx, prefix)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, int) []models.Mark); ok {
-		r0 = returnFunc(ctx, userId)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) []string); ok {
+		r0 = returnFunc(ctx, prefix)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]models.Mark)
+			r0 = ret.Get(0).([]string)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, int) error); ok {
-		r1 = returnFunc(ctx, userId)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, prefix)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockMarksRepository_GetMarksByUserId_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetMarksByUserId'
-type MockMarksRepository_GetMarksByUserId_Call struct {
+// MockPhotosRepository_ListPhotos_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListPhotos'
+type MockPhotosRepository_ListPhotos_Call struct {
 	*mock.Call
 }
 
-// GetMarksByUserId is a helper method to define mock.On call
+// ListPhotos is a helper method to define mock.On call
 //   - ctx context.Context
-//   - userId int
-func (_e *MockMarksRepository_Expecter) GetMarksByUserId(ctx interface{}, userId interface{}) *MockMarksRepository_GetMarksByUserId_Call {
-	return &MockMarksRepository_GetMarksByUserId_Call{Call: _e.mock.On("GetMarksByUserId", ctx, userId)}
+//   - prefix string
+func (_e *MockPhotosRepository_Expecter) ListPhotos(ctx interface{}, prefix interface{}) *MockPhotosRepository_ListPhotos_Call {
+	return &MockPhotosRepository_ListPhotos_Call{Call: _e.mock.On("ListPhotos", ctx, prefix)}
 }
 
-func (_c *MockMarksRepository_GetMarksByUserId_Call) Run(run func(ctx context.Context, userId int)) *MockMarksRepository_GetMarksByUserId_Call {
+func (_c *MockPhotosRepository_ListPhotos_Call) Run(run func(ctx context.Context, prefix string)) *MockPhotosRepository_ListPhotos_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 int
+		var arg1 string
 		if args[1] != nil {
-			arg1 = args[1].(int)
+			arg1 = args[1].(string)
 		}
 		run(
 			arg0,
@@ -1495,86 +3914,89 @@ func (_c *MockMarksRepository_GetMarksByUserId_Call) Run(run func(ctx context.Co
 	return _c
 }
 
-func (_c *MockMarksRepository_GetMarksByUserId_Call) Return(marks []models.Mark, err error) *MockMarksRepository_GetMarksByUserId_Call {
-	_c.Call.Return(marks, err)
+func (_c *MockPhotosRepository_ListPhotos_Call) Return(strings []string, err error) *MockPhotosRepository_ListPhotos_Call {
+	_c.Call.Return(strings, err)
 	return _c
 }
 
-func (_c *MockMarksRepository_GetMarksByUserId_Call) RunAndReturn(run func(ctx context.Context, userId int) ([]models.Mark, error)) *MockMarksRepository_GetMarksByUserId_Call {
+func (_c *MockPhotosRepository_ListPhotos_Call) RunAndReturn(run func(ctx context.Context, prefix string) ([]string, error)) *MockPhotosRepository_ListPhotos_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// UpdateMarkStatus provides a mock function for the type MockMarksRepository
-func (_mock *MockMarksRepository) UpdateMarkStatus(ctx context.Context, markId int, markStatusId models.MarkStatusType) error {
-	ret := _mock.Called(ctx, markId, markStatusId)
+// URL provides a mock function for the type MockPhotosRepository
+func (_mock *MockPhotosRepository) URL(ctx context.Context, key string) (string, error) {
+	ret := _mock.Called(ctx, key)
 
 	if len(ret) == 0 {
-		panic("no return value specified for UpdateMarkStatus")
+		panic("no return value specified for URL")
 	}
 
-	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, int, models.MarkStatusType) error); ok {
-		r0 = returnFunc(ctx, markId, markStatusId)
+	var r0 string
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (string, error)); ok {
+		return returnFunc(ctx, key)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) string); ok {
+		r0 = returnFunc(ctx, key)
 	} else {
-		r0 = ret.Error(0)
+		r0 = ret.Get(0).(string)
 	}
-	return r0
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, key)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
 }
 
-// MockMarksRepository_UpdateMarkStatus_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateMarkStatus'
-type MockMarksRepository_UpdateMarkStatus_Call struct {
+// MockPhotosRepository_URL_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'URL'
+type MockPhotosRepository_URL_Call struct {
 	*mock.Call
 }
 
-// UpdateMarkStatus is a helper method to define mock.On call
+// URL is a helper method to define mock.On call
 //   - ctx context.Context
-//   - markId int
-//   - markStatusId models.MarkStatusType
-func (_e *MockMarksRepository_Expecter) UpdateMarkStatus(ctx interface{}, markId interface{}, markStatusId interface{}) *MockMarksRepository_UpdateMarkStatus_Call {
-	return &MockMarksRepository_UpdateMarkStatus_Call{Call: _e.mock.On("UpdateMarkStatus", ctx, markId, markStatusId)}
+//   - key string
+func (_e *MockPhotosRepository_Expecter) URL(ctx interface{}, key interface{}) *MockPhotosRepository_URL_Call {
+	return &MockPhotosRepository_URL_Call{Call: _e.mock.On("URL", ctx, key)}
 }
 
-func (_c *MockMarksRepository_UpdateMarkStatus_Call) Run(run func(ctx context.Context, markId int, markStatusId models.MarkStatusType)) *MockMarksRepository_UpdateMarkStatus_Call {
+func (_c *MockPhotosRepository_URL_Call) Run(run func(ctx context.Context, key string)) *MockPhotosRepository_URL_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 int
+		var arg1 string
 		if args[1] != nil {
-			arg1 = args[1].(int)
-		}
-		var arg2 models.MarkStatusType
-		if args[2] != nil {
-			arg2 = args[2].(models.MarkStatusType)
+			arg1 = args[1].(string)
 		}
 		run(
 			arg0,
 			arg1,
-			arg2,
 		)
 	})
 	return _c
 }
 
-func (_c *MockMarksRepository_UpdateMarkStatus_Call) Return(err error) *MockMarksRepository_UpdateMarkStatus_Call {
-	_c.Call.Return(err)
+func (_c *MockPhotosRepository_URL_Call) Return(s string, err error) *MockPhotosRepository_URL_Call {
+	_c.Call.Return(s, err)
 	return _c
 }
 
-func (_c *MockMarksRepository_UpdateMarkStatus_Call) RunAndReturn(run func(ctx context.Context, markId int, markStatusId models.MarkStatusType) error) *MockMarksRepository_UpdateMarkStatus_Call {
+func (_c *MockPhotosRepository_URL_Call) RunAndReturn(run func(ctx context.Context, key string) (string, error)) *MockPhotosRepository_URL_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// NewMockPhotosRepository creates a new instance of MockPhotosRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// NewMockModerationRepository creates a new instance of MockModerationRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
-func NewMockPhotosRepository(t interface {
+func NewMockModerationRepository(t interface {
 	mock.TestingT
 	Cleanup(func())
-}) *MockPhotosRepository {
-	mock := &MockPhotosRepository{}
+}) *MockModerationRepository {
+	mock := &MockModerationRepository{}
 	mock.Mock.Test(t)
 
 	t.Cleanup(func() { mock.AssertExpectations(t) })
@@ -1582,51 +4004,60 @@ func NewMockPhotosRepository(t interface {
 	return mock
 }
 
-// MockPhotosRepository is an autogenerated mock type for the PhotosRepository type
-type MockPhotosRepository struct {
+// MockModerationRepository is an autogenerated mock type for the ModerationRepository type
+type MockModerationRepository struct {
 	mock.Mock
 }
 
-type MockPhotosRepository_Expecter struct {
+type MockModerationRepository_Expecter struct {
 	mock *mock.Mock
 }
 
-func (_m *MockPhotosRepository) EXPECT() *MockPhotosRepository_Expecter {
-	return &MockPhotosRepository_Expecter{mock: &_m.Mock}
+func (_m *MockModerationRepository) EXPECT() *MockModerationRepository_Expecter {
+	return &MockModerationRepository_Expecter{mock: &_m.Mock}
 }
 
-// AddPhotos provides a mock function for the type MockPhotosRepository
-func (_mock *MockPhotosRepository) AddPhotos(ctx context.Context, markId int, checkId int, photos []io.Reader) error {
-	ret := _mock.Called(ctx, markId, checkId, photos)
+// ClaimMark provides a mock function for the type MockModerationRepository
+func (_mock *MockModerationRepository) ClaimMark(ctx context.Context, markId int, userId int, ttl time.Duration) (models.ModerationClaim, error) {
+	ret := _mock.Called(ctx, markId, userId, ttl)
 
 	if len(ret) == 0 {
-		panic("no return value specified for AddPhotos")
+		panic("no return value specified for ClaimMark")
 	}
 
-	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, int, int, []io.Reader) error); ok {
-		r0 = returnFunc(ctx, markId, checkId, photos)
+	var r0 models.ModerationClaim
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int, int, time.Duration) (models.ModerationClaim, error)); ok {
+		return returnFunc(ctx, markId, userId, ttl)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int, int, time.Duration) models.ModerationClaim); ok {
+		r0 = returnFunc(ctx, markId, userId, ttl)
 	} else {
-		r0 = ret.Error(0)
+		r0 = ret.Get(0).(models.ModerationClaim)
 	}
-	return r0
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int, int, time.Duration) error); ok {
+		r1 = returnFunc(ctx, markId, userId, ttl)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
 }
 
-// MockPhotosRepository_AddPhotos_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddPhotos'
-type MockPhotosRepository_AddPhotos_Call struct {
+// MockModerationRepository_ClaimMark_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ClaimMark'
+type MockModerationRepository_ClaimMark_Call struct {
 	*mock.Call
 }
 
-// AddPhotos is a helper method to define mock.On call
+// ClaimMark is a helper method to define mock.On call
 //   - ctx context.Context
 //   - markId int
-//   - checkId int
-//   - photos []io.Reader
-func (_e *MockPhotosRepository_Expecter) AddPhotos(ctx interface{}, markId interface{}, checkId interface{}, photos interface{}) *MockPhotosRepository_AddPhotos_Call {
-	return &MockPhotosRepository_AddPhotos_Call{Call: _e.mock.On("AddPhotos", ctx, markId, checkId, photos)}
+//   - userId int
+//   - ttl time.Duration
+func (_e *MockModerationRepository_Expecter) ClaimMark(ctx interface{}, markId interface{}, userId interface{}, ttl interface{}) *MockModerationRepository_ClaimMark_Call {
+	return &MockModerationRepository_ClaimMark_Call{Call: _e.mock.On("ClaimMark", ctx, markId, userId, ttl)}
 }
 
-func (_c *MockPhotosRepository_AddPhotos_Call) Run(run func(ctx context.Context, markId int, checkId int, photos []io.Reader)) *MockPhotosRepository_AddPhotos_Call {
+func (_c *MockModerationRepository_ClaimMark_Call) Run(run func(ctx context.Context, markId int, userId int, ttl time.Duration)) *MockModerationRepository_ClaimMark_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -1640,9 +4071,9 @@ func (_c *MockPhotosRepository_AddPhotos_Call) Run(run func(ctx context.Context,
 		if args[2] != nil {
 			arg2 = args[2].(int)
 		}
-		var arg3 []io.Reader
+		var arg3 time.Duration
 		if args[3] != nil {
-			arg3 = args[3].([]io.Reader)
+			arg3 = args[3].(time.Duration)
 		}
 		run(
 			arg0,
@@ -1654,193 +4085,115 @@ func (_c *MockPhotosRepository_AddPhotos_Call) Run(run func(ctx context.Context,
 	return _c
 }
 
-func (_c *MockPhotosRepository_AddPhotos_Call) Return(err error) *MockPhotosRepository_AddPhotos_Call {
-	_c.Call.Return(err)
-	return _c
-}
-
-func (_c *MockPhotosRepository_AddPhotos_Call) RunAndReturn(run func(ctx context.Context, markId int, checkId int, photos []io.Reader) error) *MockPhotosRepository_AddPhotos_Call {
-	_c.Call.Return(run)
-	return _c
-}
-
-// GetPhotos provides a mock function for the type MockPhotosRepository
-func (_mock *MockPhotosRepository) GetPhotos(ctx context.Context) (map[int]map[int][]string, error) {
-	ret := _mock.Called(ctx)
-
-	if len(ret) == 0 {
-		panic("no return value specified for GetPhotos")
-	}
-
-	var r0 map[int]map[int][]string
-	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context) (map[int]map[int][]string, error)); ok {
-		return returnFunc(ctx)
-	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context) map[int]map[int][]string); ok {
-		r0 = returnFunc(ctx)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(map[int]map[int][]string)
-		}
-	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
-		r1 = returnFunc(ctx)
-	} else {
-		r1 = ret.Error(1)
-	}
-	return r0, r1
-}
-
-// MockPhotosRepository_GetPhotos_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPhotos'
-type MockPhotosRepository_GetPhotos_Call struct {
-	*mock.Call
-}
-
-// GetPhotos is a helper method to define mock.On call
-//   - ctx context.Context
-func (_e *MockPhotosRepository_Expecter) GetPhotos(ctx interface{}) *MockPhotosRepository_GetPhotos_Call {
-	return &MockPhotosRepository_GetPhotos_Call{Call: _e.mock.On("GetPhotos", ctx)}
-}
-
-func (_c *MockPhotosRepository_GetPhotos_Call) Run(run func(ctx context.Context)) *MockPhotosRepository_GetPhotos_Call {
-	_c.Call.Run(func(args mock.Arguments) {
-		var arg0 context.Context
-		if args[0] != nil {
-			arg0 = args[0].(context.Context)
-		}
-		run(
-			arg0,
-		)
-	})
-	return _c
-}
-
-func (_c *MockPhotosRepository_GetPhotos_Call) Return(intToIntToStrings map[int]map[int][]string, err error) *MockPhotosRepository_GetPhotos_Call {
-	_c.Call.Return(intToIntToStrings, err)
+func (_c *MockModerationRepository_ClaimMark_Call) Return(moderationClaim models.ModerationClaim, err error) *MockModerationRepository_ClaimMark_Call {
+	_c.Call.Return(moderationClaim, err)
 	return _c
 }
 
-func (_c *MockPhotosRepository_GetPhotos_Call) RunAndReturn(run func(ctx context.Context) (map[int]map[int][]string, error)) *MockPhotosRepository_GetPhotos_Call {
+func (_c *MockModerationRepository_ClaimMark_Call) RunAndReturn(run func(ctx context.Context, markId int, userId int, ttl time.Duration) (models.ModerationClaim, error)) *MockModerationRepository_ClaimMark_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetPhotosByCheckId provides a mock function for the type MockPhotosRepository
-func (_mock *MockPhotosRepository) GetPhotosByCheckId(ctx context.Context, markId int, checkId int) ([]string, error) {
-	ret := _mock.Called(ctx, markId, checkId)
+// GetModerationQueue provides a mock function for the type MockModerationRepository
+func (_mock *MockModerationRepository) GetModerationQueue(ctx context.Context, filters models.GetModerationQueueFilters) ([]models.ModerationQueueItem, error) {
+	ret := _mock.Called(ctx, filters)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetPhotosByCheckId")
+		panic("no return value specified for GetModerationQueue")
 	}
 
-	var r0 []string
+	var r0 []models.ModerationQueueItem
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, int, int) ([]string, error)); ok {
-		return returnFunc(ctx, markId, checkId)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, models.GetModerationQueueFilters) ([]models.ModerationQueueItem, error)); ok {
+		return returnFunc(ctx, filters)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, int, int) []string); ok {
-		r0 = returnFunc(ctx, markId, checkId)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, models.GetModerationQueueFilters) []models.ModerationQueueItem); ok {
+		r0 = returnFunc(ctx, filters)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]string)
+			r0 = ret.Get(0).([]models.ModerationQueueItem)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, int, int) error); ok {
-		r1 = returnFunc(ctx, markId, checkId)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, models.GetModerationQueueFilters) error); ok {
+		r1 = returnFunc(ctx, filters)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockPhotosRepository_GetPhotosByCheckId_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPhotosByCheckId'
-type MockPhotosRepository_GetPhotosByCheckId_Call struct {
+// MockModerationRepository_GetModerationQueue_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetModerationQueue'
+type MockModerationRepository_GetModerationQueue_Call struct {
 	*mock.Call
 }
 
-// GetPhotosByCheckId is a helper method to define mock.On call
+// GetModerationQueue is a helper method to define mock.On call
 //   - ctx context.Context
-//   - markId int
-//   - checkId int
-func (_e *MockPhotosRepository_Expecter) GetPhotosByCheckId(ctx interface{}, markId interface{}, checkId interface{}) *MockPhotosRepository_GetPhotosByCheckId_Call {
-	return &MockPhotosRepository_GetPhotosByCheckId_Call{Call: _e.mock.On("GetPhotosByCheckId", ctx, markId, checkId)}
+//   - filters models.GetModerationQueueFilters
+func (_e *MockModerationRepository_Expecter) GetModerationQueue(ctx interface{}, filters interface{}) *MockModerationRepository_GetModerationQueue_Call {
+	return &MockModerationRepository_GetModerationQueue_Call{Call: _e.mock.On("GetModerationQueue", ctx, filters)}
 }
 
-func (_c *MockPhotosRepository_GetPhotosByCheckId_Call) Run(run func(ctx context.Context, markId int, checkId int)) *MockPhotosRepository_GetPhotosByCheckId_Call {
+func (_c *MockModerationRepository_GetModerationQueue_Call) Run(run func(ctx context.Context, filters models.GetModerationQueueFilters)) *MockModerationRepository_GetModerationQueue_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 int
+		var arg1 models.GetModerationQueueFilters
 		if args[1] != nil {
-			arg1 = args[1].(int)
-		}
-		var arg2 int
-		if args[2] != nil {
-			arg2 = args[2].(int)
+			arg1 = args[1].(models.GetModerationQueueFilters)
 		}
 		run(
 			arg0,
 			arg1,
-			arg2,
 		)
 	})
 	return _c
 }
 
-func (_c *MockPhotosRepository_GetPhotosByCheckId_Call) Return(strings []string, err error) *MockPhotosRepository_GetPhotosByCheckId_Call {
-	_c.Call.Return(strings, err)
+func (_c *MockModerationRepository_GetModerationQueue_Call) Return(moderationQueueItems []models.ModerationQueueItem, err error) *MockModerationRepository_GetModerationQueue_Call {
+	_c.Call.Return(moderationQueueItems, err)
 	return _c
 }
 
-func (_c *MockPhotosRepository_GetPhotosByCheckId_Call) RunAndReturn(run func(ctx context.Context, markId int, checkId int) ([]string, error)) *MockPhotosRepository_GetPhotosByCheckId_Call {
+func (_c *MockModerationRepository_GetModerationQueue_Call) RunAndReturn(run func(ctx context.Context, filters models.GetModerationQueueFilters) ([]models.ModerationQueueItem, error)) *MockModerationRepository_GetModerationQueue_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetPhotosByMarkId provides a mock function for the type MockPhotosRepository
-func (_mock *MockPhotosRepository) GetPhotosByMarkId(ctx context.Context, markId int) (map[int]map[int][]string, error) {
-	ret := _mock.Called(ctx, markId)
+// ReleaseMark provides a mock function for the type MockModerationRepository
+func (_mock *MockModerationRepository) ReleaseMark(ctx context.Context, markId int, userId int) error {
+	ret := _mock.Called(ctx, markId, userId)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetPhotosByMarkId")
+		panic("no return value specified for ReleaseMark")
 	}
 
-	var r0 map[int]map[int][]string
-	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, int) (map[int]map[int][]string, error)); ok {
-		return returnFunc(ctx, markId)
-	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, int) map[int]map[int][]string); ok {
-		r0 = returnFunc(ctx, markId)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(map[int]map[int][]string)
-		}
-	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, int) error); ok {
-		r1 = returnFunc(ctx, markId)
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int, int) error); ok {
+		r0 = returnFunc(ctx, markId, userId)
 	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Error(0)
 	}
-	return r0, r1
+	return r0
 }
 
-// MockPhotosRepository_GetPhotosByMarkId_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPhotosByMarkId'
-type MockPhotosRepository_GetPhotosByMarkId_Call struct {
+// MockModerationRepository_ReleaseMark_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReleaseMark'
+type MockModerationRepository_ReleaseMark_Call struct {
 	*mock.Call
 }
 
-// GetPhotosByMarkId is a helper method to define mock.On call
+// ReleaseMark is a helper method to define mock.On call
 //   - ctx context.Context
 //   - markId int
-func (_e *MockPhotosRepository_Expecter) GetPhotosByMarkId(ctx interface{}, markId interface{}) *MockPhotosRepository_GetPhotosByMarkId_Call {
-	return &MockPhotosRepository_GetPhotosByMarkId_Call{Call: _e.mock.On("GetPhotosByMarkId", ctx, markId)}
+//   - userId int
+func (_e *MockModerationRepository_Expecter) ReleaseMark(ctx interface{}, markId interface{}, userId interface{}) *MockModerationRepository_ReleaseMark_Call {
+	return &MockModerationRepository_ReleaseMark_Call{Call: _e.mock.On("ReleaseMark", ctx, markId, userId)}
 }
 
-func (_c *MockPhotosRepository_GetPhotosByMarkId_Call) Run(run func(ctx context.Context, markId int)) *MockPhotosRepository_GetPhotosByMarkId_Call {
+func (_c *MockModerationRepository_ReleaseMark_Call) Run(run func(ctx context.Context, markId int, userId int)) *MockModerationRepository_ReleaseMark_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -1850,20 +4203,25 @@ func (_c *MockPhotosRepository_GetPhotosByMarkId_Call) Run(run func(ctx context.
 		if args[1] != nil {
 			arg1 = args[1].(int)
 		}
+		var arg2 int
+		if args[2] != nil {
+			arg2 = args[2].(int)
+		}
 		run(
 			arg0,
 			arg1,
+			arg2,
 		)
 	})
 	return _c
 }
 
-func (_c *MockPhotosRepository_GetPhotosByMarkId_Call) Return(intToIntToStrings map[int]map[int][]string, err error) *MockPhotosRepository_GetPhotosByMarkId_Call {
-	_c.Call.Return(intToIntToStrings, err)
+func (_c *MockModerationRepository_ReleaseMark_Call) Return(err error) *MockModerationRepository_ReleaseMark_Call {
+	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockPhotosRepository_GetPhotosByMarkId_Call) RunAndReturn(run func(ctx context.Context, markId int) (map[int]map[int][]string, error)) *MockPhotosRepository_GetPhotosByMarkId_Call {
+func (_c *MockModerationRepository_ReleaseMark_Call) RunAndReturn(run func(ctx context.Context, markId int, userId int) error) *MockModerationRepository_ReleaseMark_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -2028,8 +4386,8 @@ func (_c *MockTasksRepository_GetTaskById_Call) RunAndReturn(run func(ctx contex
 }
 
 // GetTasks provides a mock function for the type MockTasksRepository
-func (_mock *MockTasksRepository) GetTasks(ctx context.Context) ([]models.Task, error) {
-	ret := _mock.Called(ctx)
+func (_mock *MockTasksRepository) GetTasks(ctx context.Context, pagination models.Pagination) ([]models.Task, error) {
+	ret := _mock.Called(ctx, pagination)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetTasks")
@@ -2037,18 +4395,18 @@ func (_mock *MockTasksRepository) GetTasks(ctx context.Context) ([]models.Task,
 
 	var r0 []models.Task
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context) ([]models.Task, error)); ok {
-		return returnFunc(ctx)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, models.Pagination) ([]models.Task, error)); ok {
+		return returnFunc(ctx, pagination)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context) []models.Task); ok {
-		r0 = returnFunc(ctx)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, models.Pagination) []models.Task); ok {
+		r0 = returnFunc(ctx, pagination)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]models.Task)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
-		r1 = returnFunc(ctx)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, models.Pagination) error); ok {
+		r1 = returnFunc(ctx, pagination)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -2062,18 +4420,24 @@ type MockTasksRepository_GetTasks_Call struct {
 
 // GetTasks is a helper method to define mock.On call
 //   - ctx context.Context
-func (_e *MockTasksRepository_Expecter) GetTasks(ctx interface{}) *MockTasksRepository_GetTasks_Call {
-	return &MockTasksRepository_GetTasks_Call{Call: _e.mock.On("GetTasks", ctx)}
+//   - pagination models.Pagination
+func (_e *MockTasksRepository_Expecter) GetTasks(ctx interface{}, pagination interface{}) *MockTasksRepository_GetTasks_Call {
+	return &MockTasksRepository_GetTasks_Call{Call: _e.mock.On("GetTasks", ctx, pagination)}
 }
 
-func (_c *MockTasksRepository_GetTasks_Call) Run(run func(ctx context.Context)) *MockTasksRepository_GetTasks_Call {
+func (_c *MockTasksRepository_GetTasks_Call) Run(run func(ctx context.Context, pagination models.Pagination)) *MockTasksRepository_GetTasks_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
+		var arg1 models.Pagination
+		if args[1] != nil {
+			arg1 = args[1].(models.Pagination)
+		}
 		run(
 			arg0,
+			arg1,
 		)
 	})
 	return _c
@@ -2084,7 +4448,7 @@ func (_c *MockTasksRepository_GetTasks_Call) Return(tasks []models.Task, err err
 	return _c
 }
 
-func (_c *MockTasksRepository_GetTasks_Call) RunAndReturn(run func(ctx context.Context) ([]models.Task, error)) *MockTasksRepository_GetTasks_Call {
+func (_c *MockTasksRepository_GetTasks_Call) RunAndReturn(run func(ctx context.Context, pagination models.Pagination) ([]models.Task, error)) *MockTasksRepository_GetTasks_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -2157,6 +4521,224 @@ func (_c *MockTasksRepository_GetTasksByUserId_Call) RunAndReturn(run func(ctx c
 	return _c
 }
 
+// NewMockUploadsRepository creates a new instance of MockUploadsRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockUploadsRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockUploadsRepository {
+	mock := &MockUploadsRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockUploadsRepository is an autogenerated mock type for the UploadsRepository type
+type MockUploadsRepository struct {
+	mock.Mock
+}
+
+type MockUploadsRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockUploadsRepository) EXPECT() *MockUploadsRepository_Expecter {
+	return &MockUploadsRepository_Expecter{mock: &_m.Mock}
+}
+
+// DeleteUpload provides a mock function for the type MockUploadsRepository
+func (_mock *MockUploadsRepository) DeleteUpload(ctx context.Context, key string) error {
+	ret := _mock.Called(ctx, key)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteUpload")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = returnFunc(ctx, key)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockUploadsRepository_DeleteUpload_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteUpload'
+type MockUploadsRepository_DeleteUpload_Call struct {
+	*mock.Call
+}
+
+// DeleteUpload is a helper method to define mock.On call
+//   - ctx context.Context
+//   - key string
+func (_e *MockUploadsRepository_Expecter) DeleteUpload(ctx interface{}, key interface{}) *MockUploadsRepository_DeleteUpload_Call {
+	return &MockUploadsRepository_DeleteUpload_Call{Call: _e.mock.On("DeleteUpload", ctx, key)}
+}
+
+func (_c *MockUploadsRepository_DeleteUpload_Call) Run(run func(ctx context.Context, key string)) *MockUploadsRepository_DeleteUpload_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockUploadsRepository_DeleteUpload_Call) Return(err error) *MockUploadsRepository_DeleteUpload_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockUploadsRepository_DeleteUpload_Call) RunAndReturn(run func(ctx context.Context, key string) error) *MockUploadsRepository_DeleteUpload_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetUpload provides a mock function for the type MockUploadsRepository
+func (_mock *MockUploadsRepository) GetUpload(ctx context.Context, key string) (io.ReadCloser, error) {
+	ret := _mock.Called(ctx, key)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUpload")
+	}
+
+	var r0 io.ReadCloser
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (io.ReadCloser, error)); ok {
+		return returnFunc(ctx, key)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) io.ReadCloser); ok {
+		r0 = returnFunc(ctx, key)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(io.ReadCloser)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, key)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockUploadsRepository_GetUpload_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUpload'
+type MockUploadsRepository_GetUpload_Call struct {
+	*mock.Call
+}
+
+// GetUpload is a helper method to define mock.On call
+//   - ctx context.Context
+//   - key string
+func (_e *MockUploadsRepository_Expecter) GetUpload(ctx interface{}, key interface{}) *MockUploadsRepository_GetUpload_Call {
+	return &MockUploadsRepository_GetUpload_Call{Call: _e.mock.On("GetUpload", ctx, key)}
+}
+
+func (_c *MockUploadsRepository_GetUpload_Call) Run(run func(ctx context.Context, key string)) *MockUploadsRepository_GetUpload_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockUploadsRepository_GetUpload_Call) Return(readCloser io.ReadCloser, err error) *MockUploadsRepository_GetUpload_Call {
+	_c.Call.Return(readCloser, err)
+	return _c
+}
+
+func (_c *MockUploadsRepository_GetUpload_Call) RunAndReturn(run func(ctx context.Context, key string) (io.ReadCloser, error)) *MockUploadsRepository_GetUpload_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PresignUpload provides a mock function for the type MockUploadsRepository
+func (_mock *MockUploadsRepository) PresignUpload(ctx context.Context, key string) (models.PhotoUpload, error) {
+	ret := _mock.Called(ctx, key)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PresignUpload")
+	}
+
+	var r0 models.PhotoUpload
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (models.PhotoUpload, error)); ok {
+		return returnFunc(ctx, key)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) models.PhotoUpload); ok {
+		r0 = returnFunc(ctx, key)
+	} else {
+		r0 = ret.Get(0).(models.PhotoUpload)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, key)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockUploadsRepository_PresignUpload_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PresignUpload'
+type MockUploadsRepository_PresignUpload_Call struct {
+	*mock.Call
+}
+
+// PresignUpload is a helper method to define mock.On call
+//   - ctx context.Context
+//   - key string
+func (_e *MockUploadsRepository_Expecter) PresignUpload(ctx interface{}, key interface{}) *MockUploadsRepository_PresignUpload_Call {
+	return &MockUploadsRepository_PresignUpload_Call{Call: _e.mock.On("PresignUpload", ctx, key)}
+}
+
+func (_c *MockUploadsRepository_PresignUpload_Call) Run(run func(ctx context.Context, key string)) *MockUploadsRepository_PresignUpload_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockUploadsRepository_PresignUpload_Call) Return(photoUpload models.PhotoUpload, err error) *MockUploadsRepository_PresignUpload_Call {
+	_c.Call.Return(photoUpload, err)
+	return _c
+}
+
+func (_c *MockUploadsRepository_PresignUpload_Call) RunAndReturn(run func(ctx context.Context, key string) (models.PhotoUpload, error)) *MockUploadsRepository_PresignUpload_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // NewMockUsersRepository creates a new instance of MockUsersRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewMockUsersRepository(t interface {
@@ -2383,8 +4965,8 @@ func (_c *MockUsersRepository_GetUserByLogin_Call) RunAndReturn(run func(ctx con
 }
 
 // GetUsers provides a mock function for the type MockUsersRepository
-func (_mock *MockUsersRepository) GetUsers(ctx context.Context) ([]models.User, error) {
-	ret := _mock.Called(ctx)
+func (_mock *MockUsersRepository) GetUsers(ctx context.Context, pagination models.Pagination) ([]models.User, error) {
+	ret := _mock.Called(ctx, pagination)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetUsers")
@@ -2392,18 +4974,18 @@ func (_mock *MockUsersRepository) GetUsers(ctx context.Context) ([]models.User,
 
 	var r0 []models.User
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context) ([]models.User, error)); ok {
-		return returnFunc(ctx)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, models.Pagination) ([]models.User, error)); ok {
+		return returnFunc(ctx, pagination)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context) []models.User); ok {
-		r0 = returnFunc(ctx)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, models.Pagination) []models.User); ok {
+		r0 = returnFunc(ctx, pagination)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]models.User)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
-		r1 = returnFunc(ctx)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, models.Pagination) error); ok {
+		r1 = returnFunc(ctx, pagination)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -2417,18 +4999,24 @@ type MockUsersRepository_GetUsers_Call struct {
 
 // GetUsers is a helper method to define mock.On call
 //   - ctx context.Context
-func (_e *MockUsersRepository_Expecter) GetUsers(ctx interface{}) *MockUsersRepository_GetUsers_Call {
-	return &MockUsersRepository_GetUsers_Call{Call: _e.mock.On("GetUsers", ctx)}
+//   - pagination models.Pagination
+func (_e *MockUsersRepository_Expecter) GetUsers(ctx interface{}, pagination interface{}) *MockUsersRepository_GetUsers_Call {
+	return &MockUsersRepository_GetUsers_Call{Call: _e.mock.On("GetUsers", ctx, pagination)}
 }
 
-func (_c *MockUsersRepository_GetUsers_Call) Run(run func(ctx context.Context)) *MockUsersRepository_GetUsers_Call {
+func (_c *MockUsersRepository_GetUsers_Call) Run(run func(ctx context.Context, pagination models.Pagination)) *MockUsersRepository_GetUsers_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
+		var arg1 models.Pagination
+		if args[1] != nil {
+			arg1 = args[1].(models.Pagination)
+		}
 		run(
 			arg0,
+			arg1,
 		)
 	})
 	return _c
@@ -2439,7 +5027,70 @@ func (_c *MockUsersRepository_GetUsers_Call) Return(users []models.User, err err
 	return _c
 }
 
-func (_c *MockUsersRepository_GetUsers_Call) RunAndReturn(run func(ctx context.Context) ([]models.User, error)) *MockUsersRepository_GetUsers_Call {
+func (_c *MockUsersRepository_GetUsers_Call) RunAndReturn(run func(ctx context.Context, pagination models.Pagination) ([]models.User, error)) *MockUsersRepository_GetUsers_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdatePassword provides a mock function for the type MockUsersRepository
+func (_mock *MockUsersRepository) UpdatePassword(ctx context.Context, userId int, passwordHash string) error {
+	ret := _mock.Called(ctx, userId, passwordHash)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdatePassword")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int, string) error); ok {
+		r0 = returnFunc(ctx, userId, passwordHash)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockUsersRepository_UpdatePassword_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdatePassword'
+type MockUsersRepository_UpdatePassword_Call struct {
+	*mock.Call
+}
+
+// UpdatePassword is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userId int
+//   - passwordHash string
+func (_e *MockUsersRepository_Expecter) UpdatePassword(ctx interface{}, userId interface{}, passwordHash interface{}) *MockUsersRepository_UpdatePassword_Call {
+	return &MockUsersRepository_UpdatePassword_Call{Call: _e.mock.On("UpdatePassword", ctx, userId, passwordHash)}
+}
+
+func (_c *MockUsersRepository_UpdatePassword_Call) Run(run func(ctx context.Context, userId int, passwordHash string)) *MockUsersRepository_UpdatePassword_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int
+		if args[1] != nil {
+			arg1 = args[1].(int)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockUsersRepository_UpdatePassword_Call) Return(err error) *MockUsersRepository_UpdatePassword_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockUsersRepository_UpdatePassword_Call) RunAndReturn(run func(ctx context.Context, userId int, passwordHash string) error) *MockUsersRepository_UpdatePassword_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -0,0 +1,204 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"time"
+
+	"github.com/PritOriginal/problem-map-server/internal/config"
+	"github.com/PritOriginal/problem-map-server/internal/models"
+	"github.com/PritOriginal/problem-map-server/internal/storage"
+)
+
+type ModerationRepository interface {
+	GetModerationQueue(ctx context.Context, filters models.GetModerationQueueFilters) ([]models.ModerationQueueItem, error)
+	ClaimMark(ctx context.Context, markId, userId int, ttl time.Duration) (models.ModerationClaim, error)
+	ReleaseMark(ctx context.Context, markId, userId int) error
+}
+
+const (
+	// maxModerationAge is the age of the current mark status with the full age priority
+	maxModerationAge = 7 * 24 * time.Hour
+	// maxModerationCandidates is the max number of marks the queue is prioritized from
+	maxModerationCandidates = 10000
+	// maxPopulationDensity is the population density per km² with the full density priority
+	maxPopulationDensity = 10000
+	// similarPhotoDistance is the max Hamming distance between the perceptual hashes of the similar photos
+	similarPhotoDistance = 10
+	// maxSimilarPhotos is the max number of the similar photos returned at once
+	maxSimilarPhotos = 20
+)
+
+// moderationStatuses are the mark statuses waiting for a moderator decision
+var moderationStatuses = []int{int(models.UnconfirmedStatus), int(models.UnderReviewStatus)}
+
+type Moderation struct {
+	log           *slog.Logger
+	moderationCfg config.ModerationConfig
+	repos         ModerationRepositories
+}
+
+type ModerationRepositories struct {
+	Marks      MarksRepository
+	Checks     ChecksRepository
+	Photos     PhotosRepository
+	Moderation ModerationRepository
+}
+
+func NewModeration(log *slog.Logger, moderationCfg config.ModerationConfig, repos ModerationRepositories) *Moderation {
+	return &Moderation{
+		log:           log,
+		moderationCfg: moderationCfg,
+		repos:         repos,
+	}
+}
+
+// GetQueue returns a page of the marks waiting for a moderator decision ordered by priority and the cursor to the next page.
+// The marks claimed by other moderators are skipped. The priority grows with the age of the mark status,
+// so it is computed at the time of the first page for all the pages.
+func (uc *Moderation) GetQueue(ctx context.Context, moderatorId int, pagination models.Pagination) ([]models.ModerationQueueItem, string, error) {
+	const op = "usecase.Moderation.GetQueue"
+
+	if pagination.Snapshot.IsZero() {
+		pagination.Snapshot = time.Now()
+	}
+	stalledBefore := pagination.Snapshot.Add(-uc.moderationCfg.StallAfter)
+
+	items, nextCursor, err := fetchPage(pagination, func(pagination models.Pagination) ([]models.ModerationQueueItem, error) {
+		return uc.repos.Moderation.GetModerationQueue(ctx, models.GetModerationQueueFilters{
+			MarkStatusIds: moderationStatuses,
+			ModeratorId:   moderatorId,
+			StalledBefore: stalledBefore,
+			MaxCandidates: maxModerationCandidates,
+			Priority: models.ModerationPriority{
+				MaxAge:               maxModerationAge,
+				MaxPopulationDensity: maxPopulationDensity,
+				MarkTypePriorities:   uc.moderationCfg.MarkTypePriorities,
+			},
+			Pagination: pagination,
+		})
+	})
+	if err != nil {
+		return items, "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	for i := range items {
+		explain(&items[i], stalledBefore)
+	}
+
+	return items, nextCursor, nil
+}
+
+// explain sets the reasons the queue item needs a moderator decision
+func explain(item *models.ModerationQueueItem, stalledBefore time.Time) {
+	item.Reasons = []models.ModerationReason{}
+
+	if item.VotesFor > 0 && item.VotesAgainst > 0 {
+		item.Reasons = append(item.Reasons, models.SplitVotesReason)
+	}
+	if item.LastActivityAt.Before(stalledBefore) {
+		item.Reasons = append(item.Reasons, models.StalledReason)
+	}
+	if item.DuplicatePhotos {
+		item.Reasons = append(item.Reasons, models.DuplicatePhotosReason)
+	}
+}
+
+// Claim reserves the mark for the moderator for the claim TTL, claiming the own mark again extends the claim
+func (uc *Moderation) Claim(ctx context.Context, moderatorId, markId int) (models.ModerationClaim, error) {
+	const op = "usecase.Moderation.Claim"
+
+	mark, err := uc.repos.Marks.GetMarkById(ctx, markId)
+	if err != nil {
+		return models.ModerationClaim{}, fmt.Errorf("%s: %w", op, err)
+	}
+	if !slices.Contains(moderationStatuses, int(mark.MarkStatusID)) {
+		return models.ModerationClaim{}, fmt.Errorf("%s: %w", op, ErrConflict)
+	}
+
+	claim, err := uc.repos.Moderation.ClaimMark(ctx, mark.ID, moderatorId, uc.moderationCfg.ClaimTTL)
+	if err != nil {
+		if errors.Is(err, storage.ErrExists) {
+			return claim, fmt.Errorf("%s: %w", op, ErrConflict)
+		}
+		return claim, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return claim, nil
+}
+
+// Release removes the moderator's claim of the mark
+func (uc *Moderation) Release(ctx context.Context, moderatorId, markId int) error {
+	const op = "usecase.Moderation.Release"
+
+	if err := uc.repos.Moderation.ReleaseMark(ctx, markId, moderatorId); err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			return fmt.Errorf("%s: %w", op, ErrNotFound)
+		}
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// GetSimilarPhotos returns the photos of the other checks similar to the check photo with the number ordered by similarity
+func (uc *Moderation) GetSimilarPhotos(ctx context.Context, checkId, number int) ([]models.SimilarPhoto, error) {
+	const op = "usecase.Moderation.GetSimilarPhotos"
+
+	metadata, err := uc.repos.Checks.GetPhotoMetadata(ctx, checkId, number)
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			return nil, fmt.Errorf("%s: %w", op, ErrNotFound)
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	if !metadata.PHash.Valid {
+		return []models.SimilarPhoto{}, nil
+	}
+
+	photos, err := uc.repos.Checks.GetSimilarPhotos(ctx, models.GetSimilarPhotosFilters{
+		PHash:          metadata.PHash.Int64,
+		MaxDistance:    similarPhotoDistance,
+		ExcludeCheckId: checkId,
+		Limit:          maxSimilarPhotos,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	if len(photos) == 0 {
+		return photos, nil
+	}
+
+	checkIds := make([]int, 0, len(photos))
+	for _, photo := range photos {
+		if !slices.Contains(checkIds, photo.CheckID) {
+			checkIds = append(checkIds, photo.CheckID)
+		}
+	}
+	records, err := uc.repos.Checks.GetPhotoRecordsByCheckIds(ctx, checkIds)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	for i := range photos {
+		urls := make(map[string]string)
+		var photoRecords []models.PhotoRecord
+		for _, record := range records {
+			if record.CheckID != photos[i].CheckID || record.Number != photos[i].Number {
+				continue
+			}
+			if urls[record.Key], err = uc.repos.Photos.URL(ctx, record.Key); err != nil {
+				return nil, fmt.Errorf("%s: %w", op, err)
+			}
+			photoRecords = append(photoRecords, record)
+		}
+		if grouped := storage.GroupPhotoURLs(photoRecords, urls)[photos[i].CheckID]; len(grouped) > 0 {
+			photos[i].URLs = grouped[0]
+		}
+	}
+
+	return photos, nil
+}
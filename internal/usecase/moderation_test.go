@@ -0,0 +1,365 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/PritOriginal/problem-map-server/internal/config"
+	"github.com/PritOriginal/problem-map-server/internal/models"
+	"github.com/PritOriginal/problem-map-server/internal/storage"
+	"github.com/PritOriginal/problem-map-server/internal/usecase"
+	"github.com/PritOriginal/problem-map-server/pkg/logger/slogdiscard"
+	"github.com/guregu/null/v6"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type ModerationSuite struct {
+	suite.Suite
+	uc             *usecase.Moderation
+	log            *slog.Logger
+	moderationCfg  config.ModerationConfig
+	marksRepo      *usecase.MockMarksRepository
+	checksRepo     *usecase.MockChecksRepository
+	photosRepo     *usecase.MockPhotosRepository
+	moderationRepo *usecase.MockModerationRepository
+}
+
+func (suite *ModerationSuite) SetupSuite() {
+	suite.log = slogdiscard.NewDiscardLogger()
+	suite.marksRepo = usecase.NewMockMarksRepository(suite.T())
+	suite.checksRepo = usecase.NewMockChecksRepository(suite.T())
+	suite.photosRepo = usecase.NewMockPhotosRepository(suite.T())
+	suite.moderationRepo = usecase.NewMockModerationRepository(suite.T())
+	suite.moderationCfg = config.MustLoadPath("../../configs/config-tests.yaml").Moderation
+	suite.uc = usecase.NewModeration(suite.log, suite.moderationCfg, usecase.ModerationRepositories{
+		Marks:      suite.marksRepo,
+		Checks:     suite.checksRepo,
+		Photos:     suite.photosRepo,
+		Moderation: suite.moderationRepo,
+	})
+}
+
+func TestModeration(t *testing.T) {
+	suite.Run(t, new(ModerationSuite))
+}
+
+// moderationQueue returns the queue ordered by priority with the split votes mark 3, the split votes
+// mark 1 and the stalled mark 2
+func (suite *ModerationSuite) moderationQueue() []models.ModerationQueueItem {
+	now := time.Now()
+	stalled := now.Add(-2 * suite.moderationCfg.StallAfter)
+	return []models.ModerationQueueItem{
+		{
+			Mark:              models.Mark{ID: 3, MarkTypeID: 1},
+			StatusChangedAt:   stalled,
+			LastActivityAt:    now,
+			VotesFor:          2,
+			VotesAgainst:      1,
+			PopulationDensity: 5000,
+			Priority:          2.5,
+		},
+		{
+			Mark:            models.Mark{ID: 1, MarkTypeID: 2},
+			StatusChangedAt: now,
+			LastActivityAt:  now,
+			VotesFor:        1,
+			VotesAgainst:    1,
+			Priority:        1,
+		},
+		{
+			Mark:            models.Mark{ID: 2, MarkTypeID: 2},
+			StatusChangedAt: stalled,
+			LastActivityAt:  stalled,
+			Priority:        0.5,
+		},
+	}
+}
+
+func (suite *ModerationSuite) TestGetQueue() {
+	snapshot := time.Now().Add(-time.Hour).UTC()
+
+	tests := []struct {
+		name               string
+		pagination         models.Pagination
+		getModerationQueue method[[]models.ModerationQueueItem]
+		wantIds            []int
+		wantNextCursor     bool
+	}{
+		{
+			name:       "Ok",
+			pagination: models.Pagination{SortBy: models.SortByPriority, Order: models.SortDesc},
+			getModerationQueue: method[[]models.ModerationQueueItem]{
+				data: suite.moderationQueue(),
+			},
+			wantIds: []int{3, 1, 2},
+		},
+		{
+			name:       "OkFirstPage",
+			pagination: models.Pagination{Limit: 2, SortBy: models.SortByPriority, Order: models.SortDesc},
+			getModerationQueue: method[[]models.ModerationQueueItem]{
+				data: suite.moderationQueue(),
+			},
+			wantIds:        []int{3, 1},
+			wantNextCursor: true,
+		},
+		{
+			name: "OkNextPage",
+			pagination: models.Pagination{
+				Limit:    2,
+				SortBy:   models.SortByPriority,
+				Order:    models.SortDesc,
+				Cursor:   &models.Cursor{SortBy: models.SortByPriority, Order: models.SortDesc, Value: "1", ID: 1, Snapshot: snapshot},
+				Snapshot: snapshot,
+			},
+			getModerationQueue: method[[]models.ModerationQueueItem]{
+				data: suite.moderationQueue()[2:],
+			},
+			wantIds: []int{2},
+		},
+		{
+			name:       "Err",
+			pagination: models.Pagination{SortBy: models.SortByPriority, Order: models.SortDesc},
+			getModerationQueue: method[[]models.ModerationQueueItem]{
+				data: nil,
+				err:  errors.New(""),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		suite.Run(tt.name, func() {
+			var gotFilters models.GetModerationQueueFilters
+			suite.moderationRepo.On("GetModerationQueue", mock.Anything, mock.MatchedBy(func(filters models.GetModerationQueueFilters) bool {
+				gotFilters = filters
+				return filters.ModeratorId == 1 && len(filters.MarkStatusIds) == 2
+			})).Once().
+				Return(tt.getModerationQueue.data, tt.getModerationQueue.err)
+
+			items, nextCursor, gotErr := suite.uc.GetQueue(context.Background(), 1, tt.pagination)
+
+			if tt.getModerationQueue.err == nil {
+				suite.NoError(gotErr)
+				ids := make([]int, len(items))
+				for i, item := range items {
+					ids[i] = item.ID
+				}
+				suite.Equal(tt.wantIds, ids)
+				suite.Equal(tt.wantNextCursor, nextCursor != "")
+			} else {
+				suite.NotNil(gotErr)
+			}
+
+			suite.Positive(gotFilters.MaxCandidates)
+			suite.Equal(suite.moderationCfg.MarkTypePriorities, gotFilters.Priority.MarkTypePriorities)
+			if !tt.pagination.Snapshot.IsZero() {
+				suite.True(gotFilters.Pagination.Snapshot.Equal(snapshot))
+			}
+			suite.True(gotFilters.StalledBefore.Equal(gotFilters.Pagination.Snapshot.Add(-suite.moderationCfg.StallAfter)))
+			if nextCursor != "" {
+				cursor, err := models.DecodeCursor(nextCursor)
+				suite.Require().NoError(err)
+				suite.True(cursor.Snapshot.Equal(gotFilters.Pagination.Snapshot))
+			}
+			suite.moderationRepo.AssertExpectations(suite.T())
+		})
+	}
+}
+
+func (suite *ModerationSuite) TestGetQueueReasons() {
+	now := time.Now()
+	suite.moderationRepo.On("GetModerationQueue", mock.Anything, mock.Anything).Once().
+		Return(append(suite.moderationQueue(), models.ModerationQueueItem{
+			Mark: models.Mark{ID: 4}, StatusChangedAt: now, LastActivityAt: now, DuplicatePhotos: true,
+		}), nil)
+
+	items, _, err := suite.uc.GetQueue(context.Background(), 1, models.Pagination{SortBy: models.SortByPriority, Order: models.SortDesc})
+
+	suite.Require().NoError(err)
+	reasons := map[int][]models.ModerationReason{}
+	for _, item := range items {
+		reasons[item.ID] = item.Reasons
+	}
+	suite.Equal([]models.ModerationReason{models.SplitVotesReason}, reasons[1])
+	suite.Equal([]models.ModerationReason{models.StalledReason}, reasons[2])
+	suite.Equal([]models.ModerationReason{models.SplitVotesReason}, reasons[3])
+	suite.Equal([]models.ModerationReason{models.DuplicatePhotosReason}, reasons[4])
+}
+
+func (suite *ModerationSuite) TestGetSimilarPhotos() {
+	tests := []struct {
+		name             string
+		getPhotoMetadata method[models.PhotoMetadata]
+		getSimilarPhotos *method[[]models.SimilarPhoto]
+		wantPhotos       []models.SimilarPhoto
+		wantErr          error
+	}{
+		{
+			name: "Ok",
+			getPhotoMetadata: method[models.PhotoMetadata]{
+				data: models.PhotoMetadata{PHash: null.IntFrom(7)},
+			},
+			getSimilarPhotos: &method[[]models.SimilarPhoto]{
+				data: []models.SimilarPhoto{{MarkID: 3, CheckID: 2, Number: 2, Distance: 1}},
+			},
+			wantPhotos: []models.SimilarPhoto{{
+				MarkID: 3, CheckID: 2, Number: 2, Distance: 1,
+				URLs: models.PhotoURLs{Original: "marks/3/2/2.jpg", Thumbnail: "marks/3/2/2.jpg", Medium: "marks/3/2/2.jpg"},
+			}},
+		},
+		{
+			name: "OkNoSimilar",
+			getPhotoMetadata: method[models.PhotoMetadata]{
+				data: models.PhotoMetadata{PHash: null.IntFrom(7)},
+			},
+			getSimilarPhotos: &method[[]models.SimilarPhoto]{
+				data: []models.SimilarPhoto{},
+			},
+			wantPhotos: []models.SimilarPhoto{},
+		},
+		{
+			name:       "OkNoHash",
+			wantPhotos: []models.SimilarPhoto{},
+		},
+		{
+			name: "ErrNotFound",
+			getPhotoMetadata: method[models.PhotoMetadata]{
+				err: storage.ErrNotFound,
+			},
+			wantErr: usecase.ErrNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		suite.Run(tt.name, func() {
+			suite.checksRepo.On("GetPhotoMetadata", mock.Anything, 1, 1).Once().
+				Return(tt.getPhotoMetadata.data, tt.getPhotoMetadata.err)
+			if tt.getSimilarPhotos != nil {
+				suite.checksRepo.On("GetSimilarPhotos", mock.Anything, mock.MatchedBy(func(filters models.GetSimilarPhotosFilters) bool {
+					return filters.PHash == 7 && filters.ExcludeCheckId == 1
+				})).Once().
+					Return(tt.getSimilarPhotos.data, tt.getSimilarPhotos.err)
+			}
+			if len(tt.wantPhotos) > 0 {
+				suite.checksRepo.On("GetPhotoRecordsByCheckIds", mock.Anything, []int{2}).Once().
+					Return([]models.PhotoRecord{
+						{Key: "marks/3/2/1.jpg", CheckID: 2, Number: 1, Variant: models.OriginalVariant},
+						{Key: "marks/3/2/2.jpg", CheckID: 2, Number: 2, Variant: models.OriginalVariant},
+					}, nil)
+				suite.photosRepo.On("URL", mock.Anything, "marks/3/2/2.jpg").Once().
+					Return("marks/3/2/2.jpg", nil)
+			}
+
+			photos, gotErr := suite.uc.GetSimilarPhotos(context.Background(), 1, 1)
+
+			if tt.wantErr == nil {
+				suite.NoError(gotErr)
+				suite.Equal(tt.wantPhotos, photos)
+			} else {
+				suite.ErrorIs(gotErr, tt.wantErr)
+			}
+			suite.checksRepo.AssertExpectations(suite.T())
+			suite.photosRepo.AssertExpectations(suite.T())
+		})
+	}
+}
+
+func (suite *ModerationSuite) TestClaim() {
+	tests := []struct {
+		name        string
+		getMarkById method[models.Mark]
+		claimMark   *method[models.ModerationClaim]
+		wantErr     error
+	}{
+		{
+			name: "Ok",
+			getMarkById: method[models.Mark]{
+				data: models.Mark{ID: 1, MarkStatusID: models.UnconfirmedStatus},
+			},
+			claimMark: &method[models.ModerationClaim]{
+				data: models.ModerationClaim{MarkID: 1, UserID: 1},
+			},
+		},
+		{
+			name: "ErrNotFound",
+			getMarkById: method[models.Mark]{
+				err: storage.ErrNotFound,
+			},
+			wantErr: storage.ErrNotFound,
+		},
+		{
+			name: "ErrClosedMark",
+			getMarkById: method[models.Mark]{
+				data: models.Mark{ID: 1, MarkStatusID: models.ClosedStatus},
+			},
+			wantErr: usecase.ErrConflict,
+		},
+		{
+			name: "ErrClaimedByAnother",
+			getMarkById: method[models.Mark]{
+				data: models.Mark{ID: 1, MarkStatusID: models.UnderReviewStatus},
+			},
+			claimMark: &method[models.ModerationClaim]{
+				err: storage.ErrExists,
+			},
+			wantErr: usecase.ErrConflict,
+		},
+	}
+
+	for _, tt := range tests {
+		suite.Run(tt.name, func() {
+			suite.marksRepo.On("GetMarkById", mock.Anything, 1).Once().
+				Return(tt.getMarkById.data, tt.getMarkById.err)
+			if tt.claimMark != nil {
+				suite.moderationRepo.On("ClaimMark", mock.Anything, 1, 1, suite.moderationCfg.ClaimTTL).Once().
+					Return(tt.claimMark.data, tt.claimMark.err)
+			}
+
+			_, gotErr := suite.uc.Claim(context.Background(), 1, 1)
+
+			if tt.wantErr == nil {
+				suite.NoError(gotErr)
+			} else {
+				suite.ErrorIs(gotErr, tt.wantErr)
+			}
+			suite.marksRepo.AssertExpectations(suite.T())
+			suite.moderationRepo.AssertExpectations(suite.T())
+		})
+	}
+}
+
+func (suite *ModerationSuite) TestRelease() {
+	tests := []struct {
+		name           string
+		errReleaseMark error
+		wantErr        error
+	}{
+		{
+			name: "Ok",
+		},
+		{
+			name:           "ErrNotFound",
+			errReleaseMark: storage.ErrNotFound,
+			wantErr:        usecase.ErrNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		suite.Run(tt.name, func() {
+			suite.moderationRepo.On("ReleaseMark", mock.Anything, 1, 1).Once().
+				Return(tt.errReleaseMark)
+
+			gotErr := suite.uc.Release(context.Background(), 1, 1)
+
+			if tt.wantErr == nil {
+				suite.NoError(gotErr)
+			} else {
+				suite.ErrorIs(gotErr, tt.wantErr)
+			}
+			suite.moderationRepo.AssertExpectations(suite.T())
+		})
+	}
+}
@@ -0,0 +1,25 @@
+package usecase
+
+import "github.com/PritOriginal/problem-map-server/internal/models"
+
+// fetchPage fetches one item more than the page limit to find out whether there is a next page.
+// It returns the page and the encoded cursor to the next page, which is empty on the last page.
+func fetchPage[T models.Paginated](pagination models.Pagination, fetch func(models.Pagination) ([]T, error)) ([]T, string, error) {
+	if pagination.Limit <= 0 || pagination.Limit > models.MaxPageLimit {
+		pagination.Limit = models.MaxPageLimit
+	}
+	limit := pagination.Limit
+	pagination.Limit++
+
+	items, err := fetch(pagination)
+	if err != nil {
+		return items, "", err
+	}
+
+	if len(items) <= limit {
+		return items, "", nil
+	}
+
+	items = items[:limit]
+	return items, pagination.NextCursor(items[limit-1]).Encode(), nil
+}
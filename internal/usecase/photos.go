@@ -0,0 +1,123 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"slices"
+
+	"github.com/PritOriginal/problem-map-server/internal/models"
+	"github.com/PritOriginal/problem-map-server/internal/storage"
+	"github.com/PritOriginal/problem-map-server/pkg/logger"
+)
+
+// photosBackfillBatch is the number of the checks the photo records are backfilled for at once
+const photosBackfillBatch = 100
+
+type PhotosRepositories struct {
+	Checks ChecksRepository
+	Photos PhotosRepository
+}
+
+// Photos keeps the records of the stored photo files
+type Photos struct {
+	log   *slog.Logger
+	repos PhotosRepositories
+}
+
+func NewPhotos(log *slog.Logger, repos PhotosRepositories) *Photos {
+	return &Photos{
+		log:   log,
+		repos: repos,
+	}
+}
+
+// BackfillRecords stores the records of the photo files stored before the photos were recorded,
+// the URLs of the photos are resolved only from their records.
+// The files not named by PhotoKey or not images are skipped. It returns the number of the stored records.
+func (uc *Photos) BackfillRecords(ctx context.Context) (int64, error) {
+	const op = "usecase.Photos.BackfillRecords"
+
+	keys, err := uc.repos.Photos.ListPhotos(ctx, "marks/")
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	keysByCheck := make(map[int][]string)
+	for _, key := range keys {
+		_, checkId, _, _, err := storage.ParsePhotoKey(key)
+		if err != nil {
+			uc.log.Warn("skipped photo", slog.String("key", key), logger.Err(err))
+			continue
+		}
+		keysByCheck[checkId] = append(keysByCheck[checkId], key)
+	}
+
+	checkIds := make([]int, 0, len(keysByCheck))
+	for checkId := range keysByCheck {
+		checkIds = append(checkIds, checkId)
+	}
+	slices.Sort(checkIds)
+
+	var added int64
+	for batch := range slices.Chunk(checkIds, photosBackfillBatch) {
+		n, err := uc.backfillChecks(ctx, batch, keysByCheck)
+		added += n
+		if err != nil {
+			return added, fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	return added, nil
+}
+
+// backfillChecks stores the records of the photo files of the checks missing in the photos table
+func (uc *Photos) backfillChecks(ctx context.Context, checkIds []int, keysByCheck map[int][]string) (int64, error) {
+	records, err := uc.repos.Checks.GetPhotoRecordsByCheckIds(ctx, checkIds)
+	if err != nil {
+		return 0, err
+	}
+	recorded := make(map[string]bool, len(records))
+	for _, record := range records {
+		recorded[record.Key] = true
+	}
+
+	var missing []models.PhotoRecord
+	for _, checkId := range checkIds {
+		for _, key := range keysByCheck[checkId] {
+			if recorded[key] {
+				continue
+			}
+
+			data, err := uc.readPhoto(ctx, key)
+			if errors.Is(err, storage.ErrNotFound) {
+				// the photo is deleted after the listing
+				continue
+			}
+			if err != nil {
+				return 0, err
+			}
+
+			record, err := storage.NewPhotoRecord(key, data)
+			if err != nil {
+				uc.log.Warn("skipped photo", slog.String("key", key), logger.Err(err))
+				continue
+			}
+			missing = append(missing, record)
+		}
+	}
+
+	return uc.repos.Checks.AddMissingPhotoRecords(ctx, missing)
+}
+
+func (uc *Photos) readPhoto(ctx context.Context, key string) ([]byte, error) {
+	body, err := uc.repos.Photos.GetPhoto(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+
+	return io.ReadAll(body)
+}
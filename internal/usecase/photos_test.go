@@ -0,0 +1,133 @@
+package usecase_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"slices"
+	"testing"
+
+	"github.com/PritOriginal/problem-map-server/internal/models"
+	"github.com/PritOriginal/problem-map-server/internal/storage"
+	"github.com/PritOriginal/problem-map-server/internal/usecase"
+	"github.com/PritOriginal/problem-map-server/pkg/logger/slogdiscard"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type PhotosSuite struct {
+	suite.Suite
+	uc         *usecase.Photos
+	log        *slog.Logger
+	checksRepo *usecase.MockChecksRepository
+	photosRepo *usecase.MockPhotosRepository
+}
+
+func (suite *PhotosSuite) SetupSuite() {
+	suite.log = slogdiscard.NewDiscardLogger()
+	suite.checksRepo = usecase.NewMockChecksRepository(suite.T())
+	suite.photosRepo = usecase.NewMockPhotosRepository(suite.T())
+	suite.uc = usecase.NewPhotos(suite.log, usecase.PhotosRepositories{
+		Checks: suite.checksRepo,
+		Photos: suite.photosRepo,
+	})
+}
+
+func TestPhotos(t *testing.T) {
+	suite.Run(t, new(PhotosSuite))
+}
+
+func (suite *PhotosSuite) TestBackfillRecords() {
+	photo := testJPEG(suite.T())
+
+	tests := []struct {
+		name       string
+		listPhotos method[[]string]
+		recorded   []models.PhotoRecord
+		getPhoto   map[string]method[[]byte]
+		wantKeys   []string
+		wantErr    bool
+	}{
+		{
+			name: "Ok",
+			listPhotos: method[[]string]{
+				data: []string{"marks/2/1/1.jpg", "marks/2/1/1_thumbnail.jpg", "marks/2/1/2.jpg", "marks/2/3/1.jpg"},
+			},
+			recorded: []models.PhotoRecord{{Key: "marks/2/1/1.jpg", MarkID: 2, CheckID: 1, Number: 1}},
+			getPhoto: map[string]method[[]byte]{
+				"marks/2/1/1_thumbnail.jpg": {data: photo},
+				"marks/2/1/2.jpg":           {data: photo},
+				"marks/2/3/1.jpg":           {data: photo},
+			},
+			wantKeys: []string{"marks/2/1/1_thumbnail.jpg", "marks/2/1/2.jpg", "marks/2/3/1.jpg"},
+		},
+		{
+			name: "OkSkipInvalid",
+			listPhotos: method[[]string]{
+				data: []string{"marks/2/1/notes.txt", "marks/2/1/1_large.jpg", "marks/2/1/2.jpg", "marks/2/1/3.jpg"},
+			},
+			getPhoto: map[string]method[[]byte]{
+				"marks/2/1/2.jpg": {data: []byte("not an image")},
+				"marks/2/1/3.jpg": {err: storage.ErrNotFound},
+			},
+		},
+		{
+			name: "OkNoPhotos",
+		},
+		{
+			name:       "ErrListPhotos",
+			listPhotos: method[[]string]{err: errors.New("")},
+			wantErr:    true,
+		},
+		{
+			name: "ErrGetPhoto",
+			listPhotos: method[[]string]{
+				data: []string{"marks/2/1/1.jpg"},
+			},
+			getPhoto: map[string]method[[]byte]{
+				"marks/2/1/1.jpg": {err: errors.New("")},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		suite.Run(tt.name, func() {
+			suite.photosRepo.On("ListPhotos", mock.Anything, "marks/").Once().
+				Return(tt.listPhotos.data, tt.listPhotos.err)
+			if len(tt.listPhotos.data) > 0 {
+				suite.checksRepo.On("GetPhotoRecordsByCheckIds", mock.Anything, mock.Anything).Once().
+					Return(tt.recorded, nil)
+				for key, got := range tt.getPhoto {
+					suite.photosRepo.On("GetPhoto", mock.Anything, key).Once().
+						Return(io.NopCloser(bytes.NewReader(got.data)), got.err)
+				}
+				if !tt.wantErr {
+					suite.checksRepo.On("AddMissingPhotoRecords", mock.Anything, mock.MatchedBy(func(records []models.PhotoRecord) bool {
+						keys := make([]string, len(records))
+						for i, record := range records {
+							if record.Width != 2 || record.Height != 2 || len(record.Hash) != 64 {
+								return false
+							}
+							keys[i] = record.Key
+						}
+						return slices.Equal(keys, tt.wantKeys)
+					})).Once().
+						Return(int64(len(tt.wantKeys)), nil)
+				}
+			}
+
+			added, gotErr := suite.uc.BackfillRecords(context.Background())
+
+			if tt.wantErr {
+				suite.NotNil(gotErr)
+			} else {
+				suite.NoError(gotErr)
+				suite.Equal(int64(len(tt.wantKeys)), added)
+			}
+			suite.checksRepo.AssertExpectations(suite.T())
+			suite.photosRepo.AssertExpectations(suite.T())
+		})
+	}
+}
@@ -9,7 +9,7 @@ import (
 )
 
 type TasksRepository interface {
-	GetTasks(ctx context.Context) ([]models.Task, error)
+	GetTasks(ctx context.Context, pagination models.Pagination) ([]models.Task, error)
 	GetTaskById(ctx context.Context, id int) (models.Task, error)
 	GetTasksByUserId(ctx context.Context, userId int) ([]models.Task, error)
 	AddTask(ctx context.Context, task models.Task) (int64, error)
@@ -28,15 +28,17 @@ func NewTasks(log *slog.Logger, repos TasksRepositories) *Tasks {
 	return &Tasks{log: log, repos: repos}
 }
 
-func (uc *Tasks) GetTasks(ctx context.Context) ([]models.Task, error) {
+func (uc *Tasks) GetTasks(ctx context.Context, pagination models.Pagination) ([]models.Task, string, error) {
 	const op = "usecase.Tasks.GetTasks"
 
-	tasks, err := uc.repos.Tasks.GetTasks(ctx)
+	tasks, nextCursor, err := fetchPage(pagination, func(pagination models.Pagination) ([]models.Task, error) {
+		return uc.repos.Tasks.GetTasks(ctx, pagination)
+	})
 	if err != nil {
-		return tasks, fmt.Errorf("%s: %w", op, err)
+		return tasks, "", fmt.Errorf("%s: %w", op, err)
 	}
 
-	return tasks, nil
+	return tasks, nextCursor, nil
 }
 
 func (uc *Tasks) GetTaskById(ctx context.Context, id int) (models.Task, error) {
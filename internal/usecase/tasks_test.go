@@ -56,14 +56,14 @@ func (suite *TasksSuite) TestGetTasks() {
 	for _, tt := range tests {
 		suite.Run(tt.name, func() {
 			func() {
-				suite.tasksRepo.On("GetTasks", mock.Anything).Once().
+				suite.tasksRepo.On("GetTasks", mock.Anything, mock.Anything).Once().
 					Return(tt.getTasks.data, tt.getTasks.err)
 				if tt.getTasks.err != nil {
 					return
 				}
 			}()
 
-			_, gotErr := suite.uc.GetTasks(context.Background())
+			_, _, gotErr := suite.uc.GetTasks(context.Background(), models.Pagination{})
 
 			if tt.getTasks.err == nil {
 				suite.NoError(gotErr)
@@ -0,0 +1,158 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+
+	"github.com/PritOriginal/problem-map-server/internal/config"
+	"github.com/PritOriginal/problem-map-server/internal/models"
+	"github.com/PritOriginal/problem-map-server/internal/storage"
+	"github.com/PritOriginal/problem-map-server/pkg/logger"
+)
+
+type UploadsRepository interface {
+	PresignUpload(ctx context.Context, key string) (models.PhotoUpload, error)
+	GetUpload(ctx context.Context, key string) (io.ReadCloser, error)
+	DeleteUpload(ctx context.Context, key string) error
+}
+
+// MaxUploads is the max number of photos uploaded or attached at once
+const MaxUploads = 10
+
+type UploadsRepositories struct {
+	Checks  ChecksRepository
+	Photos  PhotosRepository
+	Uploads UploadsRepository
+}
+
+// Uploads lets the clients upload the photos directly to the photo storage and attach them to the checks afterwards
+type Uploads struct {
+	log    *slog.Logger
+	awsCfg config.AwsConfig
+	repos  UploadsRepositories
+}
+
+func NewUploads(log *slog.Logger, awsCfg config.AwsConfig, repos UploadsRepositories) *Uploads {
+	return &Uploads{
+		log:    log,
+		awsCfg: awsCfg,
+		repos:  repos,
+	}
+}
+
+// CreateUploads returns the presigned URLs the user uploads the photos by
+func (uc *Uploads) CreateUploads(ctx context.Context, userId, count int) ([]models.PhotoUpload, error) {
+	const op = "usecase.Uploads.CreateUploads"
+
+	if count < 1 || count > MaxUploads {
+		return nil, fmt.Errorf("%s: %w", op, ErrInvalidInput)
+	}
+
+	uploads := make([]models.PhotoUpload, count)
+	for i := range uploads {
+		upload, err := uc.repos.Uploads.PresignUpload(ctx, storage.UploadKey(userId, rand.Text()))
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		uploads[i] = upload
+	}
+
+	return uploads, nil
+}
+
+// AttachPhotos validates the photos uploaded by the user and attaches them to the user's check after its photos.
+// The photos are processed as the ones uploaded through the API, the uploads are deleted afterwards.
+// It returns the photos of the check.
+func (uc *Uploads) AttachPhotos(ctx context.Context, userId, checkId int, keys []string) ([]models.PhotoURLs, error) {
+	const op = "usecase.Uploads.AttachPhotos"
+
+	if len(keys) == 0 || len(keys) > MaxUploads {
+		return nil, fmt.Errorf("%s: %w", op, ErrInvalidInput)
+	}
+	seen := make(map[string]bool, len(keys))
+	for _, key := range keys {
+		ownerId, err := storage.ParseUploadKey(key)
+		if err != nil || seen[key] {
+			return nil, fmt.Errorf("%s: %w: invalid upload key %q", op, ErrInvalidInput, key)
+		}
+		if ownerId != userId {
+			return nil, fmt.Errorf("%s: %w", op, ErrForbidden)
+		}
+		seen[key] = true
+	}
+
+	check, err := uc.repos.Checks.GetCheckById(ctx, checkId)
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			return nil, fmt.Errorf("%s: %w", op, ErrNotFound)
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	if check.UserID != userId {
+		return nil, fmt.Errorf("%s: %w", op, ErrForbidden)
+	}
+
+	photos := make([]models.Photo, len(keys))
+	for i, key := range keys {
+		if photos[i], err = uc.readUpload(ctx, key); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	records, err := uc.repos.Checks.GetPhotoRecordsByCheckIds(ctx, []int{checkId})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	first := 1
+	for _, record := range records {
+		first = max(first, record.Number+1)
+	}
+
+	if err := addPhotos(ctx, uc.repos.Checks, uc.repos.Photos, check.MarkID, checkId, first, photos); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	for _, key := range keys {
+		if err := uc.repos.Uploads.DeleteUpload(ctx, key); err != nil {
+			uc.log.Error("failed delete attached upload", slog.String("key", key), logger.Err(err))
+		}
+	}
+
+	checks := []models.Check{check}
+	if err := setPhotos(ctx, uc.repos.Checks, uc.repos.Photos, checks); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return checks[0].Photos, nil
+}
+
+// readUpload reads the uploaded photo, the missing, too large and not image uploads are invalid
+func (uc *Uploads) readUpload(ctx context.Context, key string) (models.Photo, error) {
+	body, err := uc.repos.Uploads.GetUpload(ctx, key)
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			return models.Photo{}, fmt.Errorf("%w: the upload %q is not found", ErrInvalidInput, key)
+		}
+		return models.Photo{}, err
+	}
+	defer body.Close()
+
+	data, err := io.ReadAll(io.LimitReader(body, uc.awsCfg.MaxUploadSize+1))
+	if err != nil {
+		return models.Photo{}, err
+	}
+	if int64(len(data)) > uc.awsCfg.MaxUploadSize {
+		return models.Photo{}, fmt.Errorf("%w: the upload %q is larger than %d bytes", ErrInvalidInput, key, uc.awsCfg.MaxUploadSize)
+	}
+
+	photo, err := storage.DecodePhoto(data)
+	if err != nil {
+		return models.Photo{}, fmt.Errorf("%w: the upload %q is not an image: %v", ErrInvalidInput, key, err)
+	}
+
+	return photo, nil
+}
@@ -0,0 +1,268 @@
+package usecase_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"image"
+	"image/jpeg"
+	"io"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/PritOriginal/problem-map-server/internal/config"
+	"github.com/PritOriginal/problem-map-server/internal/models"
+	"github.com/PritOriginal/problem-map-server/internal/storage"
+	"github.com/PritOriginal/problem-map-server/internal/usecase"
+	"github.com/PritOriginal/problem-map-server/pkg/logger/slogdiscard"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type UploadsSuite struct {
+	suite.Suite
+	uc          *usecase.Uploads
+	log         *slog.Logger
+	awsCfg      config.AwsConfig
+	checksRepo  *usecase.MockChecksRepository
+	photosRepo  *usecase.MockPhotosRepository
+	uploadsRepo *usecase.MockUploadsRepository
+}
+
+func (suite *UploadsSuite) SetupSuite() {
+	suite.log = slogdiscard.NewDiscardLogger()
+	suite.checksRepo = usecase.NewMockChecksRepository(suite.T())
+	suite.photosRepo = usecase.NewMockPhotosRepository(suite.T())
+	suite.uploadsRepo = usecase.NewMockUploadsRepository(suite.T())
+	suite.awsCfg = config.MustLoadPath("../../configs/config-tests.yaml").Aws
+	suite.awsCfg.MaxUploadSize = 1 << 12
+	suite.uc = usecase.NewUploads(suite.log, suite.awsCfg, usecase.UploadsRepositories{
+		Checks:  suite.checksRepo,
+		Photos:  suite.photosRepo,
+		Uploads: suite.uploadsRepo,
+	})
+}
+
+func TestUploads(t *testing.T) {
+	suite.Run(t, new(UploadsSuite))
+}
+
+func (suite *UploadsSuite) TestCreateUploads() {
+	tests := []struct {
+		name          string
+		count         int
+		presignUpload method[models.PhotoUpload]
+		wantErr       error
+	}{
+		{
+			name:  "Ok",
+			count: 2,
+		},
+		{
+			name:    "ErrNoUploads",
+			count:   0,
+			wantErr: usecase.ErrInvalidInput,
+		},
+		{
+			name:    "ErrTooManyUploads",
+			count:   usecase.MaxUploads + 1,
+			wantErr: usecase.ErrInvalidInput,
+		},
+		{
+			name:  "ErrPresignUpload",
+			count: 1,
+			presignUpload: method[models.PhotoUpload]{
+				err: errors.New(""),
+			},
+		},
+	}
+	for _, tt := range tests {
+		suite.Run(tt.name, func() {
+			if tt.wantErr == nil {
+				calls := tt.count
+				if tt.presignUpload.err != nil {
+					calls = 1
+				}
+				suite.uploadsRepo.On("PresignUpload", mock.Anything, mock.MatchedBy(func(key string) bool {
+					return strings.HasPrefix(key, "uploads/1/")
+				})).Times(calls).
+					Return(tt.presignUpload.data, tt.presignUpload.err)
+			}
+
+			uploads, gotErr := suite.uc.CreateUploads(context.Background(), 1, tt.count)
+
+			switch {
+			case tt.wantErr != nil:
+				suite.ErrorIs(gotErr, tt.wantErr)
+			case tt.presignUpload.err != nil:
+				suite.NotNil(gotErr)
+			default:
+				suite.NoError(gotErr)
+				suite.Len(uploads, tt.count)
+			}
+			suite.uploadsRepo.AssertExpectations(suite.T())
+		})
+	}
+}
+
+func testJPEG(t *testing.T) []byte {
+	buf := new(bytes.Buffer)
+	if err := jpeg.Encode(buf, image.NewRGBA(image.Rect(0, 0, 2, 2)), nil); err != nil {
+		t.Fatalf("jpeg.Encode() error = %v", err)
+	}
+	return buf.Bytes()
+}
+
+func (suite *UploadsSuite) TestAttachPhotos() {
+	photo := testJPEG(suite.T())
+	check := models.Check{ID: 1, MarkID: 2, UserID: 1}
+
+	tests := []struct {
+		name                      string
+		keys                      []string
+		getCheckById              method[models.Check]
+		getUpload                 method[[]byte]
+		getPhotoRecordsByCheckIds method[[]models.PhotoRecord]
+		getSimilarPhotos          method[[]models.SimilarPhoto]
+		wantFirst                 int
+		wantDuplicate             bool
+		wantErr                   error
+	}{
+		{
+			name:         "Ok",
+			keys:         []string{"uploads/1/a", "uploads/1/b"},
+			getCheckById: method[models.Check]{data: check},
+			getUpload:    method[[]byte]{data: photo},
+			getPhotoRecordsByCheckIds: method[[]models.PhotoRecord]{
+				data: []models.PhotoRecord{{CheckID: 1, Number: 1}, {CheckID: 1, Number: 2}},
+			},
+			wantFirst: 3,
+		},
+		{
+			name:         "OkNoPhotos",
+			keys:         []string{"uploads/1/a"},
+			getCheckById: method[models.Check]{data: check},
+			getUpload:    method[[]byte]{data: photo},
+			wantFirst:    1,
+		},
+		{
+			name:         "OkDuplicate",
+			keys:         []string{"uploads/1/a"},
+			getCheckById: method[models.Check]{data: check},
+			getUpload:    method[[]byte]{data: photo},
+			getSimilarPhotos: method[[]models.SimilarPhoto]{
+				data: []models.SimilarPhoto{{MarkID: 3, CheckID: 4, Number: 1}},
+			},
+			wantFirst:     1,
+			wantDuplicate: true,
+		},
+		{
+			name:    "ErrInvalidKey",
+			keys:    []string{"marks/1/1/1.jpg"},
+			wantErr: usecase.ErrInvalidInput,
+		},
+		{
+			name:    "ErrDuplicateKeys",
+			keys:    []string{"uploads/1/a", "uploads/1/a"},
+			wantErr: usecase.ErrInvalidInput,
+		},
+		{
+			name:    "ErrForeignUpload",
+			keys:    []string{"uploads/2/a"},
+			wantErr: usecase.ErrForbidden,
+		},
+		{
+			name:         "ErrNotFoundCheck",
+			keys:         []string{"uploads/1/a"},
+			getCheckById: method[models.Check]{err: storage.ErrNotFound},
+			wantErr:      usecase.ErrNotFound,
+		},
+		{
+			name:         "ErrForeignCheck",
+			keys:         []string{"uploads/1/a"},
+			getCheckById: method[models.Check]{data: models.Check{ID: 1, UserID: 2}},
+			wantErr:      usecase.ErrForbidden,
+		},
+		{
+			name:         "ErrNotFoundUpload",
+			keys:         []string{"uploads/1/a"},
+			getCheckById: method[models.Check]{data: check},
+			getUpload:    method[[]byte]{err: storage.ErrNotFound},
+			wantErr:      usecase.ErrInvalidInput,
+		},
+		{
+			name:         "ErrTooLargeUpload",
+			keys:         []string{"uploads/1/a"},
+			getCheckById: method[models.Check]{data: check},
+			getUpload:    method[[]byte]{data: make([]byte, 1<<12+1)},
+			wantErr:      usecase.ErrInvalidInput,
+		},
+		{
+			name:         "ErrNotImageUpload",
+			keys:         []string{"uploads/1/a"},
+			getCheckById: method[models.Check]{data: check},
+			getUpload:    method[[]byte]{data: []byte("not an image")},
+			wantErr:      usecase.ErrInvalidInput,
+		},
+	}
+	for _, tt := range tests {
+		suite.Run(tt.name, func() {
+			func() {
+				if tt.getCheckById.data.ID == 0 && tt.getCheckById.err == nil {
+					return
+				}
+				suite.checksRepo.On("GetCheckById", mock.Anything, 1).Once().
+					Return(tt.getCheckById.data, tt.getCheckById.err)
+				if tt.getCheckById.err != nil || tt.getCheckById.data.UserID != 1 {
+					return
+				}
+
+				for _, key := range tt.keys {
+					suite.uploadsRepo.On("GetUpload", mock.Anything, key).Once().
+						Return(io.NopCloser(bytes.NewReader(tt.getUpload.data)), tt.getUpload.err)
+					if tt.wantErr != nil {
+						return
+					}
+				}
+
+				suite.checksRepo.On("GetPhotoRecordsByCheckIds", mock.Anything, []int{1}).Once().
+					Return(tt.getPhotoRecordsByCheckIds.data, nil)
+				suite.checksRepo.On("GetSimilarPhotos", mock.Anything, mock.MatchedBy(func(filters models.GetSimilarPhotosFilters) bool {
+					return filters.ExcludeCheckId == 1
+				})).Times(len(tt.keys)).
+					Return(tt.getSimilarPhotos.data, tt.getSimilarPhotos.err)
+				suite.photosRepo.On("AddPhotos", mock.Anything, 2, 1, tt.wantFirst, mock.Anything).Once().
+					Return([]models.PhotoRecord{}, nil)
+				suite.checksRepo.On("AddPhotoRecords", mock.Anything, mock.Anything).Once().
+					Return(nil)
+				suite.checksRepo.On("AddPhotosMetadata", mock.Anything, 1, tt.wantFirst, mock.MatchedBy(func(metadata []models.PhotoMetadata) bool {
+					for _, m := range metadata {
+						if !m.PHash.Valid || m.Duplicate != tt.wantDuplicate {
+							return false
+						}
+					}
+					return len(metadata) == len(tt.keys)
+				})).Once().
+					Return(nil)
+				for _, key := range tt.keys {
+					suite.uploadsRepo.On("DeleteUpload", mock.Anything, key).Once().
+						Return(nil)
+				}
+				suite.checksRepo.On("GetPhotoRecordsByCheckIds", mock.Anything, []int{1}).Once().
+					Return([]models.PhotoRecord{}, nil)
+			}()
+
+			_, gotErr := suite.uc.AttachPhotos(context.Background(), 1, 1, tt.keys)
+
+			if tt.wantErr == nil {
+				suite.NoError(gotErr)
+			} else {
+				suite.ErrorIs(gotErr, tt.wantErr)
+			}
+			suite.checksRepo.AssertExpectations(suite.T())
+			suite.photosRepo.AssertExpectations(suite.T())
+			suite.uploadsRepo.AssertExpectations(suite.T())
+		})
+	}
+}
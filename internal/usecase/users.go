@@ -1,18 +1,23 @@
 package usecase
 
 import (
+	"cmp"
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
+	"slices"
 
 	"github.com/PritOriginal/problem-map-server/internal/models"
+	"github.com/PritOriginal/problem-map-server/internal/storage"
 )
 
 type UsersRepository interface {
 	GetUserById(ctx context.Context, id int) (models.User, error)
 	GetUserByLogin(ctx context.Context, username string) (models.User, error)
-	GetUsers(ctx context.Context) ([]models.User, error)
+	GetUsers(ctx context.Context, pagination models.Pagination) ([]models.User, error)
 	AddUser(ctx context.Context, user models.User) (int64, error)
+	UpdatePassword(ctx context.Context, userId int, passwordHash string) error
 }
 
 type Users struct {
@@ -21,7 +26,8 @@ type Users struct {
 }
 
 type UsersRepositories struct {
-	Users UsersRepository
+	Users    UsersRepository
+	Sessions SessionsRepository
 }
 
 func NewUsers(log *slog.Logger, repos UsersRepositories) *Users {
@@ -39,13 +45,48 @@ func (uc *Users) GetUserById(ctx context.Context, id int) (models.User, error) {
 	return user, nil
 }
 
-func (uc *Users) GetUsers(ctx context.Context) ([]models.User, error) {
+func (uc *Users) GetUsers(ctx context.Context, pagination models.Pagination) ([]models.User, string, error) {
 	const op = "usecase.Users.GetUsers"
 
-	users, err := uc.repos.Users.GetUsers(ctx)
+	users, nextCursor, err := fetchPage(pagination, func(pagination models.Pagination) ([]models.User, error) {
+		return uc.repos.Users.GetUsers(ctx, pagination)
+	})
 	if err != nil {
-		return users, fmt.Errorf("%s: %w", op, err)
+		return users, "", fmt.Errorf("%s: %w", op, err)
 	}
 
-	return users, nil
+	return users, nextCursor, nil
+}
+
+// GetSessions returns the active sessions of the user ordered by the last use, the current session is marked
+func (uc *Users) GetSessions(ctx context.Context, userId int, currentSessionId string) ([]models.Session, error) {
+	const op = "usecase.Users.GetSessions"
+
+	sessions, err := uc.repos.Sessions.GetUserSessions(ctx, userId)
+	if err != nil {
+		return sessions, fmt.Errorf("%s: %w", op, err)
+	}
+
+	for i := range sessions {
+		sessions[i].Current = sessions[i].ID == currentSessionId
+	}
+	slices.SortFunc(sessions, func(a, b models.Session) int {
+		return cmp.Or(b.LastUsedAt.Compare(a.LastUsedAt), cmp.Compare(a.ID, b.ID))
+	})
+
+	return sessions, nil
+}
+
+// DeleteSession revokes the user session, the access tokens issued for it stay valid until they expire
+func (uc *Users) DeleteSession(ctx context.Context, userId int, sessionId string) error {
+	const op = "usecase.Users.DeleteSession"
+
+	if err := uc.repos.Sessions.DeleteSession(ctx, userId, sessionId); err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			return fmt.Errorf("%s: %w", op, ErrNotFound)
+		}
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
 }
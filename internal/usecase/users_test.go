@@ -5,8 +5,10 @@ import (
 	"errors"
 	"log/slog"
 	"testing"
+	"time"
 
 	"github.com/PritOriginal/problem-map-server/internal/models"
+	"github.com/PritOriginal/problem-map-server/internal/storage"
 	"github.com/PritOriginal/problem-map-server/internal/usecase"
 	"github.com/PritOriginal/problem-map-server/pkg/logger/slogdiscard"
 	"github.com/stretchr/testify/mock"
@@ -15,16 +17,19 @@ import (
 
 type UsersSuite struct {
 	suite.Suite
-	uc        *usecase.Users
-	log       *slog.Logger
-	usersRepo *usecase.MockUsersRepository
+	uc           *usecase.Users
+	log          *slog.Logger
+	usersRepo    *usecase.MockUsersRepository
+	sessionsRepo *usecase.MockSessionsRepository
 }
 
 func (suite *UsersSuite) SetupSuite() {
 	suite.log = slogdiscard.NewDiscardLogger()
 	suite.usersRepo = usecase.NewMockUsersRepository(suite.T())
+	suite.sessionsRepo = usecase.NewMockSessionsRepository(suite.T())
 	suite.uc = usecase.NewUsers(suite.log, usecase.UsersRepositories{
-		Users: suite.usersRepo,
+		Users:    suite.usersRepo,
+		Sessions: suite.sessionsRepo,
 	})
 }
 
@@ -99,14 +104,14 @@ func (suite *UsersSuite) TestGetUsers() {
 	for _, tt := range tests {
 		suite.Run(tt.name, func() {
 			func() {
-				suite.usersRepo.On("GetUsers", mock.Anything).Once().
+				suite.usersRepo.On("GetUsers", mock.Anything, mock.Anything).Once().
 					Return(tt.getUsers.data, tt.getUsers.err)
 				if tt.getUsers.err != nil {
 					return
 				}
 			}()
 
-			_, gotErr := suite.uc.GetUsers(context.Background())
+			_, _, gotErr := suite.uc.GetUsers(context.Background(), models.Pagination{})
 
 			if tt.getUsers.err == nil {
 				suite.NoError(gotErr)
@@ -117,3 +122,88 @@ func (suite *UsersSuite) TestGetUsers() {
 		})
 	}
 }
+
+func (suite *UsersSuite) TestGetSessions() {
+	now := time.Now()
+
+	tests := []struct {
+		name            string
+		getUserSessions method[[]models.Session]
+		wantIds         []string
+		wantCurrent     string
+	}{
+		{
+			name: "Ok",
+			getUserSessions: method[[]models.Session]{
+				data: []models.Session{
+					{ID: "s1", LastUsedAt: now.Add(-time.Hour)},
+					{ID: "s2", LastUsedAt: now},
+					{ID: "s3", LastUsedAt: now.Add(-2 * time.Hour)},
+				},
+			},
+			wantIds:     []string{"s2", "s1", "s3"},
+			wantCurrent: "s1",
+		},
+		{
+			name: "Err",
+			getUserSessions: method[[]models.Session]{
+				err: errors.New(""),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		suite.Run(tt.name, func() {
+			suite.sessionsRepo.On("GetUserSessions", mock.Anything, 1).Once().
+				Return(tt.getUserSessions.data, tt.getUserSessions.err)
+
+			sessions, gotErr := suite.uc.GetSessions(context.Background(), 1, "s1")
+
+			if tt.getUserSessions.err == nil {
+				suite.NoError(gotErr)
+				ids := make([]string, len(sessions))
+				for i, session := range sessions {
+					ids[i] = session.ID
+					suite.Equal(session.ID == tt.wantCurrent, session.Current)
+				}
+				suite.Equal(tt.wantIds, ids)
+			} else {
+				suite.NotNil(gotErr)
+			}
+			suite.sessionsRepo.AssertExpectations(suite.T())
+		})
+	}
+}
+
+func (suite *UsersSuite) TestDeleteSession() {
+	tests := []struct {
+		name             string
+		errDeleteSession error
+		wantErr          error
+	}{
+		{
+			name: "Ok",
+		},
+		{
+			name:             "ErrNotFound",
+			errDeleteSession: storage.ErrNotFound,
+			wantErr:          usecase.ErrNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		suite.Run(tt.name, func() {
+			suite.sessionsRepo.On("DeleteSession", mock.Anything, 1, "s1").Once().
+				Return(tt.errDeleteSession)
+
+			gotErr := suite.uc.DeleteSession(context.Background(), 1, "s1")
+
+			if tt.wantErr == nil {
+				suite.NoError(gotErr)
+			} else {
+				suite.ErrorIs(gotErr, tt.wantErr)
+			}
+			suite.sessionsRepo.AssertExpectations(suite.T())
+		})
+	}
+}
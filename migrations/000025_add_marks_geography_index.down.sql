@@ -0,0 +1 @@
+DROP INDEX IF EXISTS idx_marks_geog;
\ No newline at end of file
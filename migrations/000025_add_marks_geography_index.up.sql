@@ -0,0 +1 @@
+CREATE INDEX idx_marks_geog ON marks USING GIST ((geom::geography));
\ No newline at end of file
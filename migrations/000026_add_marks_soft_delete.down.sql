@@ -0,0 +1,4 @@
+DROP TRIGGER IF EXISTS update_marks_updated_at ON marks;
+DROP FUNCTION IF EXISTS set_updated_at();
+
+ALTER TABLE marks DROP COLUMN deleted_at;
\ No newline at end of file
@@ -0,0 +1,14 @@
+ALTER TABLE marks ADD COLUMN deleted_at TIMESTAMP;
+
+CREATE OR REPLACE FUNCTION set_updated_at()
+RETURNS TRIGGER AS $$ 
+BEGIN
+    NEW.updated_at = NOW();
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER update_marks_updated_at 
+BEFORE UPDATE ON marks 
+FOR EACH ROW 
+EXECUTE FUNCTION set_updated_at();
\ No newline at end of file
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_checks_search_vector;
+ALTER TABLE checks DROP COLUMN search_vector;
+
+DROP INDEX IF EXISTS idx_marks_search_vector;
+ALTER TABLE marks DROP COLUMN search_vector;
@@ -0,0 +1,7 @@
+ALTER TABLE marks ADD COLUMN search_vector tsvector
+    GENERATED ALWAYS AS (to_tsvector('russian', coalesce(description, ''))) STORED;
+CREATE INDEX idx_marks_search_vector ON marks USING GIN (search_vector);
+
+ALTER TABLE checks ADD COLUMN search_vector tsvector
+    GENERATED ALWAYS AS (to_tsvector('russian', coalesce(comment, ''))) STORED;
+CREATE INDEX idx_checks_search_vector ON checks USING GIN (search_vector);
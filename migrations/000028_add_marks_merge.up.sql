@@ -0,0 +1,19 @@
+ALTER TABLE marks ADD COLUMN merged_into_mark_id INTEGER REFERENCES marks (mark_id);
+
+ALTER TABLE mark_status_history ADD COLUMN merged_mark_id INTEGER REFERENCES marks (mark_id);
+
+CREATE OR REPLACE FUNCTION log_mark_status_change()
+RETURNS TRIGGER AS $$ 
+BEGIN
+    INSERT INTO mark_status_history (mark_id, old_mark_status_id, new_mark_status_id, prev_id)
+    VALUES (NEW.mark_id, OLD.mark_status_id, NEW.mark_status_id, (
+        SELECT id 
+        FROM mark_status_history 
+        WHERE mark_id = NEW.mark_id AND new_mark_status_id = OLD.mark_status_id AND merged_mark_id IS NULL
+        ORDER BY changed_at DESC
+        LIMIT 1
+        )
+    );
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
@@ -0,0 +1,5 @@
+ALTER TABLE users DROP CONSTRAINT fk_user_role;
+
+ALTER TABLE users DROP COLUMN user_role_id;
+
+DROP TABLE user_roles;
@@ -0,0 +1,13 @@
+CREATE TABLE user_roles (
+    user_role_id SERIAL PRIMARY KEY,
+    name VARCHAR(40) NOT NULL UNIQUE
+);
+
+INSERT INTO
+    user_roles (name)
+VALUES
+    ('citizen'), ('moderator'), ('admin');
+
+ALTER TABLE users ADD COLUMN user_role_id INTEGER DEFAULT 1 NOT NULL;
+
+ALTER TABLE users ADD CONSTRAINT fk_user_role FOREIGN KEY (user_role_id) REFERENCES user_roles (user_role_id);
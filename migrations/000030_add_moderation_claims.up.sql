@@ -0,0 +1,8 @@
+CREATE TABLE moderation_claims (
+    mark_id INTEGER PRIMARY KEY,
+    user_id INTEGER NOT NULL,
+    claimed_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    expires_at TIMESTAMP NOT NULL,
+    CONSTRAINT fk_moderation_claims_mark FOREIGN KEY (mark_id) REFERENCES marks (mark_id),
+    CONSTRAINT fk_moderation_claims_user FOREIGN KEY (user_id) REFERENCES users (user_id)
+);
@@ -0,0 +1,7 @@
+ALTER TABLE users ALTER COLUMN rating DROP NOT NULL;
+
+ALTER TABLE users ALTER COLUMN rating SET DEFAULT 0;
+
+ALTER TABLE users DROP COLUMN agreed_checks;
+
+ALTER TABLE users DROP COLUMN resolved_checks;
@@ -0,0 +1,9 @@
+ALTER TABLE users ADD COLUMN resolved_checks INTEGER DEFAULT 0 NOT NULL;
+
+ALTER TABLE users ADD COLUMN agreed_checks INTEGER DEFAULT 0 NOT NULL;
+
+UPDATE users SET rating = 50;
+
+ALTER TABLE users ALTER COLUMN rating SET DEFAULT 50;
+
+ALTER TABLE users ALTER COLUMN rating SET NOT NULL;
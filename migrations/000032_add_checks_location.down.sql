@@ -0,0 +1,5 @@
+ALTER TABLE checks DROP COLUMN flagged;
+
+ALTER TABLE checks DROP COLUMN distance;
+
+ALTER TABLE checks DROP COLUMN location;
@@ -0,0 +1,5 @@
+ALTER TABLE checks ADD COLUMN location GEOMETRY(Point, 4326);
+
+ALTER TABLE checks ADD COLUMN distance DOUBLE PRECISION;
+
+ALTER TABLE checks ADD COLUMN flagged BOOLEAN DEFAULT false NOT NULL;
@@ -0,0 +1 @@
+DROP TABLE photos_metadata;
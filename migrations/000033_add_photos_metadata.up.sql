@@ -0,0 +1,10 @@
+CREATE TABLE photos_metadata (
+    check_id INTEGER NOT NULL,
+    number INTEGER NOT NULL,
+    captured_at TIMESTAMP,
+    latitude DOUBLE PRECISION,
+    longitude DOUBLE PRECISION,
+    orientation SMALLINT DEFAULT 1 NOT NULL,
+    PRIMARY KEY (check_id, number),
+    CONSTRAINT fk_photos_metadata_check FOREIGN KEY (check_id) REFERENCES checks (check_id)
+);
@@ -0,0 +1,15 @@
+CREATE TABLE photos (
+    key VARCHAR(255) PRIMARY KEY,
+    mark_id INTEGER NOT NULL,
+    check_id INTEGER NOT NULL,
+    number INTEGER NOT NULL,
+    variant VARCHAR(16) NOT NULL,
+    size BIGINT NOT NULL,
+    width INTEGER NOT NULL,
+    height INTEGER NOT NULL,
+    hash CHAR(64) NOT NULL,
+    created_at TIMESTAMP DEFAULT NOW() NOT NULL,
+    UNIQUE (check_id, number, variant),
+    CONSTRAINT fk_photos_mark FOREIGN KEY (mark_id) REFERENCES marks (mark_id),
+    CONSTRAINT fk_photos_check FOREIGN KEY (check_id) REFERENCES checks (check_id)
+);
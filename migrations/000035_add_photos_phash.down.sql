@@ -0,0 +1,2 @@
+ALTER TABLE photos_metadata DROP COLUMN duplicate;
+ALTER TABLE photos_metadata DROP COLUMN phash;
@@ -0,0 +1,2 @@
+ALTER TABLE photos_metadata ADD COLUMN phash BIGINT;
+ALTER TABLE photos_metadata ADD COLUMN duplicate BOOLEAN DEFAULT false NOT NULL;
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_photos_metadata_phash_bands;
+ALTER TABLE photos_metadata DROP COLUMN phash_bands;
@@ -0,0 +1,21 @@
+-- The similar photos queries compare the hashes with bit_count(bit), it needs PostgreSQL 14+.
+-- The 64 bits of the hash are split into 11 bands, the hashes within the Hamming distance 10
+-- share at least one band, so the bands index narrows the candidates of the distance check.
+-- The band is stored as its number in the high bits and its value in the low 6 bits.
+ALTER TABLE photos_metadata ADD COLUMN phash_bands INTEGER[] GENERATED ALWAYS AS (
+    CASE WHEN phash IS NOT NULL THEN ARRAY[
+        (0 << 6) | ((phash >> 0) & 63)::integer,
+        (1 << 6) | ((phash >> 6) & 63)::integer,
+        (2 << 6) | ((phash >> 12) & 63)::integer,
+        (3 << 6) | ((phash >> 18) & 63)::integer,
+        (4 << 6) | ((phash >> 24) & 63)::integer,
+        (5 << 6) | ((phash >> 30) & 63)::integer,
+        (6 << 6) | ((phash >> 36) & 63)::integer,
+        (7 << 6) | ((phash >> 42) & 63)::integer,
+        (8 << 6) | ((phash >> 48) & 63)::integer,
+        (9 << 6) | ((phash >> 54) & 31)::integer,
+        (10 << 6) | ((phash >> 59) & 31)::integer
+    ] END
+) STORED;
+
+CREATE INDEX idx_photos_metadata_phash_bands ON photos_metadata USING GIN (phash_bands);
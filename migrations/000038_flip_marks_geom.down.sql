@@ -0,0 +1 @@
+UPDATE marks SET geom = ST_FlipCoordinates(geom);
@@ -0,0 +1,2 @@
+-- The marks added through REST stored the point as (lat, lon), the point is X = longitude.
+UPDATE marks SET geom = ST_FlipCoordinates(geom);
@@ -0,0 +1,322 @@
+package exif
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/guregu/null/v6"
+)
+
+var ErrNotFound = errors.New("exif not found")
+
+// Metadata is the part of the EXIF kept as the photo evidence
+type Metadata struct {
+	// CapturedAt is the time the photo was taken, without the offset tag the camera local time is read as UTC
+	CapturedAt null.Time
+	Latitude   null.Float
+	Longitude  null.Float
+	// Orientation is the EXIF orientation from 1 to 8, 1 is the normal one
+	Orientation int
+}
+
+const (
+	markerSOI  = 0xD8
+	markerEOI  = 0xD9
+	markerSOS  = 0xDA
+	markerAPP1 = 0xE1
+)
+
+const (
+	tagOrientation        = 0x0112
+	tagDateTime           = 0x0132
+	tagExifIFD            = 0x8769
+	tagGPSIFD             = 0x8825
+	tagDateTimeOriginal   = 0x9003
+	tagOffsetTimeOriginal = 0x9011
+	tagGPSLatitudeRef     = 0x0001
+	tagGPSLatitude        = 0x0002
+	tagGPSLongitudeRef    = 0x0003
+	tagGPSLongitude       = 0x0004
+)
+
+const (
+	typeByte      = 1
+	typeASCII     = 2
+	typeShort     = 3
+	typeLong      = 4
+	typeRational  = 5
+	typeUndefined = 7
+	typeSLong     = 9
+	typeSRational = 10
+)
+
+var typeSizes = map[uint16]int{
+	typeByte:      1,
+	typeASCII:     1,
+	typeShort:     2,
+	typeLong:      4,
+	typeRational:  8,
+	typeUndefined: 1,
+	typeSLong:     4,
+	typeSRational: 8,
+}
+
+const dateTimeLayout = "2006:01:02 15:04:05"
+
+// Decode reads the metadata from the EXIF of the JPEG image.
+// It returns ErrNotFound when the image has no EXIF.
+func Decode(r io.Reader) (Metadata, error) {
+	const op = "exif.Decode"
+
+	payload, err := findExif(bufio.NewReader(r))
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return Metadata{}, ErrNotFound
+		}
+		return Metadata{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	metadata, err := parseTIFF(payload)
+	if err != nil {
+		return Metadata{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return metadata, nil
+}
+
+// findExif returns the TIFF payload of the EXIF segment, the segments after the start of scan are not read
+func findExif(r *bufio.Reader) ([]byte, error) {
+	var soi [2]byte
+	if _, err := io.ReadFull(r, soi[:]); err != nil {
+		return nil, ErrNotFound
+	}
+	if soi[0] != 0xFF || soi[1] != markerSOI {
+		return nil, ErrNotFound
+	}
+
+	for {
+		b, err := r.ReadByte()
+		if err != nil {
+			return nil, ErrNotFound
+		}
+		if b != 0xFF {
+			return nil, errors.New("invalid marker")
+		}
+
+		marker, err := r.ReadByte()
+		for err == nil && marker == 0xFF {
+			marker, err = r.ReadByte()
+		}
+		if err != nil {
+			return nil, ErrNotFound
+		}
+
+		switch {
+		case marker == markerEOI || marker == markerSOS:
+			return nil, ErrNotFound
+		case marker == 0x01 || (marker >= 0xD0 && marker <= 0xD7):
+			continue
+		}
+
+		var length uint16
+		if err := binary.Read(r, binary.BigEndian, &length); err != nil {
+			return nil, ErrNotFound
+		}
+		if length < 2 {
+			return nil, errors.New("invalid segment length")
+		}
+
+		segment := make([]byte, length-2)
+		if _, err := io.ReadFull(r, segment); err != nil {
+			return nil, ErrNotFound
+		}
+
+		if marker == markerAPP1 && bytes.HasPrefix(segment, []byte("Exif\x00\x00")) {
+			return segment[6:], nil
+		}
+	}
+}
+
+type entry struct {
+	typ   uint16
+	count uint32
+	data  []byte
+}
+
+type tiff struct {
+	data  []byte
+	order binary.ByteOrder
+}
+
+func parseTIFF(data []byte) (Metadata, error) {
+	if len(data) < 8 {
+		return Metadata{}, errors.New("invalid tiff header")
+	}
+
+	t := tiff{data: data}
+	switch string(data[:2]) {
+	case "II":
+		t.order = binary.LittleEndian
+	case "MM":
+		t.order = binary.BigEndian
+	default:
+		return Metadata{}, errors.New("invalid tiff byte order")
+	}
+	if t.order.Uint16(data[2:]) != 42 {
+		return Metadata{}, errors.New("invalid tiff magic")
+	}
+
+	ifd0, err := t.readIFD(t.order.Uint32(data[4:]))
+	if err != nil {
+		return Metadata{}, err
+	}
+
+	metadata := Metadata{Orientation: 1}
+
+	if e, ok := ifd0[tagOrientation]; ok {
+		if orientation, ok := t.uint(e); ok && orientation >= 1 && orientation <= 8 {
+			metadata.Orientation = int(orientation)
+		}
+	}
+
+	dateTime, _ := t.string(ifd0[tagDateTime])
+	offsetTime := ""
+	if e, ok := ifd0[tagExifIFD]; ok {
+		if offset, ok := t.uint(e); ok {
+			if exifIFD, err := t.readIFD(offset); err == nil {
+				if original, ok := t.string(exifIFD[tagDateTimeOriginal]); ok {
+					dateTime = original
+					offsetTime, _ = t.string(exifIFD[tagOffsetTimeOriginal])
+				}
+			}
+		}
+	}
+	if capturedAt, ok := parseDateTime(dateTime, offsetTime); ok {
+		metadata.CapturedAt = null.TimeFrom(capturedAt)
+	}
+
+	if e, ok := ifd0[tagGPSIFD]; ok {
+		if offset, ok := t.uint(e); ok {
+			if gpsIFD, err := t.readIFD(offset); err == nil {
+				latitude, latOk := t.coordinate(gpsIFD[tagGPSLatitude], gpsIFD[tagGPSLatitudeRef], "S")
+				longitude, lonOk := t.coordinate(gpsIFD[tagGPSLongitude], gpsIFD[tagGPSLongitudeRef], "W")
+				if latOk && lonOk {
+					metadata.Latitude = null.FloatFrom(latitude)
+					metadata.Longitude = null.FloatFrom(longitude)
+				}
+			}
+		}
+	}
+
+	return metadata, nil
+}
+
+// readIFD reads the entries of the image file directory at the offset, the broken entries are skipped
+func (t tiff) readIFD(offset uint32) (map[uint16]entry, error) {
+	if int(offset)+2 > len(t.data) {
+		return nil, errors.New("invalid ifd offset")
+	}
+
+	count := int(t.order.Uint16(t.data[offset:]))
+	start := int(offset) + 2
+	if start+count*12 > len(t.data) {
+		return nil, errors.New("invalid ifd length")
+	}
+
+	entries := make(map[uint16]entry, count)
+	for i := range count {
+		raw := t.data[start+i*12 : start+(i+1)*12]
+
+		e := entry{
+			typ:   t.order.Uint16(raw[2:]),
+			count: t.order.Uint32(raw[4:]),
+		}
+		size, ok := typeSizes[e.typ]
+		if !ok {
+			continue
+		}
+
+		length := uint64(size) * uint64(e.count)
+		if length <= 4 {
+			e.data = raw[8 : 8+length]
+		} else {
+			valueOffset := uint64(t.order.Uint32(raw[8:]))
+			if valueOffset+length > uint64(len(t.data)) {
+				continue
+			}
+			e.data = t.data[valueOffset : valueOffset+length]
+		}
+
+		entries[t.order.Uint16(raw)] = e
+	}
+
+	return entries, nil
+}
+
+func (t tiff) uint(e entry) (uint32, bool) {
+	switch {
+	case e.typ == typeShort && len(e.data) >= 2:
+		return uint32(t.order.Uint16(e.data)), true
+	case e.typ == typeLong && len(e.data) >= 4:
+		return t.order.Uint32(e.data), true
+	default:
+		return 0, false
+	}
+}
+
+func (t tiff) string(e entry) (string, bool) {
+	if e.typ != typeASCII || len(e.data) == 0 {
+		return "", false
+	}
+	return strings.TrimRight(string(e.data), "\x00 "), true
+}
+
+// coordinate returns the GPS coordinate in degrees from the degrees, minutes and seconds rationals,
+// it is negative for the negative ref
+func (t tiff) coordinate(value, ref entry, negativeRef string) (float64, bool) {
+	if value.typ != typeRational || value.count < 3 {
+		return 0, false
+	}
+
+	var parts [3]float64
+	for i := range parts {
+		numerator := t.order.Uint32(value.data[i*8:])
+		denominator := t.order.Uint32(value.data[i*8+4:])
+		if denominator == 0 {
+			return 0, false
+		}
+		parts[i] = float64(numerator) / float64(denominator)
+	}
+	coordinate := parts[0] + parts[1]/60 + parts[2]/3600
+
+	if r, _ := t.string(ref); r == negativeRef {
+		coordinate = -coordinate
+	}
+
+	return coordinate, true
+}
+
+func parseDateTime(dateTime, offset string) (time.Time, bool) {
+	if dateTime == "" {
+		return time.Time{}, false
+	}
+
+	if offset != "" {
+		if t, err := time.Parse(dateTimeLayout+"-07:00", dateTime+offset); err == nil {
+			return t, true
+		}
+	}
+
+	t, err := time.Parse(dateTimeLayout, dateTime)
+	if err != nil {
+		return time.Time{}, false
+	}
+
+	return t, true
+}
@@ -0,0 +1,187 @@
+package exif
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"math"
+	"testing"
+	"time"
+)
+
+type testTag struct {
+	id    uint16
+	typ   uint16
+	count uint32
+	value []byte
+}
+
+// buildIFD returns the little endian IFD at the offset with the long values placed after the directory
+func buildIFD(offset int, tags []testTag) []byte {
+	dir := new(bytes.Buffer)
+	data := new(bytes.Buffer)
+	dataOffset := offset + 2 + len(tags)*12 + 4
+
+	binary.Write(dir, binary.LittleEndian, uint16(len(tags)))
+	for _, tag := range tags {
+		binary.Write(dir, binary.LittleEndian, tag.id)
+		binary.Write(dir, binary.LittleEndian, tag.typ)
+		binary.Write(dir, binary.LittleEndian, tag.count)
+		if len(tag.value) <= 4 {
+			value := make([]byte, 4)
+			copy(value, tag.value)
+			dir.Write(value)
+		} else {
+			binary.Write(dir, binary.LittleEndian, uint32(dataOffset+data.Len()))
+			data.Write(tag.value)
+		}
+	}
+	binary.Write(dir, binary.LittleEndian, uint32(0))
+
+	return append(dir.Bytes(), data.Bytes()...)
+}
+
+func ascii(s string) testTag {
+	return testTag{typ: typeASCII, count: uint32(len(s) + 1), value: append([]byte(s), 0)}
+}
+
+func long(v uint32) []byte {
+	return binary.LittleEndian.AppendUint32(nil, v)
+}
+
+func rationals(values ...uint32) []byte {
+	var b []byte
+	for i := 0; i < len(values); i += 2 {
+		b = binary.LittleEndian.AppendUint32(b, values[i])
+		b = binary.LittleEndian.AppendUint32(b, values[i+1])
+	}
+	return b
+}
+
+func buildTIFF() []byte {
+	dateTime := ascii("2024:05:01 10:20:30")
+	dateTime.id = tagDateTimeOriginal
+	offsetTime := ascii("+03:00")
+	offsetTime.id = tagOffsetTimeOriginal
+	latitudeRef := ascii("S")
+	latitudeRef.id = tagGPSLatitudeRef
+	longitudeRef := ascii("E")
+	longitudeRef.id = tagGPSLongitudeRef
+
+	ifd0 := func(exifOffset, gpsOffset uint32) []testTag {
+		return []testTag{
+			{id: tagOrientation, typ: typeShort, count: 1, value: binary.LittleEndian.AppendUint16(nil, 6)},
+			{id: tagExifIFD, typ: typeLong, count: 1, value: long(exifOffset)},
+			{id: tagGPSIFD, typ: typeLong, count: 1, value: long(gpsOffset)},
+		}
+	}
+
+	exifOffset := 8 + len(buildIFD(8, ifd0(0, 0)))
+	exifIFD := buildIFD(exifOffset, []testTag{dateTime, offsetTime})
+	gpsOffset := exifOffset + len(exifIFD)
+	gpsIFD := buildIFD(gpsOffset, []testTag{
+		latitudeRef,
+		{id: tagGPSLatitude, typ: typeRational, count: 3, value: rationals(55, 1, 45, 1, 2100, 100)},
+		longitudeRef,
+		{id: tagGPSLongitude, typ: typeRational, count: 3, value: rationals(37, 1, 37, 1, 3, 1)},
+	})
+
+	tiff := []byte("II")
+	tiff = binary.LittleEndian.AppendUint16(tiff, 42)
+	tiff = binary.LittleEndian.AppendUint32(tiff, 8)
+	tiff = append(tiff, buildIFD(8, ifd0(uint32(exifOffset), uint32(gpsOffset)))...)
+	tiff = append(tiff, exifIFD...)
+	return append(tiff, gpsIFD...)
+}
+
+func buildJPEG(t *testing.T, tiff []byte) []byte {
+	img := image.NewRGBA(image.Rect(0, 0, 2, 1))
+	buf := new(bytes.Buffer)
+	if err := jpeg.Encode(buf, img, nil); err != nil {
+		t.Fatalf("jpeg.Encode() error = %v", err)
+	}
+	if tiff == nil {
+		return buf.Bytes()
+	}
+
+	segment := append([]byte("Exif\x00\x00"), tiff...)
+	app1 := []byte{0xFF, markerAPP1}
+	app1 = binary.BigEndian.AppendUint16(app1, uint16(len(segment)+2))
+	app1 = append(app1, segment...)
+
+	data := buf.Bytes()
+	return append(append(data[:2:2], app1...), data[2:]...)
+}
+
+func TestDecode(t *testing.T) {
+	metadata, err := Decode(bytes.NewReader(buildJPEG(t, buildTIFF())))
+	if err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+
+	if metadata.Orientation != 6 {
+		t.Errorf("Decode() orientation = %v, want 6", metadata.Orientation)
+	}
+	wantCapturedAt := time.Date(2024, 5, 1, 7, 20, 30, 0, time.UTC)
+	if !metadata.CapturedAt.Valid || !metadata.CapturedAt.Time.Equal(wantCapturedAt) {
+		t.Errorf("Decode() captured at = %v, want %v", metadata.CapturedAt, wantCapturedAt)
+	}
+	if wantLatitude := -(55 + 45.0/60 + 21.0/3600); math.Abs(metadata.Latitude.Float64-wantLatitude) > 1e-9 {
+		t.Errorf("Decode() latitude = %v, want %v", metadata.Latitude, wantLatitude)
+	}
+	if wantLongitude := 37 + 37.0/60 + 3.0/3600; math.Abs(metadata.Longitude.Float64-wantLongitude) > 1e-9 {
+		t.Errorf("Decode() longitude = %v, want %v", metadata.Longitude, wantLongitude)
+	}
+}
+
+func TestDecode_NotFound(t *testing.T) {
+	if _, err := Decode(bytes.NewReader(buildJPEG(t, nil))); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Decode() error = %v, want %v", err, ErrNotFound)
+	}
+}
+
+func TestDecode_Broken(t *testing.T) {
+	if _, err := Decode(bytes.NewReader(buildJPEG(t, []byte("XX*\x00")))); err == nil {
+		t.Errorf("Decode() error = nil, want an error")
+	}
+}
+
+func TestOrient(t *testing.T) {
+	red := color.RGBA{R: 255, A: 255}
+	blue := color.RGBA{B: 255, A: 255}
+
+	img := image.NewRGBA(image.Rect(0, 0, 2, 1))
+	img.Set(0, 0, red)
+	img.Set(1, 0, blue)
+
+	tests := []struct {
+		name        string
+		orientation int
+		want        [][]color.RGBA
+	}{
+		{name: "Normal", orientation: 1, want: [][]color.RGBA{{red, blue}}},
+		{name: "FlipHorizontal", orientation: 2, want: [][]color.RGBA{{blue, red}}},
+		{name: "Rotate180", orientation: 3, want: [][]color.RGBA{{blue, red}}},
+		{name: "Rotate90", orientation: 6, want: [][]color.RGBA{{red}, {blue}}},
+		{name: "Rotate270", orientation: 8, want: [][]color.RGBA{{blue}, {red}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Orient(img, tt.orientation)
+
+			if b := got.Bounds(); b.Dx() != len(tt.want[0]) || b.Dy() != len(tt.want) {
+				t.Fatalf("Orient() size = %v, want %vx%v", b.Size(), len(tt.want[0]), len(tt.want))
+			}
+			for y, row := range tt.want {
+				for x, want := range row {
+					if got := color.RGBAModel.Convert(got.At(x, y)); got != want {
+						t.Errorf("Orient() at %v,%v = %v, want %v", x, y, got, want)
+					}
+				}
+			}
+		})
+	}
+}
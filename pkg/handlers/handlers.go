@@ -1,11 +1,6 @@
 package handlers
 
 import (
-	"bytes"
-	"image"
-	"image/jpeg"
-	"io"
-	"mime/multipart"
 	"strconv"
 	"strings"
 )
@@ -34,24 +29,26 @@ func ParseIntArray(param string) ([]int, error) {
 	return result, nil
 }
 
-func ParsePhotos(fheaders []*multipart.FileHeader) ([]io.Reader, error) {
-	var photos []io.Reader
-	for _, header := range fheaders {
-		file, err := header.Open()
-		if err != nil {
-			return photos, err
-		}
+func ParseFloatArray(param string) ([]float64, error) {
+	if param == "" {
+		return []float64{}, nil
+	}
 
-		img, _, err := image.Decode(file)
-		if err != nil {
-			return photos, err
+	parts := strings.Split(param, ",")
+	result := make([]float64, 0, len(parts))
+
+	for _, part := range parts {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
 		}
 
-		buf := new(bytes.Buffer)
-		if err := jpeg.Encode(buf, img, nil); err != nil {
-			return photos, err
+		num, err := strconv.ParseFloat(part, 64)
+		if err != nil {
+			return nil, err
 		}
-		photos = append(photos, buf)
+		result = append(result, num)
 	}
-	return photos, nil
+
+	return result, nil
 }
@@ -0,0 +1,34 @@
+package imaging
+
+import (
+	"image"
+	"math/bits"
+)
+
+// DHash returns the difference hash of the image, the hashes of the near-identical images differ in a few bits.
+// Every bit tells whether a pixel of the 9x8 grayscale thumbnail of the image is brighter than its right neighbour,
+// so the hash survives rescaling, recompression and small color changes.
+func DHash(img image.Image) uint64 {
+	small := Resize(img, 9, 8)
+
+	var hash uint64
+	for y := range 8 {
+		for x := range 8 {
+			if luma(small, x, y) > luma(small, x+1, y) {
+				hash |= 1 << (y*8 + x)
+			}
+		}
+	}
+
+	return hash
+}
+
+// Distance returns the Hamming distance between the hashes
+func Distance(a, b uint64) int {
+	return bits.OnesCount64(a ^ b)
+}
+
+func luma(img *image.RGBA, x, y int) int {
+	i := img.PixOffset(x, y)
+	return 299*int(img.Pix[i]) + 587*int(img.Pix[i+1]) + 114*int(img.Pix[i+2])
+}
@@ -0,0 +1,49 @@
+package imaging
+
+import (
+	"image"
+	"image/color"
+	"testing"
+)
+
+// gradient returns the image with the horizontal gradient, the reversed gradient goes the other way
+func gradient(width, height int, reversed bool) *image.RGBA {
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y := range height {
+		for x := range width {
+			v := uint8(x * 255 / (width - 1))
+			if reversed {
+				v = 255 - v
+			}
+			img.Set(x, y, color.RGBA{R: v, G: v / 2, B: uint8(y * 255 / height), A: 255})
+		}
+	}
+	return img
+}
+
+func TestDHash(t *testing.T) {
+	img := gradient(300, 200, false)
+
+	tests := []struct {
+		name         string
+		other        image.Image
+		wantDistance func(int) bool
+	}{
+		{name: "Same", other: img, wantDistance: func(d int) bool { return d == 0 }},
+		{name: "Rescaled", other: Fit(img, 90), wantDistance: func(d int) bool { return d <= 5 }},
+		{name: "Different", other: gradient(300, 200, true), wantDistance: func(d int) bool { return d > 32 }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Distance(DHash(img), DHash(tt.other)); !tt.wantDistance(got) {
+				t.Errorf("Distance() = %v", got)
+			}
+		})
+	}
+}
+
+func TestDistance(t *testing.T) {
+	if got := Distance(0b1011, 0b0110); got != 3 {
+		t.Errorf("Distance() = %v, want 3", got)
+	}
+}
@@ -0,0 +1,59 @@
+package imaging
+
+import (
+	"image"
+	"image/draw"
+)
+
+// Fit downscales the image to fit into the square with the side of size pixels keeping the aspect ratio.
+// The image smaller than the square is returned as is. Every pixel is the average of the pixels it covers.
+func Fit(img image.Image, size int) image.Image {
+	b := img.Bounds()
+	w, h := b.Dx(), b.Dy()
+	if size <= 0 || (w <= size && h <= size) {
+		return img
+	}
+
+	dw, dh := size, max(h*size/w, 1)
+	if h > w {
+		dw, dh = max(w*size/h, 1), size
+	}
+
+	return Resize(img, dw, dh)
+}
+
+// Resize scales the image to the width and height pixels. Every pixel is the average of the pixels it covers,
+// the upscaled pixels repeat the nearest ones.
+func Resize(img image.Image, width, height int) *image.RGBA {
+	b := img.Bounds()
+	w, h := b.Dx(), b.Dy()
+
+	src := image.NewRGBA(image.Rect(0, 0, w, h))
+	draw.Draw(src, src.Bounds(), img, b.Min, draw.Src)
+
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y := range height {
+		y0, y1 := y*h/height, max((y+1)*h/height, y*h/height+1)
+		for x := range width {
+			x0, x1 := x*w/width, max((x+1)*w/width, x*w/width+1)
+
+			var sum [4]int
+			for sy := y0; sy < y1; sy++ {
+				for sx := x0; sx < x1; sx++ {
+					i := src.PixOffset(sx, sy)
+					for c := range sum {
+						sum[c] += int(src.Pix[i+c])
+					}
+				}
+			}
+
+			n := (x1 - x0) * (y1 - y0)
+			i := dst.PixOffset(x, y)
+			for c := range sum {
+				dst.Pix[i+c] = uint8(sum[c] / n)
+			}
+		}
+	}
+
+	return dst
+}
@@ -0,0 +1,52 @@
+package imaging
+
+import (
+	"image"
+	"image/color"
+	"testing"
+)
+
+func TestFit(t *testing.T) {
+	tests := []struct {
+		name         string
+		width        int
+		height       int
+		size         int
+		wantWidth    int
+		wantHeight   int
+		wantOriginal bool
+	}{
+		{name: "Landscape", width: 400, height: 200, size: 100, wantWidth: 100, wantHeight: 50},
+		{name: "Portrait", width: 200, height: 400, size: 100, wantWidth: 50, wantHeight: 100},
+		{name: "Thin", width: 1000, height: 2, size: 100, wantWidth: 100, wantHeight: 1},
+		{name: "Small", width: 80, height: 60, size: 100, wantWidth: 80, wantHeight: 60, wantOriginal: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			img := image.NewRGBA(image.Rect(0, 0, tt.width, tt.height))
+
+			got := Fit(img, tt.size)
+
+			if got.Bounds().Dx() != tt.wantWidth || got.Bounds().Dy() != tt.wantHeight {
+				t.Errorf("Fit() size = %v, want %vx%v", got.Bounds().Size(), tt.wantWidth, tt.wantHeight)
+			}
+			if (got == image.Image(img)) != tt.wantOriginal {
+				t.Errorf("Fit() returned the original = %v, want %v", got == image.Image(img), tt.wantOriginal)
+			}
+		})
+	}
+}
+
+func TestFit_Average(t *testing.T) {
+	img := image.NewRGBA(image.Rect(0, 0, 2, 2))
+	img.Set(0, 0, color.RGBA{R: 200, A: 255})
+	img.Set(1, 0, color.RGBA{R: 100, A: 255})
+	img.Set(0, 1, color.RGBA{G: 40, A: 255})
+	img.Set(1, 1, color.RGBA{B: 80, A: 255})
+
+	got := color.RGBAModel.Convert(Fit(img, 1).At(0, 0))
+
+	if want := (color.RGBA{R: 75, G: 10, B: 20, A: 255}); got != want {
+		t.Errorf("Fit() pixel = %v, want %v", got, want)
+	}
+}
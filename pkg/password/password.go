@@ -1,6 +1,10 @@
 package password
 
-import "golang.org/x/crypto/bcrypt"
+import (
+	"sync"
+
+	"golang.org/x/crypto/bcrypt"
+)
 
 func HashPassword(password string) (string, error) {
 	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
@@ -11,3 +15,16 @@ func CheckPasswordHash(password, hash string) bool {
 	err := bcrypt.CompareHashAndPassword([]byte(hash), []byte(password))
 	return err == nil
 }
+
+// dummyHash is the hash the passwords of the missing users are checked against
+var dummyHash = sync.OnceValue(func() []byte {
+	hash, _ := bcrypt.GenerateFromPassword([]byte("dummy password"), bcrypt.DefaultCost)
+	return hash
+})
+
+// CheckDummyHash checks the password of the missing user, it takes as long as CheckPasswordHash
+// so the missing users can't be told apart by the response time
+func CheckDummyHash(password string) bool {
+	_ = bcrypt.CompareHashAndPassword(dummyHash(), []byte(password))
+	return false
+}
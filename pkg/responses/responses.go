@@ -1,7 +1,10 @@
 package responses
 
 import (
+	"math"
 	"net/http"
+	"strconv"
+	"time"
 
 	"github.com/gin-gonic/gin"
 )
@@ -44,6 +47,14 @@ func Fail(c *gin.Context, status int, message string) {
 	})
 }
 
+func FailWithData[T any](c *gin.Context, status int, message string, data T) {
+	c.JSON(status, Response[T]{
+		Success: false,
+		Payload: data,
+		Error:   &ErrorInfo{Message: message},
+	})
+}
+
 func BadRequest(c *gin.Context, message string) {
 	Fail(c, http.StatusBadRequest, message)
 }
@@ -56,10 +67,20 @@ func Unauthorized(c *gin.Context, message string) {
 	Fail(c, http.StatusUnauthorized, message)
 }
 
+func Forbidden(c *gin.Context, message string) {
+	Fail(c, http.StatusForbidden, message)
+}
+
 func Conflict(c *gin.Context, message string) {
 	Fail(c, http.StatusConflict, message)
 }
 
+// TooManyRequests fails the request with the Retry-After header in whole seconds
+func TooManyRequests(c *gin.Context, message string, retryAfter time.Duration) {
+	c.Header("Retry-After", strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+	Fail(c, http.StatusTooManyRequests, message)
+}
+
 func Internal(c *gin.Context, message string) {
 	Fail(c, http.StatusInternalServerError, message)
 }
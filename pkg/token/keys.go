@@ -0,0 +1,238 @@
+package token
+
+import (
+	"cmp"
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"slices"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// Key is the key the tokens are signed and verified by
+type Key struct {
+	// ID is the kid header of the tokens signed by the key, the HMAC key has no id
+	ID     string
+	Method jwt.SigningMethod
+	// SignKey is the secret or the private key, the key without it only verifies the tokens
+	SignKey any
+	// VerifyKey is the secret or the public key
+	VerifyKey any
+}
+
+// HMACKey returns the HS256 key without id, the secret is never published
+func HMACKey(secret string) Key {
+	return Key{
+		Method:    jwt.SigningMethodHS256,
+		SignKey:   []byte(secret),
+		VerifyKey: []byte(secret),
+	}
+}
+
+// LoadKey loads the RS256 or EdDSA key from the PEM files. The public key is taken from the private one
+// when the public key file is empty, the key without the private key file only verifies the tokens.
+func LoadKey(id, algorithm, privateKeyFile, publicKeyFile string) (Key, error) {
+	const op = "token.LoadKey"
+
+	key := Key{ID: id}
+	if id == "" {
+		return key, fmt.Errorf("%s: the key has no id", op)
+	}
+	if privateKeyFile == "" && publicKeyFile == "" {
+		return key, fmt.Errorf("%s: the key %q has no key files", op, id)
+	}
+
+	var parsePrivate func([]byte) (crypto.Signer, error)
+	var parsePublic func([]byte) (crypto.PublicKey, error)
+	switch algorithm {
+	case jwt.SigningMethodRS256.Alg():
+		key.Method = jwt.SigningMethodRS256
+		parsePrivate = func(data []byte) (crypto.Signer, error) { return jwt.ParseRSAPrivateKeyFromPEM(data) }
+		parsePublic = func(data []byte) (crypto.PublicKey, error) { return jwt.ParseRSAPublicKeyFromPEM(data) }
+	case jwt.SigningMethodEdDSA.Alg():
+		key.Method = jwt.SigningMethodEdDSA
+		parsePrivate = func(data []byte) (crypto.Signer, error) {
+			private, err := jwt.ParseEdPrivateKeyFromPEM(data)
+			if err != nil {
+				return nil, err
+			}
+			signer, ok := private.(crypto.Signer)
+			if !ok {
+				return nil, jwt.ErrNotEdPrivateKey
+			}
+			return signer, nil
+		}
+		parsePublic = jwt.ParseEdPublicKeyFromPEM
+	default:
+		return key, fmt.Errorf("%s: the key %q has unsupported algorithm %q", op, id, algorithm)
+	}
+
+	if privateKeyFile != "" {
+		data, err := os.ReadFile(privateKeyFile)
+		if err != nil {
+			return key, fmt.Errorf("%s: %w", op, err)
+		}
+		private, err := parsePrivate(data)
+		if err != nil {
+			return key, fmt.Errorf("%s: the private key %q: %w", op, id, err)
+		}
+		key.SignKey = private
+		key.VerifyKey = private.Public()
+	}
+	if publicKeyFile != "" {
+		data, err := os.ReadFile(publicKeyFile)
+		if err != nil {
+			return key, fmt.Errorf("%s: %w", op, err)
+		}
+		if key.VerifyKey, err = parsePublic(data); err != nil {
+			return key, fmt.Errorf("%s: the public key %q: %w", op, id, err)
+		}
+	}
+
+	return key, nil
+}
+
+// KeySet signs the tokens by its signing key and verifies them by the key of their kid header,
+// so the keys can be rotated while the tokens of the previous keys are still valid
+type KeySet struct {
+	signing Key
+	keys    map[string]Key
+}
+
+// NewKeySet returns the set signing by the key with the signing key id
+func NewKeySet(signingKeyID string, keys ...Key) (*KeySet, error) {
+	const op = "token.NewKeySet"
+
+	set := &KeySet{keys: make(map[string]Key, len(keys))}
+	for _, key := range keys {
+		if _, ok := set.keys[key.ID]; ok {
+			return nil, fmt.Errorf("%s: duplicate key %q", op, key.ID)
+		}
+		set.keys[key.ID] = key
+	}
+
+	signing, ok := set.keys[signingKeyID]
+	if !ok || signing.SignKey == nil {
+		return nil, fmt.Errorf("%s: no signing key %q", op, signingKeyID)
+	}
+	set.signing = signing
+
+	return set, nil
+}
+
+// NewHMACKeySet returns the set of the HS256 key
+func NewHMACKeySet(secret string) *KeySet {
+	key := HMACKey(secret)
+	return &KeySet{
+		signing: key,
+		keys:    map[string]Key{key.ID: key},
+	}
+}
+
+// CreateToken creates a token signed by the signing key with the extra claims, they can't override the registered ones
+func (s *KeySet) CreateToken(ttl time.Duration, userId int, extraClaims map[string]any) (string, error) {
+	token := jwt.NewWithClaims(s.signing.Method, newClaims(ttl, userId, extraClaims))
+	if s.signing.ID != "" {
+		token.Header["kid"] = s.signing.ID
+	}
+
+	tokenString, err := token.SignedString(s.signing.SignKey)
+	if err != nil {
+		return "", fmt.Errorf("create: sign token: %w", err)
+	}
+
+	return tokenString, nil
+}
+
+// ParseClaims validates the token and returns its claims
+func (s *KeySet) ParseClaims(tokenString string) (map[string]any, error) {
+	token, err := jwt.Parse(tokenString, s.Keyfunc)
+	if err != nil {
+		return nil, fmt.Errorf("validate: %w", err)
+	}
+
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok || !token.Valid {
+		return nil, fmt.Errorf("validate: invalid token")
+	}
+
+	return claims, nil
+}
+
+// Keyfunc returns the verification key of the token by its kid header, the token must be signed
+// with the algorithm of the key
+func (s *KeySet) Keyfunc(t *jwt.Token) (any, error) {
+	kid, _ := t.Header["kid"].(string)
+	key, ok := s.keys[kid]
+	if !ok {
+		return nil, fmt.Errorf("unknown key %q", kid)
+	}
+	if t.Method.Alg() != key.Method.Alg() {
+		return nil, fmt.Errorf("unexpected signing method: %v", t.Header["alg"])
+	}
+
+	return key.VerifyKey, nil
+}
+
+// JWK is the public key in the JSON Web Key format
+type JWK struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+	// N and E are the modulus and the exponent of the RSA key
+	N string `json:"n,omitempty"`
+	E string `json:"e,omitempty"`
+	// Crv and X are the curve and the public key of the EdDSA key
+	Crv string `json:"crv,omitempty"`
+	X   string `json:"x,omitempty"`
+}
+
+// JWKS is the JSON Web Key Set
+type JWKS struct {
+	Keys []JWK `json:"keys"`
+}
+
+// JWKS returns the public keys of the set ordered by id, the HMAC secrets are skipped
+func (s *KeySet) JWKS() JWKS {
+	jwks := JWKS{Keys: []JWK{}}
+	for _, key := range s.keys {
+		jwk, err := key.JWK()
+		if err != nil {
+			continue
+		}
+		jwks.Keys = append(jwks.Keys, jwk)
+	}
+	slices.SortFunc(jwks.Keys, func(a, b JWK) int {
+		return cmp.Compare(a.Kid, b.Kid)
+	})
+
+	return jwks
+}
+
+// JWK returns the public key in the JSON Web Key format, the HMAC keys have no public part
+func (k Key) JWK() (JWK, error) {
+	jwk := JWK{Kid: k.ID, Use: "sig", Alg: k.Method.Alg()}
+
+	switch key := k.VerifyKey.(type) {
+	case *rsa.PublicKey:
+		jwk.Kty = "RSA"
+		jwk.N = base64.RawURLEncoding.EncodeToString(key.N.Bytes())
+		jwk.E = base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes())
+	case ed25519.PublicKey:
+		jwk.Kty = "OKP"
+		jwk.Crv = "Ed25519"
+		jwk.X = base64.RawURLEncoding.EncodeToString(key)
+	default:
+		return jwk, errors.New("the key has no public part")
+	}
+
+	return jwk, nil
+}
@@ -0,0 +1,182 @@
+package token_test
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/PritOriginal/problem-map-server/pkg/token"
+)
+
+// writePEM writes the PKCS #8 private key or the PKIX public key to the PEM file in the temp dir
+func writePEM(t *testing.T, name string, key any) string {
+	t.Helper()
+
+	var block *pem.Block
+	switch key.(type) {
+	case *rsa.PrivateKey, ed25519.PrivateKey:
+		der, err := x509.MarshalPKCS8PrivateKey(key)
+		if err != nil {
+			t.Fatalf("MarshalPKCS8PrivateKey() error = %v", err)
+		}
+		block = &pem.Block{Type: "PRIVATE KEY", Bytes: der}
+	default:
+		der, err := x509.MarshalPKIXPublicKey(key)
+		if err != nil {
+			t.Fatalf("MarshalPKIXPublicKey() error = %v", err)
+		}
+		block = &pem.Block{Type: "PUBLIC KEY", Bytes: der}
+	}
+
+	file := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(file, pem.EncodeToMemory(block), 0o600); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+	return file
+}
+
+func TestKeySet(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("rsa.GenerateKey() error = %v", err)
+	}
+	edPublic, edPrivate, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("ed25519.GenerateKey() error = %v", err)
+	}
+
+	oldKey, err := token.LoadKey("old", "RS256", writePEM(t, "old.pem", rsaKey), "")
+	if err != nil {
+		t.Fatalf("LoadKey() error = %v", err)
+	}
+	newKey, err := token.LoadKey("new", "EdDSA", writePEM(t, "new.pem", edPrivate), "")
+	if err != nil {
+		t.Fatalf("LoadKey() error = %v", err)
+	}
+	newPublicKey, err := token.LoadKey("new", "EdDSA", "", writePEM(t, "new.pub.pem", edPublic))
+	if err != nil {
+		t.Fatalf("LoadKey() error = %v", err)
+	}
+
+	oldSet, err := token.NewKeySet("old", oldKey)
+	if err != nil {
+		t.Fatalf("NewKeySet() error = %v", err)
+	}
+	// the new key signs, the old one still verifies the tokens issued before the rotation
+	rotatedSet, err := token.NewKeySet("new", oldKey, newKey)
+	if err != nil {
+		t.Fatalf("NewKeySet() error = %v", err)
+	}
+	otherSet, err := token.NewKeySet("new", newKey, token.HMACKey("secret"))
+	if err != nil {
+		t.Fatalf("NewKeySet() error = %v", err)
+	}
+
+	oldToken, err := oldSet.CreateToken(time.Minute, 1, nil)
+	if err != nil {
+		t.Fatalf("CreateToken() error = %v", err)
+	}
+	newToken, err := rotatedSet.CreateToken(time.Minute, 2, map[string]any{token.RoleClaim: "moderator"})
+	if err != nil {
+		t.Fatalf("CreateToken() error = %v", err)
+	}
+	hmacToken, err := token.CreateToken(time.Minute, 3, "secret")
+	if err != nil {
+		t.Fatalf("CreateToken() error = %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		set     *token.KeySet
+		token   string
+		wantSub string
+		wantErr bool
+	}{
+		{name: "RS256", set: oldSet, token: oldToken, wantSub: "1"},
+		{name: "EdDSA", set: rotatedSet, token: newToken, wantSub: "2"},
+		{name: "RotatedKey", set: rotatedSet, token: oldToken, wantSub: "1"},
+		{name: "HMACKey", set: otherSet, token: hmacToken, wantSub: "3"},
+		{name: "UnknownKey", set: oldSet, token: newToken, wantErr: true},
+		{name: "NoHMACKey", set: rotatedSet, token: hmacToken, wantErr: true},
+		{name: "RemovedKey", set: otherSet, token: oldToken, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			claims, err := tt.set.ParseClaims(tt.token)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("ParseClaims() error = nil, want error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseClaims() error = %v", err)
+			}
+			if claims["sub"] != tt.wantSub {
+				t.Errorf("ParseClaims() sub = %v, want %v", claims["sub"], tt.wantSub)
+			}
+		})
+	}
+
+	t.Run("VerifyOnlyKey", func(t *testing.T) {
+		if _, err := token.NewKeySet("new", newPublicKey); err == nil {
+			t.Fatalf("NewKeySet() error = nil, want error")
+		}
+
+		set, err := token.NewKeySet("old", oldKey, newPublicKey)
+		if err != nil {
+			t.Fatalf("NewKeySet() error = %v", err)
+		}
+		if _, err := set.ParseClaims(newToken); err != nil {
+			t.Errorf("ParseClaims() error = %v", err)
+		}
+	})
+}
+
+func TestKeySetJWKS(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("rsa.GenerateKey() error = %v", err)
+	}
+	_, edPrivate, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("ed25519.GenerateKey() error = %v", err)
+	}
+
+	rsaJWK, err := token.LoadKey("b", "RS256", writePEM(t, "b.pem", rsaKey), "")
+	if err != nil {
+		t.Fatalf("LoadKey() error = %v", err)
+	}
+	edJWK, err := token.LoadKey("a", "EdDSA", writePEM(t, "a.pem", edPrivate), "")
+	if err != nil {
+		t.Fatalf("LoadKey() error = %v", err)
+	}
+	set, err := token.NewKeySet("a", rsaJWK, edJWK, token.HMACKey("secret"))
+	if err != nil {
+		t.Fatalf("NewKeySet() error = %v", err)
+	}
+
+	jwks := set.JWKS()
+
+	want := []token.JWK{
+		{Kty: "OKP", Kid: "a", Use: "sig", Alg: "EdDSA", Crv: "Ed25519", X: jwks.Keys[0].X},
+		{Kty: "RSA", Kid: "b", Use: "sig", Alg: "RS256", N: jwks.Keys[1].N, E: "AQAB"},
+	}
+	if len(jwks.Keys) != len(want) {
+		t.Fatalf("JWKS() = %v, want %v", jwks.Keys, want)
+	}
+	for i := range want {
+		if jwks.Keys[i] != want[i] {
+			t.Errorf("JWKS() key %d = %v, want %v", i, jwks.Keys[i], want[i])
+		}
+	}
+	if len(jwks.Keys[0].X) != 43 || len(jwks.Keys[1].N) != 342 {
+		t.Errorf("JWKS() has invalid key encoding: %v", jwks.Keys)
+	}
+}
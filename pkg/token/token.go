@@ -1,46 +1,56 @@
 package token
 
 import (
-	"fmt"
 	"strconv"
 	"time"
 
-	"github.com/golang-jwt/jwt"
+	"github.com/golang-jwt/jwt/v5"
 )
 
-func CreateToken(ttl time.Duration, userId int, key string) (string, error) {
-	timeNow := time.Now()
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
-		"iat": timeNow.Unix(),
-		"nbf": timeNow.Unix(),
-		"exp": timeNow.Add(ttl).Unix(),
-		"sub": strconv.Itoa(userId),
-	})
-	tokenString, err := token.SignedString([]byte(key))
+const (
+	// RoleClaim is the claim of the access token with the name of the user role
+	RoleClaim = "role"
+	// IDClaim is the claim with the unique id of the token
+	IDClaim = "jti"
+	// SessionClaim is the claim with the id of the session the token was issued for
+	SessionClaim = "sid"
+)
 
-	if err != nil {
-		return "", fmt.Errorf("create: sign token: %w", err)
-	}
+func CreateToken(ttl time.Duration, userId int, key string) (string, error) {
+	return CreateTokenWithClaims(ttl, userId, key, nil)
+}
 
-	return tokenString, nil
+// CreateTokenWithClaims creates a token signed with HS256 by the key with the extra claims,
+// they can't override the registered ones
+func CreateTokenWithClaims(ttl time.Duration, userId int, key string, extraClaims map[string]any) (string, error) {
+	return NewHMACKeySet(key).CreateToken(ttl, userId, extraClaims)
 }
 
 func ValidateToken(tokenString string, key string) (interface{}, error) {
-	token, err := jwt.Parse(tokenString, func(t *jwt.Token) (interface{}, error) {
-		if _, ok := t.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, fmt.Errorf("unexpected signing method: %v", t.Header["alg"])
-		}
-		return []byte(key), nil
-	})
-
+	claims, err := ParseClaims(tokenString, key)
 	if err != nil {
-		return nil, fmt.Errorf("validate: %w", err)
+		return nil, err
 	}
 
-	claims, ok := token.Claims.(jwt.MapClaims)
-	if !ok || !token.Valid {
-		return nil, fmt.Errorf("validate: invalid token")
+	return claims["sub"], nil
+}
+
+// ParseClaims validates the token signed with HS256 by the key and returns its claims
+func ParseClaims(tokenString string, key string) (map[string]any, error) {
+	return NewHMACKeySet(key).ParseClaims(tokenString)
+}
+
+// newClaims returns the registered claims of the token with the extra claims
+func newClaims(ttl time.Duration, userId int, extraClaims map[string]any) jwt.MapClaims {
+	timeNow := time.Now()
+	claims := jwt.MapClaims{}
+	for name, value := range extraClaims {
+		claims[name] = value
 	}
+	claims["iat"] = timeNow.Unix()
+	claims["nbf"] = timeNow.Unix()
+	claims["exp"] = timeNow.Add(ttl).Unix()
+	claims["sub"] = strconv.Itoa(userId)
 
-	return claims["sub"], nil
+	return claims
 }
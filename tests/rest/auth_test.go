@@ -8,11 +8,18 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/PritOriginal/problem-map-server/internal/config"
 	authrest "github.com/PritOriginal/problem-map-server/internal/handler/auth"
+	"github.com/PritOriginal/problem-map-server/internal/models"
+	"github.com/PritOriginal/problem-map-server/internal/notifier"
 	"github.com/PritOriginal/problem-map-server/pkg/responses"
+	"github.com/PritOriginal/problem-map-server/pkg/token"
 	"github.com/brianvoe/gofakeit/v7"
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
@@ -157,7 +164,7 @@ func (st *AuthSuite) TestSignIn() {
 		{
 			name: "Err401",
 			req: authrest.SignInRequest{
-				Login:    "username",
+				Login:    gofakeit.Username(),
 				Password: "password",
 			},
 			statusCode: http.StatusUnauthorized,
@@ -204,6 +211,40 @@ func signIn(t *testing.T, req io.Reader, cfg *config.RESTConfig, expectedStatusC
 	return response
 }
 
+func (st *AuthSuite) TestSignInLockout() {
+	login := gofakeit.Username()
+	password := gofakeit.Password(true, true, true, true, true, 10)
+
+	signUpReqJSON, err := json.Marshal(authrest.SignUpRequest{
+		Username: gofakeit.FirstName(),
+		Login:    login,
+		Password: password,
+	})
+	st.Require().NoError(err)
+	_ = signUp(st.T(), bytes.NewBuffer(signUpReqJSON), &st.Cfg.REST, http.StatusCreated)
+
+	wrongReqJSON, err := json.Marshal(authrest.SignInRequest{Login: login, Password: password + "wrong"})
+	st.Require().NoError(err)
+	// the attempt over the free ones fails as usual and locks the login out
+	for range st.Cfg.Auth.SignIn.LoginAttempts + 1 {
+		_ = signIn(st.T(), bytes.NewBuffer(wrongReqJSON), &st.Cfg.REST, http.StatusUnauthorized)
+	}
+
+	// the right password is locked out too
+	reqJSON, err := json.Marshal(authrest.SignInRequest{Login: login, Password: password})
+	st.Require().NoError(err)
+	resp, err := http.Post(
+		fmt.Sprintf("http://%s:%d/auth/signin", st.Cfg.REST.Host, st.Cfg.REST.Port),
+		"application/json",
+		bytes.NewBuffer(reqJSON),
+	)
+	st.Require().NoError(err)
+	defer resp.Body.Close()
+
+	st.Equal(http.StatusTooManyRequests, resp.StatusCode)
+	st.NotEmpty(resp.Header.Get("Retry-After"))
+}
+
 func (st *AuthSuite) TestRefreshTokens() {
 	username := gofakeit.FirstName()
 	login := gofakeit.Username()
@@ -298,6 +339,223 @@ func (st *AuthSuite) TestRefreshTokens() {
 	}
 }
 
+func refreshTokens(t *testing.T, refreshToken string, cfg *config.RESTConfig, expectedStatusCode int) responses.Response[authrest.RefreshTokensResponse] {
+	reqJSON, err := json.Marshal(authrest.RefreshTokensRequest{
+		RefreshToken: refreshToken,
+	})
+	require.NoError(t, err)
+
+	resp, err := http.Post(
+		fmt.Sprintf("http://%s:%d/auth/tokens/refresh", cfg.Host, cfg.Port),
+		"application/json",
+		bytes.NewBuffer(reqJSON),
+	)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	require.Equal(t, expectedStatusCode, resp.StatusCode)
+
+	var response responses.Response[authrest.RefreshTokensResponse]
+	err = json.NewDecoder(resp.Body).Decode(&response)
+	require.NoError(t, err)
+
+	return response
+}
+
+func (st *AuthSuite) TestRefreshTokensReuse() {
+	signInResponse := addNewUser(st.T(), &st.Cfg.REST)
+
+	refreshed := refreshTokens(st.T(), signInResponse.Payload.RefreshToken, &st.Cfg.REST, http.StatusOK)
+
+	// the rotated token is reused, so the whole session is revoked
+	_ = refreshTokens(st.T(), signInResponse.Payload.RefreshToken, &st.Cfg.REST, http.StatusUnauthorized)
+	_ = refreshTokens(st.T(), refreshed.Payload.RefreshToken, &st.Cfg.REST, http.StatusUnauthorized)
+}
+
+func (st *AuthSuite) TestLogout() {
+	signInResponse := addNewUser(st.T(), &st.Cfg.REST)
+
+	reqJSON, err := json.Marshal(authrest.LogoutRequest{
+		RefreshToken: signInResponse.Payload.RefreshToken,
+	})
+	st.NoError(err)
+
+	resp, err := http.Post(
+		fmt.Sprintf("http://%s:%d/auth/logout", st.Cfg.REST.Host, st.Cfg.REST.Port),
+		"application/json",
+		bytes.NewBuffer(reqJSON),
+	)
+	st.Require().NoError(err)
+	defer resp.Body.Close()
+	st.Equal(http.StatusOK, resp.StatusCode)
+
+	_ = refreshTokens(st.T(), signInResponse.Payload.RefreshToken, &st.Cfg.REST, http.StatusUnauthorized)
+}
+
+func (st *AuthSuite) TestLogoutAll() {
+	signInResponse := addNewUser(st.T(), &st.Cfg.REST)
+
+	req, err := http.NewRequest("POST", fmt.Sprintf("http://%s:%d/auth/logout/all", st.Cfg.REST.Host, st.Cfg.REST.Port), nil)
+	st.Require().NoError(err)
+	req.Header.Set("Authorization", "Bearer "+signInResponse.Payload.AccessToken)
+
+	resp, err := http.DefaultClient.Do(req)
+	st.Require().NoError(err)
+	defer resp.Body.Close()
+	st.Equal(http.StatusOK, resp.StatusCode)
+
+	_ = refreshTokens(st.T(), signInResponse.Payload.RefreshToken, &st.Cfg.REST, http.StatusUnauthorized)
+}
+
+func (st *AuthSuite) TestChangePassword() {
+	login := gofakeit.Username()
+	password := gofakeit.Password(true, true, true, true, true, 10)
+	newPassword := gofakeit.Password(true, true, true, true, true, 12)
+
+	signUpReqJSON, err := json.Marshal(authrest.SignUpRequest{
+		Username: gofakeit.FirstName(),
+		Login:    login,
+		Password: password,
+	})
+	st.Require().NoError(err)
+	_ = signUp(st.T(), bytes.NewBuffer(signUpReqJSON), &st.Cfg.REST, http.StatusCreated)
+
+	signInReqJSON, err := json.Marshal(authrest.SignInRequest{Login: login, Password: password})
+	st.Require().NoError(err)
+	current := signIn(st.T(), bytes.NewBuffer(signInReqJSON), &st.Cfg.REST, http.StatusOK)
+	other := signIn(st.T(), bytes.NewBuffer(signInReqJSON), &st.Cfg.REST, http.StatusOK)
+
+	_ = changePassword(st.T(), current.Payload.AccessToken, authrest.ChangePasswordRequest{
+		OldPassword: newPassword,
+		NewPassword: password,
+	}, &st.Cfg.REST, http.StatusForbidden)
+	_ = changePassword(st.T(), current.Payload.AccessToken, authrest.ChangePasswordRequest{
+		OldPassword: password,
+		NewPassword: newPassword,
+	}, &st.Cfg.REST, http.StatusOK)
+
+	// the other sessions are revoked
+	_ = refreshTokens(st.T(), current.Payload.RefreshToken, &st.Cfg.REST, http.StatusOK)
+	_ = refreshTokens(st.T(), other.Payload.RefreshToken, &st.Cfg.REST, http.StatusUnauthorized)
+
+	_ = signIn(st.T(), bytes.NewBuffer(signInReqJSON), &st.Cfg.REST, http.StatusUnauthorized)
+	signInReqJSON, err = json.Marshal(authrest.SignInRequest{Login: login, Password: newPassword})
+	st.Require().NoError(err)
+	_ = signIn(st.T(), bytes.NewBuffer(signInReqJSON), &st.Cfg.REST, http.StatusOK)
+}
+
+func changePassword(t *testing.T, accessToken string, changeReq authrest.ChangePasswordRequest, cfg *config.RESTConfig, expectedStatusCode int) responses.Response[authrest.ChangePasswordResponse] {
+	reqJSON, err := json.Marshal(changeReq)
+	require.NoError(t, err)
+
+	req, err := http.NewRequest("PUT", fmt.Sprintf("http://%s:%d/auth/password", cfg.Host, cfg.Port), bytes.NewBuffer(reqJSON))
+	require.NoError(t, err)
+	req.Header.Set("Authorization", "Bearer "+accessToken)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	require.Equal(t, expectedStatusCode, resp.StatusCode)
+
+	var response responses.Response[authrest.ChangePasswordResponse]
+	err = json.NewDecoder(resp.Body).Decode(&response)
+	require.NoError(t, err)
+
+	return response
+}
+
+func (st *AuthSuite) TestResetPassword() {
+	if st.Cfg.Notifier.Type != config.FileNotifier {
+		st.T().Skip("the reset token is read from the notifications file of the file notifier")
+	}
+
+	login := gofakeit.Username()
+	newPassword := gofakeit.Password(true, true, true, true, true, 12)
+
+	signUpReqJSON, err := json.Marshal(authrest.SignUpRequest{
+		Username: gofakeit.FirstName(),
+		Login:    login,
+		Password: gofakeit.Password(true, true, true, true, true, 10),
+	})
+	st.Require().NoError(err)
+	_ = signUp(st.T(), bytes.NewBuffer(signUpReqJSON), &st.Cfg.REST, http.StatusCreated)
+
+	// the unknown login is accepted too
+	requestPasswordReset(st.T(), gofakeit.Username()+"-unknown", &st.Cfg.REST)
+	requestPasswordReset(st.T(), login, &st.Cfg.REST)
+	resetToken := readResetToken(st.T(), st.Cfg.Notifier.File, login)
+
+	resetPassword(st.T(), resetToken, newPassword, &st.Cfg.REST, http.StatusOK)
+	// the token is used up
+	resetPassword(st.T(), resetToken, newPassword, &st.Cfg.REST, http.StatusBadRequest)
+
+	signInReqJSON, err := json.Marshal(authrest.SignInRequest{Login: login, Password: newPassword})
+	st.Require().NoError(err)
+	_ = signIn(st.T(), bytes.NewBuffer(signInReqJSON), &st.Cfg.REST, http.StatusOK)
+}
+
+func requestPasswordReset(t *testing.T, login string, cfg *config.RESTConfig) {
+	reqJSON, err := json.Marshal(authrest.RequestPasswordResetRequest{Login: login})
+	require.NoError(t, err)
+
+	resp, err := http.Post(
+		fmt.Sprintf("http://%s:%d/auth/password/reset/request", cfg.Host, cfg.Port),
+		"application/json",
+		bytes.NewBuffer(reqJSON),
+	)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	require.Equal(t, http.StatusAccepted, resp.StatusCode)
+}
+
+func resetPassword(t *testing.T, resetToken, newPassword string, cfg *config.RESTConfig, expectedStatusCode int) {
+	reqJSON, err := json.Marshal(authrest.ResetPasswordRequest{Token: resetToken, NewPassword: newPassword})
+	require.NoError(t, err)
+
+	resp, err := http.Post(
+		fmt.Sprintf("http://%s:%d/auth/password/reset", cfg.Host, cfg.Port),
+		"application/json",
+		bytes.NewBuffer(reqJSON),
+	)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	require.Equal(t, expectedStatusCode, resp.StatusCode)
+}
+
+// readResetToken returns the last password reset token sent to the user by the file notifier,
+// the server is expected to run from the repository root. The tokens are sent in the background,
+// so it waits for the token for a few seconds.
+func readResetToken(t *testing.T, file, login string) string {
+	if !filepath.IsAbs(file) {
+		file = filepath.Join("../..", file)
+	}
+
+	var resetToken string
+	require.Eventually(t, func() bool {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return false
+		}
+		for line := range strings.Lines(string(data)) {
+			var notification notifier.Notification
+			if err := json.Unmarshal([]byte(line), &notification); err != nil {
+				// the line is being written
+				return false
+			}
+			if notification.Type == notifier.PasswordResetNotification && notification.Login == login {
+				resetToken = notification.Token
+			}
+		}
+		return resetToken != ""
+	}, 5*time.Second, 100*time.Millisecond)
+
+	return resetToken
+}
+
 func addNewUser(t *testing.T, cfg *config.RESTConfig) responses.Response[authrest.SignInResponse] {
 	username := gofakeit.FirstName()
 	login := gofakeit.Username()
@@ -317,3 +575,14 @@ func addNewUser(t *testing.T, cfg *config.RESTConfig) responses.Response[authres
 	})
 	return signIn(t, bytes.NewBuffer(signInReqJson), cfg, http.StatusOK)
 }
+
+// moderatorAccessToken signs an access token with the moderator role,
+// there is no API to grant roles
+func moderatorAccessToken(t *testing.T, authCfg config.AuthConfing) string {
+	accessToken, err := token.CreateTokenWithClaims(authCfg.JWT.Access.ExpiredIn, 1, authCfg.JWT.Access.Key, map[string]any{
+		token.RoleClaim: models.ModeratorRole.String(),
+	})
+	require.NoError(t, err)
+
+	return accessToken
+}
@@ -169,18 +169,30 @@ func (st *ChecksSuite) TestAddCheck() {
 	getMarksResponse := getMarks(st.T(), &st.Cfg.REST, "", http.StatusOK)
 	randomMarkIndex := rand.Intn(len(getMarksResponse.Payload.Marks))
 	randomMark := getMarksResponse.Payload.Marks[randomMarkIndex]
+	longitude, latitude := randomMark.Geom.Ewkb.Coords().X(), randomMark.Geom.Ewkb.Coords().Y()
 
 	tests := []struct {
 		name       string
 		req        checksrest.AddCheckRequest
 		statusCode int
 	}{
+		{
+			name: "Err400InvalidLocation",
+			req: checksrest.AddCheckRequest{
+				MarkID:    randomMark.ID,
+				Result:    true,
+				Longitude: &longitude,
+			},
+			statusCode: 400,
+		},
 		{
 			name: "Ok201",
 			req: checksrest.AddCheckRequest{
-				MarkID:  randomMark.ID,
-				Result:  true,
-				Comment: "",
+				MarkID:    randomMark.ID,
+				Result:    true,
+				Comment:   "",
+				Longitude: &longitude,
+				Latitude:  &latitude,
 			},
 			statusCode: 201,
 		},
@@ -255,6 +267,12 @@ func addCheck(t *testing.T, cfg *config.RESTConfig, request checksrest.AddCheckR
 	mpw.WriteField("mark_id", strconv.Itoa(request.MarkID))
 	mpw.WriteField("result", strconv.FormatBool(request.Result))
 	mpw.WriteField("comment", request.Comment)
+	if request.Longitude != nil {
+		mpw.WriteField("longitude", strconv.FormatFloat(*request.Longitude, 'f', -1, 64))
+	}
+	if request.Latitude != nil {
+		mpw.WriteField("latitude", strconv.FormatFloat(*request.Latitude, 'f', -1, 64))
+	}
 
 	image := gofakeit.ImageJpeg(10, 10)
 	fw, err := mpw.CreateFormFile("photos", "test.jpg")
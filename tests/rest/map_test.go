@@ -154,6 +154,106 @@ func (st *MapSuite) TestGetAdminBoundariesMarksCount() {
 	}
 }
 
+func (st *MapSuite) TestGetMarkClusters() {
+	tests := []struct {
+		name       string
+		query      string
+		statusCode int
+	}{
+		{
+			name:       "Ok200",
+			query:      "?bbox=41.3,52.6,41.6,52.8&zoom=10",
+			statusCode: http.StatusOK,
+		},
+		{
+			name:       "Ok200",
+			query:      "?bbox=41.3,52.6,41.6,52.8&zoom=17&mark_type_ids=1",
+			statusCode: http.StatusOK,
+		},
+		{
+			name:       "Err400",
+			query:      "?bbox=41.3,52.6,41.6,52.8",
+			statusCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		st.Run(tt.name, func() {
+			resp, err := http.Get(
+				fmt.Sprintf("http://%s:%d/map/marks/clusters%s",
+					st.Cfg.REST.Host,
+					st.Cfg.REST.Port,
+					tt.query,
+				),
+			)
+			st.NoError(err)
+			defer resp.Body.Close()
+
+			st.Equal(tt.statusCode, resp.StatusCode)
+
+			var response responses.Response[maprest.GetMarkClustersResponse]
+			err = json.NewDecoder(resp.Body).Decode(&response)
+			st.NoError(err)
+
+			if tt.statusCode < 300 {
+				st.Equal(response.Success, true)
+				st.NotNil(response.Payload.Clusters)
+				st.NotNil(response.Payload.Marks)
+			} else {
+				st.Equal(response.Success, false)
+			}
+		})
+	}
+}
+
+func (st *MapSuite) TestGetTile() {
+	tests := []struct {
+		name       string
+		path       string
+		statusCode int
+	}{
+		{
+			name:       "Ok200",
+			path:       "/marks/12/2519/1343.mvt",
+			statusCode: http.StatusOK,
+		},
+		{
+			name:       "Ok200",
+			path:       "/admin_boundaries/8/157/83.mvt",
+			statusCode: http.StatusOK,
+		},
+		{
+			name:       "Err404",
+			path:       "/roads/12/2519/1343.mvt",
+			statusCode: http.StatusNotFound,
+		},
+		{
+			name:       "Err400",
+			path:       "/marks/2/4/0.mvt",
+			statusCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		st.Run(tt.name, func() {
+			resp, err := http.Get(
+				fmt.Sprintf("http://%s:%d/map/tiles%s",
+					st.Cfg.REST.Host,
+					st.Cfg.REST.Port,
+					tt.path,
+				),
+			)
+			st.NoError(err)
+			defer resp.Body.Close()
+
+			st.Equal(tt.statusCode, resp.StatusCode)
+			if tt.statusCode < 300 {
+				st.Equal("application/vnd.mapbox-vector-tile", resp.Header.Get("Content-Type"))
+			}
+		})
+	}
+}
+
 func (st *MapSuite) TestGetRegions() {
 	resp, err := http.Get(fmt.Sprintf("http://%s:%d/map/regions", st.Cfg.REST.Host, st.Cfg.REST.Port))
 
@@ -11,12 +11,14 @@ import (
 	"math/rand"
 	"mime/multipart"
 	"net/http"
+	"net/url"
 	"strconv"
 	"strings"
 	"testing"
 
 	"github.com/PritOriginal/problem-map-server/internal/config"
 	marksrest "github.com/PritOriginal/problem-map-server/internal/handler/marks"
+	"github.com/PritOriginal/problem-map-server/internal/models"
 	"github.com/PritOriginal/problem-map-server/pkg/responses"
 	"github.com/brianvoe/gofakeit/v7"
 	"github.com/stretchr/testify/require"
@@ -76,6 +78,26 @@ func (st *MarksSuite) TestGetMarks() {
 			query:      "?mark_status_ids=a",
 			statusCode: http.StatusBadRequest,
 		},
+		{
+			name:       "Ok200",
+			query:      "?bbox=41.3,52.6,41.6,52.8&limit=100",
+			statusCode: http.StatusOK,
+		},
+		{
+			name:       "Ok400",
+			query:      "?bbox=41.3,52.6,41.6",
+			statusCode: http.StatusBadRequest,
+		},
+		{
+			name:       "Ok200",
+			query:      "?limit=10&sort=updated_at&order=asc",
+			statusCode: http.StatusOK,
+		},
+		{
+			name:       "Ok400",
+			query:      "?cursor=invalid",
+			statusCode: http.StatusBadRequest,
+		},
 	}
 
 	for _, tt := range tests {
@@ -92,6 +114,19 @@ func (st *MarksSuite) TestGetMarks() {
 	}
 }
 
+func (st *MarksSuite) TestGetMarksPagination() {
+	first := getMarks(st.T(), &st.Cfg.REST, "?limit=1", http.StatusOK)
+	st.Require().Len(first.Payload.Marks, 1)
+	st.Require().NotEmpty(first.Payload.NextCursor)
+	st.True(first.Payload.Truncated)
+
+	second := getMarks(st.T(), &st.Cfg.REST, "?limit=1&cursor="+first.Payload.NextCursor, http.StatusOK)
+	st.Require().Len(second.Payload.Marks, 1)
+	st.NotEqual(first.Payload.Marks[0].ID, second.Payload.Marks[0].ID)
+
+	getMarks(st.T(), &st.Cfg.REST, "?limit=1&order=asc&cursor="+first.Payload.NextCursor, http.StatusBadRequest)
+}
+
 func getMarks(t *testing.T, cfg *config.RESTConfig, query string, expectedStatusCode int) responses.Response[marksrest.GetMarksResponse] {
 	resp, err := http.Get(
 		fmt.Sprintf(
@@ -114,6 +149,178 @@ func getMarks(t *testing.T, cfg *config.RESTConfig, query string, expectedStatus
 	return response
 }
 
+func (st *MarksSuite) TestGetNearbyMarks() {
+	tests := []struct {
+		name       string
+		query      string
+		statusCode int
+	}{
+		{
+			name:       "Ok200",
+			query:      "?longitude=41.46&latitude=52.71",
+			statusCode: http.StatusOK,
+		},
+		{
+			name:       "Ok200",
+			query:      "?longitude=41.46&latitude=52.71&radius=1000&limit=10",
+			statusCode: http.StatusOK,
+		},
+		{
+			name:       "Ok400",
+			query:      "?longitude=41.46",
+			statusCode: http.StatusBadRequest,
+		},
+		{
+			name:       "Ok400",
+			query:      "?longitude=41.46&latitude=52.71&radius=-1",
+			statusCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		st.Run(tt.name, func() {
+			response := getNearbyMarks(st.T(), &st.Cfg.REST, tt.query, tt.statusCode)
+
+			if tt.statusCode < 300 {
+				st.Equal(response.Success, true)
+				st.NotNil(response.Payload.Marks)
+				for i := 1; i < len(response.Payload.Marks); i++ {
+					st.LessOrEqual(response.Payload.Marks[i-1].Distance, response.Payload.Marks[i].Distance)
+				}
+			} else {
+				st.Equal(response.Success, false)
+			}
+		})
+	}
+}
+
+func getNearbyMarks(t *testing.T, cfg *config.RESTConfig, query string, expectedStatusCode int) responses.Response[marksrest.GetNearbyMarksResponse] {
+	resp, err := http.Get(
+		fmt.Sprintf(
+			"http://%s:%d/marks/nearby%s",
+			cfg.Host,
+			cfg.Port,
+			query,
+		),
+	)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	require.Equal(t, expectedStatusCode, resp.StatusCode)
+
+	var response responses.Response[marksrest.GetNearbyMarksResponse]
+
+	err = json.NewDecoder(resp.Body).Decode(&response)
+	require.NoError(t, err)
+
+	return response
+}
+
+func (st *MarksSuite) TestSearchMarks() {
+	tests := []struct {
+		name       string
+		query      string
+		statusCode int
+	}{
+		{
+			name:       "Ok200",
+			query:      "?q=" + url.QueryEscape("яма на дороге"),
+			statusCode: http.StatusOK,
+		},
+		{
+			name:       "Ok200",
+			query:      "?q=" + url.QueryEscape("яма") + "&mark_type_ids=1,2&bbox=41.3,52.6,41.6,52.8&limit=10",
+			statusCode: http.StatusOK,
+		},
+		{
+			name:       "Ok400",
+			statusCode: http.StatusBadRequest,
+		},
+		{
+			name:       "Ok400",
+			query:      "?q=" + url.QueryEscape("яма") + "&bbox=41.3,52.6,41.6",
+			statusCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		st.Run(tt.name, func() {
+			response := searchMarks(st.T(), &st.Cfg.REST, tt.query, tt.statusCode)
+
+			if tt.statusCode < 300 {
+				st.Equal(response.Success, true)
+				st.NotNil(response.Payload.Marks)
+				for i := 1; i < len(response.Payload.Marks); i++ {
+					st.GreaterOrEqual(response.Payload.Marks[i-1].Rank, response.Payload.Marks[i].Rank)
+				}
+			} else {
+				st.Equal(response.Success, false)
+			}
+		})
+	}
+}
+
+func searchMarks(t *testing.T, cfg *config.RESTConfig, query string, expectedStatusCode int) responses.Response[marksrest.SearchMarksResponse] {
+	resp, err := http.Get(
+		fmt.Sprintf(
+			"http://%s:%d/marks/search%s",
+			cfg.Host,
+			cfg.Port,
+			query,
+		),
+	)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	require.Equal(t, expectedStatusCode, resp.StatusCode)
+
+	var response responses.Response[marksrest.SearchMarksResponse]
+
+	err = json.NewDecoder(resp.Body).Decode(&response)
+	require.NoError(t, err)
+
+	return response
+}
+
+func (st *MarksSuite) TestFindDuplicateMarks() {
+	tests := []struct {
+		name       string
+		query      string
+		statusCode int
+	}{
+		{
+			name:       "Ok200",
+			query:      "?longitude=41.46&latitude=52.71&mark_type_id=1",
+			statusCode: http.StatusOK,
+		},
+		{
+			name:       "Ok400",
+			query:      "?longitude=41.46&latitude=52.71",
+			statusCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		st.Run(tt.name, func() {
+			resp, err := http.Get(fmt.Sprintf("http://%s:%d/marks/duplicates%s", st.Cfg.REST.Host, st.Cfg.REST.Port, tt.query))
+			st.Require().NoError(err)
+			defer resp.Body.Close()
+
+			st.Require().Equal(tt.statusCode, resp.StatusCode)
+
+			var response responses.Response[marksrest.DuplicateMarksResponse]
+			st.Require().NoError(json.NewDecoder(resp.Body).Decode(&response))
+
+			if tt.statusCode < 300 {
+				st.Equal(response.Success, true)
+				st.NotNil(response.Payload.Marks)
+			} else {
+				st.Equal(response.Success, false)
+			}
+		})
+	}
+}
+
 func (st *MarksSuite) TestGetMarkById() {
 	getMarksResponse := getMarks(st.T(), &st.Cfg.REST, "", http.StatusOK)
 
@@ -210,9 +417,9 @@ func (st *MarksSuite) TestAddMark() {
 	randomMarkTypeIndex := rand.Intn(len(markTypesResponse.Payload.MarkTypes))
 	randomMarkType := markTypesResponse.Payload.MarkTypes[randomMarkTypeIndex]
 
-	long, err := gofakeit.LatitudeInRange(52.6, 52.8)
+	long, err := gofakeit.LongitudeInRange(41.25, 41.55)
 	st.NoError(err)
-	lat, err := gofakeit.LongitudeInRange(41.25, 41.55)
+	lat, err := gofakeit.LatitudeInRange(52.6, 52.8)
 	st.NoError(err)
 
 	tests := []struct {
@@ -223,25 +430,46 @@ func (st *MarksSuite) TestAddMark() {
 		{
 			name: "Ok201",
 			req: marksrest.AddMarkRequest{
-				Longitude:   long,
-				Latitude:    lat,
+				Longitude:   &long,
+				Latitude:    &lat,
+				MarkTypeID:  randomMarkType.ID,
+				Description: "",
+			},
+			statusCode: http.StatusCreated,
+		},
+		{
+			name: "Err409Duplicate",
+			req: marksrest.AddMarkRequest{
+				Longitude:   &long,
+				Latitude:    &lat,
 				MarkTypeID:  randomMarkType.ID,
 				Description: "",
 			},
+			statusCode: http.StatusConflict,
+		},
+		{
+			name: "Ok201Force",
+			req: marksrest.AddMarkRequest{
+				Longitude:   &long,
+				Latitude:    &lat,
+				MarkTypeID:  randomMarkType.ID,
+				Description: "",
+				Force:       true,
+			},
 			statusCode: http.StatusCreated,
 		},
 		{
 			name: "Err400InvalidReq-1",
 			req: marksrest.AddMarkRequest{
-				Longitude: 42,
-				Latitude:  52,
+				Longitude: &long,
+				Latitude:  &lat,
 			},
 			statusCode: http.StatusBadRequest,
 		},
 		{
 			name: "Err400InvalidReq-2",
 			req: marksrest.AddMarkRequest{
-				Longitude:   42,
+				Longitude:   &long,
 				MarkTypeID:  1,
 				Description: "",
 			},
@@ -250,8 +478,8 @@ func (st *MarksSuite) TestAddMark() {
 		{
 			name: "Err400InvalidReq-3",
 			req: marksrest.AddMarkRequest{
-				Longitude:   42,
-				Latitude:    52,
+				Longitude:   &long,
+				Latitude:    &lat,
 				MarkTypeID:  1,
 				Description: strings.Repeat("A", 257),
 			},
@@ -263,10 +491,15 @@ func (st *MarksSuite) TestAddMark() {
 		st.Run(tt.name, func() {
 			b := &bytes.Buffer{}
 			mpw := multipart.NewWriter(b)
-			mpw.WriteField("longitude", strconv.FormatFloat(tt.req.Longitude, 'f', -1, 64))
-			mpw.WriteField("latitude", strconv.FormatFloat(tt.req.Latitude, 'f', -1, 64))
+			if tt.req.Longitude != nil {
+				mpw.WriteField("longitude", strconv.FormatFloat(*tt.req.Longitude, 'f', -1, 64))
+			}
+			if tt.req.Latitude != nil {
+				mpw.WriteField("latitude", strconv.FormatFloat(*tt.req.Latitude, 'f', -1, 64))
+			}
 			mpw.WriteField("mark_type_id", strconv.Itoa(tt.req.MarkTypeID))
 			mpw.WriteField("description", tt.req.Description)
+			mpw.WriteField("force", strconv.FormatBool(tt.req.Force))
 
 			image := gofakeit.ImageJpeg(10, 10)
 			fw, err := mpw.CreateFormFile("photos", "test.jpg")
@@ -294,22 +527,62 @@ func (st *MarksSuite) TestAddMark() {
 	}
 }
 
+func (st *MarksSuite) TestAddMarkFindByLocation() {
+	signInResponse := addNewUser(st.T(), &st.Cfg.REST)
+
+	long, err := gofakeit.LongitudeInRange(41.25, 41.55)
+	st.NoError(err)
+	lat, err := gofakeit.LatitudeInRange(52.6, 52.8)
+	st.NoError(err)
+
+	markId := addNewMarkAt(st.T(), &st.Cfg.REST, signInResponse.Payload.AccessToken, long, lat).Payload.MarkId
+
+	st.Run("BBox", func() {
+		query := fmt.Sprintf("?bbox=%f,%f,%f,%f&limit=1000", long-0.0001, lat-0.0001, long+0.0001, lat+0.0001)
+		response := getMarks(st.T(), &st.Cfg.REST, query, http.StatusOK)
+
+		ids := make([]int, len(response.Payload.Marks))
+		for i, mark := range response.Payload.Marks {
+			ids[i] = mark.ID
+		}
+		st.Contains(ids, markId)
+	})
+
+	st.Run("Nearby", func() {
+		query := fmt.Sprintf("?longitude=%f&latitude=%f&radius=10&limit=1000", long, lat)
+		response := getNearbyMarks(st.T(), &st.Cfg.REST, query, http.StatusOK)
+
+		ids := make([]int, len(response.Payload.Marks))
+		for i, mark := range response.Payload.Marks {
+			ids[i] = mark.ID
+			st.Less(mark.Distance, 10.0)
+		}
+		st.Contains(ids, markId)
+	})
+}
+
 func addNewMark(t *testing.T, cfg *config.RESTConfig, accessToken string) responses.Response[marksrest.AddMarkResponse] {
+	long, err := gofakeit.LongitudeInRange(41.25, 41.55)
+	require.NoError(t, err)
+	lat, err := gofakeit.LatitudeInRange(52.6, 52.8)
+	require.NoError(t, err)
+
+	return addNewMarkAt(t, cfg, accessToken, long, lat)
+}
+
+func addNewMarkAt(t *testing.T, cfg *config.RESTConfig, accessToken string, long, lat float64) responses.Response[marksrest.AddMarkResponse] {
 	markTypesResponse := getMarkTypes(t, cfg, http.StatusOK)
 	randomMarkTypeIndex := rand.Intn(len(markTypesResponse.Payload.MarkTypes))
 	randomMarkType := markTypesResponse.Payload.MarkTypes[randomMarkTypeIndex]
 
-	long, err := gofakeit.LatitudeInRange(52.6, 52.8)
-	require.NoError(t, err)
-	lat, err := gofakeit.LongitudeInRange(41.25, 41.55)
-	require.NoError(t, err)
-
 	b := &bytes.Buffer{}
 	mpw := multipart.NewWriter(b)
 	mpw.WriteField("longitude", strconv.FormatFloat(long, 'f', -1, 64))
 	mpw.WriteField("latitude", strconv.FormatFloat(lat, 'f', -1, 64))
 	mpw.WriteField("mark_type_id", strconv.Itoa(randomMarkType.ID))
 	mpw.WriteField("description", "")
+	// random marks are not duplicates of each other even if they happen to be close
+	mpw.WriteField("force", "true")
 
 	image := gofakeit.ImageJpeg(10, 10)
 	fw, err := mpw.CreateFormFile("photos", "test.jpg")
@@ -477,6 +750,312 @@ func (st *MarksSuite) TestGetMarkStatusHistoryByMarkId() {
 	}
 }
 
+func (st *MarksSuite) TestExplainConsensus() {
+	signInResponse := addNewUser(st.T(), &st.Cfg.REST)
+	addMarkResponse := addNewMark(st.T(), &st.Cfg.REST, signInResponse.Payload.AccessToken)
+	markId := strconv.Itoa(addMarkResponse.Payload.MarkId)
+
+	tests := []struct {
+		name       string
+		id         string
+		statusCode int
+	}{
+		{
+			name:       "Ok200",
+			id:         markId,
+			statusCode: http.StatusOK,
+		},
+		{
+			name:       "Err400",
+			id:         "a",
+			statusCode: http.StatusBadRequest,
+		},
+		{
+			name:       "Err404",
+			id:         "0",
+			statusCode: http.StatusNotFound,
+		},
+	}
+	for _, tt := range tests {
+		st.Run(tt.name, func() {
+			resp, err := http.Get(fmt.Sprintf(
+				"http://%s:%d/marks/%s/consensus",
+				st.Cfg.REST.Host,
+				st.Cfg.REST.Port,
+				tt.id,
+			))
+			st.NoError(err)
+			defer resp.Body.Close()
+
+			st.Equal(tt.statusCode, resp.StatusCode)
+
+			var response responses.Response[marksrest.ExplainConsensusResponse]
+			err = json.NewDecoder(resp.Body).Decode(&response)
+			st.NoError(err)
+
+			if tt.statusCode < 300 {
+				st.Equal(response.Success, true)
+				st.Equal(models.NoDecision, response.Payload.Explanation.Decision)
+				st.NotEmpty(response.Payload.Explanation.Reasons)
+			} else {
+				st.Equal(response.Success, false)
+			}
+		})
+	}
+}
+
+func (st *MarksSuite) TestUpdateMark() {
+	signInResponse := addNewUser(st.T(), &st.Cfg.REST)
+	addMarkResponse := addNewMark(st.T(), &st.Cfg.REST, signInResponse.Payload.AccessToken)
+	markId := addMarkResponse.Payload.MarkId
+
+	otherUserSignInResponse := addNewUser(st.T(), &st.Cfg.REST)
+
+	tests := []struct {
+		name        string
+		id          string
+		body        string
+		accessToken string
+		statusCode  int
+	}{
+		{
+			name:        "Ok200",
+			id:          strconv.Itoa(markId),
+			body:        `{"description":"updated description"}`,
+			accessToken: signInResponse.Payload.AccessToken,
+			statusCode:  http.StatusOK,
+		},
+		{
+			name:        "Ok400",
+			id:          strconv.Itoa(markId),
+			body:        `{}`,
+			accessToken: signInResponse.Payload.AccessToken,
+			statusCode:  http.StatusBadRequest,
+		},
+		{
+			name:        "Ok403",
+			id:          strconv.Itoa(markId),
+			body:        `{"description":"updated description"}`,
+			accessToken: otherUserSignInResponse.Payload.AccessToken,
+			statusCode:  http.StatusForbidden,
+		},
+		{
+			name:        "Ok404",
+			id:          "0",
+			body:        `{"description":"updated description"}`,
+			accessToken: signInResponse.Payload.AccessToken,
+			statusCode:  http.StatusNotFound,
+		},
+	}
+	for _, tt := range tests {
+		st.Run(tt.name, func() {
+			response := updateMark(st.T(), &st.Cfg.REST, tt.id, strings.NewReader(tt.body), tt.accessToken, tt.statusCode)
+
+			if tt.statusCode < 300 {
+				st.Equal(response.Success, true)
+			} else {
+				st.Equal(response.Success, false)
+			}
+		})
+	}
+}
+
+func updateMark(t *testing.T, cfg *config.RESTConfig, id string, request io.Reader, accessToken string, expectedStatusCode int) responses.Response[marksrest.UpdateMarkResponse] {
+	req, err := http.NewRequest(
+		http.MethodPatch,
+		fmt.Sprintf(
+			"http://%s:%d/marks/%s",
+			cfg.Host,
+			cfg.Port,
+			id,
+		),
+		request,
+	)
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+accessToken)
+
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	require.Equal(t, expectedStatusCode, resp.StatusCode)
+
+	var response responses.Response[marksrest.UpdateMarkResponse]
+	err = json.NewDecoder(resp.Body).Decode(&response)
+	require.NoError(t, err)
+
+	return response
+}
+
+func (st *MarksSuite) TestDeleteMark() {
+	signInResponse := addNewUser(st.T(), &st.Cfg.REST)
+	addMarkResponse := addNewMark(st.T(), &st.Cfg.REST, signInResponse.Payload.AccessToken)
+	markId := addMarkResponse.Payload.MarkId
+
+	otherUserSignInResponse := addNewUser(st.T(), &st.Cfg.REST)
+
+	tests := []struct {
+		name        string
+		id          string
+		accessToken string
+		statusCode  int
+	}{
+		{
+			name:        "Ok403",
+			id:          strconv.Itoa(markId),
+			accessToken: otherUserSignInResponse.Payload.AccessToken,
+			statusCode:  http.StatusForbidden,
+		},
+		{
+			name:        "Ok200",
+			id:          strconv.Itoa(markId),
+			accessToken: signInResponse.Payload.AccessToken,
+			statusCode:  http.StatusOK,
+		},
+		{
+			name:        "Ok404",
+			id:          strconv.Itoa(markId),
+			accessToken: signInResponse.Payload.AccessToken,
+			statusCode:  http.StatusNotFound,
+		},
+	}
+	for _, tt := range tests {
+		st.Run(tt.name, func() {
+			response := deleteMark(st.T(), &st.Cfg.REST, tt.id, tt.accessToken, tt.statusCode)
+
+			if tt.statusCode < 300 {
+				st.Equal(response.Success, true)
+			} else {
+				st.Equal(response.Success, false)
+			}
+		})
+	}
+}
+
+func deleteMark(t *testing.T, cfg *config.RESTConfig, id string, accessToken string, expectedStatusCode int) responses.Response[marksrest.DeleteMarkResponse] {
+	req, err := http.NewRequest(
+		http.MethodDelete,
+		fmt.Sprintf(
+			"http://%s:%d/marks/%s",
+			cfg.Host,
+			cfg.Port,
+			id,
+		),
+		nil,
+	)
+	require.NoError(t, err)
+	req.Header.Set("Authorization", "Bearer "+accessToken)
+
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	require.Equal(t, expectedStatusCode, resp.StatusCode)
+
+	var response responses.Response[marksrest.DeleteMarkResponse]
+	err = json.NewDecoder(resp.Body).Decode(&response)
+	require.NoError(t, err)
+
+	return response
+}
+
+func (st *MarksSuite) TestMergeMarks() {
+	signInResponse := addNewUser(st.T(), &st.Cfg.REST)
+	markId := addNewMark(st.T(), &st.Cfg.REST, signInResponse.Payload.AccessToken).Payload.MarkId
+	mergedMarkId := addNewMark(st.T(), &st.Cfg.REST, signInResponse.Payload.AccessToken).Payload.MarkId
+	moderatorToken := moderatorAccessToken(st.T(), st.Cfg.Auth)
+
+	tests := []struct {
+		name        string
+		id          string
+		body        string
+		accessToken string
+		statusCode  int
+	}{
+		{
+			name:        "Ok403",
+			id:          strconv.Itoa(markId),
+			body:        fmt.Sprintf(`{"mark_ids":[%d]}`, mergedMarkId),
+			accessToken: signInResponse.Payload.AccessToken,
+			statusCode:  http.StatusForbidden,
+		},
+		{
+			name:        "Ok400",
+			id:          strconv.Itoa(markId),
+			body:        fmt.Sprintf(`{"mark_ids":[%d]}`, markId),
+			accessToken: moderatorToken,
+			statusCode:  http.StatusBadRequest,
+		},
+		{
+			name:        "Ok200",
+			id:          strconv.Itoa(markId),
+			body:        fmt.Sprintf(`{"mark_ids":[%d]}`, mergedMarkId),
+			accessToken: moderatorToken,
+			statusCode:  http.StatusOK,
+		},
+		{
+			name:        "Ok404",
+			id:          strconv.Itoa(markId),
+			body:        fmt.Sprintf(`{"mark_ids":[%d]}`, mergedMarkId),
+			accessToken: moderatorToken,
+			statusCode:  http.StatusNotFound,
+		},
+	}
+	for _, tt := range tests {
+		st.Run(tt.name, func() {
+			response := mergeMarks(st.T(), &st.Cfg.REST, tt.id, strings.NewReader(tt.body), tt.accessToken, tt.statusCode)
+
+			if tt.statusCode < 300 {
+				st.Equal(response.Success, true)
+			} else {
+				st.Equal(response.Success, false)
+			}
+		})
+	}
+
+	st.Run("MergedMarkResolvesToMark", func() {
+		resp, err := http.Get(fmt.Sprintf("http://%s:%d/marks/%d", st.Cfg.REST.Host, st.Cfg.REST.Port, mergedMarkId))
+		st.NoError(err)
+		defer resp.Body.Close()
+
+		st.Equal(http.StatusOK, resp.StatusCode)
+
+		var response responses.Response[marksrest.GetMarkByIdResponse]
+		err = json.NewDecoder(resp.Body).Decode(&response)
+		st.NoError(err)
+		st.Equal(markId, response.Payload.Mark.ID)
+	})
+}
+
+func mergeMarks(t *testing.T, cfg *config.RESTConfig, id string, request io.Reader, accessToken string, expectedStatusCode int) responses.Response[marksrest.MergeMarksResponse] {
+	req, err := http.NewRequest(
+		http.MethodPost,
+		fmt.Sprintf(
+			"http://%s:%d/marks/%s/merge",
+			cfg.Host,
+			cfg.Port,
+			id,
+		),
+		request,
+	)
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+accessToken)
+
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	require.Equal(t, expectedStatusCode, resp.StatusCode)
+
+	var response responses.Response[marksrest.MergeMarksResponse]
+	err = json.NewDecoder(resp.Body).Decode(&response)
+	require.NoError(t, err)
+
+	return response
+}
+
 func (st *MarksSuite) TestConfirm() {
 	signInResponse := addNewUser(st.T(), &st.Cfg.REST)
 	addMarkResponse := addNewMark(st.T(), &st.Cfg.REST, signInResponse.Payload.AccessToken)
@@ -484,33 +1063,44 @@ func (st *MarksSuite) TestConfirm() {
 
 	addMarkForRejectResponse := addNewMark(st.T(), &st.Cfg.REST, signInResponse.Payload.AccessToken)
 	markForRejectId := addMarkForRejectResponse.Payload.MarkId
+	moderatorToken := moderatorAccessToken(st.T(), st.Cfg.Auth)
 	reject(
 		st.T(),
 		&st.Cfg.REST,
 		strconv.Itoa(markForRejectId),
-		signInResponse.Payload.AccessToken,
+		moderatorToken,
 		http.StatusOK,
 	)
 
 	tests := []struct {
-		name       string
-		id         string
-		statusCode int
+		name        string
+		id          string
+		accessToken string
+		statusCode  int
 	}{
 		{
-			name:       "Ok200",
-			id:         strconv.Itoa(markId),
-			statusCode: http.StatusOK,
+			name:        "Ok403",
+			id:          strconv.Itoa(markId),
+			accessToken: signInResponse.Payload.AccessToken,
+			statusCode:  http.StatusForbidden,
 		},
 		{
-			name:       "Ok400",
-			id:         "a",
-			statusCode: http.StatusBadRequest,
+			name:        "Ok200",
+			id:          strconv.Itoa(markId),
+			accessToken: moderatorToken,
+			statusCode:  http.StatusOK,
 		},
 		{
-			name:       "Ok409",
-			id:         strconv.Itoa(markForRejectId),
-			statusCode: http.StatusConflict,
+			name:        "Ok400",
+			id:          "a",
+			accessToken: moderatorToken,
+			statusCode:  http.StatusBadRequest,
+		},
+		{
+			name:        "Ok409",
+			id:          strconv.Itoa(markForRejectId),
+			accessToken: moderatorToken,
+			statusCode:  http.StatusConflict,
 		},
 	}
 	for _, tt := range tests {
@@ -519,7 +1109,7 @@ func (st *MarksSuite) TestConfirm() {
 				st.T(),
 				&st.Cfg.REST,
 				tt.id,
-				signInResponse.Payload.AccessToken,
+				tt.accessToken,
 				tt.statusCode,
 			)
 
@@ -540,33 +1130,44 @@ func (st *MarksSuite) TestReject() {
 
 	addMarkForRejectResponse := addNewMark(st.T(), &st.Cfg.REST, signInResponse.Payload.AccessToken)
 	markForRejectId := addMarkForRejectResponse.Payload.MarkId
+	moderatorToken := moderatorAccessToken(st.T(), st.Cfg.Auth)
 	reject(
 		st.T(),
 		&st.Cfg.REST,
 		strconv.Itoa(markForRejectId),
-		signInResponse.Payload.AccessToken,
+		moderatorToken,
 		http.StatusOK,
 	)
 
 	tests := []struct {
-		name       string
-		id         string
-		statusCode int
+		name        string
+		id          string
+		accessToken string
+		statusCode  int
 	}{
 		{
-			name:       "Ok200",
-			id:         strconv.Itoa(markId),
-			statusCode: http.StatusOK,
+			name:        "Ok403",
+			id:          strconv.Itoa(markId),
+			accessToken: signInResponse.Payload.AccessToken,
+			statusCode:  http.StatusForbidden,
 		},
 		{
-			name:       "Ok400",
-			id:         "a",
-			statusCode: http.StatusBadRequest,
+			name:        "Ok200",
+			id:          strconv.Itoa(markId),
+			accessToken: moderatorToken,
+			statusCode:  http.StatusOK,
 		},
 		{
-			name:       "Ok409",
-			id:         strconv.Itoa(markForRejectId),
-			statusCode: http.StatusConflict,
+			name:        "Ok400",
+			id:          "a",
+			accessToken: moderatorToken,
+			statusCode:  http.StatusBadRequest,
+		},
+		{
+			name:        "Ok409",
+			id:          strconv.Itoa(markForRejectId),
+			accessToken: moderatorToken,
+			statusCode:  http.StatusConflict,
 		},
 	}
 	for _, tt := range tests {
@@ -575,7 +1176,7 @@ func (st *MarksSuite) TestReject() {
 				st.T(),
 				&st.Cfg.REST,
 				tt.id,
-				signInResponse.Payload.AccessToken,
+				tt.accessToken,
 				tt.statusCode,
 			)
 
@@ -0,0 +1,146 @@
+//go:build functional && rest
+
+package tests
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"testing"
+
+	"github.com/PritOriginal/problem-map-server/internal/config"
+	moderationrest "github.com/PritOriginal/problem-map-server/internal/handler/moderation"
+	"github.com/PritOriginal/problem-map-server/pkg/responses"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type ModerationSuite struct {
+	suite.Suite
+	Cfg *config.Config
+}
+
+func (st *ModerationSuite) SetupSuite() {
+	st.Cfg = config.MustLoadPath("../../configs/config.yaml")
+}
+
+func TestModerationSuite(t *testing.T) {
+	suite.Run(t, new(ModerationSuite))
+}
+
+func (st *ModerationSuite) TestGetQueue() {
+	signInResponse := addNewUser(st.T(), &st.Cfg.REST)
+
+	tests := []struct {
+		name        string
+		accessToken string
+		statusCode  int
+	}{
+		{
+			name:        "Ok200",
+			accessToken: moderatorAccessToken(st.T(), st.Cfg.Auth),
+			statusCode:  http.StatusOK,
+		},
+		{
+			name:        "Ok403",
+			accessToken: signInResponse.Payload.AccessToken,
+			statusCode:  http.StatusForbidden,
+		},
+	}
+	for _, tt := range tests {
+		st.Run(tt.name, func() {
+			response := moderationRequest[moderationrest.GetQueueResponse](st.T(), &st.Cfg.REST, http.MethodGet, "/moderation/queue", tt.accessToken, tt.statusCode)
+
+			if tt.statusCode < 300 {
+				st.Equal(response.Success, true)
+				st.NotNil(response.Payload.Items)
+			} else {
+				st.Equal(response.Success, false)
+			}
+		})
+	}
+}
+
+func (st *ModerationSuite) TestClaimAndRelease() {
+	signInResponse := addNewUser(st.T(), &st.Cfg.REST)
+	addMarkResponse := addNewMark(st.T(), &st.Cfg.REST, signInResponse.Payload.AccessToken)
+	claimPath := "/moderation/queue/" + strconv.Itoa(addMarkResponse.Payload.MarkId) + "/claim"
+	moderatorToken := moderatorAccessToken(st.T(), st.Cfg.Auth)
+
+	tests := []struct {
+		name        string
+		method      string
+		accessToken string
+		statusCode  int
+	}{
+		{
+			name:        "ClaimOk403",
+			method:      http.MethodPost,
+			accessToken: signInResponse.Payload.AccessToken,
+			statusCode:  http.StatusForbidden,
+		},
+		{
+			name:        "ClaimOk200",
+			method:      http.MethodPost,
+			accessToken: moderatorToken,
+			statusCode:  http.StatusOK,
+		},
+		{
+			name:        "ClaimOk200Extend",
+			method:      http.MethodPost,
+			accessToken: moderatorToken,
+			statusCode:  http.StatusOK,
+		},
+		{
+			name:        "ReleaseOk200",
+			method:      http.MethodDelete,
+			accessToken: moderatorToken,
+			statusCode:  http.StatusOK,
+		},
+		{
+			name:        "ReleaseOk404",
+			method:      http.MethodDelete,
+			accessToken: moderatorToken,
+			statusCode:  http.StatusNotFound,
+		},
+	}
+	for _, tt := range tests {
+		st.Run(tt.name, func() {
+			response := moderationRequest[any](st.T(), &st.Cfg.REST, tt.method, claimPath, tt.accessToken, tt.statusCode)
+
+			if tt.statusCode < 300 {
+				st.Equal(response.Success, true)
+			} else {
+				st.Equal(response.Success, false)
+			}
+		})
+	}
+}
+
+func moderationRequest[T any](t *testing.T, cfg *config.RESTConfig, method, path string, accessToken string, expectedStatusCode int) responses.Response[T] {
+	req, err := http.NewRequest(
+		method,
+		fmt.Sprintf(
+			"http://%s:%d%s",
+			cfg.Host,
+			cfg.Port,
+			path,
+		),
+		nil,
+	)
+	require.NoError(t, err)
+	req.Header.Set("Authorization", "Bearer "+accessToken)
+
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	require.Equal(t, expectedStatusCode, resp.StatusCode)
+
+	var response responses.Response[T]
+	err = json.NewDecoder(resp.Body).Decode(&response)
+	require.NoError(t, err)
+
+	return response
+}
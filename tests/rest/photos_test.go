@@ -0,0 +1,59 @@
+//go:build functional && rest
+
+package tests
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"testing"
+
+	"github.com/PritOriginal/problem-map-server/internal/config"
+	checksrest "github.com/PritOriginal/problem-map-server/internal/handler/checks"
+	"github.com/PritOriginal/problem-map-server/pkg/responses"
+	"github.com/stretchr/testify/suite"
+)
+
+type PhotosSuite struct {
+	suite.Suite
+	Cfg *config.Config
+}
+
+func (st *PhotosSuite) SetupSuite() {
+	st.Cfg = config.MustLoadPath("../../configs/config.yaml")
+}
+
+func TestPhotosSuite(t *testing.T) {
+	suite.Run(t, new(PhotosSuite))
+}
+
+func (st *PhotosSuite) TestGetPhoto() {
+	if st.Cfg.PhotoStorage == config.S3 {
+		st.T().Skip("the photos are served by S3")
+	}
+
+	signInResponse := addNewUser(st.T(), &st.Cfg.REST)
+	addCheckResponse := addNewCheck(st.T(), &st.Cfg.REST, signInResponse.Payload.AccessToken)
+
+	resp, err := http.Get(fmt.Sprintf("http://%s:%d/checks/%s", st.Cfg.REST.Host, st.Cfg.REST.Port, strconv.Itoa(addCheckResponse.Payload.CheckId)))
+	st.Require().NoError(err)
+	defer resp.Body.Close()
+
+	var response responses.Response[checksrest.GetCheckByIdResponse]
+	st.Require().NoError(json.NewDecoder(resp.Body).Decode(&response))
+	st.Require().Len(response.Payload.Check.Photos, 1)
+
+	photo := response.Payload.Check.Photos[0]
+	for _, url := range []string{photo.Original, photo.Medium, photo.Thumbnail} {
+		st.Run(url, func() {
+			resp, err := http.Get(url)
+			st.NoError(err)
+			defer resp.Body.Close()
+
+			st.Equal(http.StatusOK, resp.StatusCode)
+			st.Equal("image/jpeg", resp.Header.Get("Content-Type"))
+			st.NotEmpty(resp.Header.Get("Cache-Control"))
+		})
+	}
+}
@@ -159,11 +159,15 @@ func (st *TasksSuite) TestAddTask() {
 	markIndex := rand.Intn(len(getMarksResponse.Payload.Marks))
 	mark := getMarksResponse.Payload.Marks[markIndex]
 
+	signInResponse := addNewUser(st.T(), &st.Cfg.REST)
+	moderatorToken := moderatorAccessToken(st.T(), st.Cfg.Auth)
+
 	tests := []struct {
-		name       string
-		rawReq     string
-		req        tasksrest.AddTaskRequest
-		statusCode int
+		name        string
+		rawReq      string
+		req         tasksrest.AddTaskRequest
+		accessToken string
+		statusCode  int
 	}{
 		{
 			name: "Ok201",
@@ -172,19 +176,41 @@ func (st *TasksSuite) TestAddTask() {
 				UserID: user.Id,
 				MarkID: mark.ID,
 			},
-			statusCode: http.StatusCreated,
+			accessToken: moderatorToken,
+			statusCode:  http.StatusCreated,
 		},
 		{
-			name:       "Err400InvalidJSON",
-			rawReq:     "{",
-			statusCode: http.StatusBadRequest,
+			name:        "Err400InvalidJSON",
+			rawReq:      "{",
+			accessToken: moderatorToken,
+			statusCode:  http.StatusBadRequest,
 		},
 		{
 			name: "Err400InvalidReq",
 			req: tasksrest.AddTaskRequest{
 				Name: "test",
 			},
-			statusCode: http.StatusBadRequest,
+			accessToken: moderatorToken,
+			statusCode:  http.StatusBadRequest,
+		},
+		{
+			name: "Err401",
+			req: tasksrest.AddTaskRequest{
+				Name:   "test",
+				UserID: user.Id,
+				MarkID: mark.ID,
+			},
+			statusCode: http.StatusUnauthorized,
+		},
+		{
+			name: "Err403",
+			req: tasksrest.AddTaskRequest{
+				Name:   "test",
+				UserID: user.Id,
+				MarkID: mark.ID,
+			},
+			accessToken: signInResponse.Payload.AccessToken,
+			statusCode:  http.StatusForbidden,
 		},
 	}
 	for _, tt := range tests {
@@ -198,12 +224,19 @@ func (st *TasksSuite) TestAddTask() {
 				request = bytes.NewBuffer([]byte(tt.rawReq))
 			}
 
-			resp, err := http.Post(
+			req, err := http.NewRequest(
+				http.MethodPost,
 				fmt.Sprintf("http://%s:%d/tasks", st.Cfg.REST.Host, st.Cfg.REST.Port),
-				"application/json",
 				request,
 			)
 			st.NoError(err)
+			req.Header.Set("Content-Type", "application/json")
+			if tt.accessToken != "" {
+				req.Header.Set("Authorization", "Bearer "+tt.accessToken)
+			}
+
+			resp, err := http.DefaultClient.Do(req)
+			st.NoError(err)
 			defer resp.Body.Close()
 
 			st.Equal(tt.statusCode, resp.StatusCode)
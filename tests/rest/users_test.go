@@ -100,3 +100,42 @@ func (st *UsersSuite) TestGetUserById() {
 		})
 	}
 }
+
+func (st *UsersSuite) TestSessions() {
+	signInResponse := addNewUser(st.T(), &st.Cfg.REST)
+	accessToken := signInResponse.Payload.AccessToken
+
+	req, err := http.NewRequest("GET", fmt.Sprintf("http://%s:%d/users/me/sessions", st.Cfg.REST.Host, st.Cfg.REST.Port), nil)
+	st.Require().NoError(err)
+	req.Header.Set("Authorization", "Bearer "+accessToken)
+
+	resp, err := http.DefaultClient.Do(req)
+	st.Require().NoError(err)
+	defer resp.Body.Close()
+	st.Require().Equal(http.StatusOK, resp.StatusCode)
+
+	var response responses.Response[usersrest.GetSessionsResponse]
+	st.Require().NoError(json.NewDecoder(resp.Body).Decode(&response))
+	st.Require().Len(response.Payload.Sessions, 1)
+	session := response.Payload.Sessions[0]
+	st.True(session.Current)
+
+	deleteSession := func(accessToken string, expectedStatusCode int) {
+		req, err := http.NewRequest("DELETE", fmt.Sprintf("http://%s:%d/users/me/sessions/%s", st.Cfg.REST.Host, st.Cfg.REST.Port, session.ID), nil)
+		st.Require().NoError(err)
+		req.Header.Set("Authorization", "Bearer "+accessToken)
+
+		resp, err := http.DefaultClient.Do(req)
+		st.Require().NoError(err)
+		defer resp.Body.Close()
+		st.Equal(expectedStatusCode, resp.StatusCode)
+	}
+
+	// the session of another user is not found and stays active
+	deleteSession(addNewUser(st.T(), &st.Cfg.REST).Payload.AccessToken, http.StatusNotFound)
+	refreshResponse := refreshTokens(st.T(), signInResponse.Payload.RefreshToken, &st.Cfg.REST, http.StatusOK)
+
+	deleteSession(accessToken, http.StatusOK)
+	_ = refreshTokens(st.T(), refreshResponse.Payload.RefreshToken, &st.Cfg.REST, http.StatusUnauthorized)
+	deleteSession(accessToken, http.StatusNotFound)
+}